@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// ListAPITokens lists the api tokens of a project which have not been revoked
+func (c *Client) ListAPITokens(
+	ctx context.Context,
+	projectID uint,
+) ([]*types.APITokenMeta, error) {
+	resp := make([]*types.APITokenMeta, 0)
+
+	err := c.getRequest(
+		fmt.Sprintf("/projects/%d/api_token", projectID),
+		nil,
+		&resp,
+	)
+
+	return resp, err
+}
+
+// CreateAPIToken creates an api token in a project
+func (c *Client) CreateAPIToken(
+	ctx context.Context,
+	projectID uint,
+	req *types.CreateAPIToken,
+) (*types.APIToken, error) {
+	resp := &types.APIToken{}
+
+	err := c.postRequest(
+		fmt.Sprintf("/projects/%d/api_token", projectID),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RotateAPIToken issues a new secret for an api token
+func (c *Client) RotateAPIToken(
+	ctx context.Context,
+	projectID uint,
+	tokenID string,
+	req *types.RotateAPITokenRequest,
+) (*types.APIToken, error) {
+	resp := &types.APIToken{}
+
+	err := c.postRequest(
+		fmt.Sprintf("/projects/%d/api_token/%s/rotate", projectID, tokenID),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RevokeAPIToken revokes an api token
+func (c *Client) RevokeAPIToken(
+	ctx context.Context,
+	projectID uint,
+	tokenID string,
+) (*types.APITokenMeta, error) {
+	resp := &types.APITokenMeta{}
+
+	err := c.postRequest(
+		fmt.Sprintf("/projects/%d/api_token/%s/revoke", projectID, tokenID),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
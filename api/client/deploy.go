@@ -108,6 +108,65 @@ func (c *Client) UpgradeRelease(
 	)
 }
 
+// RollbackRelease rolls back a release to a previous revision
+func (c *Client) RollbackRelease(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace, name string,
+	req *types.RollbackReleaseRequest,
+) error {
+	return c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/rollback",
+			projID, clusterID,
+			namespace, name,
+		),
+		req,
+		nil,
+	)
+}
+
+// UpgradeReleaseDryRun renders an upgrade of a release with new values or chart version,
+// without applying it
+func (c *Client) UpgradeReleaseDryRun(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace, name string,
+	req *types.UpgradeReleaseRequest,
+) (*types.DryRunReleaseResponse, error) {
+	resp := &types.DryRunReleaseResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/upgrade/dry_run",
+			projID, clusterID,
+			namespace, name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// CreateReleaseDryRun renders a new release from a chart, without installing it
+func (c *Client) CreateReleaseDryRun(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace string,
+	req *types.CreateReleaseBaseRequest,
+) (*types.DryRunReleaseResponse, error) {
+	resp := &types.DryRunReleaseResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf("/projects/%d/clusters/%d/namespaces/%s/releases/dry_run", projID, clusterID, namespace),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
 // DeleteRelease deletes a Porter release
 func (c *Client) DeleteRelease(
 	ctx context.Context,
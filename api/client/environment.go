@@ -150,3 +150,78 @@ func (c *Client) DeleteDeployment(
 		nil, nil,
 	)
 }
+
+func (c *Client) WakeDeployment(
+	ctx context.Context,
+	projID, clusterID, deploymentID uint,
+) (*types.Deployment, error) {
+	resp := &types.Deployment{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/deployments/%d/wake",
+			projID, clusterID, deploymentID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+func (c *Client) CreateGitlabDeployment(
+	ctx context.Context,
+	projID, clusterID, environmentID uint,
+	req *types.CreateGitlabDeploymentRequest,
+) (*types.Deployment, error) {
+	resp := &types.Deployment{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/environments/%d/gitlab_deployment",
+			projID, clusterID, environmentID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+func (c *Client) FinalizeGitlabDeployment(
+	ctx context.Context,
+	projID, clusterID, environmentID uint,
+	req *types.FinalizeDeploymentRequest,
+) (*types.Deployment, error) {
+	resp := &types.Deployment{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/environments/%d/gitlab_deployment/finalize",
+			projID, clusterID, environmentID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+func (c *Client) FinalizeGitlabDeploymentWithErrors(
+	ctx context.Context,
+	projID, clusterID, environmentID uint,
+	req *types.FinalizeDeploymentWithErrorsRequest,
+) (*types.Deployment, error) {
+	resp := &types.Deployment{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/environments/%d/gitlab_deployment/finalize_errors",
+			projID, clusterID, environmentID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
@@ -23,8 +23,8 @@ func (c *Client) AuthCheck(ctx context.Context) (*types.GetAuthenticatedUserResp
 }
 
 // Login authorizes the user and grants them a cookie-based session
-func (c *Client) Login(ctx context.Context, req *types.LoginUserRequest) (*types.GetAuthenticatedUserResponse, error) {
-	resp := &types.GetAuthenticatedUserResponse{}
+func (c *Client) Login(ctx context.Context, req *types.LoginUserRequest) (*types.LoginUserResponse, error) {
+	resp := &types.LoginUserResponse{}
 
 	err := c.postRequest(
 		fmt.Sprintf(
@@ -37,6 +37,22 @@ func (c *Client) Login(ctx context.Context, req *types.LoginUserRequest) (*types
 	return resp, err
 }
 
+// LoginTwoFactor completes a login for a user with two-factor authentication enabled,
+// using the session cookie set by Login
+func (c *Client) LoginTwoFactor(ctx context.Context, req *types.LoginTwoFactorRequest) (*types.LoginUserResponse, error) {
+	resp := &types.LoginUserResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/login/2fa",
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
 // Logout logs the user out and deauthorizes the cookie-based session
 func (c *Client) Logout(ctx context.Context) error {
 	err := c.postRequest(
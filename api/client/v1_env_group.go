@@ -0,0 +1,58 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// ImportEnvGroup imports variables from the contents of files into an env group
+func (c *Client) ImportEnvGroup(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+	req *types.ImportEnvGroupRequest,
+) (*types.V1EnvGroupResponse, error) {
+	resp := &types.V1EnvGroupResponse{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/v1/projects/%d/clusters/%d/namespaces/%s/env_groups/%s/import",
+			projectID, clusterID, namespace, name,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ExportEnvGroup exports the variables of an env group as the contents of a file. The values
+// of secret variables are only included if includeSecrets is set.
+func (c *Client) ExportEnvGroup(
+	ctx context.Context,
+	projectID, clusterID uint,
+	namespace, name string,
+	includeSecrets bool,
+	req *types.ExportEnvGroupRequest,
+) (*types.ExportEnvGroupResponse, error) {
+	resp := &types.ExportEnvGroupResponse{}
+
+	path := fmt.Sprintf(
+		"/v1/projects/%d/clusters/%d/namespaces/%s/env_groups/%s/export",
+		projectID, clusterID, namespace, name,
+	)
+
+	var err error
+
+	// secrets are exported with a POST request, as the export requires permission to update
+	// the settings of the project
+	if includeSecrets {
+		err = c.postRequest(path+"/secrets", req, resp)
+	} else {
+		err = c.getRequest(path, req, resp)
+	}
+
+	return resp, err
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+func TestExportEnvGroup(t *testing.T) {
+	tests := []struct {
+		name           string
+		includeSecrets bool
+		expMethod      string
+		expPath        string
+	}{
+		{"without secrets", false, http.MethodGet, "/v1/projects/1/clusters/2/namespaces/default/env_groups/my-env/export"},
+		{"with secrets", true, http.MethodPost, "/v1/projects/1/clusters/2/namespaces/default/env_groups/my-env/export/secrets"},
+	}
+
+	for _, test := range tests {
+		req := &types.ExportEnvGroupRequest{}
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != test.expMethod || r.URL.Path != test.expPath {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			// the format and version are sent as query parameters of a GET request, and in the
+			// body of a POST request
+			if r.Method == http.MethodGet {
+				req.Format = types.EnvGroupFileFormat(r.URL.Query().Get("format"))
+			} else if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			json.NewEncoder(w).Encode(&types.ExportEnvGroupResponse{Version: 3})
+		}))
+
+		client := NewClientWithToken(server.URL, "token")
+
+		resp, err := client.ExportEnvGroup(context.Background(), 1, 2, "default", "my-env", test.includeSecrets, &types.ExportEnvGroupRequest{
+			Format: types.EnvGroupFileFormatYAML,
+		})
+
+		server.Close()
+
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		if resp.Version != 3 {
+			t.Errorf("%s: expected version 3, got %d", test.name, resp.Version)
+		}
+
+		if req.Format != types.EnvGroupFileFormatYAML {
+			t.Errorf("%s: expected format %s to be sent, got %q", test.name, types.EnvGroupFileFormatYAML, req.Format)
+		}
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/gorilla/sessions"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/models"
@@ -151,6 +152,15 @@ func (authn *AuthN) verifyTokenWithNext(w http.ResponseWriter, r *http.Request,
 			return
 		}
 
+		// the secret changes when the token is rotated, so tokens issued before a rotation
+		// stop working once the grace period has elapsed
+		if !apiToken.IsValidSecret(tok.Secret) {
+			authn.sendForbiddenError(fmt.Errorf("token with id %s has an invalid secret", tok.TokenID), w, r)
+			return
+		}
+
+		authn.updateAPITokenLastUsed(r, apiToken)
+
 		authn.nextWithAPIToken(w, r, apiToken)
 	} else {
 		// otherwise we just use nextWithUser using the `iby` field for the token
@@ -158,6 +168,29 @@ func (authn *AuthN) verifyTokenWithNext(w http.ResponseWriter, r *http.Request,
 	}
 }
 
+// apiTokenLastUsedInterval is the minimum interval between two updates of the last used
+// time of a token, to avoid writing to the database on every request
+const apiTokenLastUsedInterval = time.Minute
+
+// updateAPITokenLastUsed records the time and the client IP of the latest use of a token.
+// Failing to do so is logged but does not fail the request.
+func (authn *AuthN) updateAPITokenLastUsed(r *http.Request, tok *models.APIToken) {
+	now := time.Now()
+	ip := requestutils.GetClientIP(r, authn.config.TrustedProxies)
+
+	if tok.LastUsedAt != nil && now.Sub(*tok.LastUsedAt) < apiTokenLastUsedInterval && tok.LastUsedIP == ip {
+		return
+	}
+
+	if err := authn.config.Repo.APIToken().UpdateAPITokenLastUsed(tok.ID, now, ip); err != nil {
+		authn.config.Logger.Error().Err(err).Str("token_id", tok.UniqueID).Msg("could not update api token last used time")
+		return
+	}
+
+	tok.LastUsedAt = &now
+	tok.LastUsedIP = ip
+}
+
 // nextWithAPIToken sets the token in context
 func (authn *AuthN) nextWithAPIToken(w http.ResponseWriter, r *http.Request, tok *models.APIToken) {
 	ctx := r.Context()
@@ -1,7 +1,9 @@
 package authn
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/internal/models"
@@ -29,6 +31,11 @@ func SaveUserAuthenticated(
 	session.Values["user_id"] = user.ID
 	session.Values["email"] = user.Email
 
+	// the second login step, if any, is complete
+	session.Values["2fa_user_id"] = nil
+	session.Values["2fa_expiry"] = nil
+	session.Values["2fa_failures"] = nil
+
 	// we unset the redirect uri after login
 	session.Values["redirect_uri"] = ""
 
@@ -49,5 +56,92 @@ func SaveUserUnauthenticated(
 	session.Values["authenticated"] = false
 	session.Values["user_id"] = nil
 	session.Values["email"] = nil
+	session.Values["2fa_user_id"] = nil
+	session.Values["2fa_expiry"] = nil
+	session.Values["2fa_failures"] = nil
+	return session.Save(r, w)
+}
+
+// twoFactorPendingTTL is how long a user has to enter a two-factor code after entering
+// their password
+const twoFactorPendingTTL = 5 * time.Minute
+
+// SaveUserTwoFactorPending stores in the session that the user has entered a correct
+// password, but still needs to enter a two-factor code. The session is not authenticated
+// until the code is verified.
+func SaveUserTwoFactorPending(
+	w http.ResponseWriter,
+	r *http.Request,
+	config *config.Config,
+	user *models.User,
+) error {
+	session, err := config.Store.Get(r, config.ServerConf.CookieName)
+
+	if err != nil {
+		return err
+	}
+
+	session.Values["authenticated"] = false
+	session.Values["user_id"] = nil
+	session.Values["email"] = nil
+	session.Values["2fa_user_id"] = user.ID
+	session.Values["2fa_expiry"] = time.Now().Add(twoFactorPendingTTL).Unix()
+	session.Values["2fa_failures"] = 0
+
 	return session.Save(r, w)
 }
+
+// maxTwoFactorFailures is the number of incorrect two-factor codes after which the user
+// has to enter their password again
+const maxTwoFactorFailures = 5
+
+// SaveUserTwoFactorFailure records an incorrect two-factor code in the session. Once
+// maxTwoFactorFailures codes are incorrect, the pending login is cleared and true is returned.
+func SaveUserTwoFactorFailure(
+	w http.ResponseWriter,
+	r *http.Request,
+	config *config.Config,
+) (bool, error) {
+	session, err := config.Store.Get(r, config.ServerConf.CookieName)
+
+	if err != nil {
+		return false, err
+	}
+
+	failures, _ := session.Values["2fa_failures"].(int)
+	failures++
+
+	session.Values["2fa_failures"] = failures
+
+	cleared := failures >= maxTwoFactorFailures
+
+	if cleared {
+		session.Values["2fa_user_id"] = nil
+		session.Values["2fa_expiry"] = nil
+		session.Values["2fa_failures"] = nil
+	}
+
+	return cleared, session.Save(r, w)
+}
+
+// GetTwoFactorPendingUserID returns the id of the user waiting for the second login step
+// in the session
+func GetTwoFactorPendingUserID(r *http.Request, config *config.Config) (uint, error) {
+	session, err := config.Store.Get(r, config.ServerConf.CookieName)
+
+	if err != nil {
+		return 0, err
+	}
+
+	userID, ok := session.Values["2fa_user_id"].(uint)
+
+	if !ok || userID == 0 {
+		return 0, fmt.Errorf("no login is waiting for a two-factor code")
+	}
+
+	if expiry, ok := session.Values["2fa_expiry"].(int64); !ok || time.Now().Unix() > expiry {
+		return 0, fmt.Errorf("the login has expired, please log in again")
+	}
+
+	return userID, nil
+}
@@ -70,6 +70,33 @@ func (b *RepoPolicyDocumentLoader) LoadPolicyDocuments(
 			return types.DeveloperPolicy, nil
 		case types.RoleViewer:
 			return types.ViewerPolicy, nil
+		case types.RoleCustom:
+			if role.PolicyUID == "" {
+				return nil, apierrors.NewErrForbidden(
+					fmt.Errorf("custom role for user %d, project %d does not reference a policy", userID, projectID),
+				)
+			}
+
+			apiPolicy, reqErr := GetAPIPolicyFromUID(b.policyRepo, projectID, role.PolicyUID)
+
+			if reqErr != nil {
+				// the policy no longer exists in the project, so the role grants no access
+				if reqErr.GetStatusCode() == http.StatusBadRequest {
+					return nil, apierrors.NewErrForbidden(
+						fmt.Errorf("policy %s for custom role of user %d not found in project %d", role.PolicyUID, userID, projectID),
+					)
+				}
+
+				return nil, reqErr
+			}
+
+			for _, policyDoc := range apiPolicy.Policy {
+				if policyDoc != nil {
+					policyDoc.ReadableParents = true
+				}
+			}
+
+			return apiPolicy.Policy, nil
 		default:
 			return nil, apierrors.NewErrForbidden(
 				fmt.Errorf("%s role not supported for user %d, project %d", string(role.Kind), userID, projectID),
@@ -1,6 +1,7 @@
 package policy_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -39,11 +40,11 @@ var basicLoaderTests = []basicLoaderTest{
 		expPolicy:   types.ViewerPolicy,
 	},
 	{
-		description:      "should not load custom policy for basic loader",
+		description:      "should not load custom role without a policy",
 		roleKind:         types.RoleCustom,
 		expErr:           true,
 		expErrStatusCode: http.StatusForbidden,
-		expErrString:     "custom role not supported for user 1, project 1",
+		expErrString:     "custom role for user 1, project 1 does not reference a policy",
 	},
 }
 
@@ -121,6 +122,165 @@ func TestBasicPolicyDocumentLoader(t *testing.T) {
 	}
 }
 
+func TestCustomRolePolicyDocumentLoader(t *testing.T) {
+	assert := assert.New(t)
+
+	projRepo := test.NewProjectRepository(true)
+	policyRepo := test.NewPolicyRepository(true)
+	loader := policy.NewBasicPolicyDocumentLoader(projRepo, policyRepo)
+
+	project, err := projRepo.CreateProject(&models.Project{
+		Name: "test-project",
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// a "deployer" role which can only write releases in the "staging" namespace
+	deployerPolicy := []*types.PolicyDocument{
+		{
+			Scope: types.ProjectScope,
+			Verbs: types.ReadVerbGroup(),
+			Children: map[types.PermissionScope]*types.PolicyDocument{
+				types.ClusterScope: {
+					Scope: types.ClusterScope,
+					Verbs: types.ReadVerbGroup(),
+					Children: map[types.PermissionScope]*types.PolicyDocument{
+						types.NamespaceScope: {
+							Scope: types.NamespaceScope,
+							Verbs: types.ReadWriteVerbGroup(),
+							Resources: []types.NameOrUInt{
+								{
+									Name: "staging",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	policyBytes, err := json.Marshal(deployerPolicy)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, err = policyRepo.CreatePolicy(&models.Policy{
+		UniqueID:    "deployer-uid",
+		ProjectID:   project.ID,
+		Name:        "deployer",
+		PolicyBytes: policyBytes,
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, err = projRepo.CreateProjectRole(project, &models.Role{
+		Role: types.Role{
+			UserID:    1,
+			ProjectID: project.ID,
+			Kind:      types.RoleCustom,
+			PolicyUID: "deployer-uid",
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	docs, reqErr := loader.LoadPolicyDocuments(&policy.PolicyLoaderOpts{
+		ProjectID: project.ID,
+		UserID:    1,
+	})
+
+	if reqErr != nil {
+		t.Fatalf("%v", reqErr)
+	}
+
+	// the loader marks the documents of custom roles, so that parent scopes only need to be readable
+	deployerPolicy[0].ReadableParents = true
+
+	if diff := deep.Equal(deployerPolicy, docs); diff != nil {
+		t.Errorf("policy documents not equal:")
+		t.Error(diff)
+	}
+
+	writeRelease := func(namespace string) map[types.PermissionScope]*types.RequestAction {
+		return map[types.PermissionScope]*types.RequestAction{
+			types.ClusterScope: {
+				Verb:     types.APIVerbUpdate,
+				Resource: types.NameOrUInt{UInt: 1},
+			},
+			types.NamespaceScope: {
+				Verb:     types.APIVerbUpdate,
+				Resource: types.NameOrUInt{Name: namespace},
+			},
+			types.ReleaseScope: {
+				Verb:     types.APIVerbUpdate,
+				Resource: types.NameOrUInt{Name: "web"},
+			},
+		}
+	}
+
+	assert.True(policy.HasScopeAccess(docs, writeRelease("staging")), "should write releases in staging")
+	assert.False(policy.HasScopeAccess(docs, writeRelease("production")), "should not write releases in production")
+
+	// an API token with the same policy keeps the legacy semantics, which require the
+	// requested verb on the cluster as well
+	tokenDocs, reqErr := loader.LoadPolicyDocuments(&policy.PolicyLoaderOpts{
+		ProjectID: project.ID,
+		ProjectToken: &models.APIToken{
+			ProjectID: project.ID,
+			PolicyUID: "deployer-uid",
+		},
+	})
+
+	if reqErr != nil {
+		t.Fatalf("%v", reqErr)
+	}
+
+	assert.False(policy.HasScopeAccess(tokenDocs, writeRelease("staging")), "api token should not write releases in staging")
+
+	// a custom role referencing a policy which does not exist should be forbidden
+	_, err = projRepo.CreateProjectRole(project, &models.Role{
+		Role: types.Role{
+			UserID:    2,
+			ProjectID: project.ID,
+			Kind:      types.RoleCustom,
+			PolicyUID: "missing-uid",
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, reqErr = loader.LoadPolicyDocuments(&policy.PolicyLoaderOpts{
+		ProjectID: project.ID,
+		UserID:    2,
+	})
+
+	if reqErr == nil {
+		t.Fatalf("Expected forbidden error for missing custom role policy")
+	}
+
+	assert.Equal(
+		http.StatusForbidden,
+		reqErr.GetStatusCode(),
+		"status is not status forbidden",
+	)
+
+	assert.Equal(
+		"policy missing-uid for custom role of user 2 not found in project 1",
+		reqErr.Error(),
+		"error message is not correct",
+	)
+}
+
 func TestErrorForbiddenInvalidRole(t *testing.T) {
 	assert := assert.New(t)
 
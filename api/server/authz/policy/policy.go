@@ -35,8 +35,17 @@ func HasScopeAccess(
 				}
 			}
 
-			// for the matching scope, make sure it matches the allowed verbs
-			if !isVerbAllowed(matchDoc, reqScopes[matchScope].Verb) {
+			// for the matching scope, make sure it matches the allowed verbs. For custom roles,
+			// the requested verb only applies to the most specific scope of the request, while
+			// parent scopes only need to be readable: this lets a role grant write access to a
+			// child, such as a single namespace, without granting write access to the whole cluster.
+			verb := reqScopes[matchScope].Verb
+
+			if policyDoc.ReadableParents && !isRequestLeafScope(matchScope, types.ScopeHeirarchy, reqScopes) {
+				verb = types.APIVerbGet
+			}
+
+			if !isVerbAllowed(matchDoc, verb) {
 				isValid = false
 			}
 		}
@@ -49,6 +58,68 @@ func HasScopeAccess(
 	return false
 }
 
+// IsValidPolicy checks that every document of a `policy` is valid for the current scope
+// heirarchy. Invalid documents are never matched by HasScopeAccess, so this should be used
+// to reject them before a policy is stored.
+func IsValidPolicy(policy []*types.PolicyDocument) bool {
+	if len(policy) == 0 {
+		return false
+	}
+
+	for _, policyDoc := range policy {
+		if policyDoc == nil {
+			return false
+		}
+
+		isValid, _ := populateAndVerifyPolicyDocument(
+			policyDoc,
+			types.ScopeHeirarchy,
+			types.ProjectScope,
+			types.ReadWriteVerbGroup(),
+			nil,
+			nil,
+		)
+
+		if !isValid {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isRequestLeafScope returns false if any scope nested under `scope` is part of the request
+func isRequestLeafScope(
+	scope types.PermissionScope,
+	tree types.ScopeTree,
+	reqScopes map[types.PermissionScope]*types.RequestAction,
+) bool {
+	for currScope, subTree := range tree {
+		if currScope == scope {
+			return !hasRequestScope(subTree, reqScopes)
+		}
+
+		if !isRequestLeafScope(scope, subTree, reqScopes) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func hasRequestScope(
+	tree types.ScopeTree,
+	reqScopes map[types.PermissionScope]*types.RequestAction,
+) bool {
+	for currScope, subTree := range tree {
+		if _, ok := reqScopes[currScope]; ok || hasRequestScope(subTree, reqScopes) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func isResourceAllowed(
 	matchDoc *types.PolicyDocument,
 	resource types.NameOrUInt,
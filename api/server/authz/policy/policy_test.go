@@ -138,6 +138,82 @@ var hasScopeAccessTests = []testHasScopeAccess{
 		},
 		expRes: false,
 	},
+	{
+		description: "namespace write policy can write the namespace",
+		policy:      testPolicyNamespaceWrite,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ClusterScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					UInt: 500,
+				},
+			},
+			types.NamespaceScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					Name: "staging",
+				},
+			},
+			types.ReleaseScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					Name: "web",
+				},
+			},
+		},
+		expRes: true,
+	},
+	{
+		description: "namespace write policy cannot write the cluster",
+		policy:      testPolicyNamespaceWrite,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ClusterScope: {
+				Verb: types.APIVerbDelete,
+				Resource: types.NameOrUInt{
+					UInt: 500,
+				},
+			},
+		},
+		expRes: false,
+	},
+	{
+		description: "namespace write policy cannot write other namespaces",
+		policy:      testPolicyNamespaceWrite,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ClusterScope: {
+				Verb: types.APIVerbCreate,
+				Resource: types.NameOrUInt{
+					UInt: 500,
+				},
+			},
+			types.NamespaceScope: {
+				Verb: types.APIVerbCreate,
+				Resource: types.NameOrUInt{
+					Name: "production",
+				},
+			},
+		},
+		expRes: false,
+	},
+	{
+		description: "legacy namespace write policy cannot write the namespace",
+		policy:      testPolicyNamespaceWriteLegacy,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ClusterScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					UInt: 500,
+				},
+			},
+			types.NamespaceScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					Name: "staging",
+				},
+			},
+		},
+		expRes: false,
+	},
 	{
 		description: "test invalid policy document",
 		policy:      testInvalidPolicyDocument,
@@ -277,6 +353,69 @@ var testPolicyNamespaceSpecific = []*types.PolicyDocument{
 	},
 }
 
+// This custom role document allows a user to write to the namespace "staging" in the
+// cluster with id 500, while only being able to read the cluster itself.
+var testPolicyNamespaceWrite = []*types.PolicyDocument{
+	{
+		Scope:           types.ProjectScope,
+		Verbs:           types.ReadVerbGroup(),
+		ReadableParents: true,
+		Children: map[types.PermissionScope]*types.PolicyDocument{
+			types.ClusterScope: {
+				Scope: types.ClusterScope,
+				Verbs: types.ReadVerbGroup(),
+				Resources: []types.NameOrUInt{
+					{
+						UInt: 500,
+					},
+				},
+				Children: map[types.PermissionScope]*types.PolicyDocument{
+					types.NamespaceScope: {
+						Scope: types.NamespaceScope,
+						Verbs: types.ReadWriteVerbGroup(),
+						Resources: []types.NameOrUInt{
+							{
+								Name: "staging",
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+// This is the same document as testPolicyNamespaceWrite, as stored for an API token. Legacy
+// policies require the requested verb on every scope, so it cannot write the namespace.
+var testPolicyNamespaceWriteLegacy = []*types.PolicyDocument{
+	{
+		Scope: types.ProjectScope,
+		Verbs: types.ReadVerbGroup(),
+		Children: map[types.PermissionScope]*types.PolicyDocument{
+			types.ClusterScope: {
+				Scope: types.ClusterScope,
+				Verbs: types.ReadVerbGroup(),
+				Resources: []types.NameOrUInt{
+					{
+						UInt: 500,
+					},
+				},
+				Children: map[types.PermissionScope]*types.PolicyDocument{
+					types.NamespaceScope: {
+						Scope: types.NamespaceScope,
+						Verbs: types.ReadWriteVerbGroup(),
+						Resources: []types.NameOrUInt{
+							{
+								Name: "staging",
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
 // NOTE: these are invalid policy documents that don't follow the accepted heirarchy
 // for scopes. Don't use this as a model for a valid doc.
 var testInvalidPolicyDocument = []*types.PolicyDocument{
@@ -327,3 +466,14 @@ var testInvalidPolicyDocumentNested = []*types.PolicyDocument{
 		},
 	},
 }
+
+func TestIsValidPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(policy.IsValidPolicy(types.AdminPolicy), "admin policy should be valid")
+	assert.True(policy.IsValidPolicy(types.ViewerPolicy), "viewer policy should be valid")
+	assert.True(policy.IsValidPolicy(testPolicyNamespaceSpecific), "namespace policy should be valid")
+	assert.False(policy.IsValidPolicy(testInvalidPolicyDocument), "cluster above project should be invalid")
+	assert.False(policy.IsValidPolicy(testInvalidPolicyDocumentNested), "release under cluster should be invalid")
+	assert.False(policy.IsValidPolicy([]*types.PolicyDocument{}), "empty policy should be invalid")
+}
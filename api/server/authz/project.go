@@ -52,6 +52,22 @@ func (p *ProjectScopedMiddleware) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	// collaborators who log in with a password must have enabled two-factor authentication
+	// if the project requires it. Users of external identity providers and api tokens are
+	// not affected.
+	if project.TwoFactorRequired {
+		user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+		if user != nil && user.HasPasswordLogin() && !user.TOTPEnabled {
+			apierrors.HandleAPIError(p.config.Logger, p.config.Alerter, w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("this project requires two-factor authentication, please enable it in your account settings"),
+				http.StatusForbidden,
+			), true)
+
+			return
+		}
+	}
+
 	ctx := NewProjectContext(r.Context(), project)
 	r = r.Clone(ctx)
 	p.next.ServeHTTP(w, r)
@@ -49,6 +49,15 @@ func (p *APITokenCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	// if the expiry time is not set, set the expiry to 1 year
 	if req.ExpiresAt.IsZero() {
 		req.ExpiresAt = time.Now().Add(time.Hour * 24 * 365)
+
+		if maxExpiry, ok := getMaxTokenExpiry(proj); ok && req.ExpiresAt.After(maxExpiry) {
+			req.ExpiresAt = maxExpiry
+		}
+	}
+
+	if reqErr := checkTokenExpiry(proj, req.ExpiresAt); reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
 	}
 
 	apiPolicy, reqErr := policy.GetAPIPolicyFromUID(p.Repo().Policy(), proj.ID, req.PolicyUID)
@@ -116,3 +125,31 @@ func (p *APITokenCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 
 	p.WriteResult(w, r, apiToken.ToAPITokenType(apiPolicy.Policy, encoded))
 }
+
+// getMaxTokenExpiry returns the latest expiry allowed by the project for tokens issued now,
+// if the project limits the lifetime of api tokens
+func getMaxTokenExpiry(proj *models.Project) (time.Time, bool) {
+	if proj.APITokenMaxLifetimeDays == 0 {
+		return time.Time{}, false
+	}
+
+	return time.Now().Add(time.Duration(proj.APITokenMaxLifetimeDays) * 24 * time.Hour), true
+}
+
+func checkTokenExpiry(proj *models.Project, expiresAt time.Time) apierrors.RequestError {
+	if expiresAt.Before(time.Now()) {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("expires_at must be in the future"),
+			http.StatusBadRequest,
+		)
+	}
+
+	if maxExpiry, ok := getMaxTokenExpiry(proj); ok && expiresAt.After(maxExpiry) {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("tokens in this project cannot be valid for more than %d days", proj.APITokenMaxLifetimeDays),
+			http.StatusBadRequest,
+		)
+	}
+
+	return nil
+}
@@ -0,0 +1,136 @@
+package audit_log
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+var auditLogCSVHeader = []string{
+	"id",
+	"created_at",
+	"project_id",
+	"cluster_id",
+	"namespace",
+	"user_id",
+	"api_token_id",
+	"ip_address",
+	"method",
+	"path",
+	"route",
+	"verb",
+	"resource_type",
+	"resource_name",
+	"status_code",
+	"outcome",
+}
+
+// ExportAuditLogsHandler writes all audit logs of a project matching the filter as a
+// CSV or JSON attachment
+type ExportAuditLogsHandler struct {
+	handlers.PorterHandlerReader
+}
+
+func NewExportAuditLogsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+) *ExportAuditLogsHandler {
+	return &ExportAuditLogsHandler{
+		PorterHandlerReader: handlers.NewDefaultPorterHandler(config, decoderValidator, nil),
+	}
+}
+
+func (p *ExportAuditLogsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ExportAuditLogsRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.Format == "" {
+		request.Format = types.AuditLogExportFormatCSV
+	}
+
+	logs, _, err := p.Repo().AuditLog().ListAuditLogsByProjectID(project.ID, &request.AuditLogFilter, 0, 0)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	filename := fmt.Sprintf("audit-logs-%d-%s.%s", project.ID, time.Now().UTC().Format("20060102T150405Z"), request.Format)
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	switch request.Format {
+	case types.AuditLogExportFormatJSON:
+		res := make([]*types.AuditLog, 0)
+
+		for _, log := range logs {
+			res = append(res, log.ToAuditLogType())
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(res); err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	case types.AuditLogExportFormatCSV:
+		w.Header().Set("Content-Type", "text/csv")
+
+		csvWriter := csv.NewWriter(w)
+
+		if err := csvWriter.Write(auditLogCSVHeader); err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		for _, log := range logs {
+			if err := csvWriter.Write(getAuditLogCSVRecord(log)); err != nil {
+				p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+		}
+
+		csvWriter.Flush()
+
+		if err := csvWriter.Error(); err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+}
+
+func getAuditLogCSVRecord(log *models.AuditLog) []string {
+	return []string{
+		strconv.FormatUint(uint64(log.ID), 10),
+		log.CreatedAt.UTC().Format(time.RFC3339),
+		strconv.FormatUint(uint64(log.ProjectID), 10),
+		strconv.FormatUint(uint64(log.ClusterID), 10),
+		log.Namespace,
+		strconv.FormatUint(uint64(log.UserID), 10),
+		log.APITokenID,
+		log.IPAddress,
+		log.Method,
+		log.Path,
+		log.Route,
+		string(log.Verb),
+		string(log.ResourceType),
+		log.ResourceName,
+		strconv.Itoa(log.StatusCode),
+		string(log.Outcome),
+	}
+}
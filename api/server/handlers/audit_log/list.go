@@ -0,0 +1,60 @@
+package audit_log
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListAuditLogsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewListAuditLogsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListAuditLogsHandler {
+	return &ListAuditLogsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *ListAuditLogsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ListAuditLogsRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.Limit == 0 {
+		request.Limit = 50
+	}
+
+	logs, count, err := p.Repo().AuditLog().ListAuditLogsByProjectID(project.ID, &request.AuditLogFilter, request.Limit, request.Skip)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.ListAuditLogsResponse{
+		Count:     count,
+		Limit:     request.Limit,
+		Skip:      request.Skip,
+		AuditLogs: make([]*types.AuditLog, 0),
+	}
+
+	for _, log := range logs {
+		res.AuditLogs = append(res.AuditLogs, log.ToAuditLogType())
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,156 @@
+package cluster
+
+import (
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/eventsapi"
+	"github.com/porter-dev/porter/internal/notifier/sendgrid"
+	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+)
+
+// incidentTargets constructs the notifiers of the routing targets of a project
+type incidentTargets struct {
+	config  *config.Config
+	cluster *models.Cluster
+
+	slackInts   []*integrations.SlackIntegration
+	webhookInts []*integrations.WebhookIntegration
+
+	// email is nil if sendgrid is not configured
+	email notifier.IncidentNotifier
+}
+
+// getIncidentNotifier returns the notifier for the incidents of a cluster. Incidents are routed
+// according to the routing rules of the project, and incidents which do not match any rule are
+// sent to all slack, webhook and email notifiers of the project.
+func getIncidentNotifier(
+	config *config.Config,
+	cluster *models.Cluster,
+	notifConf *types.NotificationConfig,
+) (notifier.IncidentNotifier, error) {
+	slackInts, _ := config.Repo.SlackIntegration().ListSlackIntegrationsByProjectID(cluster.ProjectID)
+	webhookInts, _ := config.Repo.WebhookIntegration().ListWebhookIntegrationsByProjectID(cluster.ProjectID)
+
+	targets := &incidentTargets{
+		config:      config,
+		cluster:     cluster,
+		slackInts:   slackInts,
+		webhookInts: webhookInts,
+	}
+
+	if sc := config.ServerConf; sc.SendgridAPIKey != "" && sc.SendgridSenderEmail != "" && sc.SendgridIncidentAlertTemplateID != "" {
+		users, err := getUsersByProjectID(config.Repo, cluster.ProjectID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		targets.email = sendgrid.NewIncidentNotifier(&sendgrid.IncidentNotifierOpts{
+			SharedOpts: &sendgrid.SharedOpts{
+				APIKey:      sc.SendgridAPIKey,
+				SenderEmail: sc.SendgridSenderEmail,
+			},
+			IncidentAlertTemplateID:    sc.SendgridIncidentAlertTemplateID,
+			IncidentResolvedTemplateID: sc.SendgridIncidentResolvedTemplateID,
+			Users:                      users,
+		})
+	}
+
+	fallback := make([]notifier.IncidentNotifier, 0)
+
+	for _, kind := range []types.IncidentRoutingTargetKind{
+		types.IncidentRoutingTargetSlack,
+		types.IncidentRoutingTargetWebhook,
+		types.IncidentRoutingTargetEmail,
+	} {
+		if n := targets.getNotifier(&models.IncidentRoutingTarget{Kind: kind}); n != nil {
+			fallback = append(fallback, n)
+		}
+	}
+
+	rules, err := config.Repo.IncidentRoutingRule().ListIncidentRoutingRulesByProjectID(cluster.ProjectID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	routes := make([]*notifier.IncidentRoute, 0)
+
+	for _, rule := range rules {
+		route := &notifier.IncidentRoute{
+			Matches:  rule.Matches,
+			Continue: rule.Continue,
+		}
+
+		for i := range rule.Targets {
+			if n := targets.getNotifier(&rule.Targets[i]); n != nil {
+				route.Notifiers = append(route.Notifiers, n)
+			}
+		}
+
+		routes = append(routes, route)
+	}
+
+	return notifier.NewMultiIncidentNotifier(
+		notifConf,
+		notifier.NewRoutingIncidentNotifier(routes, fallback...),
+	), nil
+}
+
+// getNotifier returns the notifier of a routing target, or nil if the target cannot
+// be notified
+func (t *incidentTargets) getNotifier(target *models.IncidentRoutingTarget) notifier.IncidentNotifier {
+	switch target.Kind {
+	case types.IncidentRoutingTargetSlack:
+		if t.config.SlackConf == nil {
+			return nil
+		}
+
+		slackInts := make([]*integrations.SlackIntegration, 0)
+
+		for _, slackInt := range t.slackInts {
+			if target.IntegrationID == 0 || slackInt.ID == target.IntegrationID {
+				slackInts = append(slackInts, slackInt)
+			}
+		}
+
+		return slack.NewIncidentNotifier(slackInts...)
+	case types.IncidentRoutingTargetWebhook:
+		webhookInts := make([]*integrations.WebhookIntegration, 0)
+
+		for _, webhookInt := range t.webhookInts {
+			if target.IntegrationID == 0 || webhookInt.ID == target.IntegrationID {
+				webhookInts = append(webhookInts, webhookInt)
+			}
+		}
+
+		if len(webhookInts) == 0 {
+			return nil
+		}
+
+		return webhook.NewIncidentNotifier(
+			t.cluster.ProjectID,
+			webhook.NewSender(t.config.Repo.WebhookIntegration()),
+			webhookInts...,
+		)
+	case types.IncidentRoutingTargetEmail:
+		return t.email
+	case types.IncidentRoutingTargetEventsAPIV2:
+		return eventsapi.NewIncidentNotifier(&eventsapi.IncidentNotifierOpts{
+			URL:         target.URL,
+			RoutingKey:  string(target.RoutingKey),
+			ClusterID:   t.cluster.ID,
+			ClusterName: t.cluster.Name,
+			SeverityMapping: map[types.SeverityType]string{
+				types.SeverityCritical: target.CriticalSeverity,
+				types.SeverityNormal:   target.NormalSeverity,
+			},
+		})
+	}
+
+	return nil
+}
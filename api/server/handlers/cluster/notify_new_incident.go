@@ -13,9 +13,6 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
-	"github.com/porter-dev/porter/internal/notifier"
-	"github.com/porter-dev/porter/internal/notifier/sendgrid"
-	"github.com/porter-dev/porter/internal/notifier/slack"
 	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
 )
@@ -45,8 +42,6 @@ func (c *NotifyNewIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(cluster.ProjectID)
-
 	rel, err := c.Repo().Release().ReadRelease(cluster.ID, request.ReleaseName, request.ReleaseNamespace)
 
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -67,35 +62,13 @@ func (c *NotifyNewIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		notifConf = conf.ToNotificationConfigType()
 	}
 
-	users, err := getUsersByProjectID(c.Repo(), cluster.ProjectID)
+	multi, err := getIncidentNotifier(c.Config(), cluster, notifConf)
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
-	notifiers := make([]notifier.IncidentNotifier, 0)
-
-	if c.Config().SlackConf != nil {
-		notifiers = append(notifiers, slack.NewIncidentNotifier(slackInts...))
-	}
-
-	if sc := c.Config().ServerConf; sc.SendgridAPIKey != "" && sc.SendgridSenderEmail != "" && sc.SendgridIncidentAlertTemplateID != "" {
-		notifiers = append(notifiers, sendgrid.NewIncidentNotifier(&sendgrid.IncidentNotifierOpts{
-			SharedOpts: &sendgrid.SharedOpts{
-				APIKey:      c.Config().ServerConf.SendgridAPIKey,
-				SenderEmail: c.Config().ServerConf.SendgridSenderEmail,
-			},
-			IncidentAlertTemplateID: sc.SendgridIncidentAlertTemplateID,
-			Users:                   users,
-		}))
-	}
-
-	multi := notifier.NewMultiIncidentNotifier(
-		notifConf,
-		notifiers...,
-	)
-
 	if !cluster.NotificationsDisabled {
 		url := fmt.Sprintf(
 			"%s/applications/%s/%s/%s?project_id=%d",
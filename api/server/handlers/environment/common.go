@@ -9,6 +9,7 @@ import (
 
 	"github.com/bradleyfalzon/ghinstallation/v2"
 	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
@@ -46,6 +47,26 @@ func getGithubClientFromEnvironment(config *config.Config, env *models.Environme
 	return github.NewClient(&http.Client{Transport: itr}), nil
 }
 
+// wakeDeployment scales the deployments in the namespace of a sleeping preview deployment back up
+func wakeDeployment(
+	agentGetter authz.KubernetesAgentGetter,
+	r *http.Request,
+	cluster *models.Cluster,
+	depl *models.Deployment,
+) apierrors.RequestError {
+	agent, err := agentGetter.GetAgent(r, cluster, "")
+
+	if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	if err := agent.WakeNamespace(depl.Namespace); err != nil {
+		return apierrors.NewErrInternal(fmt.Errorf("error waking up namespace %s: %w", depl.Namespace, err))
+	}
+
+	return nil
+}
+
 func isSystemNamespace(namespace string) bool {
 	return namespace == "cert-manager" || namespace == "ingress-nginx" ||
 		namespace == "kube-node-lease" || namespace == "kube-public" ||
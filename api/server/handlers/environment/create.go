@@ -77,6 +77,9 @@ func (c *CreateEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		WebhookID:           string(webhookUID),
 		NewCommentsDisabled: request.DisableNewComments,
 		GitDeployBranches:   strings.Join(request.GitDeployBranches, ","),
+
+		IdleTimeoutHours:      request.IdleTimeoutHours,
+		MaxDeploymentAgeHours: request.MaxDeploymentAgeHours,
 	}
 
 	if len(request.NamespaceLabels) > 0 {
@@ -97,8 +97,7 @@ func (c *CreateDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	// create the deployment
-	depl, err := c.Repo().Environment().CreateDeployment(&models.Deployment{
+	depl := &models.Deployment{
 		EnvironmentID:  env.ID,
 		Namespace:      request.Namespace,
 		Status:         types.DeploymentStatusCreating,
@@ -110,7 +109,12 @@ func (c *CreateDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		CommitSHA:      request.GitHubMetadata.CommitSHA,
 		PRBranchFrom:   request.GitHubMetadata.PRBranchFrom,
 		PRBranchInto:   request.GitHubMetadata.PRBranchInto,
-	})
+	}
+
+	depl.RecordActivity()
+
+	// create the deployment
+	depl, err = c.Repo().Environment().CreateDeployment(depl)
 
 	if err != nil {
 		// try to delete the GitHub deployment
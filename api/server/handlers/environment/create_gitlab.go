@@ -0,0 +1,180 @@
+package environment
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/token"
+	"github.com/porter-dev/porter/internal/encryption"
+	gitlabCI "github.com/porter-dev/porter/internal/integrations/ci/gitlab"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/xanzy/go-gitlab"
+)
+
+type CreateGitlabEnvironmentHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewCreateGitlabEnvironmentHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateGitlabEnvironmentHandler {
+	return &CreateGitlabEnvironmentHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *CreateGitlabEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	gi, _ := r.Context().Value(types.GitlabIntegrationScope).(*ints.GitlabIntegration)
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	owner, name, ok := commonutils.GetOwnerAndNameParams(c, w, r)
+
+	if !ok {
+		return
+	}
+
+	request := &types.CreateEnvironmentRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if len(request.GitDeployBranches) > 0 {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("branch deploys are not supported for preview environments using gitlab"), http.StatusBadRequest,
+		))
+		return
+	}
+
+	// create a random webhook id
+	webhookUID, err := encryption.GenerateRandomBytes(32)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("error generating webhook UID for new preview "+
+			"environment: %w", err)))
+		return
+	}
+
+	env := &models.Environment{
+		ProjectID:           project.ID,
+		ClusterID:           cluster.ID,
+		GitlabIntegrationID: gi.ID,
+		GitlabUserID:        user.ID,
+		Name:                request.Name,
+		GitRepoOwner:        owner,
+		GitRepoName:         name,
+		GitRepoBranches:     strings.Join(request.GitRepoBranches, ","),
+		Mode:                request.Mode,
+		WebhookID:           string(webhookUID),
+		NewCommentsDisabled: request.DisableNewComments,
+
+		IdleTimeoutHours:      request.IdleTimeoutHours,
+		MaxDeploymentAgeHours: request.MaxDeploymentAgeHours,
+	}
+
+	if len(request.NamespaceLabels) > 0 {
+		var labels []string
+
+		for k, v := range request.NamespaceLabels {
+			labels = append(labels, fmt.Sprintf("%s=%s", k, v))
+		}
+
+		env.NamespaceLabels = []byte(strings.Join(labels, ","))
+	}
+
+	client, _, err := getGitlabClientFromEnvironment(c.Config(), env)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	pID := getGitlabProjectID(owner, name)
+
+	// create incoming webhook
+	hook, _, err := client.Projects.AddProjectHook(pID, &gitlab.AddProjectHookOptions{
+		URL:                   gitlab.String(getGitlabWebhookURLFromUID(c.Config().ServerConf.ServerURL, string(webhookUID))),
+		MergeRequestsEvents:   gitlab.Bool(true),
+		PushEvents:            gitlab.Bool(false),
+		Token:                 gitlab.String(c.Config().ServerConf.GitlabIncomingWebhookSecret),
+		EnableSSLVerification: gitlab.Bool(true),
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("%v: %w", errGitlabAPI, err),
+			http.StatusConflict))
+		return
+	}
+
+	env.GitlabWebhookID = hook.ID
+
+	env, err = c.Repo().Environment().CreateEnvironment(env)
+
+	if err != nil {
+		client.Projects.DeleteProjectHook(pID, hook.ID)
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("error creating environment: %w", err)))
+		return
+	}
+
+	// cleanup removes the webhook and the environment if setting up the environment fails
+	cleanup := func() {
+		client.Projects.DeleteProjectHook(pID, hook.ID)
+		c.Repo().Environment().DeleteEnvironment(env)
+	}
+
+	// generate porter jwt token
+	jwt, err := token.GetTokenForAPI(user.ID, project.ID)
+
+	if err != nil {
+		cleanup()
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("error getting token for API: %w", err)))
+		return
+	}
+
+	encoded, err := jwt.EncodeToken(c.Config().TokenConf)
+
+	if err != nil {
+		cleanup()
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("error encoding API token: %w", err)))
+		return
+	}
+
+	err = gitlabCI.SetupPreviewEnv(&gitlabCI.PreviewEnvOpts{
+		Client:          client,
+		ServerURL:       c.Config().ServerConf.ServerURL,
+		PorterToken:     encoded,
+		GitRepoOwner:    owner,
+		GitRepoName:     name,
+		EnvironmentName: request.Name,
+		InstanceName:    c.Config().ServerConf.InstanceName,
+		ProjectID:       project.ID,
+		ClusterID:       cluster.ID,
+		EnvironmentID:   env.ID,
+	})
+
+	if err != nil {
+		cleanup()
+
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("error setting up preview environment in the gitlab project: %w", err), http.StatusConflict,
+		))
+		return
+	}
+
+	c.WriteResult(w, r, env.ToEnvironmentType())
+}
@@ -0,0 +1,159 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/xanzy/go-gitlab"
+	"gorm.io/gorm"
+)
+
+type CreateGitlabDeploymentHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewCreateGitlabDeploymentHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateGitlabDeploymentHandler {
+	return &CreateGitlabDeploymentHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *CreateGitlabDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	envID, reqErr := requestutils.GetURLParamUint(r, "environment_id")
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.CreateGitlabDeploymentRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	env, apiErr := readGitlabEnvironment(c.Config(), project.ID, cluster.ID, envID)
+
+	if apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	client, _, err := getGitlabClientFromEnvironment(c.Config(), env)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// add a check for GitLab MR status
+	mrClosed, err := isGitlabMRClosed(client, env.GitRepoOwner, env.GitRepoName, int(request.MergeRequestID))
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusConflict))
+		return
+	}
+
+	if mrClosed {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("attempting to create deployment for a closed gitlab merge request"), http.StatusConflict,
+		))
+		return
+	}
+
+	gitlabDepl, _, err := client.Deployments.CreateProjectDeployment(
+		getGitlabProjectID(env.GitRepoOwner, env.GitRepoName),
+		&gitlab.CreateProjectDeploymentOptions{
+			Environment: gitlab.String(fmt.Sprintf("%s/mr-%d", env.Name, request.MergeRequestID)),
+			Ref:         gitlab.String(request.MRBranchFrom),
+			SHA:         gitlab.String(request.CommitSHA),
+			Tag:         gitlab.Bool(false),
+			Status:      gitlab.DeploymentStatus(gitlab.DeploymentStatusRunning),
+		},
+	)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("%v: %w", errGitlabAPI, err),
+			http.StatusConflict))
+		return
+	}
+
+	commitSHA := request.CommitSHA
+
+	if len(commitSHA) > 7 {
+		commitSHA = commitSHA[:7]
+	}
+
+	// the deployment may already exist if it was created by the incoming webhook, or if this
+	// is a new pipeline for an existing merge request
+	depl, err := c.Repo().Environment().ReadDeploymentByGitDetails(
+		env.ID, env.GitRepoOwner, env.GitRepoName, request.MergeRequestID,
+	)
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	} else if err != nil {
+		depl = &models.Deployment{
+			EnvironmentID: env.ID,
+			PullRequestID: request.MergeRequestID,
+			RepoOwner:     env.GitRepoOwner,
+			RepoName:      env.GitRepoName,
+		}
+	}
+
+	if depl.Status == types.DeploymentStatusSleeping {
+		if apiErr := wakeDeployment(c.KubernetesAgentGetter, r, cluster, depl); apiErr != nil {
+			c.HandleAPIError(w, r, apiErr)
+			return
+		}
+	}
+
+	if depl.Status == types.DeploymentStatusExpired {
+		depl.Restart()
+	}
+
+	depl.Status = types.DeploymentStatusCreating
+	depl.RecordActivity()
+	depl.Namespace = request.Namespace
+	depl.GitlabDeploymentID = gitlabDepl.ID
+	depl.GitlabPipelineID = int(request.PipelineID)
+	depl.CommitSHA = commitSHA
+	depl.PRBranchFrom = request.MRBranchFrom
+	depl.PRBranchInto = request.MRBranchInto
+
+	if request.MRName != "" {
+		depl.PRName = request.MRName
+	}
+
+	if depl.ID == 0 {
+		depl, err = c.Repo().Environment().CreateDeployment(depl)
+	} else {
+		depl, err = c.Repo().Environment().UpdateDeployment(depl)
+	}
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("error creating deployment: %w", err)))
+		return
+	}
+
+	c.WriteResult(w, r, depl.ToDeploymentType())
+}
@@ -15,6 +15,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/xanzy/go-gitlab"
 	"gorm.io/gorm"
 )
 
@@ -96,6 +97,22 @@ func (c *DeleteDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if env.IsGitlabEnvironment() {
+		// FIXME: ignore the status of this API call for now
+		if client, _, err := getGitlabClientFromEnvironment(c.Config(), env); err == nil && depl.GitlabDeploymentID != 0 {
+			client.Deployments.UpdateProjectDeployment(
+				getGitlabProjectID(env.GitRepoOwner, env.GitRepoName),
+				depl.GitlabDeploymentID,
+				&gitlab.UpdateProjectDeploymentOptions{
+					Status: gitlab.DeploymentStatus(gitlab.DeploymentStatusCanceled),
+				},
+			)
+		}
+
+		c.WriteResult(w, r, depl.ToDeploymentType())
+		return
+	}
+
 	client, err := getGithubClientFromEnvironment(c.Config(), env)
 
 	if err != nil {
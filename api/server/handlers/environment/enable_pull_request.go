@@ -13,7 +13,9 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	gitlabCI "github.com/porter-dev/porter/internal/integrations/ci/gitlab"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/xanzy/go-gitlab"
 	"gorm.io/gorm"
 )
 
@@ -93,6 +95,11 @@ func (c *EnablePullRequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
+	if env.IsGitlabEnvironment() {
+		c.enableGitlabMergeRequest(w, r, env, request)
+		return
+	}
+
 	client, err := getGithubClientFromEnvironment(c.Config(), env)
 
 	if err != nil {
@@ -173,3 +180,71 @@ func (c *EnablePullRequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 
 	c.WriteResult(w, r, depl.ToDeploymentType())
 }
+
+func (c *EnablePullRequestHandler) enableGitlabMergeRequest(
+	w http.ResponseWriter,
+	r *http.Request,
+	env *models.Environment,
+	request *types.PullRequest,
+) {
+	client, _, err := getGitlabClientFromEnvironment(c.Config(), env)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// add an extra check that the user has permission to read this merge request
+	mr, _, err := client.MergeRequests.GetMergeRequest(
+		getGitlabProjectID(env.GitRepoOwner, env.GitRepoName), int(request.Number), &gitlab.GetMergeRequestsOptions{},
+	)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("%v: %w", errGitlabAPI, err),
+			http.StatusConflict))
+		return
+	}
+
+	if mr.State != "opened" {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("cannot enable deployment for closed merge request"),
+			http.StatusConflict))
+		return
+	}
+
+	pipeline, err := gitlabCI.TriggerPreviewPipeline(client, env.GitRepoOwner, env.GitRepoName, &gitlabCI.PreviewPipelineOpts{
+		EnvironmentID:  env.ID,
+		MergeRequestID: mr.IID,
+		MRName:         mr.Title,
+		MRBranchFrom:   mr.SourceBranch,
+		MRBranchInto:   mr.TargetBranch,
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("please make sure the preview environment job is present in .gitlab-ci.yml of merge request "+
+				"branch %s: %w", mr.SourceBranch, err), http.StatusConflict,
+		))
+		return
+	}
+
+	// create the deployment
+	depl, err := c.Repo().Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID:    env.ID,
+		Namespace:        "",
+		Status:           types.DeploymentStatusCreating,
+		PullRequestID:    uint(mr.IID),
+		RepoOwner:        env.GitRepoOwner,
+		RepoName:         env.GitRepoName,
+		PRName:           mr.Title,
+		PRBranchFrom:     mr.SourceBranch,
+		PRBranchInto:     mr.TargetBranch,
+		GitlabPipelineID: pipeline.ID,
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, depl.ToDeploymentType())
+}
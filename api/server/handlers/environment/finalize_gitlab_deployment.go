@@ -0,0 +1,136 @@
+package environment
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/xanzy/go-gitlab"
+)
+
+type FinalizeGitlabDeploymentHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewFinalizeGitlabDeploymentHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *FinalizeGitlabDeploymentHandler {
+	return &FinalizeGitlabDeploymentHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *FinalizeGitlabDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	envID, reqErr := requestutils.GetURLParamUint(r, "environment_id")
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.FinalizeDeploymentRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	env, apiErr := readGitlabEnvironment(c.Config(), project.ID, cluster.ID, envID)
+
+	if apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	depl, apiErr := readGitlabDeployment(c.Config(), env, request.PRNumber, request.Namespace)
+
+	if apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	depl.Subdomain = request.Subdomain
+	depl.Status = types.DeploymentStatusCreated
+	depl.LastErrors = ""
+
+	// update the deployment
+	depl, err := c.Repo().Environment().UpdateDeployment(depl)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	client, instanceURL, err := getGitlabClientFromEnvironment(c.Config(), env)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if depl.GitlabDeploymentID != 0 {
+		_, _, err = client.Deployments.UpdateProjectDeployment(
+			getGitlabProjectID(env.GitRepoOwner, env.GitRepoName),
+			depl.GitlabDeploymentID,
+			&gitlab.UpdateProjectDeploymentOptions{
+				Status: gitlab.DeploymentStatus(gitlab.DeploymentStatusSuccess),
+			},
+		)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("%v: %w", errGitlabAPI, err),
+				http.StatusConflict))
+			return
+		}
+	}
+
+	// add a check for the MR to be open before creating a note
+	mrClosed, err := isGitlabMRClosed(client, env.GitRepoOwner, env.GitRepoName, int(depl.PullRequestID))
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("error fetching details of gitlab merge request for deployment ID: %d. Error: %w",
+				depl.ID, err), http.StatusConflict,
+		))
+		return
+	}
+
+	if mrClosed {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("gitlab merge request has been closed"),
+			http.StatusConflict))
+		return
+	}
+
+	noteBody := "## Porter Preview Environments\n"
+
+	if depl.Subdomain == "" {
+		noteBody += fmt.Sprintf(
+			"✅ The latest SHA ([`%s`](%s)) has been successfully deployed.",
+			depl.CommitSHA, getGitlabCommitURL(instanceURL, depl),
+		)
+	} else {
+		noteBody += fmt.Sprintf(
+			"✅ The latest SHA ([`%s`](%s)) has been successfully deployed to %s",
+			depl.CommitSHA, getGitlabCommitURL(instanceURL, depl), depl.Subdomain,
+		)
+	}
+
+	err = createOrUpdateGitlabNote(client, c.Repo(), env.NewCommentsDisabled, depl, noteBody)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, depl.ToDeploymentType())
+}
@@ -0,0 +1,300 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	gitlabCI "github.com/porter-dev/porter/internal/integrations/ci/gitlab"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/xanzy/go-gitlab"
+	"gorm.io/gorm"
+)
+
+var errGitlabAPI = errors.New("error communicating with the gitlab API")
+
+// getGitlabClientFromEnvironment returns a GitLab client for a GitLab preview environment,
+// along with the URL of the GitLab instance
+func getGitlabClientFromEnvironment(config *config.Config, env *models.Environment) (*gitlab.Client, string, error) {
+	gi, err := config.Repo.GitlabIntegration().ReadGitlabIntegration(env.ProjectID, env.GitlabIntegrationID)
+
+	if err != nil {
+		return nil, "", fmt.Errorf("error reading gitlab integration of preview environment: %w", err)
+	}
+
+	client, err := gitlabCI.NewClient(config.Repo, config, env.GitlabUserID, env.ProjectID, env.GitlabIntegrationID)
+
+	if err != nil {
+		return nil, "", fmt.Errorf("error in creating gitlab client from preview environment: %w", err)
+	}
+
+	return client, strings.TrimSuffix(gi.InstanceURL, "/"), nil
+}
+
+func getGitlabWebhookURLFromUID(serverURL, webhookUID string) string {
+	return fmt.Sprintf("%s/api/gitlab/incoming_webhook/%s", serverURL, webhookUID)
+}
+
+func getGitlabProjectID(owner, name string) string {
+	return fmt.Sprintf("%s/%s", owner, name)
+}
+
+func isGitlabMRClosed(
+	client *gitlab.Client,
+	owner, name string,
+	mrIID int,
+) (bool, error) {
+	mr, _, err := client.MergeRequests.GetMergeRequest(getGitlabProjectID(owner, name), mrIID, &gitlab.GetMergeRequestsOptions{})
+
+	if err != nil {
+		return false, fmt.Errorf("%v: %w", errGitlabAPI, err)
+	}
+
+	return mr.State != "opened", nil
+}
+
+func getGitlabCommitURL(instanceURL string, depl *models.Deployment) string {
+	return fmt.Sprintf("%s/%s/%s/-/commit/%s", instanceURL, depl.RepoOwner, depl.RepoName, depl.CommitSHA)
+}
+
+func getGitlabPipelineURL(instanceURL string, depl *models.Deployment) string {
+	return fmt.Sprintf("%s/%s/%s/-/pipelines/%d", instanceURL, depl.RepoOwner, depl.RepoName, depl.GitlabPipelineID)
+}
+
+// createOrUpdateGitlabNote mirrors createOrUpdateComment for GitLab merge requests: if new
+// comments are disabled for the environment, the existing note of the deployment is updated
+func createOrUpdateGitlabNote(
+	client *gitlab.Client,
+	repo repository.Repository,
+	newCommentsDisabled bool,
+	depl *models.Deployment,
+	body string,
+) error {
+	pID := getGitlabProjectID(depl.RepoOwner, depl.RepoName)
+
+	if newCommentsDisabled && depl.GitlabNoteID != 0 {
+		_, resp, err := client.Notes.UpdateMergeRequestNote(
+			pID, int(depl.PullRequestID), depl.GitlabNoteID,
+			&gitlab.UpdateMergeRequestNoteOptions{
+				Body: gitlab.String(body),
+			},
+		)
+
+		if err == nil {
+			return nil
+		} else if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("error updating gitlab note for deployment with ID: %d. Error: %w", depl.ID, err)
+		}
+
+		// perhaps a deleted note? create a new note
+	}
+
+	note, _, err := client.Notes.CreateMergeRequestNote(
+		pID, int(depl.PullRequestID),
+		&gitlab.CreateMergeRequestNoteOptions{
+			Body: gitlab.String(body),
+		},
+	)
+
+	if err != nil {
+		return fmt.Errorf("error creating new gitlab note for owner: %s repo %s mrNumber: %d. Error: %w",
+			depl.RepoOwner, depl.RepoName, depl.PullRequestID, err)
+	}
+
+	depl.GitlabNoteID = note.ID
+
+	_, err = repo.Environment().UpdateDeployment(depl)
+
+	if err != nil {
+		return fmt.Errorf("error updating deployment with ID: %d. Error: %w", depl.ID, err)
+	}
+
+	return nil
+}
+
+// readGitlabEnvironment reads a preview environment by its ID, and returns a bad request
+// error if the environment does not use GitLab
+func readGitlabEnvironment(
+	config *config.Config,
+	projectID, clusterID, envID uint,
+) (*models.Environment, apierrors.RequestError) {
+	env, err := config.Repo.Environment().ReadEnvironmentByID(projectID, clusterID, envID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierrors.NewErrNotFound(errEnvironmentNotFound)
+		}
+
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	if !env.IsGitlabEnvironment() {
+		return nil, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("preview environment with ID %d does not use gitlab", envID), http.StatusBadRequest,
+		)
+	}
+
+	return env, nil
+}
+
+// readGitlabDeployment reads the deployment of a GitLab preview environment by its merge
+// request number or namespace
+func readGitlabDeployment(
+	config *config.Config,
+	env *models.Environment,
+	mrNumber uint,
+	namespace string,
+) (*models.Deployment, apierrors.RequestError) {
+	if namespace == "" && mrNumber == 0 {
+		return nil, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("either namespace or pr_number must be present in request body"), http.StatusBadRequest,
+		)
+	}
+
+	var depl *models.Deployment
+	var err error
+
+	if mrNumber != 0 {
+		depl, err = config.Repo.Environment().ReadDeploymentByGitDetails(env.ID, env.GitRepoOwner, env.GitRepoName, mrNumber)
+	} else {
+		depl, err = config.Repo.Environment().ReadDeployment(env.ID, namespace)
+	}
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierrors.NewErrNotFound(errDeploymentNotFound)
+		}
+
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return depl, nil
+}
+
+func fetchOpenGitlabMergeRequests(
+	client *gitlab.Client,
+	env *models.Environment,
+	deplInfoMap map[string]bool,
+) ([]*types.PullRequest, error) {
+	branchesMap := make(map[string]bool)
+
+	for _, br := range env.ToEnvironmentType().GitRepoBranches {
+		branchesMap[br] = true
+	}
+
+	opts := &gitlab.ListProjectMergeRequestsOptions{
+		State: gitlab.String("opened"),
+		ListOptions: gitlab.ListOptions{
+			PerPage: 100,
+		},
+	}
+
+	var mrs []*types.PullRequest
+
+	for {
+		openMRs, resp, err := client.MergeRequests.ListProjectMergeRequests(
+			getGitlabProjectID(env.GitRepoOwner, env.GitRepoName), opts,
+		)
+
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return mrs, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("%v: %w", errGitlabAPI, err)
+		}
+
+		for _, mr := range openMRs {
+			if len(branchesMap) > 0 {
+				if _, ok := branchesMap[mr.TargetBranch]; !ok {
+					continue
+				}
+			}
+
+			if _, ok := deplInfoMap[fmt.Sprintf("%s-%s-%d", env.GitRepoOwner, env.GitRepoName, mr.IID)]; ok {
+				continue
+			}
+
+			pr := &types.PullRequest{
+				Title:      mr.Title,
+				Number:     uint(mr.IID),
+				RepoOwner:  env.GitRepoOwner,
+				RepoName:   env.GitRepoName,
+				BranchFrom: mr.SourceBranch,
+				BranchInto: mr.TargetBranch,
+			}
+
+			if mr.CreatedAt != nil {
+				pr.CreatedAt = *mr.CreatedAt
+			}
+
+			if mr.UpdatedAt != nil {
+				pr.UpdatedAt = *mr.UpdatedAt
+			}
+
+			mrs = append(mrs, pr)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	return mrs, nil
+}
+
+// triggerGitlabPreviewPipeline creates a new preview pipeline for the merge request of a
+// deployment and stores the ID of the pipeline in the deployment
+func triggerGitlabPreviewPipeline(
+	config *config.Config,
+	env *models.Environment,
+	depl *models.Deployment,
+	status types.DeploymentStatus,
+) apierrors.RequestError {
+	client, _, err := getGitlabClientFromEnvironment(config, env)
+
+	if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	// add a check for the MR to be open before creating a pipeline
+	mrClosed, err := isGitlabMRClosed(client, depl.RepoOwner, depl.RepoName, int(depl.PullRequestID))
+
+	if err != nil {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("error fetching details of gitlab merge request for deployment ID: %d. Error: %w",
+				depl.ID, err), http.StatusConflict,
+		)
+	}
+
+	if mrClosed {
+		return apierrors.NewErrPassThroughToClient(fmt.Errorf("gitlab merge request has been closed"),
+			http.StatusConflict)
+	}
+
+	pipeline, err := gitlabCI.TriggerPreviewPipeline(client, env.GitRepoOwner, env.GitRepoName, &gitlabCI.PreviewPipelineOpts{
+		EnvironmentID:  env.ID,
+		MergeRequestID: int(depl.PullRequestID),
+		MRName:         depl.PRName,
+		MRBranchFrom:   depl.PRBranchFrom,
+		MRBranchInto:   depl.PRBranchInto,
+	})
+
+	if err != nil {
+		return apierrors.NewErrPassThroughToClient(fmt.Errorf("%v: %w", errGitlabAPI, err), http.StatusConflict)
+	}
+
+	depl.Status = status
+	depl.GitlabPipelineID = pipeline.ID
+
+	if _, err := config.Repo.Environment().UpdateDeployment(depl); err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	return nil
+}
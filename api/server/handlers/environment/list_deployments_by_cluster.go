@@ -84,6 +84,11 @@ func (c *ListDeploymentsByClusterHandler) ServeHTTP(w http.ResponseWriter, r *ht
 				return
 			}
 
+			if env.IsGitlabEnvironment() {
+				wg.Done()
+				continue
+			}
+
 			if _, ok := envToGithubClientMap[env.ID]; !ok {
 				client, err := getGithubClientFromEnvironment(c.Config(), env)
 
@@ -112,6 +117,18 @@ func (c *ListDeploymentsByClusterHandler) ServeHTTP(w http.ResponseWriter, r *ht
 		}
 
 		for _, env := range envList {
+			if env.IsGitlabEnvironment() {
+				mrs, err := c.listGitlabEnvironmentMergeRequests(env, deployments, deplInfoMap)
+
+				if err != nil {
+					c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+					return
+				}
+
+				pullRequests = append(pullRequests, mrs...)
+				continue
+			}
+
 			if _, ok := envToGithubClientMap[env.ID]; !ok {
 				client, err := getGithubClientFromEnvironment(c.Config(), env)
 
@@ -149,6 +166,30 @@ func (c *ListDeploymentsByClusterHandler) ServeHTTP(w http.ResponseWriter, r *ht
 
 		deplInfoMap := make(map[string]bool)
 
+		if env.IsGitlabEnvironment() {
+			for _, depl := range depls {
+				deployment := depl.ToDeploymentType()
+				deplInfoMap[fmt.Sprintf(
+					"%s-%s-%d", deployment.RepoOwner, deployment.RepoName, deployment.PullRequestID,
+				)] = true
+
+				deployments = append(deployments, deployment)
+			}
+
+			mrs, err := c.listGitlabEnvironmentMergeRequests(env, deployments, deplInfoMap)
+
+			if err != nil {
+				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+
+			c.WriteResult(w, r, map[string]interface{}{
+				"pull_requests": mrs,
+				"deployments":   deployments,
+			})
+			return
+		}
+
 		client, err := getGithubClientFromEnvironment(c.Config(), env)
 
 		if err != nil {
@@ -196,6 +237,34 @@ func (c *ListDeploymentsByClusterHandler) ServeHTTP(w http.ResponseWriter, r *ht
 	})
 }
 
+// listGitlabEnvironmentMergeRequests sets the pipeline URLs of the deployments of a GitLab
+// environment, and returns the open merge requests of the environment without a deployment
+func (c *ListDeploymentsByClusterHandler) listGitlabEnvironmentMergeRequests(
+	env *models.Environment,
+	deployments []*types.Deployment,
+	deplInfoMap map[string]bool,
+) ([]*types.PullRequest, error) {
+	client, instanceURL, err := getGitlabClientFromEnvironment(c.Config(), env)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, deployment := range deployments {
+		if deployment.EnvironmentID != env.ID {
+			continue
+		}
+
+		depl, err := c.Repo().Environment().ReadDeploymentByID(env.ProjectID, env.ClusterID, deployment.ID)
+
+		if err == nil && depl.GitlabPipelineID != 0 {
+			deployment.LastWorkflowRunURL = getGitlabPipelineURL(instanceURL, depl)
+		}
+	}
+
+	return fetchOpenGitlabMergeRequests(client, env, deplInfoMap)
+}
+
 func updateDeploymentWithGithubWorkflowRunStatus(
 	config *config.Config,
 	client *github.Client,
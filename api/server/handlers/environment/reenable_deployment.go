@@ -64,6 +64,14 @@ func (c *ReenableDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if env.IsGitlabEnvironment() {
+		if apiErr := triggerGitlabPreviewPipeline(c.Config(), env, depl, types.DeploymentStatusCreating); apiErr != nil {
+			c.HandleAPIError(w, r, apiErr)
+		}
+
+		return
+	}
+
 	client, err := getGithubClientFromEnvironment(c.Config(), env)
 
 	if err != nil {
@@ -65,6 +65,14 @@ func (c *TriggerDeploymentWorkflowHandler) ServeHTTP(w http.ResponseWriter, r *h
 		return
 	}
 
+	if env.IsGitlabEnvironment() {
+		if apiErr := triggerGitlabPreviewPipeline(c.Config(), env, depl, types.DeploymentStatusUpdating); apiErr != nil {
+			c.HandleAPIError(w, r, apiErr)
+		}
+
+		return
+	}
+
 	client, err := getGithubClientFromEnvironment(c.Config(), env)
 
 	if err != nil {
@@ -141,6 +141,22 @@ func (c *UpdateDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if depl.Status == types.DeploymentStatusSleeping {
+		if apiErr := wakeDeployment(c.KubernetesAgentGetter, r, cluster, depl); apiErr != nil {
+			c.HandleAPIError(w, r, apiErr)
+			return
+		}
+	}
+
+	if depl.Status == types.DeploymentStatusExpired {
+		depl.Restart()
+	}
+
+	if depl.Status == types.DeploymentStatusSleeping || depl.Status == types.DeploymentStatusExpired {
+		depl.Status = types.DeploymentStatusUpdating
+	}
+
+	depl.RecordActivity()
 	depl.Namespace = request.Namespace
 	depl.GHDeploymentID = ghDeployment.GetID()
 	depl.CommitSHA = request.CommitSHA
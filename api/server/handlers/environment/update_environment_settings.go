@@ -64,6 +64,13 @@ func (c *UpdateEnvironmentSettingsHandler) ServeHTTP(w http.ResponseWriter, r *h
 		return
 	}
 
+	if env.IsGitlabEnvironment() && len(request.GitDeployBranches) > 0 {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("branch deploys are not supported for preview environments using gitlab"), http.StatusBadRequest,
+		))
+		return
+	}
+
 	var newBranches []string
 
 	for _, br := range request.GitRepoBranches {
@@ -162,6 +169,16 @@ func (c *UpdateEnvironmentSettingsHandler) ServeHTTP(w http.ResponseWriter, r *h
 		changed = true
 	}
 
+	if request.IdleTimeoutHours != nil && *request.IdleTimeoutHours != env.IdleTimeoutHours {
+		env.IdleTimeoutHours = *request.IdleTimeoutHours
+		changed = true
+	}
+
+	if request.MaxDeploymentAgeHours != nil && *request.MaxDeploymentAgeHours != env.MaxDeploymentAgeHours {
+		env.MaxDeploymentAgeHours = *request.MaxDeploymentAgeHours
+		changed = true
+	}
+
 	if len(request.NamespaceLabels) > 0 {
 		var labels []string
 
@@ -17,6 +17,7 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/integrations/preview"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/xanzy/go-gitlab"
 	"gorm.io/gorm"
 )
 
@@ -65,6 +66,11 @@ func (c *ValidatePorterYAMLHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if env.IsGitlabEnvironment() {
+		c.validateGitlabPorterYAML(w, r, env, req)
+		return
+	}
+
 	ghClient, err := getGithubClientFromEnvironment(c.Config(), env)
 
 	if err != nil {
@@ -126,3 +132,65 @@ func (c *ValidatePorterYAMLHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 
 	c.WriteResult(w, r, res)
 }
+
+func (c *ValidatePorterYAMLHandler) validateGitlabPorterYAML(
+	w http.ResponseWriter,
+	r *http.Request,
+	env *models.Environment,
+	req *types.ValidatePorterYAMLRequest,
+) {
+	client, _, err := getGitlabClientFromEnvironment(c.Config(), env)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.ValidatePorterYAMLResponse{
+		Errors: []string{},
+	}
+
+	pID := getGitlabProjectID(env.GitRepoOwner, env.GitRepoName)
+
+	if req.Branch == "" { // get the default branch name
+		project, _, err := client.Projects.GetProject(pID, &gitlab.GetProjectOptions{})
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		req.Branch = project.DefaultBranch
+	}
+
+	fileContents, glResp, err := client.RepositoryFiles.GetRawFile(pID, "porter.yaml", &gitlab.GetRawFileOptions{
+		Ref: gitlab.String(req.Branch),
+	})
+
+	if glResp != nil && glResp.StatusCode == http.StatusNotFound {
+		res.Errors = append(res.Errors, preview.ErrNoPorterYAMLFile.Error())
+		c.WriteResult(w, r, res)
+		return
+	}
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	contents := string(fileContents)
+
+	if strings.TrimSpace(contents) == "" {
+		res.Errors = append(res.Errors, preview.ErrEmptyPorterYAMLFile.Error())
+		c.WriteResult(w, r, res)
+		return
+	}
+
+	for _, err := range preview.Validate(contents) {
+		if err != nil {
+			res.Errors = append(res.Errors, err.Error())
+		}
+	}
+
+	c.WriteResult(w, r, res)
+}
@@ -0,0 +1,62 @@
+package incident_routing
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type CreateIncidentRoutingRuleHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewCreateIncidentRoutingRuleHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateIncidentRoutingRuleHandler {
+	return &CreateIncidentRoutingRuleHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *CreateIncidentRoutingRuleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateIncidentRoutingRuleRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	targets, reqErr := getRoutingTargets(p.Repo(), project.ID, request.Targets)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	rule, err := p.Repo().IncidentRoutingRule().CreateIncidentRoutingRule(&models.IncidentRoutingRule{
+		ProjectID:          project.ID,
+		Name:               request.Name,
+		Priority:           request.Priority,
+		Continue:           request.Continue,
+		ReleaseName:        request.ReleaseName,
+		Namespace:          request.Namespace,
+		Severity:           request.Severity,
+		InvolvedObjectKind: request.InvolvedObjectKind,
+		Targets:            targets,
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, rule.ToIncidentRoutingRuleType())
+}
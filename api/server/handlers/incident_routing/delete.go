@@ -0,0 +1,41 @@
+package incident_routing
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type DeleteIncidentRoutingRuleHandler struct {
+	handlers.PorterHandler
+}
+
+func NewDeleteIncidentRoutingRuleHandler(
+	config *config.Config,
+) *DeleteIncidentRoutingRuleHandler {
+	return &DeleteIncidentRoutingRuleHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+func (p *DeleteIncidentRoutingRuleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	rule, reqErr := readIncidentRoutingRule(p.Repo(), r, project.ID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	if err := p.Repo().IncidentRoutingRule().DeleteIncidentRoutingRule(rule); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,109 @@
+package incident_routing
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// readIncidentRoutingRule reads the routing rule referenced by the URL of the request,
+// which must belong to the given project
+func readIncidentRoutingRule(
+	repo repository.Repository,
+	r *http.Request,
+	projectID uint,
+) (*models.IncidentRoutingRule, apierrors.RequestError) {
+	ruleID, reqErr := requestutils.GetURLParamUint(r, types.URLParamIncidentRoutingRuleID)
+
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	rule, err := repo.IncidentRoutingRule().ReadIncidentRoutingRule(projectID, ruleID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("incident routing rule with id %d not found in project", ruleID),
+				http.StatusNotFound,
+			)
+		}
+
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return rule, nil
+}
+
+// getRoutingTargets validates the targets of a request, and converts them to models
+func getRoutingTargets(
+	repo repository.Repository,
+	projectID uint,
+	request []*types.CreateIncidentRoutingTargetRequest,
+) ([]models.IncidentRoutingTarget, apierrors.RequestError) {
+	targets := make([]models.IncidentRoutingTarget, 0)
+
+	for _, target := range request {
+		switch target.Kind {
+		case types.IncidentRoutingTargetSlack:
+			if target.IntegrationID != 0 {
+				slackInts, err := repo.SlackIntegration().ListSlackIntegrationsByProjectID(projectID)
+
+				if err != nil {
+					return nil, apierrors.NewErrInternal(err)
+				}
+
+				found := false
+
+				for _, slackInt := range slackInts {
+					found = found || slackInt.ID == target.IntegrationID
+				}
+
+				if !found {
+					return nil, apierrors.NewErrPassThroughToClient(
+						fmt.Errorf("slack integration with id %d not found in project", target.IntegrationID),
+						http.StatusBadRequest,
+					)
+				}
+			}
+		case types.IncidentRoutingTargetWebhook:
+			if target.IntegrationID != 0 {
+				_, err := repo.WebhookIntegration().ReadWebhookIntegration(projectID, target.IntegrationID)
+
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					return nil, apierrors.NewErrPassThroughToClient(
+						fmt.Errorf("webhook integration with id %d not found in project", target.IntegrationID),
+						http.StatusBadRequest,
+					)
+				} else if err != nil {
+					return nil, apierrors.NewErrInternal(err)
+				}
+			}
+		case types.IncidentRoutingTargetEventsAPIV2:
+			if target.RoutingKey == "" {
+				return nil, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("routing_key is required for events_api_v2 targets"),
+					http.StatusBadRequest,
+				)
+			}
+		}
+
+		targets = append(targets, models.IncidentRoutingTarget{
+			Kind:             target.Kind,
+			IntegrationID:    target.IntegrationID,
+			URL:              target.URL,
+			RoutingKey:       []byte(target.RoutingKey),
+			CriticalSeverity: target.CriticalSeverity,
+			NormalSeverity:   target.NormalSeverity,
+		})
+	}
+
+	return targets, nil
+}
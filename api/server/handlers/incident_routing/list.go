@@ -0,0 +1,44 @@
+package incident_routing
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListIncidentRoutingRulesHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewListIncidentRoutingRulesHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListIncidentRoutingRulesHandler {
+	return &ListIncidentRoutingRulesHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *ListIncidentRoutingRulesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	rules, err := p.Repo().IncidentRoutingRule().ListIncidentRoutingRulesByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListIncidentRoutingRulesResponse, 0)
+
+	for _, rule := range rules {
+		res = append(res, rule.ToIncidentRoutingRuleType())
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,68 @@
+package incident_routing
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type UpdateIncidentRoutingRuleHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUpdateIncidentRoutingRuleHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdateIncidentRoutingRuleHandler {
+	return &UpdateIncidentRoutingRuleHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *UpdateIncidentRoutingRuleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	rule, reqErr := readIncidentRoutingRule(p.Repo(), r, project.ID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.UpdateIncidentRoutingRuleRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	targets, reqErr := getRoutingTargets(p.Repo(), project.ID, request.Targets)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	rule.Name = request.Name
+	rule.Priority = request.Priority
+	rule.Continue = request.Continue
+	rule.ReleaseName = request.ReleaseName
+	rule.Namespace = request.Namespace
+	rule.Severity = request.Severity
+	rule.InvolvedObjectKind = request.InvolvedObjectKind
+	rule.Targets = targets
+
+	rule, err := p.Repo().IncidentRoutingRule().UpdateIncidentRoutingRule(rule)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, rule.ToIncidentRoutingRuleType())
+}
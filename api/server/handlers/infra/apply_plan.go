@@ -0,0 +1,88 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	ptypes "github.com/porter-dev/porter/provisioner/types"
+)
+
+type InfraApplyPlanHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewInfraApplyPlanHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *InfraApplyPlanHandler {
+	return &InfraApplyPlanHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *InfraApplyPlanHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	infra, _ := r.Context().Value(types.InfraScope).(*models.Infra)
+	operation, _ := r.Context().Value(types.OperationScope).(*models.Operation)
+
+	if operation.Type != "plan" || operation.Status != "completed" {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("operation %s is not a completed plan operation", operation.UID),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	operations, err := c.Repo().Infra().ListOperations(infra.ID)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// drift detection operations do not change the infra, so they are ignored when looking for
+	// the last operation
+	var lastOperation *models.Operation
+
+	for _, op := range operations {
+		if op.Type != "detect_drift" {
+			lastOperation = op
+			break
+		}
+	}
+
+	// the plan was computed against the state at the time of planning, so it can only be applied
+	// if no other operation has been run since
+	if lastOperation != nil && lastOperation.UID != operation.UID {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("plan %s is outdated, as operation %s has been run since. Please create a new plan.",
+				operation.UID, lastOperation.UID),
+			http.StatusPreconditionFailed,
+		))
+
+		return
+	}
+
+	// call apply on the provisioner service, which applies the stored plan with the values it was
+	// planned with
+	resp, err := c.Config().ProvisionerClient.Apply(context.Background(), proj.ID, infra.ID, &ptypes.ApplyBaseRequest{
+		Kind:            string(infra.Kind),
+		OperationKind:   "update",
+		PlanOperationID: operation.UID,
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, resp)
+}
@@ -0,0 +1,240 @@
+package infra_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers/infra"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/provisioner/client"
+	ptypes "github.com/porter-dev/porter/provisioner/types"
+)
+
+// fakeProvisioner records the apply requests sent to the provisioner service
+type fakeProvisioner struct {
+	applyReqs []*ptypes.ApplyBaseRequest
+}
+
+func (p *fakeProvisioner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/projects/1/infras/1/apply" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	req := &ptypes.ApplyBaseRequest{}
+
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	p.applyReqs = append(p.applyReqs, req)
+
+	json.NewEncoder(w).Encode(&types.Operation{
+		OperationMeta: &types.OperationMeta{
+			UID:     "applied",
+			InfraID: 1,
+			Type:    "update",
+			Status:  "starting",
+		},
+	})
+}
+
+type applyPlanTestEnv struct {
+	config      *config.Config
+	proj        *models.Project
+	infra       *models.Infra
+	provisioner *fakeProvisioner
+}
+
+// newApplyPlanTestEnv creates a project with two infras, the first of which has the given
+// operations, and the second of which has a completed plan operation
+func newApplyPlanTestEnv(t *testing.T, operations ...*models.Operation) *applyPlanTestEnv {
+	config := apitest.LoadConfig(t)
+
+	proj, err := config.Repo.Project().CreateProject(&models.Project{Name: "test-project"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	infras := make([]*models.Infra, 0)
+
+	for i := 0; i < 2; i++ {
+		infra, err := config.Repo.Infra().CreateInfra(&models.Infra{
+			ProjectID: proj.ID,
+			Kind:      types.InfraEKS,
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		infras = append(infras, infra)
+	}
+
+	for _, op := range operations {
+		if _, err := config.Repo.Infra().AddOperation(infras[0], op); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	_, err = config.Repo.Infra().AddOperation(infras[1], &models.Operation{
+		UID:    "other-infra-plan",
+		Type:   "plan",
+		Status: "completed",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provisioner := &fakeProvisioner{}
+	server := httptest.NewServer(provisioner)
+
+	t.Cleanup(server.Close)
+
+	config.ProvisionerClient = &client.Client{
+		BaseURL:    server.URL,
+		HTTPClient: server.Client(),
+	}
+
+	return &applyPlanTestEnv{
+		config:      config,
+		proj:        proj,
+		infra:       infras[0],
+		provisioner: provisioner,
+	}
+}
+
+// applyPlan calls the apply plan handler through the operation scope middleware, which reads
+// the operation of the infra
+func (e *applyPlanTestEnv) applyPlan(t *testing.T, operationUID string) *httptest.ResponseRecorder {
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/infras/1/operations/"+operationUID+"/apply_plan", nil)
+
+	req = apitest.WithProject(t, req, e.proj)
+	req = req.WithContext(authz.NewInfraContext(req.Context(), e.infra))
+	req = apitest.WithRequestScopes(t, req, map[types.PermissionScope]*types.RequestAction{
+		types.OperationScope: {
+			Verb:     types.APIVerbUpdate,
+			Resource: types.NameOrUInt{Name: operationUID},
+		},
+	})
+
+	handler := authz.NewOperationScopedFactory(e.config).Middleware(infra.NewInfraApplyPlanHandler(
+		e.config,
+		shared.NewDefaultResultWriter(e.config.Logger, e.config.Alerter),
+	))
+
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestApplyPlanSuccessful(t *testing.T) {
+	env := newApplyPlanTestEnv(t,
+		&models.Operation{UID: "create", Type: "create", Status: "completed"},
+		&models.Operation{UID: "plan", Type: "plan", Status: "completed"},
+		&models.Operation{UID: "drift", Type: "detect_drift", Status: "completed"},
+	)
+
+	rr := env.applyPlan(t, "plan")
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d: %s", http.StatusOK, rr.Code, rr.Body.String())
+	}
+
+	if len(env.provisioner.applyReqs) != 1 {
+		t.Fatalf("expected 1 apply request to the provisioner, got %d", len(env.provisioner.applyReqs))
+	}
+
+	req := env.provisioner.applyReqs[0]
+
+	if req.PlanOperationID != "plan" || req.OperationKind != "update" || req.Kind != string(types.InfraEKS) {
+		t.Errorf("expected the plan to be applied as an update, got %+v", req)
+	}
+
+	gotOperation := &types.Operation{}
+
+	if err := json.NewDecoder(rr.Body).Decode(gotOperation); err != nil {
+		t.Fatal(err)
+	}
+
+	if gotOperation.UID != "applied" {
+		t.Errorf("expected the operation of the provisioner to be returned, got %s", gotOperation.UID)
+	}
+}
+
+func TestApplyPlanErrors(t *testing.T) {
+	tests := []struct {
+		name         string
+		operations   []*models.Operation
+		operationUID string
+		expCode      int
+	}{
+		{
+			name:         "plan not found",
+			operationUID: "missing",
+			expCode:      http.StatusForbidden,
+		},
+		{
+			name:         "plan belongs to another infra",
+			operationUID: "other-infra-plan",
+			expCode:      http.StatusForbidden,
+		},
+		{
+			name: "plan not completed",
+			operations: []*models.Operation{
+				{UID: "plan", Type: "plan", Status: "starting"},
+			},
+			operationUID: "plan",
+			expCode:      http.StatusBadRequest,
+		},
+		{
+			name: "plan errored",
+			operations: []*models.Operation{
+				{UID: "plan", Type: "plan", Status: "errored", Errored: true},
+			},
+			operationUID: "plan",
+			expCode:      http.StatusBadRequest,
+		},
+		{
+			name: "operation is not a plan",
+			operations: []*models.Operation{
+				{UID: "update", Type: "update", Status: "completed"},
+			},
+			operationUID: "update",
+			expCode:      http.StatusBadRequest,
+		},
+		{
+			name: "plan outdated",
+			operations: []*models.Operation{
+				{UID: "plan", Type: "plan", Status: "completed"},
+				{UID: "update", Type: "update", Status: "completed"},
+			},
+			operationUID: "plan",
+			expCode:      http.StatusPreconditionFailed,
+		},
+	}
+
+	for _, test := range tests {
+		env := newApplyPlanTestEnv(t, test.operations...)
+
+		rr := env.applyPlan(t, test.operationUID)
+
+		if rr.Code != test.expCode {
+			t.Errorf("%s: expected status %d, got %d: %s", test.name, test.expCode, rr.Code, rr.Body.String())
+		}
+
+		if len(env.provisioner.applyReqs) != 0 {
+			t.Errorf("%s: expected no apply requests to the provisioner, got %d", test.name, len(env.provisioner.applyReqs))
+		}
+	}
+}
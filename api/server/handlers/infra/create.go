@@ -246,7 +246,7 @@ func (i *InfraRDSPostrenderer) Run(w http.ResponseWriter, r *http.Request, opts
 			return nil, false
 		}
 
-		clusterInfraOperation, err := i.config.Repo.Infra().GetLatestOperation(clusterInfra)
+		clusterInfraOperation, err := i.config.Repo.Infra().GetLatestAppliedOperation(clusterInfra)
 
 		// get the raw state for the cluster
 		rawState, err := i.config.ProvisionerClient.GetRawState(context.Background(), models.GetWorkspaceID(clusterInfra, clusterInfraOperation))
@@ -47,7 +47,8 @@ func (c *InfraDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	lastOperation, err := c.Repo().Infra().GetLatestOperation(infra)
+	// plans and drift detections do not change the infra, so they never block other operations
+	lastOperation, err := c.Repo().Infra().GetLatestAppliedOperation(infra)
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -32,8 +32,9 @@ func (c *InfraGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	res := infra.ToInfraType()
 
-	// look for the latest operation and attach it, if it exists
-	operation, err := c.Repo().Infra().GetLatestOperation(infra)
+	// look for the latest operation and attach it, if it exists. Plans and drift detections are
+	// skipped, as they do not change the infra.
+	operation, err := c.Repo().Infra().GetLatestAppliedOperation(infra)
 
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
@@ -54,5 +55,14 @@ func (c *InfraGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	res.LatestOperation = op
 
+	// if drift was detected, attach the resources which drifted from the latest applied operation
+	if infra.DriftStatus == types.InfraDriftDrifted && operation.DriftDetectedAt != nil {
+		res.Drift = &types.InfraDrift{
+			OperationID: operation.UID,
+			DetectedAt:  *operation.DriftDetectedAt,
+			Resources:   op.DriftedResources,
+		}
+	}
+
 	c.WriteResult(w, r, res)
 }
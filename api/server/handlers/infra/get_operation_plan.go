@@ -0,0 +1,50 @@
+package infra
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type InfraGetOperationPlanHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewInfraGetOperationPlanHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *InfraGetOperationPlanHandler {
+	return &InfraGetOperationPlanHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *InfraGetOperationPlanHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	infra, _ := r.Context().Value(types.InfraScope).(*models.Infra)
+	operation, _ := r.Context().Value(types.OperationScope).(*models.Operation)
+
+	if !operation.IsPlan() {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("operation %s is not a plan or drift detection operation", operation.UID),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	plan, err := c.Config().ProvisionerClient.GetPlan(context.Background(), models.GetWorkspaceID(infra, operation))
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, plan.ToInfraPlan(operation.UID))
+}
@@ -63,7 +63,8 @@ func (c *InfraRetryCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	lastOperation, err := c.Repo().Infra().GetLatestOperation(infra)
+	// plans and drift detections do not change the infra, so they never block other operations
+	lastOperation, err := c.Repo().Infra().GetLatestAppliedOperation(infra)
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -82,10 +83,7 @@ func (c *InfraRetryCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 
 	// if the values are nil, get the last applied values and marshal them
 	if req.Values == nil || len(req.Values) == 0 {
-
-		rawValues := lastOperation.LastApplied
-
-		err = json.Unmarshal(rawValues, &req.Values)
+		err = json.Unmarshal(lastOperation.LastApplied, &req.Values)
 
 		if err != nil {
 			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
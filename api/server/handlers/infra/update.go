@@ -36,6 +36,37 @@ func (c *InfraUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	vals, ok := getInfraUpdateValues(c, w, r, proj, infra, req)
+
+	if !ok {
+		return
+	}
+
+	// call apply on the provisioner service
+	resp, err := c.Config().ProvisionerClient.Apply(context.Background(), proj.ID, infra.ID, &ptypes.ApplyBaseRequest{
+		Kind:          string(infra.Kind),
+		Values:        vals,
+		OperationKind: "update",
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, resp)
+}
+
+// getInfraUpdateValues verifies that a new operation can be run against the infra, and returns the
+// values to run it with, falling back to the values of the latest operation
+func getInfraUpdateValues(
+	c handlers.PorterHandlerReadWriter,
+	w http.ResponseWriter,
+	r *http.Request,
+	proj *models.Project,
+	infra *models.Infra,
+	req *types.RetryInfraRequest,
+) (map[string]interface{}, bool) {
 	var cluster *models.Cluster
 	var err error
 
@@ -51,7 +82,7 @@ func (c *InfraUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 			}
 
-			return
+			return nil, false
 		}
 	}
 
@@ -60,14 +91,15 @@ func (c *InfraUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
-		return
+		return nil, false
 	}
 
-	lastOperation, err := c.Repo().Infra().GetLatestOperation(infra)
+	// plans and drift detections do not change the infra, so they never block other operations
+	lastOperation, err := c.Repo().Infra().GetLatestAppliedOperation(infra)
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
+		return nil, false
 	}
 
 	// if the last operation is in a "starting" state, block apply
@@ -77,18 +109,17 @@ func (c *InfraUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			http.StatusBadRequest,
 		))
 
-		return
+		return nil, false
 	}
 
-	// if the values are nil, get the last applied values and marshal them
+	// if the values are nil, get the last applied values and marshal them. Plans are skipped, as
+	// their values have not been applied.
 	if req.Values == nil || len(req.Values) == 0 {
-		rawValues := lastOperation.LastApplied
-
-		err = json.Unmarshal(rawValues, &req.Values)
+		err = json.Unmarshal(lastOperation.LastApplied, &req.Values)
 
 		if err != nil {
 			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-			return
+			return nil, false
 		}
 	}
 
@@ -106,21 +137,9 @@ func (c *InfraUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			Cluster: cluster,
 			Values:  vals,
 		}); !ok {
-			return
+			return nil, false
 		}
 	}
 
-	// call apply on the provisioner service
-	resp, err := c.Config().ProvisionerClient.Apply(context.Background(), proj.ID, infra.ID, &ptypes.ApplyBaseRequest{
-		Kind:          string(infra.Kind),
-		Values:        vals,
-		OperationKind: "update",
-	})
-
-	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
-	}
-
-	c.WriteResult(w, r, resp)
+	return vals, true
 }
@@ -97,11 +97,20 @@ func (c *CloneEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		secretVars[key] = string(val)
 	}
 
+	// the clone keeps reading secrets from the same external secret store paths
+	sourceEnvGroup, err := envgroup.ToEnvGroup(cm)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
 	configMap, err := envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
-		Name:            request.TargetName,
-		Namespace:       request.TargetNamespace,
-		Variables:       vars,
-		SecretVariables: secretVars,
+		Name:                    request.TargetName,
+		Namespace:               request.TargetNamespace,
+		Variables:               vars,
+		SecretVariables:         secretVars,
+		ExternalSecretVariables: sourceEnvGroup.ExternalSecretVariables,
 	})
 
 	if err != nil {
@@ -0,0 +1,54 @@
+package policy_pack
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type CreatePolicyPackHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewCreatePolicyPackHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreatePolicyPackHandler {
+	return &CreatePolicyPackHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *CreatePolicyPackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreatePolicyPackRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	pack := &models.PolicyPack{
+		ProjectID: project.ID,
+	}
+
+	if reqErr := setPolicyPackFromRequest(p.Repo(), pack, request); reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	pack, err := p.Repo().PolicyPack().CreatePolicyPack(pack)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, pack.ToPolicyPackType())
+}
@@ -0,0 +1,96 @@
+package policy_pack
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/opa"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// readPolicyPack reads the policy pack referenced by the URL of the request, which must
+// belong to the given project
+func readPolicyPack(
+	repo repository.Repository,
+	r *http.Request,
+	projectID uint,
+) (*models.PolicyPack, apierrors.RequestError) {
+	packID, reqErr := requestutils.GetURLParamUint(r, types.URLParamPolicyPackID)
+
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	pack, err := repo.PolicyPack().ReadPolicyPack(projectID, packID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("policy pack with id %d not found in project", packID),
+				http.StatusNotFound,
+			)
+		}
+
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return pack, nil
+}
+
+// setPolicyPackFromRequest populates the pack from the request, and checks that the name
+// of the pack is unique within the project and that all of its policies compile
+func setPolicyPackFromRequest(
+	repo repository.Repository,
+	pack *models.PolicyPack,
+	request *types.CreatePolicyPackRequest,
+) apierrors.RequestError {
+	packs, err := repo.PolicyPack().ListPolicyPacksByProjectID(pack.ProjectID)
+
+	if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	for _, existing := range packs {
+		if existing.ID != pack.ID && existing.Name == request.Name {
+			return apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("policy pack with name %s already exists in project", request.Name),
+				http.StatusConflict,
+			)
+		}
+	}
+
+	match, err := json.Marshal(request.Match)
+
+	if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	policies := make([]models.PolicyPackPolicy, 0)
+
+	for _, policy := range request.Policies {
+		policies = append(policies, models.PolicyPackPolicy{
+			Name:   policy.Name,
+			Source: policy.Source,
+		})
+	}
+
+	pack.Name = request.Name
+	pack.Kind = request.Kind
+	pack.Match = match
+	pack.MustExist = request.MustExist
+	pack.OverrideSeverity = request.OverrideSeverity
+	pack.Policies = policies
+
+	if _, err := opa.CompilePolicyPack(pack); err != nil {
+		return apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest)
+	}
+
+	return nil
+}
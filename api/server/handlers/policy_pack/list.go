@@ -0,0 +1,44 @@
+package policy_pack
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListPolicyPacksHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewListPolicyPacksHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListPolicyPacksHandler {
+	return &ListPolicyPacksHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *ListPolicyPacksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	packs, err := p.Repo().PolicyPack().ListPolicyPacksByProjectID(project.ID)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListPolicyPacksResponse, 0)
+
+	for _, pack := range packs {
+		res = append(res, pack.ToPolicyPackType())
+	}
+
+	p.WriteResult(w, r, res)
+}
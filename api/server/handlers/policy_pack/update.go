@@ -0,0 +1,59 @@
+package policy_pack
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type UpdatePolicyPackHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUpdatePolicyPackHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UpdatePolicyPackHandler {
+	return &UpdatePolicyPackHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *UpdatePolicyPackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	pack, reqErr := readPolicyPack(p.Repo(), r, project.ID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.UpdatePolicyPackRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	createReq := types.CreatePolicyPackRequest(*request)
+
+	if reqErr := setPolicyPackFromRequest(p.Repo(), pack, &createReq); reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	pack, err := p.Repo().PolicyPack().UpdatePolicyPack(pack)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, pack.ToPolicyPackType())
+}
@@ -59,6 +59,9 @@ func (p *CollaboratorsListHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 			UserID:    roleMap[user.ID].UserID,
 			Email:     user.Email,
 			ProjectID: roleMap[user.ID].ProjectID,
+			PolicyUID: roleMap[user.ID].PolicyUID,
+
+			TwoFactorEnabled: user.TOTPEnabled,
 		})
 	}
 
@@ -1,6 +1,8 @@
 package project
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/porter-dev/porter/api/server/handlers"
@@ -9,6 +11,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
 )
 
 type RoleUpdateHandler struct {
@@ -44,6 +47,47 @@ func (p *RoleUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	role.Kind = types.RoleKind(request.Kind)
+	role.PolicyUID = ""
+
+	switch role.Kind {
+	case types.RoleAdmin, types.RoleDeveloper, types.RoleViewer:
+		// preset roles are not backed by a project policy
+	case types.RoleCustom:
+		// custom roles are enforced using one of the policies created in the project
+		if request.PolicyUID == "" {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("policy_uid is required for custom roles"),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		policy, err := p.Repo().Policy().ReadPolicy(proj.ID, request.PolicyUID)
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("policy not found in project"),
+					http.StatusBadRequest,
+				))
+
+				return
+			}
+
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		role.PolicyUID = policy.UniqueID
+	default:
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("role kind %s is not supported", request.Kind),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
 
 	role, err = p.Repo().Project().UpdateProjectRole(proj.ID, role)
 
@@ -0,0 +1,59 @@
+package project
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type TwoFactorSettingsUpdateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewTwoFactorSettingsUpdateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *TwoFactorSettingsUpdateHandler {
+	return &TwoFactorSettingsUpdateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *TwoFactorSettingsUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.UpdateTwoFactorSettingsRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// make sure the user does not lock themselves out of the project
+	if request.TwoFactorRequired && user.HasPasswordLogin() && !user.TOTPEnabled {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("you must enable two-factor authentication before requiring it for the project"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	proj.TwoFactorRequired = request.TwoFactorRequired
+
+	proj, err := p.Repo().Project().UpdateProject(proj)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, proj.ToProjectType())
+}
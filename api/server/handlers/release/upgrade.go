@@ -17,6 +17,7 @@ import (
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/notifier"
 	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
 	"github.com/porter-dev/porter/internal/stacks"
 	"github.com/stefanmcshane/helm/pkg/release"
 )
@@ -168,6 +169,7 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	}
 
 	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(cluster.ProjectID)
+	webhookInts, _ := c.Repo().WebhookIntegration().ListWebhookIntegrationsByProjectID(cluster.ProjectID)
 
 	rel, releaseErr := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
 
@@ -184,7 +186,10 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		notifConf = conf.ToNotificationConfigType()
 	}
 
-	deplNotifier := slack.NewDeploymentNotifier(notifConf, slackInts...)
+	deplNotifier := notifier.NewMultiNotifier(
+		slack.NewDeploymentNotifier(notifConf, slackInts...),
+		webhook.NewDeploymentNotifier(notifConf, webhook.NewSender(c.Repo().WebhookIntegration()), webhookInts...),
+	)
 
 	notifyOpts := &notifier.NotifyOpts{
 		ProjectID:   cluster.ProjectID,
@@ -16,6 +16,7 @@ import (
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/notifier"
 	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
 	"gorm.io/gorm"
 )
 
@@ -142,6 +143,7 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(release.ProjectID)
+	webhookInts, _ := c.Repo().WebhookIntegration().ListWebhookIntegrationsByProjectID(release.ProjectID)
 
 	var notifConf *types.NotificationConfig
 	notifConf = nil
@@ -156,7 +158,10 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		notifConf = conf.ToNotificationConfigType()
 	}
 
-	deplNotifier := slack.NewDeploymentNotifier(notifConf, slackInts...)
+	deplNotifier := notifier.NewMultiNotifier(
+		slack.NewDeploymentNotifier(notifConf, slackInts...),
+		webhook.NewDeploymentNotifier(notifConf, webhook.NewSender(c.Repo().WebhookIntegration()), webhookInts...),
+	)
 
 	notifyOpts := &notifier.NotifyOpts{
 		ProjectID:   release.ProjectID,
@@ -62,6 +62,21 @@ func (u *UserLoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// if two-factor authentication is enabled, the login is completed once the user has
+	// sent a code to the second step endpoint
+	if storedUser.TOTPEnabled {
+		if err := authn.SaveUserTwoFactorPending(w, r, u.Config(), storedUser); err != nil {
+			u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		u.WriteResult(w, r, &types.LoginUserResponse{
+			TwoFactorRequired: true,
+		})
+
+		return
+	}
+
 	// save the user as authenticated in the session
 	redirect, err := authn.SaveUserAuthenticated(w, r, u.Config(), storedUser)
 
@@ -75,7 +90,9 @@ func (u *UserLoginHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	u.WriteResult(w, r, storedUser.ToUserType())
+	u.WriteResult(w, r, &types.LoginUserResponse{
+		User: storedUser.ToUserType(),
+	})
 }
 
 // checkUserRestrictions checks login restrictions specified by environment variables on the
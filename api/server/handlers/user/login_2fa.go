@@ -0,0 +1,98 @@
+package user
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authn"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+)
+
+type UserLoginTwoFactorHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUserLoginTwoFactorHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UserLoginTwoFactorHandler {
+	return &UserLoginTwoFactorHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (u *UserLoginTwoFactorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	request := &types.LoginTwoFactorRequest{}
+
+	if ok := u.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	// the user id is only set in the session after a correct password
+	userID, err := authn.GetTwoFactorPendingUserID(r, u.Config())
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusUnauthorized))
+		return
+	}
+
+	storedUser, err := u.Repo().User().ReadUser(userID)
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if !storedUser.TOTPEnabled || !verifyTwoFactorCode(storedUser, request.Code) {
+		// the pending login is cleared after too many incorrect codes, so that codes cannot
+		// be guessed within the lifetime of a pending login
+		cleared, err := authn.SaveUserTwoFactorFailure(w, r, u.Config())
+
+		if err != nil {
+			u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		errMsg := "incorrect two-factor code"
+
+		if cleared {
+			errMsg = "too many incorrect two-factor codes, please log in again"
+		}
+
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			errors.New(errMsg),
+			http.StatusUnauthorized,
+		))
+
+		return
+	}
+
+	// save the used code or recovery code
+	storedUser, err = u.Repo().User().UpdateUser(storedUser)
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	redirect, err := authn.SaveUserAuthenticated(w, r, u.Config(), storedUser)
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if redirect != "" {
+		http.Redirect(w, r, redirect, http.StatusFound)
+		return
+	}
+
+	u.WriteResult(w, r, &types.LoginUserResponse{
+		User: storedUser.ToUserType(),
+	})
+}
@@ -0,0 +1,207 @@
+package user_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers/user"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/totp"
+)
+
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestLoginUserTwoFactor(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	createTestUserWithTwoFactor(t, config)
+
+	cookie := loginWithPassword(t, config)
+
+	code, err := totp.GenerateCode(testTOTPSecret, time.Now())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := loginWithTwoFactorCode(t, config, cookie, code)
+
+	expUser := &types.LoginUserResponse{
+		User: &types.User{
+			ID:               1,
+			Email:            "test@test.it",
+			EmailVerified:    true,
+			TwoFactorEnabled: true,
+		},
+	}
+
+	gotUser := &types.LoginUserResponse{}
+
+	apitest.AssertResponseExpected(t, rr, expUser, gotUser)
+
+	// the same code cannot be used for another login
+	cookie = loginWithPassword(t, config)
+	rr = loginWithTwoFactorCode(t, config, cookie, code)
+
+	apitest.AssertResponseError(t, rr, http.StatusUnauthorized, &types.ExternalError{
+		Error: "incorrect two-factor code",
+	})
+}
+
+func TestLoginUserTwoFactorRecoveryCode(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	recoveryCodes := createTestUserWithTwoFactor(t, config)
+
+	cookie := loginWithPassword(t, config)
+	rr := loginWithTwoFactorCode(t, config, cookie, recoveryCodes[0])
+
+	if rr.Result().StatusCode != http.StatusOK {
+		t.Fatalf("expected login with recovery code to succeed, got status %d", rr.Result().StatusCode)
+	}
+
+	// recovery codes are single-use
+	cookie = loginWithPassword(t, config)
+	rr = loginWithTwoFactorCode(t, config, cookie, recoveryCodes[0])
+
+	apitest.AssertResponseError(t, rr, http.StatusUnauthorized, &types.ExternalError{
+		Error: "incorrect two-factor code",
+	})
+}
+
+func TestLoginUserTwoFactorWithoutPassword(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	createTestUserWithTwoFactor(t, config)
+
+	code, err := totp.GenerateCode(testTOTPSecret, time.Now())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := loginWithTwoFactorCode(t, config, nil, code)
+
+	apitest.AssertResponseError(t, rr, http.StatusUnauthorized, &types.ExternalError{
+		Error: "no login is waiting for a two-factor code",
+	})
+}
+
+func TestLoginUserTwoFactorTooManyFailures(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	createTestUserWithTwoFactor(t, config)
+
+	cookie := loginWithPassword(t, config)
+
+	for i := 0; i < 4; i++ {
+		rr := loginWithTwoFactorCode(t, config, cookie, "000000")
+
+		apitest.AssertResponseError(t, rr, http.StatusUnauthorized, &types.ExternalError{
+			Error: "incorrect two-factor code",
+		})
+	}
+
+	rr := loginWithTwoFactorCode(t, config, cookie, "000000")
+
+	apitest.AssertResponseError(t, rr, http.StatusUnauthorized, &types.ExternalError{
+		Error: "too many incorrect two-factor codes, please log in again",
+	})
+
+	// a correct code is rejected once the pending login is cleared
+	code, err := totp.GenerateCode(testTOTPSecret, time.Now())
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr = loginWithTwoFactorCode(t, config, cookie, code)
+
+	apitest.AssertResponseError(t, rr, http.StatusUnauthorized, &types.ExternalError{
+		Error: "no login is waiting for a two-factor code",
+	})
+}
+
+func createTestUserWithTwoFactor(t *testing.T, config *config.Config) []string {
+	testUser := apitest.CreateTestUser(t, config, true)
+
+	codes, hashes, err := totp.GenerateRecoveryCodes(2)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testUser.TOTPEnabled = true
+	testUser.TOTPSecret = []byte(testTOTPSecret)
+	testUser.SetTOTPRecoveryCodes(hashes)
+
+	if _, err := config.Repo.User().UpdateUser(testUser); err != nil {
+		t.Fatal(err)
+	}
+
+	return codes
+}
+
+// loginWithPassword performs the first login step and returns the session cookie
+func loginWithPassword(t *testing.T, config *config.Config) *http.Cookie {
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/login",
+		&types.LoginUserRequest{
+			Email:    "test@test.it",
+			Password: "hello",
+		},
+	)
+
+	handler := user.NewUserLoginHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	cookies := rr.Result().Cookies()
+
+	apitest.AssertResponseExpected(t, rr, &types.LoginUserResponse{
+		TwoFactorRequired: true,
+	}, &types.LoginUserResponse{})
+
+	if len(cookies) == 0 {
+		t.Fatal("no cookie in login response")
+	}
+
+	return cookies[0]
+}
+
+func loginWithTwoFactorCode(
+	t *testing.T,
+	config *config.Config,
+	cookie *http.Cookie,
+	code string,
+) *httptest.ResponseRecorder {
+	req, rr := apitest.GetRequestAndRecorder(
+		t,
+		string(types.HTTPVerbPost),
+		"/api/login/2fa",
+		&types.LoginTwoFactorRequest{
+			Code: code,
+		},
+	)
+
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+
+	handler := user.NewUserLoginTwoFactorHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
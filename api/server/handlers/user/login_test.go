@@ -35,9 +35,11 @@ func TestLoginUserSuccessful(t *testing.T) {
 	handler.ServeHTTP(rr, req)
 
 	expUser := &types.LoginUserResponse{
-		ID:            1,
-		Email:         "test@test.it",
-		EmailVerified: true,
+		User: &types.User{
+			ID:            1,
+			Email:         "test@test.it",
+			EmailVerified: true,
+		},
 	}
 
 	gotUser := &types.LoginUserResponse{}
@@ -0,0 +1,269 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/server/authn"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/analytics"
+	"github.com/porter-dev/porter/internal/auth/oidc"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// errOIDCLoginNotAllowed is returned for users which may not log in, and is shown to the user
+type errOIDCLoginNotAllowed struct {
+	msg string
+}
+
+func (e *errOIDCLoginNotAllowed) Error() string {
+	return e.msg
+}
+
+type UserOAuthOIDCCallbackHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUserOAuthOIDCCallbackHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UserOAuthOIDCCallbackHandler {
+	return &UserOAuthOIDCCallbackHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *UserOAuthOIDCCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if p.Config().OIDCProvider == nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("OIDC login is not enabled"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	session, err := p.Config().Store.Get(r, p.Config().ServerConf.CookieName)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if _, ok := session.Values["state"]; !ok {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("state not found in session")))
+		return
+	}
+
+	if r.URL.Query().Get("state") != session.Values["state"] {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("state does not match")))
+		return
+	}
+
+	nonce, ok := session.Values["oidc_nonce"].(string)
+
+	if !ok || nonce == "" {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("nonce not found in session")))
+		return
+	}
+
+	// the provider redirects with an error if the user denied access or is not assigned to the client
+	if errMsg := r.URL.Query().Get("error"); errMsg != "" {
+		http.Redirect(w, r, "/login?error="+url.QueryEscape(errMsg), 302)
+		return
+	}
+
+	claims, err := p.Config().OIDCProvider.Exchange(r.Context(), r.URL.Query().Get("code"), nonce)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	user, err := upsertOIDCUserFromClaims(p.Config(), claims)
+
+	var notAllowedErr *errOIDCLoginNotAllowed
+
+	if err != nil && errors.As(err, &notAllowedErr) {
+		http.Redirect(w, r, "/login?error="+url.QueryEscape(err.Error()), 302)
+		return
+	} else if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if err := syncOIDCProjectRoles(p.Config(), user, claims.Groups); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.Config().AnalyticsClient.Identify(analytics.CreateSegmentIdentifyUser(user))
+
+	// save the user as authenticated in the session
+	redirect, err := authn.SaveUserAuthenticated(w, r, p.Config(), user)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// non-fatal send email verification
+	if !user.EmailVerified {
+		err = startEmailVerification(p.Config(), w, r, user)
+
+		if err != nil {
+			p.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		}
+	}
+
+	if redirect != "" {
+		http.Redirect(w, r, redirect, http.StatusFound)
+		return
+	}
+
+	http.Redirect(w, r, "/dashboard", 302)
+}
+
+func upsertOIDCUserFromClaims(config *config.Config, claims *oidc.Claims) (*models.User, error) {
+	if claims.Email == "" {
+		return nil, &errOIDCLoginNotAllowed{"The identity provider did not return an email address."}
+	}
+
+	// the email address decides which user is logged in and whether it is in an allowed
+	// domain, so it must be verified by the provider
+	if !claims.EmailVerified {
+		return nil, &errOIDCLoginNotAllowed{"The identity provider has not verified your email address."}
+	}
+
+	if err := checkUserRestrictions(config.ServerConf, claims.Email); err != nil {
+		return nil, &errOIDCLoginNotAllowed{err.Error()}
+	}
+
+	if !isAllowedOIDCDomain(config.ServerConf.OIDCAllowedDomains, claims.Email) {
+		return nil, &errOIDCLoginNotAllowed{"Email is not in an allowed domain."}
+	}
+
+	user, err := config.Repo.User().ReadUserByOIDCUserID(claims.Subject)
+
+	if err == nil {
+		return user, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("unexpected error occurred:%s", err.Error())
+	}
+
+	// if a user with that email address already exists, link the user to the provider
+	user, err = config.Repo.User().ReadUserByEmail(claims.Email)
+
+	if err == nil {
+		if user.OIDCUserID != "" {
+			return nil, &errOIDCLoginNotAllowed{"Email already registered."}
+		}
+
+		user.OIDCUserID = claims.Subject
+		user.EmailVerified = true
+
+		return config.Repo.User().UpdateUser(user)
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// otherwise, provision a new user
+	user, err = config.Repo.User().CreateUser(&models.User{
+		Email:         claims.Email,
+		EmailVerified: true,
+		OIDCUserID:    claims.Subject,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := addUserToDefaultProject(config, user); err != nil {
+		return nil, err
+	}
+
+	config.AnalyticsClient.Track(analytics.UserCreateTrack(&analytics.UserCreateTrackOpts{
+		UserScopedTrackOpts: analytics.GetUserScopedTrackOpts(user.ID),
+		Email:               user.Email,
+	}))
+
+	return user, nil
+}
+
+func isAllowedOIDCDomain(allowedDomains []string, email string) bool {
+	if len(allowedDomains) == 0 {
+		return true
+	}
+
+	atIndex := strings.LastIndex(email, "@")
+
+	if atIndex < 0 {
+		return false
+	}
+
+	domain := strings.ToLower(email[atIndex+1:])
+
+	for _, allowed := range allowedDomains {
+		if strings.ToLower(strings.TrimSpace(allowed)) == domain {
+			return true
+		}
+	}
+
+	return false
+}
+
+// syncOIDCProjectRoles grants the user the project roles mapped to its groups at the
+// provider. Existing roles are only raised to the mapped role, so that roles granted
+// within Porter are never downgraded on login. Custom roles, and roles of projects which
+// are not mapped to any group of the user, are left untouched.
+func syncOIDCProjectRoles(config *config.Config, user *models.User, groups []string) error {
+	for projectID, kind := range oidc.GetProjectRoles(config.OIDCGroupRoles, groups) {
+		project, err := config.Repo.Project().ReadProject(projectID)
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		role, err := config.Repo.Project().ReadProjectRole(projectID, user.ID)
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			_, err = config.Repo.Project().CreateProjectRole(project, &models.Role{
+				Role: types.Role{
+					UserID:    user.ID,
+					ProjectID: projectID,
+					Kind:      kind,
+				},
+			})
+
+			if err != nil {
+				return err
+			}
+
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		if role.Kind != types.RoleCustom && oidc.IsHigherRole(kind, role.Kind) {
+			role.Kind = kind
+
+			if _, err := config.Repo.Project().UpdateProjectRole(projectID, role); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
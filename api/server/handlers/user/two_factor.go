@@ -0,0 +1,61 @@
+package user
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/auth/totp"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+const (
+	// twoFactorIssuer is the name shown for the account in authenticator apps
+	twoFactorIssuer = "Porter"
+
+	// numRecoveryCodes is the number of recovery codes generated when two-factor
+	// authentication is enabled
+	numRecoveryCodes = 10
+)
+
+// verifyTwoFactorCode checks a code from an authenticator app or a recovery code for
+// a user. The code is marked as used on the user if it is valid, so the caller must
+// save the user afterwards.
+func verifyTwoFactorCode(user *models.User, code string) bool {
+	if len(user.TOTPSecret) == 0 {
+		return false
+	}
+
+	if step, ok := totp.ValidateCode(string(user.TOTPSecret), code, time.Now()); ok {
+		// a code cannot be used twice
+		if step <= user.TOTPLastUsedStep {
+			return false
+		}
+
+		user.TOTPLastUsedStep = step
+
+		return true
+	}
+
+	hashes := user.GetTOTPRecoveryCodes()
+
+	if idx := totp.MatchRecoveryCode(hashes, code); idx >= 0 {
+		user.SetTOTPRecoveryCodes(append(hashes[:idx], hashes[idx+1:]...))
+
+		return true
+	}
+
+	return false
+}
+
+// resetRecoveryCodes generates new recovery codes for a user, replacing the previous
+// ones, and returns them in plaintext
+func resetRecoveryCodes(user *models.User) ([]string, error) {
+	codes, hashes, err := totp.GenerateRecoveryCodes(numRecoveryCodes)
+
+	if err != nil {
+		return nil, err
+	}
+
+	user.SetTOTPRecoveryCodes(hashes)
+
+	return codes, nil
+}
@@ -0,0 +1,89 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/totp"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type TwoFactorActivateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewTwoFactorActivateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *TwoFactorActivateHandler {
+	return &TwoFactorActivateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (u *TwoFactorActivateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	request := &types.ActivateTwoFactorRequest{}
+
+	if ok := u.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if user.TOTPEnabled {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("two-factor authentication is already enabled"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if len(user.TOTPSecret) == 0 {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("two-factor authentication must be enrolled before it is activated"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	// only a code from the authenticator app confirms that the secret was set up
+	// correctly, so recovery codes are not accepted here
+	step, ok := totp.ValidateCode(string(user.TOTPSecret), request.Code, time.Now())
+
+	if !ok {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("incorrect two-factor code"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	codes, err := resetRecoveryCodes(user)
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	user.TOTPEnabled = true
+	user.TOTPLastUsedStep = step
+
+	if _, err := u.Repo().User().UpdateUser(user); err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	u.WriteResult(w, r, &types.TwoFactorRecoveryCodesResponse{
+		RecoveryCodes: codes,
+	})
+}
@@ -0,0 +1,69 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type TwoFactorDisableHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewTwoFactorDisableHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *TwoFactorDisableHandler {
+	return &TwoFactorDisableHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (u *TwoFactorDisableHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	request := &types.DisableTwoFactorRequest{}
+
+	if ok := u.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if !user.TOTPEnabled {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("two-factor authentication is not enabled"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if !verifyTwoFactorCode(user, request.Code) {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("incorrect two-factor code"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	user.TOTPEnabled = false
+	user.TOTPSecret = []byte{}
+	user.TOTPLastUsedStep = 0
+	user.SetTOTPRecoveryCodes([]string{})
+
+	user, err := u.Repo().User().UpdateUser(user)
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	u.WriteResult(w, r, user.ToUserType())
+}
@@ -0,0 +1,73 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/totp"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type TwoFactorEnrollHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewTwoFactorEnrollHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *TwoFactorEnrollHandler {
+	return &TwoFactorEnrollHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (u *TwoFactorEnrollHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	if !user.HasPasswordLogin() {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("two-factor authentication is only available for users who log in with a password"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if user.TOTPEnabled {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("two-factor authentication is already enabled"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	secret, err := totp.GenerateSecret()
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the secret is only used for logins once a code has been verified through the
+	// activation endpoint
+	user.TOTPSecret = []byte(secret)
+	user.TOTPLastUsedStep = 0
+
+	user, err = u.Repo().User().UpdateUser(user)
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	u.WriteResult(w, r, &types.EnrollTwoFactorResponse{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(twoFactorIssuer, user.Email, secret),
+	})
+}
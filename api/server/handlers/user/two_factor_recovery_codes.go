@@ -0,0 +1,73 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type TwoFactorRecoveryCodesHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewTwoFactorRecoveryCodesHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *TwoFactorRecoveryCodesHandler {
+	return &TwoFactorRecoveryCodesHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP replaces the recovery codes of the user, for example once most of them
+// have been used
+func (u *TwoFactorRecoveryCodesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+
+	request := &types.RegenerateTwoFactorRecoveryCodesRequest{}
+
+	if ok := u.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if !user.TOTPEnabled {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("two-factor authentication is not enabled"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if !verifyTwoFactorCode(user, request.Code) {
+		u.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("incorrect two-factor code"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	codes, err := resetRecoveryCodes(user)
+
+	if err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if _, err := u.Repo().User().UpdateUser(user); err != nil {
+		u.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	u.WriteResult(w, r, &types.TwoFactorRecoveryCodesResponse{
+		RecoveryCodes: codes,
+	})
+}
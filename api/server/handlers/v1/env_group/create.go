@@ -4,9 +4,6 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
-	"sync"
-
-	"sigs.k8s.io/yaml"
 
 	"github.com/stefanmcshane/helm/pkg/release"
 	v1 "k8s.io/api/core/v1"
@@ -77,12 +74,25 @@ func (c *CreateEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	configMap, err := envgroup.CreateEnvGroup(agent, types.ConfigMapInput{
-		Name:            request.Name,
-		Namespace:       namespace,
-		Variables:       request.Variables,
-		SecretVariables: request.SecretVariables,
-	})
+	input := &types.ConfigMapInput{
+		Name:                    request.Name,
+		Namespace:               namespace,
+		Variables:               request.Variables,
+		SecretVariables:         request.SecretVariables,
+		ExternalSecretVariables: request.ExternalSecretVariables,
+	}
+
+	if err := envgroup.ResolveExternalSecrets(r.Context(), c.Config().SecretStore, cluster.ProjectID, input); err != nil {
+		if envgroup.IsExternalSecretError(err) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	configMap, err := envgroup.CreateEnvGroup(agent, *input)
 
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -109,6 +119,8 @@ func (c *CreateEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		Name:      envGroup.Name,
 		Releases:  envGroup.Applications,
 		Variables: envGroup.Variables,
+
+		ExternalSecretVariables: envGroup.ExternalSecretVariables,
 	}
 
 	stackId, err := stacks.GetStackForEnvGroup(c.Config(), cluster.ProjectID, cluster.ID, envGroup)
@@ -149,247 +161,13 @@ func rolloutApplications(
 	configMap *v1.ConfigMap,
 	releases []*release.Release,
 ) []error {
-	registries, err := config.Repo.Registry().ListRegistriesByProjectID(cluster.ProjectID)
-
-	if err != nil {
-		return []error{err}
-	}
-
-	// construct the synced env section that should be written
-	newSection := &SyncedEnvSection{
-		Name:    envGroup.Name,
-		Version: envGroup.Version,
-	}
-
-	newSectionKeys := make([]SyncedEnvSectionKey, 0)
-
-	for key, val := range configMap.Data {
-		newSectionKeys = append(newSectionKeys, SyncedEnvSectionKey{
-			Name:   key,
-			Secret: strings.Contains(val, "PORTERSECRET"),
-		})
-	}
-
-	newSection.Keys = newSectionKeys
-
-	// asynchronously update releases with that image repo uri
-	var wg sync.WaitGroup
-	mu := &sync.Mutex{}
-	errors := make([]error, 0)
-
-	for i, rel := range releases {
-		index := i
-		release := rel
-		wg.Add(1)
-
-		go func() {
-			defer wg.Done()
-			// read release via agent
-			newConfig, err := getNewConfig(release.Config, newSection)
-
-			if err != nil {
-				mu.Lock()
-				errors = append(errors, err)
-				mu.Unlock()
-				return
-			}
-
-			// if this is a job chart, update the config and set correct paused param to true
-			if release.Chart.Name() == "job" {
-				newConfig["paused"] = true
-			}
-
-			conf := &helm.UpgradeReleaseConfig{
-				Name:       releases[index].Name,
-				Cluster:    cluster,
-				Repo:       config.Repo,
-				Registries: registries,
-				Values:     newConfig,
-			}
-
-			_, err = helmAgent.UpgradeReleaseByValues(conf, config.DOConf, config.ServerConf.DisablePullSecretsInjection)
-
-			if err != nil {
-				mu.Lock()
-				errors = append(errors, err)
-				mu.Unlock()
-				return
-			}
-		}()
-	}
-
-	wg.Wait()
-
-	return errors
-}
-
-type SyncedEnvSection struct {
-	Name    string                `json:"name" yaml:"name"`
-	Version uint                  `json:"version" yaml:"version"`
-	Keys    []SyncedEnvSectionKey `json:"keys" yaml:"keys"`
-}
-
-type SyncedEnvSectionKey struct {
-	Name   string `json:"name" yaml:"name"`
-	Secret bool   `json:"secret" yaml:"secret"`
-}
-
-func getNewConfig(curr map[string]interface{}, syncedEnvSection *SyncedEnvSection) (map[string]interface{}, error) {
-	// look for container.env.synced
-	envConf, err := getNestedMap(curr, "container", "env")
-
-	if err != nil {
-		return nil, err
-	}
-
-	syncedEnvInter, syncedEnvExists := envConf["synced"]
-
-	if !syncedEnvExists {
-		return curr, nil
-	} else {
-		syncedArr := make([]*SyncedEnvSection, 0)
-		syncedArrInter, ok := syncedEnvInter.([]interface{})
-
-		if !ok {
-			return nil, fmt.Errorf("could not convert to synced env section: not an array")
-		}
-
-		for _, syncedArrInterObj := range syncedArrInter {
-			syncedArrObj := &SyncedEnvSection{}
-			syncedArrInterObjMap, ok := syncedArrInterObj.(map[string]interface{})
-
-			if !ok {
-				continue
-			}
-
-			if nameField, nameFieldExists := syncedArrInterObjMap["name"]; nameFieldExists {
-				syncedArrObj.Name, ok = nameField.(string)
-
-				if !ok {
-					continue
-				}
-			}
-
-			if versionField, versionFieldExists := syncedArrInterObjMap["version"]; versionFieldExists {
-				versionFloat, ok := versionField.(float64)
-
-				if !ok {
-					continue
-				}
-
-				syncedArrObj.Version = uint(versionFloat)
-			}
-
-			if keyField, keyFieldExists := syncedArrInterObjMap["keys"]; keyFieldExists {
-				keyFieldInterArr, ok := keyField.([]interface{})
-
-				if !ok {
-					continue
-				}
-
-				keyFieldMapArr := make([]map[string]interface{}, 0)
-
-				for _, keyFieldInter := range keyFieldInterArr {
-					mapConv, ok := keyFieldInter.(map[string]interface{})
-
-					if !ok {
-						continue
-					}
-
-					keyFieldMapArr = append(keyFieldMapArr, mapConv)
-				}
-
-				keyFieldRes := make([]SyncedEnvSectionKey, 0)
-
-				for _, keyFieldMap := range keyFieldMapArr {
-					toAdd := SyncedEnvSectionKey{}
-
-					if nameField, nameFieldExists := keyFieldMap["name"]; nameFieldExists {
-						toAdd.Name, ok = nameField.(string)
-
-						if !ok {
-							continue
-						}
-					}
-
-					if secretField, secretFieldExists := keyFieldMap["secret"]; secretFieldExists {
-						toAdd.Secret, ok = secretField.(bool)
-
-						if !ok {
-							continue
-						}
-					}
-
-					keyFieldRes = append(keyFieldRes, toAdd)
-				}
-
-				syncedArrObj.Keys = keyFieldRes
-			}
-
-			syncedArr = append(syncedArr, syncedArrObj)
-		}
-
-		resArr := make([]SyncedEnvSection, 0)
-		foundMatch := false
-
-		for _, candidate := range syncedArr {
-			if candidate.Name == syncedEnvSection.Name {
-				resArr = append(resArr, *syncedEnvSection)
-				foundMatch = true
-			} else {
-				resArr = append(resArr, *candidate)
-			}
-		}
-
-		if !foundMatch {
-			return curr, nil
-		}
-
-		envConf["synced"] = resArr
-	}
-
-	// to remove all types that Helm may not be able to work with, we marshal to and from
-	// yaml for good measure. Otherwise we get silly error messages like:
-	// Upgrade failed: template: web/templates/deployment.yaml:138:40: executing \"web/templates/deployment.yaml\"
-	// at <$syncedEnv.keys>: can't evaluate field keys in type namespace.SyncedEnvSection
-	currYAML, err := yaml.Marshal(curr)
-
-	if err != nil {
-		return nil, err
-	}
-
-	res := make(map[string]interface{})
-
-	err = yaml.Unmarshal([]byte(currYAML), &res)
-
-	if err != nil {
-		return nil, err
-	}
-
-	return res, nil
-}
-
-func getNestedMap(obj map[string]interface{}, fields ...string) (map[string]interface{}, error) {
-	var res map[string]interface{}
-	curr := obj
-
-	for _, field := range fields {
-		objField, ok := curr[field]
-
-		if !ok {
-			return nil, fmt.Errorf("%s not found", field)
-		}
-
-		res, ok = objField.(map[string]interface{})
-
-		if !ok {
-			return nil, fmt.Errorf("%s is not a nested object", field)
-		}
-
-		curr = res
-	}
-
-	return res, nil
+	return envgroup.RolloutApplications(&envgroup.RolloutOpts{
+		Repo:                        config.Repo,
+		Cluster:                     cluster,
+		HelmAgent:                   helmAgent,
+		DOConf:                      config.DOConf,
+		DisablePullSecretsInjection: config.ServerConf.DisablePullSecretsInjection,
+	}, envGroup, configMap, releases)
 }
 
 // postUpgrade runs any necessary scripting after the release has been upgraded.
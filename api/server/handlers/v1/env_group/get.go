@@ -76,6 +76,8 @@ func (c *GetEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		Name:      envGroup.Name,
 		Releases:  envGroup.Applications,
 		Variables: envGroup.Variables,
+
+		ExternalSecretVariables: envGroup.ExternalSecretVariables,
 	}
 
 	stackId, err := stacks.GetStackForEnvGroup(c.Config(), cluster.ProjectID, cluster.ID, envGroup)
@@ -80,6 +80,8 @@ func (c *GetEnvGroupAllVersionsHandler) ServeHTTP(w http.ResponseWriter, r *http
 			Name:      eg.Name,
 			Releases:  eg.Applications,
 			Variables: eg.Variables,
+
+			ExternalSecretVariables: eg.ExternalSecretVariables,
 		}
 
 		stackId, err := stacks.GetStackForEnvGroup(c.Config(), cluster.ProjectID, cluster.ID, eg)
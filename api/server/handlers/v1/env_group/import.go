@@ -0,0 +1,221 @@
+package env_group
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/envfile"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/stacks"
+)
+
+type ImportEnvGroupHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewImportEnvGroupHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ImportEnvGroupHandler {
+	return &ImportEnvGroupHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *ImportEnvGroupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	namespace := r.Context().Value(types.NamespaceScope).(string)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	name, reqErr := requestutils.GetURLParamString(r, types.URLParamEnvGroupName)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.ImportEnvGroupRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	vars, secretVars, err := parseImportRequest(request)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	agent, err := c.GetAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	envGroup, err := envgroup.GetEnvGroup(agent, name, namespace, 0)
+
+	// if the environment group exists and has MetaVersion=1, throw an error
+	if envGroup != nil && envGroup.MetaVersion == 1 {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("env group with that name already exists"),
+			http.StatusNotFound,
+		))
+
+		return
+	}
+
+	helmAgent, err := c.GetHelmAgent(r, cluster, namespace)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	input, err := envgroup.GetImportInput(agent, &envgroup.ImportOpts{
+		Name:            name,
+		Namespace:       namespace,
+		Variables:       vars,
+		SecretVariables: secretVars,
+		Replace:         request.Replace,
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// external secrets which are kept are read from the secret store again
+	if err := envgroup.ResolveExternalSecrets(r.Context(), c.Config().SecretStore, cluster.ProjectID, input); err != nil {
+		if envgroup.IsExternalSecretError(err) {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	configMap, err := envgroup.CreateEnvGroup(agent, *input)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	envGroup, err = envgroup.ToEnvGroup(configMap)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	releases, err := envgroup.GetSyncedReleases(helmAgent, configMap)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.V1EnvGroupResponse{
+		CreatedAt: envGroup.CreatedAt,
+		Version:   envGroup.Version,
+		Name:      envGroup.Name,
+		Releases:  envGroup.Applications,
+		Variables: envGroup.Variables,
+
+		ExternalSecretVariables: envGroup.ExternalSecretVariables,
+	}
+
+	stackId, err := stacks.GetStackForEnvGroup(c.Config(), cluster.ProjectID, cluster.ID, envGroup)
+
+	if err == nil && len(stackId) > 0 {
+		res.StackID = stackId
+	}
+
+	c.WriteResult(w, r, res)
+
+	// trigger rollout of new applications after writing the result
+	errs := rolloutApplications(c.Config(), cluster, helmAgent, envGroup, configMap, releases)
+
+	if len(errs) > 0 {
+		errStrArr := make([]string, 0)
+
+		for _, err := range errs {
+			errStrArr = append(errStrArr, err.Error())
+		}
+
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(fmt.Errorf(strings.Join(errStrArr, ","))))
+		return
+	}
+
+	err = postUpgrade(c.Config(), cluster.ProjectID, cluster.ID, envGroup)
+
+	if err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+}
+
+// parseImportRequest reads the variables and secret variables from the files of an import
+// request
+func parseImportRequest(request *types.ImportEnvGroupRequest) (map[string]string, map[string]string, error) {
+	parsed, err := envfile.Parse(string(request.Format), request.Content)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing variables: %w", err)
+	}
+
+	vars := make(map[string]string)
+	secretVars := make(map[string]string)
+
+	for key, val := range parsed {
+		if request.SecretPrefix != "" && strings.HasPrefix(key, request.SecretPrefix) {
+			secretKey := strings.TrimPrefix(key, request.SecretPrefix)
+
+			if secretKey == "" {
+				return nil, nil, fmt.Errorf("variable %s only consists of the secret prefix", key)
+			}
+
+			secretVars[secretKey] = val
+		} else {
+			vars[key] = val
+		}
+	}
+
+	if request.SecretContent != "" {
+		parsedSecrets, err := envfile.Parse(string(request.Format), request.SecretContent)
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing secret variables: %w", err)
+		}
+
+		for key, val := range parsedSecrets {
+			secretVars[key] = val
+		}
+	}
+
+	for key := range secretVars {
+		if _, exists := vars[key]; exists {
+			return nil, nil, fmt.Errorf("variable %s is imported both as a variable and as a secret", key)
+		}
+	}
+
+	if len(vars) == 0 && len(secretVars) == 0 {
+		return nil, nil, fmt.Errorf("no variables to import")
+	}
+
+	return vars, secretVars, nil
+}
@@ -0,0 +1,339 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	gitlabCI "github.com/porter-dev/porter/internal/integrations/ci/gitlab"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/xanzy/go-gitlab"
+	"gorm.io/gorm"
+)
+
+type GitlabIncomingWebhookHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewGitlabIncomingWebhookHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *GitlabIncomingWebhookHandler {
+	return &GitlabIncomingWebhookHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *GitlabIncomingWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("X-Gitlab-Token")
+	secret := c.Config().ServerConf.GitlabIncomingWebhookSecret
+
+	if subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+		c.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("invalid gitlab webhook token")))
+		return
+	}
+
+	payload, err := ioutil.ReadAll(r.Body)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("error reading webhook payload: %w", err)))
+		return
+	}
+
+	event, err := gitlab.ParseWebhook(gitlab.HookEventType(r), payload)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("error parsing webhook: %w", err)))
+		return
+	}
+
+	switch event := event.(type) {
+	case *gitlab.MergeEvent:
+		err = c.processMergeRequestEvent(event, r)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("error processing merge request webhook event: %w", err)))
+			return
+		}
+	}
+}
+
+func (c *GitlabIncomingWebhookHandler) processMergeRequestEvent(event *gitlab.MergeEvent, r *http.Request) error {
+	// get the webhook id from the request
+	webhookID, reqErr := requestutils.GetURLParamString(r, types.URLParamIncomingWebhookID)
+
+	if reqErr != nil {
+		return fmt.Errorf(reqErr.Error())
+	}
+
+	owner, repo := splitGitlabProjectPath(event.Project.PathWithNamespace)
+
+	env, err := c.Repo().Environment().ReadEnvironmentByWebhookIDOwnerRepoName(webhookID, owner, repo)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s] error reading environment: %w", webhookID, owner, repo, err)
+	}
+
+	if !env.IsGitlabEnvironment() {
+		return nil
+	}
+
+	mr := event.ObjectAttributes
+	action := getMergeRequestAction(env, event)
+
+	if action == mergeRequestActionNone {
+		return nil
+	}
+
+	client, err := gitlabCI.NewClient(c.Repo(), c.Config(), env.GitlabUserID, env.ProjectID, env.GitlabIntegrationID)
+
+	if err != nil {
+		return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, mrNumber: %d] "+
+			"error getting gitlab client: %w", webhookID, owner, repo, env.ID, mr.IID, err)
+	}
+
+	pipelineOpts := &gitlabCI.PreviewPipelineOpts{
+		EnvironmentID:  env.ID,
+		MergeRequestID: mr.IID,
+		MRName:         mr.Title,
+		MRBranchFrom:   mr.SourceBranch,
+		MRBranchInto:   mr.TargetBranch,
+	}
+
+	if action == mergeRequestActionCreate {
+		commitSHA := mr.LastCommit.ID
+
+		if len(commitSHA) > 7 {
+			commitSHA = commitSHA[:7]
+		}
+
+		depl, err := c.Repo().Environment().CreateDeployment(&models.Deployment{
+			EnvironmentID: env.ID,
+			Namespace:     "",
+			Status:        types.DeploymentStatusCreating,
+			PullRequestID: uint(mr.IID),
+			PRName:        mr.Title,
+			RepoName:      repo,
+			RepoOwner:     owner,
+			CommitSHA:     commitSHA,
+			PRBranchFrom:  mr.SourceBranch,
+			PRBranchInto:  mr.TargetBranch,
+		})
+
+		if err != nil {
+			return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, mrNumber: %d] "+
+				"error creating new deployment: %w", webhookID, owner, repo, env.ID, mr.IID, err)
+		}
+
+		pipeline, err := gitlabCI.TriggerPreviewPipeline(client, owner, repo, pipelineOpts)
+
+		if err != nil {
+			return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, mrNumber: %d] "+
+				"error triggering preview pipeline: %w", webhookID, owner, repo, env.ID, mr.IID, err)
+		}
+
+		depl.GitlabPipelineID = pipeline.ID
+
+		_, err = c.Repo().Environment().UpdateDeployment(depl)
+
+		if err != nil {
+			return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, deploymentID: %d, mrNumber: %d] "+
+				"error updating deployment: %w", webhookID, owner, repo, env.ID, depl.ID, mr.IID, err)
+		}
+	} else {
+		depl, err := c.Repo().Environment().ReadDeploymentByGitDetails(env.ID, owner, repo, uint(mr.IID))
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return nil
+			}
+
+			return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, mrNumber: %d] "+
+				"error reading deployment: %w", webhookID, owner, repo, env.ID, mr.IID, err)
+		}
+
+		if depl.Status == types.DeploymentStatusInactive {
+			return nil
+		}
+
+		if action == mergeRequestActionUpdate || action == mergeRequestActionRedeploy {
+			if action == mergeRequestActionRedeploy {
+				pipeline, err := gitlabCI.TriggerPreviewPipeline(client, owner, repo, pipelineOpts)
+
+				if err != nil {
+					return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, deploymentID: %d, mrNumber: %d] "+
+						"error triggering preview pipeline: %w", webhookID, owner, repo, env.ID, depl.ID, mr.IID, err)
+				}
+
+				depl.GitlabPipelineID = pipeline.ID
+			}
+
+			depl.PRName = mr.Title
+			depl.PRBranchInto = mr.TargetBranch
+
+			_, err := c.Repo().Environment().UpdateDeployment(depl)
+
+			if err != nil {
+				return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, deploymentID: %d, mrNumber: %d] "+
+					"error updating deployment to reflect changes in the merge request %w", webhookID, owner, repo,
+					env.ID, depl.ID, mr.IID, err)
+			}
+		} else {
+			var cancelErr error
+
+			if depl.GitlabPipelineID != 0 {
+				cancelErr = gitlabCI.CancelPreviewPipeline(client, owner, repo, depl.GitlabPipelineID)
+			}
+
+			err = c.deleteGitlabDeployment(r, depl, env, client)
+
+			if err != nil {
+				deleteErr := fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, deploymentID: %d, mrNumber: %d] "+
+					"error deleting deployment: %w", webhookID, owner, repo, env.ID, depl.ID, mr.IID, err)
+
+				if cancelErr != nil {
+					deleteErr = fmt.Errorf("%s. error found while trying to cancel active pipeline %w", deleteErr.Error(), cancelErr)
+				}
+
+				return deleteErr
+			} else if cancelErr != nil {
+				return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, deploymentID: %d, mrNumber: %d] "+
+					"deployment deleted but error found while trying to cancel active pipeline %w", webhookID, owner, repo,
+					env.ID, depl.ID, mr.IID, cancelErr)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *GitlabIncomingWebhookHandler) deleteGitlabDeployment(
+	r *http.Request,
+	depl *models.Deployment,
+	env *models.Environment,
+	client *gitlab.Client,
+) error {
+	cluster, err := c.Repo().Cluster().ReadCluster(env.ProjectID, env.ClusterID)
+
+	if err != nil {
+		return fmt.Errorf("[projectID: %d, clusterID: %d] error reading cluster when deleting existing deployment: %w",
+			env.ProjectID, env.ClusterID, err)
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+
+	if err != nil {
+		return err
+	}
+
+	// make sure we do not delete any kubernetes "system" namespaces
+	if depl.Namespace != "" && !isSystemNamespace(depl.Namespace) {
+		err = agent.DeleteNamespace(depl.Namespace)
+
+		if err != nil {
+			return fmt.Errorf("[owner: %s, repo: %s, environmentID: %d, deploymentID: %d] error deleting namespace '%s': %w",
+				env.GitRepoOwner, env.GitRepoName, env.ID, depl.ID, depl.Namespace, err)
+		}
+	}
+
+	if depl.GitlabDeploymentID != 0 {
+		client.Deployments.UpdateProjectDeployment(
+			fmt.Sprintf("%s/%s", env.GitRepoOwner, env.GitRepoName),
+			depl.GitlabDeploymentID,
+			&gitlab.UpdateProjectDeploymentOptions{
+				Status: gitlab.DeploymentStatus(gitlab.DeploymentStatusCanceled),
+			},
+		)
+	}
+
+	_, err = c.Repo().Environment().DeleteDeployment(depl)
+
+	if err != nil {
+		return fmt.Errorf("[owner: %s, repo: %s, environmentID: %d, deploymentID: %d] error updating deployment: %w",
+			env.GitRepoOwner, env.GitRepoName, env.ID, depl.ID, err)
+	}
+
+	return nil
+}
+
+type mergeRequestAction int
+
+const (
+	mergeRequestActionNone mergeRequestAction = iota
+	// create a deployment and trigger its preview pipeline
+	mergeRequestActionCreate
+	// update the merge request details of the deployment
+	mergeRequestActionUpdate
+	// update the deployment and trigger its preview pipeline again
+	mergeRequestActionRedeploy
+	// cancel the preview pipeline and delete the deployment
+	mergeRequestActionDelete
+)
+
+// getMergeRequestAction returns what should happen to the deployment of a merge request
+// when a merge request event is received for a preview environment
+func getMergeRequestAction(env *models.Environment, event *gitlab.MergeEvent) mergeRequestAction {
+	mr := event.ObjectAttributes
+
+	if branches := env.ToEnvironmentType().GitRepoBranches; len(branches) > 0 {
+		found := false
+
+		for _, br := range branches {
+			if br == mr.TargetBranch {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return mergeRequestActionNone
+		}
+	}
+
+	switch mr.Action {
+	case "open", "reopen":
+		if env.Mode == "auto" {
+			return mergeRequestActionCreate
+		}
+	case "update":
+		// GitLab sends the previous head of the merge request only when new commits were pushed
+		if mr.OldRev != "" {
+			return mergeRequestActionRedeploy
+		}
+
+		return mergeRequestActionUpdate
+	case "close", "merge":
+		return mergeRequestActionDelete
+	}
+
+	return mergeRequestActionNone
+}
+
+// splitGitlabProjectPath splits the path of a GitLab project into its namespace, which may
+// contain subgroups, and its name
+func splitGitlabProjectPath(path string) (string, string) {
+	idx := strings.LastIndex(path, "/")
+
+	if idx < 0 {
+		return "", path
+	}
+
+	return path[:idx], path[idx+1:]
+}
@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/xanzy/go-gitlab"
+)
+
+func TestGitlabIncomingWebhookToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		expCode int
+	}{
+		{"missing token", "", http.StatusForbidden},
+		{"invalid token", "not-the-secret", http.StatusForbidden},
+		{"valid token", "webhook-secret", http.StatusOK},
+	}
+
+	config := apitest.LoadConfig(t)
+	config.ServerConf.GitlabIncomingWebhookSecret = "webhook-secret"
+
+	handler := NewGitlabIncomingWebhookHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	for _, test := range tests {
+		// push events are ignored, so a request with a valid token succeeds without reading
+		// any environments
+		req, rr := apitest.GetRequestAndRecorder(t, http.MethodPost, "/api/gitlab/incoming_webhook/1", map[string]string{
+			"object_kind": "push",
+		})
+
+		req.Header.Set("X-Gitlab-Event", string(gitlab.EventTypePush))
+
+		if test.token != "" {
+			req.Header.Set("X-Gitlab-Token", test.token)
+		}
+
+		handler.ServeHTTP(rr, req)
+
+		if rr.Code != test.expCode {
+			t.Errorf("%s: expected status %d, got %d", test.name, test.expCode, rr.Code)
+		}
+	}
+}
+
+func TestGetMergeRequestAction(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         string
+		branches     string
+		action       string
+		targetBranch string
+		oldRev       string
+		expected     mergeRequestAction
+	}{
+		{"open in auto mode", "auto", "", "open", "main", "", mergeRequestActionCreate},
+		{"reopen in auto mode", "auto", "", "reopen", "main", "", mergeRequestActionCreate},
+		{"open in manual mode", "manual", "", "open", "main", "", mergeRequestActionNone},
+		{"update with new commits", "auto", "", "update", "main", "abc1234", mergeRequestActionRedeploy},
+		{"update without new commits", "auto", "", "update", "main", "", mergeRequestActionUpdate},
+		{"update in manual mode", "manual", "", "update", "main", "abc1234", mergeRequestActionRedeploy},
+		{"close", "auto", "", "close", "main", "", mergeRequestActionDelete},
+		{"merge", "manual", "", "merge", "main", "", mergeRequestActionDelete},
+		{"approved", "auto", "", "approved", "main", "", mergeRequestActionNone},
+		{"open into a watched branch", "auto", "main, release", "open", "release", "", mergeRequestActionCreate},
+		{"open into another branch", "auto", "main, release", "open", "feature", "", mergeRequestActionNone},
+		{"close into another branch", "auto", "main", "close", "feature", "", mergeRequestActionNone},
+	}
+
+	for _, test := range tests {
+		env := &models.Environment{
+			Mode:            test.mode,
+			GitRepoBranches: test.branches,
+		}
+
+		event := &gitlab.MergeEvent{}
+		event.ObjectAttributes.Action = test.action
+		event.ObjectAttributes.TargetBranch = test.targetBranch
+		event.ObjectAttributes.OldRev = test.oldRev
+
+		if res := getMergeRequestAction(env, event); res != test.expected {
+			t.Errorf("%s: expected action %d, got %d", test.name, test.expected, res)
+		}
+	}
+}
+
+func TestSplitGitlabProjectPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expOwner string
+		expRepo  string
+	}{
+		{"porter-dev/porter", "porter-dev", "porter"},
+		{"porter-dev/infra/porter", "porter-dev/infra", "porter"},
+		{"porter", "", "porter"},
+	}
+
+	for _, test := range tests {
+		owner, repo := splitGitlabProjectPath(test.path)
+
+		if owner != test.expOwner || repo != test.expRepo {
+			t.Errorf("%q: expected (%q, %q), got (%q, %q)", test.path, test.expOwner, test.expRepo, owner, repo)
+		}
+	}
+}
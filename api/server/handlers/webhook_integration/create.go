@@ -0,0 +1,71 @@
+package webhook_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type WebhookIntegrationCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewWebhookIntegrationCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *WebhookIntegrationCreateHandler {
+	return &WebhookIntegrationCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *WebhookIntegrationCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateWebhookIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	secret := request.Secret
+
+	if secret == "" {
+		var err error
+		secret, err = encryption.GenerateRandomBytes(32)
+
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	webhookInt, err := p.Repo().WebhookIntegration().CreateWebhookIntegration(&ints.WebhookIntegration{
+		ProjectID:        project.ID,
+		Name:             request.Name,
+		URL:              []byte(request.URL),
+		Secret:           []byte(secret),
+		DeploymentEvents: request.DeploymentEvents,
+		IncidentEvents:   request.IncidentEvents,
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.CreateWebhookIntegrationResponse{
+		WebhookIntegration: webhookInt.ToWebhookIntegrationType(),
+		Secret:             secret,
+	}
+
+	p.WriteResult(w, r, res)
+}
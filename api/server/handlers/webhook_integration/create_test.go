@@ -0,0 +1,68 @@
+package webhook_integration_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/project"
+	"github.com/porter-dev/porter/api/server/handlers/webhook_integration"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestCreateWebhookIntegrationMasksURL(t *testing.T) {
+	config := apitest.LoadConfig(t)
+	user := apitest.CreateTestUser(t, config, true)
+	proj, _, err := project.CreateProjectWithUser(config.Repo.Project(), &models.Project{
+		Name: "test-project",
+	}, user)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/integrations/webhook",
+		&types.CreateWebhookIntegrationRequest{
+			Name:             "alerts",
+			URL:              "https://hooks.example.com/services/T000/B000/XXXX?token=secret",
+			DeploymentEvents: true,
+		},
+	)
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+	req = apitest.WithProject(t, req, proj)
+
+	handler := webhook_integration.NewWebhookIntegrationCreateHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	gotRes := &types.CreateWebhookIntegrationResponse{}
+
+	if err := json.NewDecoder(rr.Body).Decode(gotRes); err != nil {
+		t.Fatal(err)
+	}
+
+	if gotRes.URL != "https://hooks.example.com/********" {
+		t.Errorf("expected the URL to be masked, got %s", gotRes.URL)
+	}
+
+	if gotRes.Secret == "" {
+		t.Errorf("expected a generated secret to be returned on creation")
+	}
+
+	webhookInt, err := config.Repo.WebhookIntegration().ReadWebhookIntegration(proj.ID, 1)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if string(webhookInt.URL) != "https://hooks.example.com/services/T000/B000/XXXX?token=secret" {
+		t.Errorf("expected the full URL to be stored, got %s", string(webhookInt.URL))
+	}
+}
@@ -0,0 +1,41 @@
+package webhook_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type WebhookIntegrationDeleteHandler struct {
+	handlers.PorterHandler
+}
+
+func NewWebhookIntegrationDeleteHandler(
+	config *config.Config,
+) *WebhookIntegrationDeleteHandler {
+	return &WebhookIntegrationDeleteHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+func (p *WebhookIntegrationDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	webhookInt, reqErr := readWebhookIntegration(p.Repo(), r, project.ID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	if err := p.Repo().WebhookIntegration().DeleteWebhookIntegration(webhookInt.ID); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,43 @@
+package webhook_integration
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// readWebhookIntegration reads the webhook integration referenced by the URL of the request,
+// which must belong to the given project
+func readWebhookIntegration(
+	repo repository.Repository,
+	r *http.Request,
+	projectID uint,
+) (*ints.WebhookIntegration, apierrors.RequestError) {
+	integrationID, reqErr := requestutils.GetURLParamUint(r, types.URLParamWebhookIntegrationID)
+
+	if reqErr != nil {
+		return nil, reqErr
+	}
+
+	webhookInt, err := repo.WebhookIntegration().ReadWebhookIntegration(projectID, integrationID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("webhook integration with id %d not found in project", integrationID),
+				http.StatusNotFound,
+			)
+		}
+
+		return nil, apierrors.NewErrInternal(err)
+	}
+
+	return webhookInt, nil
+}
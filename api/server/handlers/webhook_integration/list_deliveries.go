@@ -0,0 +1,66 @@
+package webhook_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+const defaultDeliveriesLimit = 50
+
+type WebhookDeliveriesListHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewWebhookDeliveriesListHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *WebhookDeliveriesListHandler {
+	return &WebhookDeliveriesListHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *WebhookDeliveriesListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	webhookInt, reqErr := readWebhookIntegration(p.Repo(), r, project.ID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.ListWebhookDeliveriesRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	limit := defaultDeliveriesLimit
+
+	if request.Limit > 0 {
+		limit = int(request.Limit)
+	}
+
+	deliveries, err := p.Repo().WebhookIntegration().ListWebhookDeliveries(webhookInt.ID, limit)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListWebhookDeliveriesResponse, 0)
+
+	for _, delivery := range deliveries {
+		res = append(res, delivery.ToWebhookDeliveryType())
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,51 @@
+package webhook_integration
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+)
+
+type WebhookIntegrationTestHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewWebhookIntegrationTestHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *WebhookIntegrationTestHandler {
+	return &WebhookIntegrationTestHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+// ServeHTTP sends a test event to the webhook, and returns the result of the delivery
+func (p *WebhookIntegrationTestHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	webhookInt, reqErr := readWebhookIntegration(p.Repo(), r, project.ID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	sender := webhook.NewSender(p.Repo().WebhookIntegration())
+
+	// the test event is not retried, so that the result is returned quickly
+	sender.MaxAttempts = 1
+
+	delivery := sender.Send(webhookInt, &types.WebhookPayload{
+		Event:     types.WebhookEventTest,
+		ProjectID: project.ID,
+		Timestamp: time.Now(),
+	})
+
+	p.WriteResult(w, r, delivery.ToWebhookDeliveryType())
+}
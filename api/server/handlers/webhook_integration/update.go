@@ -0,0 +1,68 @@
+package webhook_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type WebhookIntegrationUpdateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewWebhookIntegrationUpdateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *WebhookIntegrationUpdateHandler {
+	return &WebhookIntegrationUpdateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *WebhookIntegrationUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	webhookInt, reqErr := readWebhookIntegration(p.Repo(), r, project.ID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.UpdateWebhookIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if request.Name != nil {
+		webhookInt.Name = *request.Name
+	}
+
+	if request.URL != nil {
+		webhookInt.URL = []byte(*request.URL)
+	}
+
+	if request.DeploymentEvents != nil {
+		webhookInt.DeploymentEvents = *request.DeploymentEvents
+	}
+
+	if request.IncidentEvents != nil {
+		webhookInt.IncidentEvents = *request.IncidentEvents
+	}
+
+	webhookInt, err := p.Repo().WebhookIntegration().UpdateWebhookIntegration(webhookInt)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, webhookInt.ToWebhookIntegrationType())
+}
@@ -182,6 +182,7 @@ func GetBaseRoutes(
 				Parent:       basePath,
 				RelativePath: "/users",
 			},
+			RateLimitGroup: types.RateLimitGroupAuth,
 		},
 	)
 
@@ -206,6 +207,7 @@ func GetBaseRoutes(
 				Parent:       basePath,
 				RelativePath: "/login",
 			},
+			RateLimitGroup: types.RateLimitGroupAuth,
 		},
 	)
 
@@ -221,6 +223,31 @@ func GetBaseRoutes(
 		Router:   r,
 	})
 
+	// POST /api/login/2fa -> user.NewUserLoginTwoFactorHandler
+	loginTwoFactorEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/login/2fa",
+			},
+			RateLimitGroup: types.RateLimitGroupAuth,
+		},
+	)
+
+	loginTwoFactorHandler := user.NewUserLoginTwoFactorHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: loginTwoFactorEndpoint,
+		Handler:  loginTwoFactorHandler,
+		Router:   r,
+	})
+
 	// POST /api/cli/login/exchange -> user.NewCLILoginExchangeHandler
 	cliLoginExchangeEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -230,6 +257,7 @@ func GetBaseRoutes(
 				Parent:       basePath,
 				RelativePath: "/cli/login/exchange",
 			},
+			RateLimitGroup: types.RateLimitGroupAuth,
 		},
 	)
 
@@ -254,6 +282,7 @@ func GetBaseRoutes(
 				Parent:       basePath,
 				RelativePath: "/password/reset/initiate",
 			},
+			RateLimitGroup: types.RateLimitGroupAuth,
 		},
 	)
 
@@ -278,6 +307,7 @@ func GetBaseRoutes(
 				Parent:       basePath,
 				RelativePath: "/password/reset/verify",
 			},
+			RateLimitGroup: types.RateLimitGroupAuth,
 		},
 	)
 
@@ -302,6 +332,7 @@ func GetBaseRoutes(
 				Parent:       basePath,
 				RelativePath: "/password/reset/finalize",
 			},
+			RateLimitGroup: types.RateLimitGroupAuth,
 		},
 	)
 
@@ -326,7 +357,8 @@ func GetBaseRoutes(
 				Parent:       basePath,
 				RelativePath: "/webhooks/deploy/{token}",
 			},
-			Scopes: []types.PermissionScope{},
+			Scopes:         []types.PermissionScope{},
+			RateLimitGroup: types.RateLimitGroupWebhook,
 		},
 	)
 
@@ -374,7 +406,8 @@ func GetBaseRoutes(
 				Parent:       basePath,
 				RelativePath: "/integrations/github-app/webhook",
 			},
-			Scopes: []types.PermissionScope{},
+			Scopes:         []types.PermissionScope{},
+			RateLimitGroup: types.RateLimitGroupWebhook,
 		},
 	)
 
@@ -490,6 +523,56 @@ func GetBaseRoutes(
 		Router:   r,
 	})
 
+	// GET /api/oauth/login/oidc
+	oidcLoginStartEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/oauth/login/oidc",
+			},
+			Scopes: []types.PermissionScope{},
+		},
+	)
+
+	oidcLoginStartHandler := user.NewUserOAuthOIDCHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: oidcLoginStartEndpoint,
+		Handler:  oidcLoginStartHandler,
+		Router:   r,
+	})
+
+	// GET /api/oauth/oidc/callback
+	oidcLoginCallbackEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/oauth/oidc/callback",
+			},
+			Scopes: []types.PermissionScope{},
+		},
+	)
+
+	oidcLoginCallbackHandler := user.NewUserOAuthOIDCCallbackHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: oidcLoginCallbackEndpoint,
+		Handler:  oidcLoginCallbackHandler,
+		Router:   r,
+	})
+
 	// GET /api/internal/credentials
 	getCredentialsEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -525,7 +608,8 @@ func GetBaseRoutes(
 					Parent:       basePath,
 					RelativePath: fmt.Sprintf("/github/incoming_webhook/{%s}", types.URLParamIncomingWebhookID),
 				},
-				Scopes: []types.PermissionScope{},
+				Scopes:         []types.PermissionScope{},
+				RateLimitGroup: types.RateLimitGroupWebhook,
 			},
 		)
 
@@ -542,5 +626,33 @@ func GetBaseRoutes(
 		})
 	}
 
+	if config.ServerConf.GitlabIncomingWebhookSecret != "" {
+		// POST /api/gitlab/incoming_webhook/{webhook_id} -> webhook.NewGitlabIncomingWebhook
+		gitlabIncomingWebhookEndpoint := factory.NewAPIEndpoint(
+			&types.APIRequestMetadata{
+				Verb:   types.APIVerbCreate,
+				Method: types.HTTPVerbPost,
+				Path: &types.Path{
+					Parent:       basePath,
+					RelativePath: fmt.Sprintf("/gitlab/incoming_webhook/{%s}", types.URLParamIncomingWebhookID),
+				},
+				Scopes:         []types.PermissionScope{},
+				RateLimitGroup: types.RateLimitGroupWebhook,
+			},
+		)
+
+		gitlabIncomingWebhookHandler := webhook.NewGitlabIncomingWebhookHandler(
+			config,
+			factory.GetDecoderValidator(),
+			factory.GetResultWriter(),
+		)
+
+		routes = append(routes, &router.Route{
+			Endpoint: gitlabIncomingWebhookEndpoint,
+			Handler:  gitlabIncomingWebhookHandler,
+			Router:   r,
+		})
+	}
+
 	return routes
 }
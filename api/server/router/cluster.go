@@ -288,7 +288,7 @@ func getClusterRoutes(
 		Router:   r,
 	})
 
-	if config.ServerConf.GithubIncomingWebhookSecret != "" {
+	if config.ServerConf.GithubIncomingWebhookSecret != "" || config.ServerConf.GitlabIncomingWebhookSecret != "" {
 
 		// GET /api/projects/{project_id}/clusters/{cluster_id}/environments -> environment.NewListEnvironmentHandler
 		listEnvEndpoint := factory.NewAPIEndpoint(
@@ -589,6 +589,37 @@ func getClusterRoutes(
 			Router:   r,
 		})
 
+		// POST /api/projects/{project_id}/clusters/{cluster_id}/deployments/{deployment_id}/wake ->
+		// environment.NewWakeDeploymentHandler
+		wakeDeploymentEndpoint := factory.NewAPIEndpoint(
+			&types.APIRequestMetadata{
+				Verb:   types.APIVerbUpdate,
+				Method: types.HTTPVerbPost,
+				Path: &types.Path{
+					Parent:       basePath,
+					RelativePath: relPath + "/deployments/{deployment_id}/wake",
+				},
+				Scopes: []types.PermissionScope{
+					types.UserScope,
+					types.ProjectScope,
+					types.ClusterScope,
+					types.PreviewEnvironmentScope,
+				},
+			},
+		)
+
+		wakeDeploymentHandler := environment.NewWakeDeploymentHandler(
+			config,
+			factory.GetDecoderValidator(),
+			factory.GetResultWriter(),
+		)
+
+		routes = append(routes, &router.Route{
+			Endpoint: wakeDeploymentEndpoint,
+			Handler:  wakeDeploymentHandler,
+			Router:   r,
+		})
+
 		// PATCH /api/projects/{project_id}/clusters/{cluster_id}/environments/{environment_id}/settings ->
 		// environment.NewUpdateEnvironmentSettingsHandler
 		updateEnvironmentSettingsEndpoint := factory.NewAPIEndpoint(
@@ -621,6 +652,101 @@ func getClusterRoutes(
 
 	}
 
+	if config.ServerConf.GitlabIncomingWebhookSecret != "" {
+		// POST /api/projects/{project_id}/clusters/{cluster_id}/environments/{environment_id}/gitlab_deployment ->
+		// environment.NewCreateGitlabDeploymentHandler
+		createGitlabDeploymentEndpoint := factory.NewAPIEndpoint(
+			&types.APIRequestMetadata{
+				Verb:   types.APIVerbCreate,
+				Method: types.HTTPVerbPost,
+				Path: &types.Path{
+					Parent:       basePath,
+					RelativePath: relPath + "/environments/{environment_id}/gitlab_deployment",
+				},
+				Scopes: []types.PermissionScope{
+					types.UserScope,
+					types.ProjectScope,
+					types.ClusterScope,
+					types.PreviewEnvironmentScope,
+				},
+			},
+		)
+
+		createGitlabDeploymentHandler := environment.NewCreateGitlabDeploymentHandler(
+			config,
+			factory.GetDecoderValidator(),
+			factory.GetResultWriter(),
+		)
+
+		routes = append(routes, &router.Route{
+			Endpoint: createGitlabDeploymentEndpoint,
+			Handler:  createGitlabDeploymentHandler,
+			Router:   r,
+		})
+
+		// POST /api/projects/{project_id}/clusters/{cluster_id}/environments/{environment_id}/gitlab_deployment/finalize ->
+		// environment.NewFinalizeGitlabDeploymentHandler
+		finalizeGitlabDeploymentEndpoint := factory.NewAPIEndpoint(
+			&types.APIRequestMetadata{
+				Verb:   types.APIVerbCreate,
+				Method: types.HTTPVerbPost,
+				Path: &types.Path{
+					Parent:       basePath,
+					RelativePath: relPath + "/environments/{environment_id}/gitlab_deployment/finalize",
+				},
+				Scopes: []types.PermissionScope{
+					types.UserScope,
+					types.ProjectScope,
+					types.ClusterScope,
+					types.PreviewEnvironmentScope,
+				},
+			},
+		)
+
+		finalizeGitlabDeploymentHandler := environment.NewFinalizeGitlabDeploymentHandler(
+			config,
+			factory.GetDecoderValidator(),
+			factory.GetResultWriter(),
+		)
+
+		routes = append(routes, &router.Route{
+			Endpoint: finalizeGitlabDeploymentEndpoint,
+			Handler:  finalizeGitlabDeploymentHandler,
+			Router:   r,
+		})
+
+		// POST /api/projects/{project_id}/clusters/{cluster_id}/environments/{environment_id}/gitlab_deployment/finalize_errors ->
+		// environment.NewFinalizeGitlabDeploymentWithErrorsHandler
+		finalizeGitlabDeploymentWithErrorsEndpoint := factory.NewAPIEndpoint(
+			&types.APIRequestMetadata{
+				Verb:   types.APIVerbCreate,
+				Method: types.HTTPVerbPost,
+				Path: &types.Path{
+					Parent:       basePath,
+					RelativePath: relPath + "/environments/{environment_id}/gitlab_deployment/finalize_errors",
+				},
+				Scopes: []types.PermissionScope{
+					types.UserScope,
+					types.ProjectScope,
+					types.ClusterScope,
+					types.PreviewEnvironmentScope,
+				},
+			},
+		)
+
+		finalizeGitlabDeploymentWithErrorsHandler := environment.NewFinalizeGitlabDeploymentWithErrorsHandler(
+			config,
+			factory.GetDecoderValidator(),
+			factory.GetResultWriter(),
+		)
+
+		routes = append(routes, &router.Route{
+			Endpoint: finalizeGitlabDeploymentWithErrorsEndpoint,
+			Handler:  finalizeGitlabDeploymentWithErrorsHandler,
+			Router:   r,
+		})
+	}
+
 	// GET /api/projects/{project_id}/clusters/{cluster_id}/namespaces -> cluster.NewClusterListNamespacesHandler
 	listNamespacesEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
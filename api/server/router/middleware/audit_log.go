@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi"
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// AuditLogMiddleware records an audit log entry for every call to a project-scoped endpoint
+// with a create, update or delete verb. It is registered before the policy middleware, so calls
+// which are forbidden by the policy of the project are recorded as well.
+type AuditLogMiddleware struct {
+	config       *config.Config
+	endpointMeta *types.APIRequestMetadata
+}
+
+func NewAuditLogMiddleware(config *config.Config, endpointMeta *types.APIRequestMetadata) *AuditLogMiddleware {
+	return &AuditLogMiddleware{config, endpointMeta}
+}
+
+func (mw *AuditLogMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the verb of the endpoint, rather than the HTTP method, decides whether the call is
+		// audited, since some endpoints which read sensitive data require write access
+		if !isAuditedVerb(mw.endpointMeta.Verb) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		reqScopes, reqErr := authz.GetRequestActionForEndpoint(r, *mw.endpointMeta)
+
+		if reqErr != nil {
+			// the policy middleware rejects requests with invalid URL parameters
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		rw := newRequestLoggerResponseWriter(w)
+
+		next.ServeHTTP(rw, r)
+
+		projID := reqScopes[types.ProjectScope].Resource.UInt
+
+		auditLog := &models.AuditLog{
+			ProjectID:  projID,
+			IPAddress:  requestutils.GetClientIP(r, mw.config.TrustedProxies),
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			Verb:       mw.endpointMeta.Verb,
+			StatusCode: rw.statusCode,
+			Outcome:    types.AuditLogOutcomeSuccess,
+		}
+
+		if rw.statusCode >= http.StatusBadRequest {
+			auditLog.Outcome = types.AuditLogOutcomeFailure
+		}
+
+		if rctx := chi.RouteContext(r.Context()); rctx != nil {
+			auditLog.Route = rctx.RoutePattern()
+		}
+
+		if apiToken, ok := r.Context().Value("api_token").(*models.APIToken); ok {
+			auditLog.APITokenID = apiToken.UniqueID
+		} else if user, ok := r.Context().Value(types.UserScope).(*models.User); ok {
+			auditLog.UserID = user.ID
+		}
+
+		if action, ok := reqScopes[types.ClusterScope]; ok {
+			auditLog.ClusterID = action.Resource.UInt
+		}
+
+		if action, ok := reqScopes[types.NamespaceScope]; ok {
+			auditLog.Namespace = action.Resource.Name
+		}
+
+		auditLog.ResourceType, auditLog.ResourceName = mw.getTargetResource(reqScopes)
+
+		if _, err := mw.config.Repo.AuditLog().CreateAuditLog(auditLog); err != nil {
+			mw.config.Logger.Error().Err(err).Uint("project_id", projID).Msg("could not create audit log")
+		}
+	})
+}
+
+func isAuditedVerb(verb types.APIVerb) bool {
+	return verb == types.APIVerbCreate || verb == types.APIVerbUpdate || verb == types.APIVerbDelete
+}
+
+// getTargetResource returns the most specific resource of the request scopes of the endpoint
+func (mw *AuditLogMiddleware) getTargetResource(
+	reqScopes map[types.PermissionScope]*types.RequestAction,
+) (types.PermissionScope, string) {
+	for i := len(mw.endpointMeta.Scopes) - 1; i >= 0; i-- {
+		scope := mw.endpointMeta.Scopes[i]
+
+		action, exists := reqScopes[scope]
+
+		if !exists || action == nil {
+			continue
+		}
+
+		if action.Resource.Name != "" {
+			return scope, action.Resource.Name
+		} else if action.Resource.UInt != 0 {
+			return scope, fmt.Sprintf("%d", action.Resource.UInt)
+		}
+	}
+
+	return types.ProjectScope, ""
+}
@@ -0,0 +1,90 @@
+package middleware_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/router/middleware"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestAuditLogMiddlewareRecordsForbiddenCalls(t *testing.T) {
+	config, handler := loadAuditLogHandler(t, types.APIVerbDelete)
+
+	logs := serveAuditedRequest(t, config, handler)
+
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 audit log, got %d", len(logs))
+	}
+
+	if logs[0].StatusCode != http.StatusForbidden || logs[0].Outcome != types.AuditLogOutcomeFailure {
+		t.Errorf("expected a forbidden call to be recorded as a failure, got %d %s", logs[0].StatusCode, logs[0].Outcome)
+	}
+
+	if logs[0].ClusterID != 1 || logs[0].ResourceType != types.ClusterScope || logs[0].ResourceName != "1" {
+		t.Errorf("expected the cluster to be recorded, got %+v", logs[0])
+	}
+}
+
+func TestAuditLogMiddlewareSkipsReadVerbs(t *testing.T) {
+	config, handler := loadAuditLogHandler(t, types.APIVerbGet)
+
+	if logs := serveAuditedRequest(t, config, handler); len(logs) != 0 {
+		t.Errorf("expected no audit logs for a get verb, got %d", len(logs))
+	}
+}
+
+func loadAuditLogHandler(t *testing.T, verb types.APIVerb) (*config.Config, http.Handler) {
+	config := apitest.LoadConfig(t)
+
+	endpointMeta := types.APIRequestMetadata{
+		Verb:   verb,
+		Method: types.HTTPVerbPost,
+		Scopes: []types.PermissionScope{
+			types.UserScope,
+			types.ProjectScope,
+			types.ClusterScope,
+		},
+	}
+
+	// the user is not a member of the project, so the policy middleware forbids every call
+	loader := policy.NewBasicPolicyDocumentLoader(config.Repo.Project(), config.Repo.Policy())
+	policyMw := authz.NewPolicyMiddleware(config, endpointMeta, loader)
+	auditLogMw := middleware.NewAuditLogMiddleware(config, &endpointMeta)
+
+	return config, auditLogMw.Middleware(policyMw.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("expected the call to be forbidden")
+	})))
+}
+
+func serveAuditedRequest(t *testing.T, config *config.Config, handler http.Handler) []*models.AuditLog {
+	user := apitest.CreateTestUser(t, config, true)
+
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/clusters/1", nil)
+
+	req = apitest.WithURLParams(t, req, map[string]string{
+		"project_id": "1",
+		"cluster_id": "1",
+	})
+
+	req = apitest.WithAuthenticatedUser(t, req, user)
+
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected status %d, got %d", http.StatusForbidden, rr.Code)
+	}
+
+	logs, _, err := config.Repo.AuditLog().ListAuditLogsByProjectID(1, &types.AuditLogFilter{}, 10, 0)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return logs
+}
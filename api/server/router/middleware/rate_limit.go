@@ -0,0 +1,103 @@
+package middleware
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// RateLimitMiddleware limits the number of requests to a group of endpoints per window.
+// Requests are counted per API token or user when the request is authenticated, and per
+// IP address otherwise.
+type RateLimitMiddleware struct {
+	config *config.Config
+	group  types.RateLimitGroup
+	limit  uint
+}
+
+func NewRateLimitMiddleware(config *config.Config, group types.RateLimitGroup) *RateLimitMiddleware {
+	if group == "" {
+		group = types.RateLimitGroupAPI
+	}
+
+	return &RateLimitMiddleware{config, group, getRateLimit(config, group)}
+}
+
+// getRateLimit returns the number of requests allowed per window for a group of endpoints,
+// or 0 if the requests to the group are not limited
+func getRateLimit(config *config.Config, group types.RateLimitGroup) uint {
+	if config.RateLimiter == nil {
+		return 0
+	}
+
+	switch group {
+	case types.RateLimitGroupAuth:
+		return config.ServerConf.RateLimitAuth
+	case types.RateLimitGroupWebhook:
+		return config.ServerConf.RateLimitWebhook
+	default:
+		return config.ServerConf.RateLimitAPI
+	}
+}
+
+func (mw *RateLimitMiddleware) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if mw.limit == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		key := fmt.Sprintf("%s:%s", mw.group, getRateLimitSubject(r, mw.config.TrustedProxies))
+
+		allowed, retryAfter, err := mw.config.RateLimiter.Allow(r.Context(), key, mw.limit, mw.config.ServerConf.RateLimitWindow)
+
+		// don't block requests if the rate limiter is unavailable
+		if err != nil {
+			mw.config.Logger.Error().Err(err).Str("key", key).Msg("could not check rate limit")
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if !allowed {
+			retryAfterSeconds := int(math.Max(1, math.Ceil(retryAfter.Seconds())))
+
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfterSeconds))
+
+			apierrors.HandleAPIError(
+				mw.config.Logger,
+				mw.config.Alerter,
+				w, r,
+				apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("too many requests, retry in %s", time.Duration(retryAfterSeconds)*time.Second),
+					http.StatusTooManyRequests,
+				),
+				true,
+			)
+
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func getRateLimitSubject(r *http.Request, trustedProxies []*net.IPNet) string {
+	if apiToken, ok := r.Context().Value("api_token").(*models.APIToken); ok && apiToken != nil {
+		return "token:" + apiToken.UniqueID
+	}
+
+	if user, ok := r.Context().Value(types.UserScope).(*models.User); ok && user != nil && user.ID != 0 {
+		return fmt.Sprintf("user:%d", user.ID)
+	}
+
+	return "ip:" + requestutils.GetClientIP(r, trustedProxies)
+}
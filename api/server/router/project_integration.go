@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	"github.com/go-chi/chi"
+	"github.com/porter-dev/porter/api/server/handlers/environment"
 	project_integration "github.com/porter-dev/porter/api/server/handlers/project_integration"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/config"
@@ -569,5 +570,73 @@ func getProjectIntegrationRoutes(
 		Router:   r,
 	})
 
+	if config.ServerConf.GitlabIncomingWebhookSecret != "" {
+		// POST /api/projects/{project_id}/integrations/gitlab/{integration_id}/repos/{owner}/{name}/clusters/{cluster_id}/environment ->
+		// environment.NewCreateGitlabEnvironmentHandler
+		createGitlabEnvironmentEndpoint := factory.NewAPIEndpoint(
+			&types.APIRequestMetadata{
+				Verb:   types.APIVerbCreate,
+				Method: types.HTTPVerbPost,
+				Path: &types.Path{
+					Parent: basePath,
+					RelativePath: fmt.Sprintf("%s/gitlab/{%s}/repos/{%s}/{%s}/clusters/{cluster_id}/environment", relPath,
+						types.URLParamIntegrationID, types.URLParamGitRepoOwner, types.URLParamGitRepoName),
+				},
+				Scopes: []types.PermissionScope{
+					types.UserScope,
+					types.ProjectScope,
+					types.GitlabIntegrationScope,
+					types.ClusterScope,
+					types.PreviewEnvironmentScope,
+				},
+			},
+		)
+
+		createGitlabEnvironmentHandler := environment.NewCreateGitlabEnvironmentHandler(
+			config,
+			factory.GetDecoderValidator(),
+			factory.GetResultWriter(),
+		)
+
+		routes = append(routes, &router.Route{
+			Endpoint: createGitlabEnvironmentEndpoint,
+			Handler:  createGitlabEnvironmentHandler,
+			Router:   r,
+		})
+
+		// DELETE /api/projects/{project_id}/integrations/gitlab/{integration_id}/repos/{owner}/{name}/clusters/{cluster_id}/environment ->
+		// environment.NewDeleteGitlabEnvironmentHandler
+		deleteGitlabEnvironmentEndpoint := factory.NewAPIEndpoint(
+			&types.APIRequestMetadata{
+				Verb:   types.APIVerbDelete,
+				Method: types.HTTPVerbDelete,
+				Path: &types.Path{
+					Parent: basePath,
+					RelativePath: fmt.Sprintf("%s/gitlab/{%s}/repos/{%s}/{%s}/clusters/{cluster_id}/environment", relPath,
+						types.URLParamIntegrationID, types.URLParamGitRepoOwner, types.URLParamGitRepoName),
+				},
+				Scopes: []types.PermissionScope{
+					types.UserScope,
+					types.ProjectScope,
+					types.GitlabIntegrationScope,
+					types.ClusterScope,
+					types.PreviewEnvironmentScope,
+				},
+			},
+		)
+
+		deleteGitlabEnvironmentHandler := environment.NewDeleteGitlabEnvironmentHandler(
+			config,
+			factory.GetDecoderValidator(),
+			factory.GetResultWriter(),
+		)
+
+		routes = append(routes, &router.Route{
+			Endpoint: deleteGitlabEnvironmentEndpoint,
+			Handler:  deleteGitlabEnvironmentHandler,
+			Router:   r,
+		})
+	}
+
 	return routes, newPath
 }
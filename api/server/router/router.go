@@ -39,6 +39,10 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 	projectIntegrationRegisterer := NewProjectIntegrationScopedRegisterer()
 	projectOAuthRegisterer := NewProjectOAuthScopedRegisterer()
 	slackIntegrationRegisterer := NewSlackIntegrationScopedRegisterer()
+	webhookIntegrationRegisterer := NewWebhookIntegrationScopedRegisterer()
+	incidentRoutingRuleRegisterer := NewIncidentRoutingRuleScopedRegisterer()
+	policyPackRegisterer := NewPolicyPackScopedRegisterer()
+	auditLogRegisterer := NewAuditLogScopedRegisterer()
 	projRegisterer := NewProjectScopedRegisterer(
 		clusterRegisterer,
 		registryRegisterer,
@@ -49,6 +53,10 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 		projectIntegrationRegisterer,
 		projectOAuthRegisterer,
 		slackIntegrationRegisterer,
+		webhookIntegrationRegisterer,
+		incidentRoutingRuleRegisterer,
+		policyPackRegisterer,
+		auditLogRegisterer,
 	)
 	statusRegisterer := NewStatusScopedRegisterer()
 
@@ -238,6 +246,14 @@ func registerRoutes(config *config.Config, routes []*router.Route) {
 	for _, route := range routes {
 		atomicGroup := route.Router.Group(nil)
 
+		// rate limit authenticated requests per user or token once they are authenticated, and
+		// other requests per IP address before they are handled
+		rateLimitMw := middleware.NewRateLimitMiddleware(config, route.Endpoint.Metadata.RateLimitGroup)
+
+		if !isUserScoped(route.Endpoint.Metadata) {
+			atomicGroup.Use(rateLimitMw.Middleware)
+		}
+
 		for _, scope := range route.Endpoint.Metadata.Scopes {
 			switch scope {
 			case types.UserScope:
@@ -247,9 +263,15 @@ func registerRoutes(config *config.Config, routes []*router.Route) {
 				} else {
 					atomicGroup.Use(authNFactory.NewAuthenticated)
 				}
+
+				atomicGroup.Use(rateLimitMw.Middleware)
 			case types.ProjectScope:
+				// record calls to project-scoped endpoints in the audit log of the project, including
+				// calls which are forbidden by the policy of the project
+				auditLogMw := middleware.NewAuditLogMiddleware(config, route.Endpoint.Metadata)
 				policyFactory := authz.NewPolicyMiddleware(config, *route.Endpoint.Metadata, policyDocLoader)
 
+				atomicGroup.Use(auditLogMw.Middleware)
 				atomicGroup.Use(policyFactory.Middleware)
 				atomicGroup.Use(projFactory.Middleware)
 			case types.ClusterScope:
@@ -300,3 +322,13 @@ func registerRoutes(config *config.Config, routes []*router.Route) {
 		)
 	}
 }
+
+func isUserScoped(endpointMeta *types.APIRequestMetadata) bool {
+	for _, scope := range endpointMeta.Scopes {
+		if scope == types.UserScope {
+			return true
+		}
+	}
+
+	return false
+}
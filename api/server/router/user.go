@@ -169,6 +169,105 @@ func getUserRoutes(
 		Router:   r,
 	})
 
+	// POST /api/users/current/2fa/enroll -> user.NewTwoFactorEnrollHandler
+	twoFactorEnrollEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/2fa/enroll",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	twoFactorEnrollHandler := user.NewTwoFactorEnrollHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: twoFactorEnrollEndpoint,
+		Handler:  twoFactorEnrollHandler,
+		Router:   r,
+	})
+
+	// POST /api/users/current/2fa/activate -> user.NewTwoFactorActivateHandler
+	twoFactorActivateEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/2fa/activate",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	twoFactorActivateHandler := user.NewTwoFactorActivateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: twoFactorActivateEndpoint,
+		Handler:  twoFactorActivateHandler,
+		Router:   r,
+	})
+
+	// POST /api/users/current/2fa/disable -> user.NewTwoFactorDisableHandler
+	twoFactorDisableEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/2fa/disable",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	twoFactorDisableHandler := user.NewTwoFactorDisableHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: twoFactorDisableEndpoint,
+		Handler:  twoFactorDisableHandler,
+		Router:   r,
+	})
+
+	// POST /api/users/current/2fa/recovery_codes -> user.NewTwoFactorRecoveryCodesHandler
+	twoFactorRecoveryCodesEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/users/current/2fa/recovery_codes",
+			},
+			Scopes: []types.PermissionScope{types.UserScope},
+		},
+	)
+
+	twoFactorRecoveryCodesHandler := user.NewTwoFactorRecoveryCodesHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: twoFactorRecoveryCodesEndpoint,
+		Handler:  twoFactorRecoveryCodesHandler,
+		Router:   r,
+	})
+
 	// POST /api/projects -> project.NewProjectCreateHandler
 	createEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
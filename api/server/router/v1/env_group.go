@@ -502,5 +502,194 @@ func getV1EnvGroupRoutes(
 		Router:   r,
 	})
 
+	// POST /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/env_groups/{name}/import -> env_group.NewImportEnvGroupHandler
+	// swagger:operation POST /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/env_groups/{name}/import importEnvGroup
+	//
+	// Imports variables from the contents of dotenv, JSON or YAML files into the env group denoted by `name` in the namespace denoted by
+	// `namespace`, creating the env group if it does not exist. The namespace should belong to the cluster denoted by `cluster_id`, which
+	// in turn should belong to the project denoted by `project_id`.
+	//
+	// **Note:** The linked releases with the env group will all be updated as well.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// summary: Import variables into an env group
+	// tags:
+	// - Env groups
+	// parameters:
+	//   - name: project_id
+	//   - name: cluster_id
+	//   - name: namespace
+	//   - name: name
+	//   - in: body
+	//     name: ImportEnvGroupRequest
+	//     description: The files to import
+	//     schema:
+	//       $ref: '#/definitions/ImportEnvGroupRequest'
+	// responses:
+	//   '200':
+	//     description: Successfully imported the variables
+	//     schema:
+	//       $ref: '#/definitions/V1EnvGroupResponse'
+	//   '400':
+	//     description: The files could not be parsed
+	//   '403':
+	//     description: Forbidden
+	importEnvGroupEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/{%s}/import", relPath, types.URLParamEnvGroupName),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+				types.NamespaceScope,
+			},
+		},
+	)
+
+	importEnvGroupHandler := v1EnvGroup.NewImportEnvGroupHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: importEnvGroupEndpoint,
+		Handler:  importEnvGroupHandler,
+		Router:   r,
+	})
+
+	// GET /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/env_groups/{name}/export -> env_group.NewExportEnvGroupHandler
+	// swagger:operation GET /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/env_groups/{name}/export exportEnvGroup
+	//
+	// Exports the variables of the env group denoted by `name` in the namespace denoted by `namespace` as the contents of a dotenv, JSON or
+	// YAML file. The values of secret variables are left out. The namespace should belong to the cluster denoted by `cluster_id`, which in
+	// turn should belong to the project denoted by `project_id`.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// summary: Export the variables of an env group
+	// tags:
+	// - Env groups
+	// parameters:
+	//   - name: project_id
+	//   - name: cluster_id
+	//   - name: namespace
+	//   - name: name
+	//   - name: format
+	//     in: query
+	//     description: The format of the file, one of dotenv (default), json or yaml.
+	//     type: string
+	//   - name: version
+	//     in: query
+	//     description: The version of the env group to export, defaults to the latest version.
+	//     type: integer
+	// responses:
+	//   '200':
+	//     description: Successfully exported the env group
+	//     schema:
+	//       $ref: '#/definitions/ExportEnvGroupResponse'
+	//   '403':
+	//     description: Forbidden
+	//   '404':
+	//     description: Env group not found
+	exportEnvGroupEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/{%s}/export", relPath, types.URLParamEnvGroupName),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+				types.NamespaceScope,
+			},
+		},
+	)
+
+	exportEnvGroupHandler := v1EnvGroup.NewExportEnvGroupHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: exportEnvGroupEndpoint,
+		Handler:  exportEnvGroupHandler,
+		Router:   r,
+	})
+
+	// POST /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/env_groups/{name}/export/secrets -> env_group.NewExportEnvGroupSecretsHandler
+	// swagger:operation POST /api/v1/projects/{project_id}/clusters/{cluster_id}/namespaces/{namespace}/env_groups/{name}/export/secrets exportEnvGroupSecrets
+	//
+	// Exports the variables of the env group denoted by `name` in the namespace denoted by `namespace` as the contents of a dotenv, JSON or
+	// YAML file, including the values of secret variables. This requires permission to update the settings of the project denoted by
+	// `project_id`, which only admins have by default. Every export is recorded in the audit log of the project.
+	//
+	// ---
+	// produces:
+	// - application/json
+	// summary: Export the variables of an env group including secrets
+	// tags:
+	// - Env groups
+	// parameters:
+	//   - name: project_id
+	//   - name: cluster_id
+	//   - name: namespace
+	//   - name: name
+	//   - in: body
+	//     name: ExportEnvGroupRequest
+	//     description: The format and version of the export
+	//     schema:
+	//       $ref: '#/definitions/ExportEnvGroupRequest'
+	// responses:
+	//   '200':
+	//     description: Successfully exported the env group
+	//     schema:
+	//       $ref: '#/definitions/ExportEnvGroupResponse'
+	//   '403':
+	//     description: Forbidden
+	//   '404':
+	//     description: Env group not found
+	exportEnvGroupSecretsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/{%s}/export/secrets", relPath, types.URLParamEnvGroupName),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+				types.ClusterScope,
+				types.NamespaceScope,
+			},
+		},
+	)
+
+	exportEnvGroupSecretsHandler := v1EnvGroup.NewExportEnvGroupSecretsHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: exportEnvGroupSecretsEndpoint,
+		Handler:  exportEnvGroupSecretsHandler,
+		Router:   r,
+	})
+
 	return routes, newPath
 }
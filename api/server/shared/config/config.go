@@ -1,19 +1,24 @@
 package config
 
 import (
+	"net"
+
 	"github.com/gorilla/sessions"
 	"github.com/porter-dev/porter/api/server/shared/apierrors/alerter"
 	"github.com/porter-dev/porter/api/server/shared/config/env"
 	"github.com/porter-dev/porter/api/server/shared/websocket"
 	"github.com/porter-dev/porter/internal/analytics"
+	"github.com/porter-dev/porter/internal/auth/oidc"
 	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/billing"
 	"github.com/porter-dev/porter/internal/helm/urlcache"
 	"github.com/porter-dev/porter/internal/integrations/powerdns"
 	"github.com/porter-dev/porter/internal/notifier"
 	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/ratelimit"
 	"github.com/porter-dev/porter/internal/repository"
 	"github.com/porter-dev/porter/internal/repository/credentials"
+	"github.com/porter-dev/porter/internal/secretstore"
 	"github.com/porter-dev/porter/pkg/logger"
 	"github.com/porter-dev/porter/provisioner/client"
 	"golang.org/x/oauth2"
@@ -66,6 +71,15 @@ type Config struct {
 	// GoogleConf is the configuration for a Google OAuth client
 	GoogleConf *oauth2.Config
 
+	// OIDCProvider authenticates users against a generic OpenID Connect provider
+	OIDCProvider *oidc.Provider
+
+	// OIDCGroupRoles grants project roles to the members of groups of the OpenID Connect provider
+	OIDCGroupRoles []*oidc.GroupRole
+
+	// TrustedProxies are the proxies which are trusted to set the X-Forwarded-For header
+	TrustedProxies []*net.IPNet
+
 	// SlackConf is the configuration for a Slack OAuth client
 	SlackConf *oauth2.Config
 
@@ -93,6 +107,13 @@ type Config struct {
 	// PowerDNSClient is a client for PowerDNS, if the Porter instance supports vanity URLs
 	PowerDNSClient *powerdns.Client
 
+	// RateLimiter counts requests to the API server, if rate limiting is enabled
+	RateLimiter ratelimit.Limiter
+
+	// SecretStore is the external secret store which env group variables can reference,
+	// if one is configured
+	SecretStore secretstore.Store
+
 	// CredentialBackend is the backend for credential storage, if external cred storage (like Vault)
 	// is used
 	CredentialBackend credentials.CredentialStorage
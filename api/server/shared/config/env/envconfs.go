@@ -40,6 +40,10 @@ type ServerConf struct {
 
 	GithubIncomingWebhookSecret string `env:"GITHUB_INCOMING_WEBHOOK_SECRET"`
 
+	// GitlabIncomingWebhookSecret is the secret token of the webhooks which GitLab merge request
+	// preview environments receive events through
+	GitlabIncomingWebhookSecret string `env:"GITLAB_INCOMING_WEBHOOK_SECRET"`
+
 	GithubAppClientID      string `env:"GITHUB_APP_CLIENT_ID"`
 	GithubAppClientSecret  string `env:"GITHUB_APP_CLIENT_SECRET"`
 	GithubAppName          string `env:"GITHUB_APP_NAME"`
@@ -52,6 +56,17 @@ type ServerConf struct {
 	GoogleClientSecret     string `env:"GOOGLE_CLIENT_SECRET"`
 	GoogleRestrictedDomain string `env:"GOOGLE_RESTRICTED_DOMAIN"`
 
+	// Options for logging in with a generic OpenID Connect provider, such as Keycloak or Okta.
+	// Users are provisioned on their first login, and OIDCGroupRoles grants project roles to
+	// the members of groups of the provider, with mappings of the form <group>:<project_id>:<role>
+	// separated by semicolons
+	OIDCIssuerURL      string   `env:"OIDC_ISSUER_URL"`
+	OIDCClientID       string   `env:"OIDC_CLIENT_ID"`
+	OIDCClientSecret   string   `env:"OIDC_CLIENT_SECRET"`
+	OIDCAllowedDomains []string `env:"OIDC_ALLOWED_DOMAINS"`
+	OIDCGroupsClaim    string   `env:"OIDC_GROUPS_CLAIM,default=groups"`
+	OIDCGroupRoles     []string `env:"OIDC_GROUP_ROLES"`
+
 	SendgridAPIKey                     string `env:"SENDGRID_API_KEY"`
 	SendgridPWResetTemplateID          string `env:"SENDGRID_PW_RESET_TEMPLATE_ID"`
 	SendgridPWGHTemplateID             string `env:"SENDGRID_PW_GH_TEMPLATE_ID"`
@@ -97,6 +112,28 @@ type ServerConf struct {
 	// Token for internal retool to authenticate to internal API endpoints
 	RetoolToken string `env:"RETOOL_TOKEN"`
 
+	// Options for rate limiting requests to the API server. Requests are counted per API token,
+	// user or IP address, and the limits are the number of requests allowed per window for each
+	// group of endpoints. A limit of 0 disables rate limiting for the group.
+	RateLimitEnabled bool          `env:"RATE_LIMIT_ENABLED,default=true"`
+	RateLimitWindow  time.Duration `env:"RATE_LIMIT_WINDOW,default=1m"`
+	RateLimitAPI     uint          `env:"RATE_LIMIT_API,default=1200"`
+	RateLimitAuth    uint          `env:"RATE_LIMIT_AUTH,default=10"`
+	RateLimitWebhook uint          `env:"RATE_LIMIT_WEBHOOK,default=120"`
+
+	// TrustedProxies are the IP addresses and CIDR ranges of the load balancers and proxies in
+	// front of the server. The X-Forwarded-For header is only used to find the IP address of a
+	// client if the request was sent by a trusted proxy.
+	TrustedProxies []string `env:"TRUSTED_PROXIES"`
+
+	// Options for the HashiCorp Vault KV version 2 secrets engine which env group variables
+	// can reference. External secret variables are disabled if the address is not set. The
+	// secrets of a project are read under the projects/<project_id> path of the mount.
+	ExternalSecretsVaultAddress   string `env:"EXTERNAL_SECRETS_VAULT_ADDR"`
+	ExternalSecretsVaultToken     string `env:"EXTERNAL_SECRETS_VAULT_TOKEN"`
+	ExternalSecretsVaultMount     string `env:"EXTERNAL_SECRETS_VAULT_MOUNT,default=secret"`
+	ExternalSecretsVaultNamespace string `env:"EXTERNAL_SECRETS_VAULT_NAMESPACE"`
+
 	// Enable pprof profiling endpoints
 	PprofEnabled    bool `env:"PPROF_ENABLED,default=false"`
 	ProvisionerTest bool `env:"PROVISIONER_TEST,default=false"`
@@ -11,9 +11,11 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/config/env"
 	"github.com/porter-dev/porter/api/server/shared/config/envloader"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/server/shared/websocket"
 	"github.com/porter-dev/porter/internal/adapter"
 	"github.com/porter-dev/porter/internal/analytics"
+	"github.com/porter-dev/porter/internal/auth/oidc"
 	"github.com/porter-dev/porter/internal/auth/sessionstore"
 	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/billing"
@@ -22,8 +24,10 @@ import (
 	"github.com/porter-dev/porter/internal/notifier"
 	"github.com/porter-dev/porter/internal/notifier/sendgrid"
 	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/ratelimit"
 	"github.com/porter-dev/porter/internal/repository/credentials"
 	"github.com/porter-dev/porter/internal/repository/gorm"
+	"github.com/porter-dev/porter/internal/secretstore"
 	"github.com/porter-dev/porter/provisioner/client"
 
 	lr "github.com/porter-dev/porter/pkg/logger"
@@ -147,6 +151,29 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 		})
 	}
 
+	if res.Metadata.OIDCLogin {
+		res.OIDCProvider = oidc.NewProvider(&oidc.Config{
+			IssuerURL:    sc.OIDCIssuerURL,
+			ClientID:     sc.OIDCClientID,
+			ClientSecret: sc.OIDCClientSecret,
+			RedirectURL:  sc.ServerURL + "/api/oauth/oidc/callback",
+			Scopes:       []string{"profile", "email"},
+			GroupsClaim:  sc.OIDCGroupsClaim,
+		})
+
+		res.OIDCGroupRoles, err = oidc.ParseGroupRoles(sc.OIDCGroupRoles)
+
+		if err != nil {
+			return nil, fmt.Errorf("could not parse OIDC group roles: %w", err)
+		}
+	}
+
+	res.TrustedProxies, err = requestutils.ParseTrustedProxies(sc.TrustedProxies)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not parse trusted proxies: %w", err)
+	}
+
 	if sc.GithubClientID != "" && sc.GithubClientSecret != "" {
 		res.GithubConf = oauth.NewGithubClient(&oauth.Config{
 			ClientID:     sc.GithubClientID,
@@ -226,9 +253,38 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 		res.PowerDNSClient = powerdns.NewClient(sc.PowerDNSAPIServerURL, sc.PowerDNSAPIKey, sc.AppRootDomain)
 	}
 
+	if sc.RateLimitEnabled {
+		res.RateLimiter = getRateLimiter(res)
+	}
+
+	if sc.ExternalSecretsVaultAddress != "" {
+		res.SecretStore = secretstore.NewVaultKVv2Store(&secretstore.VaultKVv2Opts{
+			Address:   sc.ExternalSecretsVaultAddress,
+			Token:     sc.ExternalSecretsVaultToken,
+			Mount:     sc.ExternalSecretsVaultMount,
+			Namespace: sc.ExternalSecretsVaultNamespace,
+		})
+	}
+
 	return res, nil
 }
 
+// getRateLimiter shares rate limits between instances of the server through redis when it
+// is enabled, and otherwise limits requests per instance
+func getRateLimiter(conf *config.Config) ratelimit.Limiter {
+	if conf.RedisConf != nil && conf.RedisConf.Enabled {
+		redisClient, err := adapter.NewRedisClient(conf.RedisConf)
+
+		if err == nil {
+			return ratelimit.NewRedisLimiter(redisClient)
+		}
+
+		conf.Logger.Warn().Err(err).Msg("could not connect to redis, falling back to in-memory rate limits")
+	}
+
+	return ratelimit.NewMemoryLimiter()
+}
+
 func getProvisionerServiceClient(sc *env.ServerConf) (*client.Client, error) {
 	if sc.ProvisionerServerURL != "" && sc.ProvisionerToken != "" {
 		baseURL := fmt.Sprintf("%s/api/v1", sc.ProvisionerServerURL)
@@ -0,0 +1,106 @@
+package requestutils
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+)
+
+// GetClientIP returns the IP address of the client which sent the request. The
+// X-Forwarded-For header is only read if the request was sent by a trusted proxy, in which
+// case the rightmost address which does not belong to a trusted proxy is the client. Any
+// address to the left of it may have been set by the client itself.
+func GetClientIP(r *http.Request, trustedProxies []*net.IPNet) string {
+	remoteIP := r.RemoteAddr
+
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		remoteIP = host
+	}
+
+	if !isTrustedProxy(remoteIP, trustedProxies) {
+		return remoteIP
+	}
+
+	forwarded := r.Header.Values("X-Forwarded-For")
+
+	if len(forwarded) == 0 {
+		return remoteIP
+	}
+
+	hops := strings.Split(strings.Join(forwarded, ","), ",")
+
+	for i := len(hops) - 1; i >= 0; i-- {
+		hop := strings.TrimSpace(hops[i])
+
+		if net.ParseIP(hop) == nil {
+			// the address cannot be trusted, so neither can any address to the left of it
+			return remoteIP
+		}
+
+		if !isTrustedProxy(hop, trustedProxies) || i == 0 {
+			return hop
+		}
+	}
+
+	return remoteIP
+}
+
+// ParseTrustedProxies parses a list of IP addresses and CIDR ranges of proxies which are
+// trusted to set the X-Forwarded-For header
+func ParseTrustedProxies(proxies []string) ([]*net.IPNet, error) {
+	res := make([]*net.IPNet, 0)
+
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+
+		if proxy == "" {
+			continue
+		}
+
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+
+			if ip == nil {
+				return nil, fmt.Errorf("invalid trusted proxy %s", proxy)
+			}
+
+			bits := 8 * net.IPv6len
+
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+
+			res = append(res, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+
+			continue
+		}
+
+		_, ipNet, err := net.ParseCIDR(proxy)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy %s: %w", proxy, err)
+		}
+
+		res = append(res, ipNet)
+	}
+
+	return res, nil
+}
+
+func isTrustedProxy(addr string, trustedProxies []*net.IPNet) bool {
+	ip := net.ParseIP(addr)
+
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range trustedProxies {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,110 @@
+package requestutils_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/stretchr/testify/assert"
+)
+
+type getClientIPTest struct {
+	description    string
+	remoteAddr     string
+	forwardedFor   string
+	trustedProxies []string
+	expClientAddr  string
+}
+
+var getClientIPTests = []getClientIPTest{
+	{
+		description:   "should use the remote address without the port",
+		remoteAddr:    "10.0.0.1:52100",
+		expClientAddr: "10.0.0.1",
+	},
+	{
+		description:   "should ignore forwarded addresses without trusted proxies",
+		remoteAddr:    "10.0.0.1:52100",
+		forwardedFor:  "203.0.113.7, 10.0.0.2",
+		expClientAddr: "10.0.0.1",
+	},
+	{
+		description:    "should ignore forwarded addresses from an untrusted remote address",
+		remoteAddr:     "198.51.100.4:52100",
+		forwardedFor:   "203.0.113.7",
+		trustedProxies: []string{"10.0.0.0/8"},
+		expClientAddr:  "198.51.100.4",
+	},
+	{
+		description:    "should use the forwarded address of a trusted proxy",
+		remoteAddr:     "10.0.0.1:52100",
+		forwardedFor:   "203.0.113.7",
+		trustedProxies: []string{"10.0.0.0/8"},
+		expClientAddr:  "203.0.113.7",
+	},
+	{
+		description:    "should use the rightmost untrusted forwarded address",
+		remoteAddr:     "10.0.0.1:52100",
+		forwardedFor:   "192.0.2.1, 203.0.113.7, 10.0.0.2",
+		trustedProxies: []string{"10.0.0.0/8"},
+		expClientAddr:  "203.0.113.7",
+	},
+	{
+		description:    "should use the leftmost address if every hop is trusted",
+		remoteAddr:     "10.0.0.1:52100",
+		forwardedFor:   "10.0.0.3, 10.0.0.2",
+		trustedProxies: []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"},
+		expClientAddr:  "10.0.0.3",
+	},
+	{
+		description:    "should stop at an invalid forwarded address",
+		remoteAddr:     "10.0.0.1:52100",
+		forwardedFor:   "203.0.113.7, unknown",
+		trustedProxies: []string{"10.0.0.0/8"},
+		expClientAddr:  "10.0.0.1",
+	},
+	{
+		description:   "should keep a remote address without a port",
+		remoteAddr:    "10.0.0.1",
+		expClientAddr: "10.0.0.1",
+	},
+}
+
+func TestGetClientIP(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, test := range getClientIPTests {
+		trustedProxies, err := requestutils.ParseTrustedProxies(test.trustedProxies)
+
+		if err != nil {
+			t.Fatalf("%s: %v", test.description, err)
+		}
+
+		r := httptest.NewRequest("GET", "/api", nil)
+		r.RemoteAddr = test.remoteAddr
+
+		if test.forwardedFor != "" {
+			r.Header.Set("X-Forwarded-For", test.forwardedFor)
+		}
+
+		assert.Equal(test.expClientAddr, requestutils.GetClientIP(r, trustedProxies), test.description)
+	}
+}
+
+func TestParseTrustedProxies(t *testing.T) {
+	proxies, err := requestutils.ParseTrustedProxies([]string{"10.0.0.0/8", " 192.0.2.1 ", "", "2001:db8::/32"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(proxies) != 3 {
+		t.Fatalf("expected 3 trusted proxies, got %d", len(proxies))
+	}
+
+	for _, invalid := range []string{"10.0.0.0/33", "proxy.internal"} {
+		if _, err := requestutils.ParseTrustedProxies([]string{invalid}); err == nil {
+			t.Errorf("expected error for trusted proxy %s", invalid)
+		}
+	}
+}
@@ -12,6 +12,13 @@ type APITokenMeta struct {
 	PolicyName string `json:"policy_name"`
 	PolicyUID  string `json:"policy_uid"`
 	Name       string `json:"name"`
+
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP string     `json:"last_used_ip,omitempty"`
+
+	// PreviousSecretExpiresAt is set when the token was rotated, and is the time at which
+	// the token issued before the rotation stops working
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty"`
 }
 
 type APIToken struct {
@@ -26,3 +33,19 @@ type CreateAPIToken struct {
 	ExpiresAt time.Time `json:"expires_at"`
 	Name      string    `json:"name" form:"required"`
 }
+
+type RotateAPITokenRequest struct {
+	// ExpiresAt optionally sets a new expiry for the token
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// GracePeriodSeconds is how long the previous token keeps working after the rotation,
+	// which defaults to one hour
+	GracePeriodSeconds *uint `json:"grace_period_seconds" form:"omitempty,max=604800"`
+}
+
+type APITokenSettings struct {
+	// MaxLifetimeDays is the maximum lifetime of new api tokens, or 0 for no limit
+	MaxLifetimeDays uint `json:"max_lifetime_days" form:"max=3650"`
+}
+
+type UpdateAPITokenSettingsRequest APITokenSettings
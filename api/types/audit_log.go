@@ -0,0 +1,79 @@
+package types
+
+import "time"
+
+type AuditLogOutcome string
+
+const (
+	AuditLogOutcomeSuccess AuditLogOutcome = "success"
+	AuditLogOutcomeFailure AuditLogOutcome = "failure"
+)
+
+// AuditLog records a single API call made within a project which requires write access
+type AuditLog struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ProjectID uint   `json:"project_id"`
+	ClusterID uint   `json:"cluster_id,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+
+	// the actor of the call is either a user, or an API token of the project
+	UserID     uint   `json:"user_id,omitempty"`
+	APITokenID string `json:"api_token_id,omitempty"`
+	IPAddress  string `json:"ip_address"`
+
+	Method string  `json:"method"`
+	Path   string  `json:"path"`
+	Route  string  `json:"route"`
+	Verb   APIVerb `json:"verb"`
+
+	// ResourceType and ResourceName identify the most specific resource targeted by the call,
+	// such as a release or a registry
+	ResourceType PermissionScope `json:"resource_type"`
+	ResourceName string          `json:"resource_name"`
+
+	StatusCode int             `json:"status_code"`
+	Outcome    AuditLogOutcome `json:"outcome"`
+}
+
+// AuditLogFilter filters the audit logs of a project. All non-empty fields must match.
+type AuditLogFilter struct {
+	ClusterID    uint            `schema:"cluster_id"`
+	Namespace    string          `schema:"namespace"`
+	UserID       uint            `schema:"user_id"`
+	APITokenID   string          `schema:"api_token_id"`
+	ResourceType PermissionScope `schema:"resource_type"`
+	Verb         APIVerb         `schema:"verb"`
+	Outcome      AuditLogOutcome `schema:"outcome" form:"omitempty,oneof=success failure"`
+	StartRange   *time.Time      `schema:"start_range"`
+	EndRange     *time.Time      `schema:"end_range"`
+}
+
+type ListAuditLogsRequest struct {
+	AuditLogFilter
+
+	Limit int `schema:"limit" form:"omitempty,max=500"`
+	Skip  int `schema:"skip"`
+}
+
+type ListAuditLogsResponse struct {
+	Count int64 `json:"count"`
+	Limit int   `json:"limit"`
+	Skip  int   `json:"skip"`
+
+	AuditLogs []*AuditLog `json:"audit_logs"`
+}
+
+type AuditLogExportFormat string
+
+const (
+	AuditLogExportFormatCSV  AuditLogExportFormat = "csv"
+	AuditLogExportFormatJSON AuditLogExportFormat = "json"
+)
+
+type ExportAuditLogsRequest struct {
+	AuditLogFilter
+
+	Format AuditLogExportFormat `schema:"format" form:"omitempty,oneof=csv json"`
+}
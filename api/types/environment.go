@@ -18,6 +18,16 @@ type Environment struct {
 	NewCommentsDisabled  bool              `json:"new_comments_disabled"`
 	NamespaceLabels      map[string]string `json:"namespace_labels,omitempty"`
 	GitDeployBranches    []string          `json:"git_deploy_branches"`
+
+	GitlabIntegrationID uint `json:"gitlab_integration_id,omitempty"`
+
+	// IdleTimeoutHours is the number of hours without a push or HTTP traffic after which the
+	// deployments of the environment are put to sleep. Deployments never sleep when it is 0.
+	IdleTimeoutHours uint `json:"idle_timeout_hours"`
+
+	// MaxDeploymentAgeHours is the number of hours after which the namespace of a deployment is
+	// deleted. Deployments never expire when it is 0.
+	MaxDeploymentAgeHours uint `json:"max_deployment_age_hours"`
 }
 
 type CreateEnvironmentRequest struct {
@@ -27,6 +37,9 @@ type CreateEnvironmentRequest struct {
 	GitRepoBranches    []string          `json:"git_repo_branches"`
 	NamespaceLabels    map[string]string `json:"namespace_labels"`
 	GitDeployBranches  []string          `json:"git_deploy_branches"`
+
+	IdleTimeoutHours      uint `json:"idle_timeout_hours"`
+	MaxDeploymentAgeHours uint `json:"max_deployment_age_hours"`
 }
 
 type GitHubMetadata struct {
@@ -48,6 +61,13 @@ const (
 	DeploymentStatusInactive DeploymentStatus = "inactive"
 	DeploymentStatusTimedOut DeploymentStatus = "timed_out"
 	DeploymentStatusFailed   DeploymentStatus = "failed"
+
+	// DeploymentStatusSleeping is the status of a deployment scaled to zero after being idle
+	DeploymentStatusSleeping DeploymentStatus = "sleeping"
+
+	// DeploymentStatusExpired is the status of a deployment whose namespace was deleted after
+	// reaching the maximum age of its environment
+	DeploymentStatusExpired DeploymentStatus = "expired"
 )
 
 type Deployment struct {
@@ -64,6 +84,7 @@ type Deployment struct {
 	InstallationID     uint             `json:"gh_installation_id"`
 	LastWorkflowRunURL string           `json:"last_workflow_run_url"`
 	LastErrors         string           `json:"last_errors"`
+	LastActivityAt     *time.Time       `json:"last_activity_at,omitempty"`
 }
 
 type CreateGHDeploymentRequest struct {
@@ -106,6 +127,18 @@ type UpdateDeploymentRequest struct {
 	Namespace    string `json:"namespace"`
 }
 
+// CreateGitlabDeploymentRequest creates or updates the deployment of a GitLab merge request,
+// sent by the pipeline which deploys the merge request
+type CreateGitlabDeploymentRequest struct {
+	Namespace      string `json:"namespace" form:"required"`
+	MergeRequestID uint   `json:"merge_request_id" form:"required"`
+	PipelineID     uint   `json:"pipeline_id"`
+	MRName         string `json:"mr_name"`
+	CommitSHA      string `json:"commit_sha" form:"required"`
+	MRBranchFrom   string `json:"mr_branch_from" form:"required"`
+	MRBranchInto   string `json:"mr_branch_into" form:"required"`
+}
+
 type ListDeploymentRequest struct {
 	EnvironmentID uint `schema:"environment_id"`
 }
@@ -161,4 +194,8 @@ type UpdateEnvironmentSettingsRequest struct {
 	GitRepoBranches    []string          `json:"git_repo_branches"`
 	NamespaceLabels    map[string]string `json:"namespace_labels"`
 	GitDeployBranches  []string          `json:"git_deploy_branches"`
+
+	// IdleTimeoutHours and MaxDeploymentAgeHours are left unchanged when omitted
+	IdleTimeoutHours      *uint `json:"idle_timeout_hours,omitempty"`
+	MaxDeploymentAgeHours *uint `json:"max_deployment_age_hours,omitempty"`
 }
@@ -0,0 +1,86 @@
+package types
+
+const (
+	URLParamIncidentRoutingRuleID URLParam = "incident_routing_rule_id"
+)
+
+type IncidentRoutingTargetKind string
+
+const (
+	IncidentRoutingTargetSlack   IncidentRoutingTargetKind = "slack"
+	IncidentRoutingTargetEmail   IncidentRoutingTargetKind = "email"
+	IncidentRoutingTargetWebhook IncidentRoutingTargetKind = "webhook"
+
+	// IncidentRoutingTargetEventsAPIV2 sends incidents to an endpoint compatible with the
+	// PagerDuty Events API v2, such as PagerDuty or Opsgenie
+	IncidentRoutingTargetEventsAPIV2 IncidentRoutingTargetKind = "events_api_v2"
+)
+
+// IncidentRoutingRule sends incidents which match all of the non-empty match fields of
+// the rule to the targets of the rule. Rules are evaluated in ascending order of priority,
+// and the first matching rule stops the evaluation unless Continue is set. Incidents which
+// do not match any rule are sent to all notifiers of the project.
+type IncidentRoutingRule struct {
+	ID        uint `json:"id"`
+	ProjectID uint `json:"project_id"`
+
+	Name     string `json:"name"`
+	Priority int    `json:"priority"`
+	Continue bool   `json:"continue"`
+
+	// ReleaseName and Namespace support shell patterns, such as "api-*"
+	ReleaseName        string             `json:"release_name,omitempty"`
+	Namespace          string             `json:"namespace,omitempty"`
+	Severity           SeverityType       `json:"severity,omitempty"`
+	InvolvedObjectKind InvolvedObjectKind `json:"involved_object_kind,omitempty"`
+
+	Targets []*IncidentRoutingTarget `json:"targets"`
+}
+
+type IncidentRoutingTarget struct {
+	Kind IncidentRoutingTargetKind `json:"kind"`
+
+	// IntegrationID is the ID of the slack or webhook integration to notify. If empty, all
+	// slack or webhook integrations of the project are notified.
+	IntegrationID uint `json:"integration_id,omitempty"`
+
+	// URL is the endpoint of an events_api_v2 target
+	URL string `json:"url,omitempty"`
+
+	// CriticalSeverity and NormalSeverity map the severity of incidents to the severities
+	// of an events_api_v2 target: one of critical, error, warning or info
+	CriticalSeverity string `json:"critical_severity,omitempty"`
+	NormalSeverity   string `json:"normal_severity,omitempty"`
+}
+
+type ListIncidentRoutingRulesResponse []*IncidentRoutingRule
+
+type CreateIncidentRoutingRuleRequest struct {
+	Name     string `json:"name" form:"required"`
+	Priority int    `json:"priority"`
+	Continue bool   `json:"continue"`
+
+	ReleaseName        string             `json:"release_name"`
+	Namespace          string             `json:"namespace"`
+	Severity           SeverityType       `json:"severity" form:"omitempty,oneof=critical normal"`
+	InvolvedObjectKind InvolvedObjectKind `json:"involved_object_kind" form:"omitempty,oneof=deployment job pod"`
+
+	Targets []*CreateIncidentRoutingTargetRequest `json:"targets" form:"required,min=1,dive,required"`
+}
+
+type CreateIncidentRoutingTargetRequest struct {
+	Kind          IncidentRoutingTargetKind `json:"kind" form:"required,oneof=slack email webhook events_api_v2"`
+	IntegrationID uint                      `json:"integration_id"`
+
+	// URL defaults to the PagerDuty Events API v2 endpoint for events_api_v2 targets
+	URL string `json:"url" form:"omitempty,url"`
+
+	// RoutingKey is the integration key of an events_api_v2 target, which is never returned
+	RoutingKey string `json:"routing_key"`
+
+	CriticalSeverity string `json:"critical_severity" form:"omitempty,oneof=critical error warning info"`
+	NormalSeverity   string `json:"normal_severity" form:"omitempty,oneof=critical error warning info"`
+}
+
+// UpdateIncidentRoutingRuleRequest replaces the rule, including all of its targets
+type UpdateIncidentRoutingRuleRequest CreateIncidentRoutingRuleRequest
@@ -14,6 +14,15 @@ const (
 	StatusDestroyed  InfraStatus = "destroyed"
 )
 
+// InfraDriftStatus is the result of checking an infrastructure for changes which were made
+// outside of Porter
+type InfraDriftStatus string
+
+const (
+	InfraDriftInSync  InfraDriftStatus = "in_sync"
+	InfraDriftDrifted InfraDriftStatus = "drifted"
+)
+
 // InfraKind is the kind that infra can be
 type InfraKind string
 
@@ -55,6 +64,13 @@ type Infra struct {
 	// Status is the status of the infra
 	Status InfraStatus `json:"status"`
 
+	// DriftStatus is the result of the last drift detection run against the infra, empty if
+	// drift has never been checked
+	DriftStatus InfraDriftStatus `json:"drift_status,omitempty"`
+
+	// DriftCheckedAt is the time at which drift was last checked
+	DriftCheckedAt *time.Time `json:"drift_checked_at,omitempty"`
+
 	// The AWS integration that was used to create the infra
 	AWSIntegrationID uint `json:"aws_integration_id,omitempty"`
 
@@ -76,6 +92,21 @@ type Infra struct {
 	// LatestOperation is the last operation that was run against this infra, if
 	// one exists
 	LatestOperation *Operation `json:"latest_operation"`
+
+	// Drift is the drift of the infra from its latest applied operation, which is only set
+	// if drift was detected
+	Drift *InfraDrift `json:"drift,omitempty"`
+}
+
+// InfraDrift is a set of resources which were changed outside of Porter since an operation
+// was applied
+type InfraDrift struct {
+	// OperationID is the ID of the applied operation that the resources drifted from
+	OperationID string `json:"operation_id"`
+
+	DetectedAt time.Time `json:"detected_at"`
+
+	Resources []*InfraPlanChange `json:"resources"`
 }
 
 type InfraCredentials struct {
@@ -126,6 +157,33 @@ type Operation struct {
 
 	LastApplied map[string]interface{} `json:"last_applied"`
 	Form        *FormYAML              `json:"form"`
+
+	// DriftedResources are the resources which were changed outside of Porter since this
+	// operation was applied
+	DriftedResources []*InfraPlanChange `json:"drifted_resources,omitempty"`
+}
+
+// InfraPlanChange is a change to a single resource which was planned by a plan operation
+type InfraPlanChange struct {
+	Address      string `json:"address"`
+	ResourceType string `json:"resource_type"`
+	ResourceName string `json:"resource_name"`
+	Provider     string `json:"provider"`
+
+	// Action is one of "create", "update", "delete", "replace", "read" or "noop"
+	Action string `json:"action"`
+}
+
+// InfraPlan is the result of a plan operation, which can be reviewed before it
+// is applied
+type InfraPlan struct {
+	OperationID string `json:"operation_id"`
+
+	Add     int64 `json:"add"`
+	Change  int64 `json:"change"`
+	Destroy int64 `json:"destroy"`
+
+	Changes []*InfraPlanChange `json:"changes"`
 }
 
 type InfraTemplateMeta struct {
@@ -97,10 +97,27 @@ type ListConfigMapsResponse struct {
 }
 
 type ConfigMapInput struct {
-	Name            string
-	Namespace       string
-	Variables       map[string]string
-	SecretVariables map[string]string
+	Name                    string
+	Namespace               string
+	Variables               map[string]string
+	SecretVariables         map[string]string
+	ExternalSecretVariables map[string]*ExternalSecretReference
+}
+
+// ExternalSecretReference points to a single key of a secret in an external secret store
+//
+// swagger:model
+type ExternalSecretReference struct {
+	// the path of the secret in the secret store, relative to the prefix of the project
+	// example: prod/database
+	Path string `json:"path" form:"required"`
+
+	// the key inside the secret
+	// example: password
+	Key string `json:"key" form:"required"`
+
+	// the version of the secret that was last synced into the env group
+	Version string `json:"version,omitempty"`
 }
 
 type CreateConfigMapRequest struct {
@@ -117,6 +134,8 @@ type EnvGroup struct {
 	Namespace    string            `json:"namespace"`
 	Applications []string          `json:"applications"`
 	Variables    map[string]string `json:"variables"`
+
+	ExternalSecretVariables map[string]*ExternalSecretReference `json:"external_secret_variables,omitempty"`
 }
 
 type EnvGroupMeta struct {
@@ -154,6 +173,100 @@ type AddEnvGroupApplicationRequest struct {
 
 type ListEnvGroupsResponse []*EnvGroupMeta
 
+type DiffEnvGroupRequest struct {
+	Name        string `schema:"name,required"`
+	FromVersion uint   `schema:"from_version,required"`
+
+	// the version to compare against, defaults to the latest version
+	ToVersion uint `schema:"to_version"`
+}
+
+// EnvGroupVariableDiff is a variable which differs between two versions of an env group.
+// The values of secret variables are never included.
+type EnvGroupVariableDiff struct {
+	Key      string `json:"key"`
+	Secret   bool   `json:"secret"`
+	OldValue string `json:"old_value,omitempty"`
+	NewValue string `json:"new_value,omitempty"`
+}
+
+type DiffEnvGroupResponse struct {
+	Name        string                  `json:"name"`
+	FromVersion uint                    `json:"from_version"`
+	ToVersion   uint                    `json:"to_version"`
+	Added       []*EnvGroupVariableDiff `json:"added"`
+	Removed     []*EnvGroupVariableDiff `json:"removed"`
+	Changed     []*EnvGroupVariableDiff `json:"changed"`
+}
+
+type RollbackEnvGroupRequest struct {
+	Name    string `json:"name" form:"required,dns1123"`
+	Version uint   `json:"version" form:"required"`
+}
+
+type EnvGroupFileFormat string
+
+const (
+	EnvGroupFileFormatDotenv EnvGroupFileFormat = "dotenv"
+	EnvGroupFileFormatJSON   EnvGroupFileFormat = "json"
+	EnvGroupFileFormatYAML   EnvGroupFileFormat = "yaml"
+)
+
+// ImportEnvGroupRequest represents the request body to import variables into an env group
+// from the contents of files
+//
+// swagger:model
+type ImportEnvGroupRequest struct {
+	// the format of the files
+	// example: dotenv
+	Format EnvGroupFileFormat `json:"format" form:"required,oneof=dotenv json yaml"`
+
+	// the contents of the file with the variables to import
+	Content string `json:"content"`
+
+	// the contents of a separate file in the same format, whose variables are all imported as secrets
+	SecretContent string `json:"secret_content"`
+
+	// variables whose name starts with this prefix are imported as secrets, with the prefix removed
+	// example: SECRET_
+	SecretPrefix string `json:"secret_prefix"`
+
+	// if true, the variables of the env group which are not in the imported files are removed,
+	// otherwise the imported variables are merged into the env group
+	Replace bool `json:"replace"`
+}
+
+// ExportEnvGroupRequest represents the format and version of an env group export, sent as query
+// parameters or, when secrets are included, as the request body
+//
+// swagger:model
+type ExportEnvGroupRequest struct {
+	// the format of the file, one of dotenv (default), json or yaml
+	// example: dotenv
+	Format EnvGroupFileFormat `json:"format" schema:"format" form:"omitempty,oneof=dotenv json yaml"`
+
+	// the version to export, defaults to the latest version
+	Version uint `json:"version" schema:"version"`
+}
+
+// ExportEnvGroupResponse contains the variables of an env group as the contents of a file
+//
+// swagger:model
+type ExportEnvGroupResponse struct {
+	// the version of the env group that was exported
+	Version uint `json:"version"`
+
+	// the format of the content
+	Format EnvGroupFileFormat `json:"format"`
+
+	// the contents of the file
+	Content string `json:"content"`
+
+	// the names of the secret variables of the env group. Their values are only part of the
+	// content if secrets were exported.
+	SecretKeys []string `json:"secret_keys"`
+}
+
 // CreateEnvGroupRequest represents the request body to create or update an env group
 //
 // swagger:model
@@ -167,6 +280,9 @@ type CreateEnvGroupRequest struct {
 
 	// the secret variables to include in the env group
 	SecretVariables map[string]string `json:"secret_variables"`
+
+	// the secret variables to read from the external secret store, keyed by variable name
+	ExternalSecretVariables map[string]*ExternalSecretReference `json:"external_secret_variables"`
 }
 
 type CreateConfigMapResponse struct {
@@ -252,6 +368,9 @@ type V1EnvGroupResponse struct {
 	// the variables contained in this env group
 	Variables map[string]string `json:"variables"`
 
+	// the secret variables of this env group which are read from the external secret store
+	ExternalSecretVariables map[string]*ExternalSecretReference `json:"external_secret_variables,omitempty"`
+
 	// the ID of the stack containing this env group (if any)
 	StackID string `json:"stack_id,omitempty"`
 }
@@ -32,6 +32,12 @@ type PolicyDocument struct {
 	Resources []NameOrUInt                        `json:"resources"`
 	Verbs     []APIVerb                           `json:"verbs"`
 	Children  map[PermissionScope]*PolicyDocument `json:"children"`
+
+	// ReadableParents is set by the policy loader on the documents of custom roles. The
+	// requested verb then only applies to the most specific scope of a request, while parent
+	// scopes only need to be readable. It is never stored, so that the policies of API tokens
+	// keep requiring the requested verb on every scope.
+	ReadableParents bool `json:"-"`
 }
 
 type ScopeTree map[PermissionScope]ScopeTree
@@ -166,6 +172,11 @@ type CreatePolicy struct {
 	Policy []*PolicyDocument `json:"policy" form:"required"`
 }
 
+type UpdatePolicyRequest struct {
+	Name   string            `json:"name" form:"required"`
+	Policy []*PolicyDocument `json:"policy" form:"required"`
+}
+
 const URLParamPolicyID URLParam = "policy_id"
 
 type APIPolicyMeta struct {
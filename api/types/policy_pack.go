@@ -0,0 +1,75 @@
+package types
+
+const (
+	URLParamPolicyPackID URLParam = "policy_pack_id"
+)
+
+type PolicyPackKind string
+
+const (
+	PolicyPackKindHelmRelease PolicyPackKind = "helm_release"
+	PolicyPackKindPod         PolicyPackKind = "pod"
+	PolicyPackKindCRDList     PolicyPackKind = "crd_list"
+	PolicyPackKindDaemonset   PolicyPackKind = "daemonset"
+)
+
+// PolicyPack is a collection of Rego policies uploaded to a project, which the recommender
+// evaluates against the objects of each cluster matching the pack, alongside the built-in
+// policies. The results are stored as monitor test results in the category of the pack.
+type PolicyPack struct {
+	ID        uint `json:"id"`
+	ProjectID uint `json:"project_id"`
+
+	Name string `json:"name"`
+
+	// Category is the category of the monitor test results created by the pack
+	Category string `json:"category"`
+
+	Kind             PolicyPackKind   `json:"kind"`
+	Match            *PolicyPackMatch `json:"match"`
+	MustExist        bool             `json:"must_exist"`
+	OverrideSeverity string           `json:"override_severity,omitempty"`
+
+	Policies []*PolicyPackPolicy `json:"policies"`
+}
+
+// PolicyPackMatch selects the objects which the policies of a pack are evaluated against
+type PolicyPackMatch struct {
+	// KubernetesService is a matching service kind, like `eks`
+	KubernetesService string `json:"kubernetes_service,omitempty"`
+
+	// parameters for helm_release packs
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	ChartName string `json:"chart_name,omitempty"`
+
+	// parameters for pod and daemonset packs
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// parameters for crd_list packs
+	Group    string `json:"group,omitempty"`
+	Version  string `json:"version,omitempty"`
+	Resource string `json:"resource,omitempty"`
+}
+
+// PolicyPackPolicy is a single Rego module. The name of the policy is the package of the
+// module, such as "web.memory_limits".
+type PolicyPackPolicy struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
+type ListPolicyPacksResponse []*PolicyPack
+
+type CreatePolicyPackRequest struct {
+	Name             string          `json:"name" form:"required,max=64"`
+	Kind             PolicyPackKind  `json:"kind" form:"required,oneof=helm_release pod crd_list daemonset"`
+	Match            PolicyPackMatch `json:"match"`
+	MustExist        bool            `json:"must_exist"`
+	OverrideSeverity string          `json:"override_severity" form:"omitempty,oneof=low high critical"`
+
+	Policies []*PolicyPackPolicy `json:"policies" form:"required,min=1,dive,required"`
+}
+
+// UpdatePolicyPackRequest replaces the pack, including all of its policies
+type UpdatePolicyPackRequest CreatePolicyPackRequest
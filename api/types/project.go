@@ -9,6 +9,16 @@ type Project struct {
 	ManagedInfraEnabled bool    `json:"managed_infra_enabled"`
 	APITokensEnabled    bool    `json:"api_tokens_enabled"`
 	StacksEnabled       bool    `json:"stacks_enabled"`
+
+	APITokenMaxLifetimeDays uint `json:"api_token_max_lifetime_days"`
+
+	// TwoFactorRequired is set if collaborators who log in with a password must have
+	// two-factor authentication enabled to access the project
+	TwoFactorRequired bool `json:"two_factor_required"`
+}
+
+type UpdateTwoFactorSettingsRequest struct {
+	TwoFactorRequired bool `json:"two_factor_required"`
 }
 
 type FeatureFlags struct {
@@ -53,6 +63,10 @@ type Collaborator struct {
 	UserID    uint   `json:"user_id"`
 	Email     string `json:"email"`
 	ProjectID uint   `json:"project_id"`
+	PolicyUID string `json:"policy_uid,omitempty"`
+
+	// TwoFactorEnabled is set if the collaborator has enabled two-factor authentication
+	TwoFactorEnabled bool `json:"two_factor_enabled"`
 }
 
 type ListCollaboratorsResponse []*Collaborator
@@ -60,6 +74,9 @@ type ListCollaboratorsResponse []*Collaborator
 type UpdateRoleRequest struct {
 	UserID uint   `json:"user_id,required"`
 	Kind   string `json:"kind,required"`
+
+	// PolicyUID is the policy to assign to the user, required when kind is "custom"
+	PolicyUID string `json:"policy_uid"`
 }
 
 type UpdateRoleResponse struct {
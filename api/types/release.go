@@ -128,6 +128,28 @@ type UpgradeReleaseRequest struct {
 	LatestRevision uint `json:"latest_revision"`
 }
 
+// DryRunReleaseResponse contains the values and rendered manifest that an upgrade or
+// install would produce, alongside the values and manifest of the currently deployed revision
+type DryRunReleaseResponse struct {
+	// Whether the release is currently deployed. If false, the current fields are empty
+	Exists bool `json:"exists"`
+
+	// The revision number of the currently deployed release
+	CurrentRevision int `json:"current_revision,omitempty"`
+
+	// The Helm values of the currently deployed release
+	CurrentValues map[string]interface{} `json:"current_values,omitempty"`
+
+	// The rendered Kubernetes manifest of the currently deployed release
+	CurrentManifest string `json:"current_manifest,omitempty"`
+
+	// The Helm values the release would be deployed with
+	Values map[string]interface{} `json:"values"`
+
+	// The rendered Kubernetes manifest the release would be deployed with
+	Manifest string `json:"manifest"`
+}
+
 type UpdateImageBatchRequest struct {
 	ImageRepoURI string `json:"image_repo_uri" form:"required"`
 	Tag          string `json:"tag" form:"required"`
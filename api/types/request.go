@@ -72,8 +72,25 @@ type APIRequestMetadata struct {
 
 	// The usage metric that the request should check for, if CheckUsage
 	UsageMetric UsageMetric
+
+	// The group of rate limits applied to the endpoint, which defaults to RateLimitGroupAPI
+	RateLimitGroup RateLimitGroup
 }
 
+type RateLimitGroup string
+
+const (
+	// RateLimitGroupAPI limits requests to authenticated endpoints
+	RateLimitGroupAPI RateLimitGroup = "api"
+
+	// RateLimitGroupAuth limits requests to endpoints which authenticate users, such as login
+	// and password resets
+	RateLimitGroupAuth RateLimitGroup = "auth"
+
+	// RateLimitGroupWebhook limits requests to endpoints called by external services
+	RateLimitGroupWebhook RateLimitGroup = "webhook"
+)
+
 const RequestScopeCtxKey = "requestscopes"
 
 type RequestAction struct {
@@ -4,6 +4,8 @@ type User struct {
 	ID            uint   `json:"id"`
 	Email         string `json:"email"`
 	EmailVerified bool   `json:"email_verified"`
+
+	TwoFactorEnabled bool `json:"two_factor_enabled"`
 }
 
 type CreateUserRequest struct {
@@ -20,7 +22,40 @@ type LoginUserRequest struct {
 	Password string `json:"password" form:"required,max=255"`
 }
 
-type LoginUserResponse User
+type LoginUserResponse struct {
+	*User
+
+	// TwoFactorRequired is set when the password is correct but the user has two-factor
+	// authentication enabled. The login is completed with a code sent to /login/2fa.
+	TwoFactorRequired bool `json:"two_factor_required,omitempty"`
+}
+
+type LoginTwoFactorRequest struct {
+	// Code is either a code from an authenticator app or a recovery code
+	Code string `json:"code" form:"required,max=32"`
+}
+
+type EnrollTwoFactorResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type ActivateTwoFactorRequest struct {
+	Code string `json:"code" form:"required,max=32"`
+}
+
+type TwoFactorRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type DisableTwoFactorRequest struct {
+	// Code is either a code from an authenticator app or a recovery code
+	Code string `json:"code" form:"required,max=32"`
+}
+
+type RegenerateTwoFactorRecoveryCodesRequest struct {
+	Code string `json:"code" form:"required,max=32"`
+}
 
 type CLILoginUserRequest struct {
 	Redirect string `schema:"redirect" form:"required"`
@@ -0,0 +1,138 @@
+package types
+
+import "time"
+
+const (
+	URLParamWebhookIntegrationID URLParam = "webhook_integration_id"
+)
+
+type WebhookEvent string
+
+const (
+	WebhookEventDeployment       WebhookEvent = "deployment"
+	WebhookEventIncidentNew      WebhookEvent = "incident.new"
+	WebhookEventIncidentResolved WebhookEvent = "incident.resolved"
+	WebhookEventInfraDrift       WebhookEvent = "infra.drift"
+	WebhookEventTest             WebhookEvent = "test"
+)
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookIntegration is an outgoing webhook which receives deployment and incident events
+// as JSON POST requests. Infra drift is sent along with incident events.
+type WebhookIntegration struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	ProjectID uint `json:"project_id"`
+
+	// A name to identify the webhook
+	Name string `json:"name"`
+
+	// The URL that events are sent to. Only the scheme and host are returned, since the
+	// rest of the URL may contain credentials.
+	URL string `json:"url"`
+
+	// Whether deployment notifications are sent to the webhook
+	DeploymentEvents bool `json:"deployment_events"`
+
+	// Whether new and resolved incidents are sent to the webhook
+	IncidentEvents bool `json:"incident_events"`
+}
+
+type ListWebhookIntegrationsResponse []*WebhookIntegration
+
+type CreateWebhookIntegrationRequest struct {
+	Name string `json:"name" form:"required"`
+	URL  string `json:"url" form:"required,url"`
+
+	// The secret used to sign payloads. If empty, a secret is generated.
+	Secret string `json:"secret"`
+
+	DeploymentEvents bool `json:"deployment_events"`
+	IncidentEvents   bool `json:"incident_events"`
+}
+
+type CreateWebhookIntegrationResponse struct {
+	*WebhookIntegration
+
+	// The secret used to sign payloads, which is only returned once on creation
+	Secret string `json:"secret"`
+}
+
+type UpdateWebhookIntegrationRequest struct {
+	Name             *string `json:"name"`
+	URL              *string `json:"url" form:"omitempty,url"`
+	DeploymentEvents *bool   `json:"deployment_events"`
+	IncidentEvents   *bool   `json:"incident_events"`
+}
+
+// WebhookDelivery is a record of the attempts to deliver an event to a webhook
+type WebhookDelivery struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+
+	WebhookIntegrationID uint `json:"webhook_integration_id"`
+
+	// The unique ID of the delivery, sent in the X-Porter-Delivery header
+	DeliveryID string `json:"delivery_id"`
+
+	Event    WebhookEvent          `json:"event"`
+	Status   WebhookDeliveryStatus `json:"status"`
+	Attempts uint                  `json:"attempts"`
+
+	// The status code of the response to the last attempt, 0 if no response was received
+	ResponseCode int `json:"response_code"`
+
+	// The error of the last attempt, if the delivery failed
+	Error string `json:"error,omitempty"`
+}
+
+type ListWebhookDeliveriesRequest struct {
+	// The maximum number of deliveries to return, most recent first. Defaults to 50.
+	Limit uint `schema:"limit"`
+}
+
+type ListWebhookDeliveriesResponse []*WebhookDelivery
+
+// WebhookPayload is the body of each request sent to a webhook
+type WebhookPayload struct {
+	Event     WebhookEvent `json:"event"`
+	ProjectID uint         `json:"project_id"`
+	Timestamp time.Time    `json:"timestamp"`
+
+	Deployment *WebhookDeploymentData `json:"deployment,omitempty"`
+	Incident   *WebhookIncidentData   `json:"incident,omitempty"`
+	InfraDrift *WebhookInfraDriftData `json:"infra_drift,omitempty"`
+}
+
+type WebhookDeploymentData struct {
+	ClusterID   uint   `json:"cluster_id"`
+	ClusterName string `json:"cluster_name"`
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	Status      string `json:"status"`
+	Info        string `json:"info,omitempty"`
+	Version     int    `json:"version,omitempty"`
+	URL         string `json:"url"`
+}
+
+type WebhookIncidentData struct {
+	*Incident
+
+	URL string `json:"url"`
+}
+
+type WebhookInfraDriftData struct {
+	*InfraDrift
+
+	InfraID   uint      `json:"infra_id"`
+	InfraName string    `json:"infra_name"`
+	InfraKind InfraKind `json:"infra_kind"`
+	URL       string    `json:"url"`
+}
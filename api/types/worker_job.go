@@ -0,0 +1,79 @@
+package types
+
+import "time"
+
+type WorkerJobStatus string
+
+const (
+	// WorkerJobStatusQueued jobs are waiting for their next attempt
+	WorkerJobStatusQueued WorkerJobStatus = "queued"
+
+	WorkerJobStatusRunning   WorkerJobStatus = "running"
+	WorkerJobStatusSucceeded WorkerJobStatus = "succeeded"
+
+	// WorkerJobStatusDead jobs have failed all of their attempts, and are only retried manually
+	WorkerJobStatusDead WorkerJobStatus = "dead"
+)
+
+// WorkerJob is a job enqueued in the worker pool
+type WorkerJob struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// JobID is the identifier of the kind of job, such as "recommender"
+	JobID string `json:"job_id"`
+
+	Status      WorkerJobStatus `json:"status"`
+	Attempts    uint            `json:"attempts"`
+	MaxAttempts uint            `json:"max_attempts"`
+
+	// RunAt is the earliest time of the next attempt of a queued job
+	RunAt      time.Time  `json:"run_at"`
+	StartedAt  *time.Time `json:"started_at,omitempty"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+
+	// LastError is the error of the last failed attempt
+	LastError string `json:"last_error,omitempty"`
+}
+
+type ListWorkerJobsRequest struct {
+	Status WorkerJobStatus `schema:"status"`
+	JobID  string          `schema:"job_id"`
+
+	// The maximum number of jobs to return, most recent first
+	Limit uint `schema:"limit"`
+}
+
+type ListWorkerJobsResponse []*WorkerJob
+
+type WorkerJobScheduleResult string
+
+const (
+	WorkerJobScheduleEnqueued WorkerJobScheduleResult = "enqueued"
+
+	// WorkerJobScheduleSkipped runs were skipped since the previous job was still queued or running
+	WorkerJobScheduleSkipped WorkerJobScheduleResult = "skipped"
+
+	WorkerJobScheduleFailed WorkerJobScheduleResult = "failed"
+)
+
+// WorkerJobSchedule is the status of a job which is enqueued on a cron schedule
+type WorkerJobSchedule struct {
+	JobID    string `json:"job_id"`
+	Schedule string `json:"schedule"`
+
+	LastRunAt     *time.Time              `json:"last_run_at,omitempty"`
+	LastResult    WorkerJobScheduleResult `json:"last_result,omitempty"`
+	LastError     string                  `json:"last_error,omitempty"`
+	LastWorkerJob *WorkerJob              `json:"last_worker_job,omitempty"`
+
+	NextRunAt time.Time `json:"next_run_at"`
+}
+
+type ListWorkerJobSchedulesResponse struct {
+	// Whether this replica of the worker pool is the leader which enqueues scheduled jobs
+	Leader bool `json:"leader"`
+
+	Schedules []*WorkerJobSchedule `json:"schedules"`
+}
@@ -0,0 +1,240 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	apiTokenPolicyUID   string
+	apiTokenExpiresIn   time.Duration
+	apiTokenGracePeriod time.Duration
+)
+
+// apiTokenCmd represents the "porter api-token" base command when called
+// without any subcommands
+var apiTokenCmd = &cobra.Command{
+	Use:     "api-token",
+	Aliases: []string{"api-tokens"},
+	Short:   "Commands that manage the API tokens of the current project",
+}
+
+var apiTokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the API tokens in the current project",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, listAPITokens)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var apiTokenCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Creates an API token in the current project and prints it",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, createAPIToken)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var apiTokenRotateCmd = &cobra.Command{
+	Use:   "rotate [id]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Issues a new secret for an API token and prints the new token",
+	Long: fmt.Sprintf(`%s
+
+Issues a new secret for the API token with the given id. The previous token keeps
+working during the grace period, so that it can be replaced wherever it is used:
+
+  %s
+
+Use --expires-in to also extend the expiry of the token.`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter api-token rotate\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter api-token rotate [id] --grace-period 24h"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, rotateAPIToken)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var apiTokenRevokeCmd = &cobra.Command{
+	Use:   "revoke [id]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Revokes the API token with the given id",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, revokeAPIToken)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(apiTokenCmd)
+
+	apiTokenCmd.AddCommand(apiTokenListCmd)
+	apiTokenCmd.AddCommand(apiTokenCreateCmd)
+	apiTokenCmd.AddCommand(apiTokenRotateCmd)
+	apiTokenCmd.AddCommand(apiTokenRevokeCmd)
+
+	apiTokenCreateCmd.PersistentFlags().StringVar(
+		&apiTokenPolicyUID,
+		"policy",
+		"developer",
+		"The policy of the token: admin, developer, viewer or the id of a project policy.",
+	)
+
+	apiTokenCreateCmd.PersistentFlags().DurationVar(
+		&apiTokenExpiresIn,
+		"expires-in",
+		0,
+		"How long the token is valid for. Defaults to one year, or the maximum lifetime set for the project.",
+	)
+
+	apiTokenRotateCmd.PersistentFlags().DurationVar(
+		&apiTokenExpiresIn,
+		"expires-in",
+		0,
+		"Sets a new expiry for the token, relative to now. By default the expiry is unchanged.",
+	)
+
+	apiTokenRotateCmd.PersistentFlags().DurationVar(
+		&apiTokenGracePeriod,
+		"grace-period",
+		time.Hour,
+		"How long the previous token keeps working after the rotation.",
+	)
+}
+
+func listAPITokens(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	tokens, err := client.ListAPITokens(context.Background(), cliConf.Project)
+
+	if err != nil {
+		return err
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "ID", "NAME", "POLICY", "EXPIRES AT", "LAST USED")
+
+	for _, token := range tokens {
+		lastUsed := "never"
+
+		if token.LastUsedAt != nil {
+			lastUsed = fmt.Sprintf("%s from %s", token.LastUsedAt.Format(time.RFC3339), token.LastUsedIP)
+		}
+
+		fmt.Fprintf(
+			w, "%s\t%s\t%s\t%s\t%s\n",
+			token.ID, token.Name, token.PolicyName, token.ExpiresAt.Format(time.RFC3339), lastUsed,
+		)
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func createAPIToken(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	req := &types.CreateAPIToken{
+		Name:      args[0],
+		PolicyUID: apiTokenPolicyUID,
+	}
+
+	if apiTokenExpiresIn > 0 {
+		req.ExpiresAt = time.Now().Add(apiTokenExpiresIn)
+	}
+
+	resp, err := client.CreateAPIToken(context.Background(), cliConf.Project, req)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Fprintf(
+		os.Stderr, "Created API token %s with id %s, which expires at %s\n",
+		resp.Name, resp.ID, resp.ExpiresAt.Format(time.RFC3339),
+	)
+
+	fmt.Println(resp.Token)
+
+	return nil
+}
+
+func rotateAPIToken(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	gracePeriodSeconds := uint(apiTokenGracePeriod.Seconds())
+
+	req := &types.RotateAPITokenRequest{
+		GracePeriodSeconds: &gracePeriodSeconds,
+	}
+
+	if apiTokenExpiresIn > 0 {
+		req.ExpiresAt = time.Now().Add(apiTokenExpiresIn)
+	}
+
+	resp, err := client.RotateAPIToken(context.Background(), cliConf.Project, args[0], req)
+
+	if err != nil {
+		return err
+	}
+
+	if resp.PreviousSecretExpiresAt != nil {
+		color.New(color.FgGreen).Fprintf(
+			os.Stderr, "Rotated API token %s, the previous token stops working at %s\n",
+			resp.ID, resp.PreviousSecretExpiresAt.Format(time.RFC3339),
+		)
+	}
+
+	fmt.Println(resp.Token)
+
+	return nil
+}
+
+func revokeAPIToken(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	userResp, err := utils.PromptPlaintext(
+		fmt.Sprintf(
+			`Are you sure you'd like to revoke the API token with id %s? %s `,
+			args[0],
+			color.New(color.FgCyan).Sprintf("[y/n]"),
+		),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if userResp := strings.ToLower(userResp); userResp == "y" || userResp == "yes" {
+		_, err := client.RevokeAPIToken(context.Background(), cliConf.Project, args[0])
+
+		if err != nil {
+			return err
+		}
+
+		color.New(color.FgGreen).Printf("Revoked API token with id %s\n", args[0])
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/url"
@@ -61,6 +62,10 @@ applying a configuration:
   PORTER_SOURCE_REPO          The URL of the Helm charts registry
   PORTER_SOURCE_VERSION       The version of the Helm chart to use
   PORTER_TAG                  The Docker image tag to use (like the git commit hash)
+
+To preview the changes without applying them, pass the --dry-run flag. Images are not built or
+pushed, and a diff of the values and Kubernetes objects of each release is printed. The command
+exits with status code 2 if any release would be changed, and 0 otherwise.
 	`,
 		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter apply\":"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter apply -f porter.yaml"),
@@ -68,7 +73,9 @@ applying a configuration:
 	Run: func(cmd *cobra.Command, args []string) {
 		err := checkLoginAndRun(args, apply)
 
-		if err != nil {
+		if errors.Is(err, ErrDriftDetected) {
+			os.Exit(2)
+		} else if err != nil {
 			if strings.Contains(err.Error(), "Forbidden") {
 				color.New(color.FgRed).Fprintf(os.Stderr, "You may have to update your GitHub secret token")
 			}
@@ -95,7 +102,15 @@ var applyValidateCmd = &cobra.Command{
 	},
 }
 
-var porterYAML string
+var (
+	porterYAML           string
+	applyConcurrency     int
+	applyContinueOnError bool
+	applyDryRun          bool
+)
+
+// ErrDriftDetected is returned by a dry run of "porter apply" if any release would be changed
+var ErrDriftDetected = errors.New("porter.yaml differs from the deployed releases")
 
 func init() {
 	rootCmd.AddCommand(applyCmd)
@@ -104,6 +119,54 @@ func init() {
 
 	applyCmd.PersistentFlags().StringVarP(&porterYAML, "file", "f", "", "path to porter.yaml")
 	applyCmd.MarkFlagRequired("file")
+
+	applyCmd.Flags().IntVar(
+		&applyConcurrency,
+		"concurrency",
+		previewV2Beta1.DefaultConcurrency,
+		"maximum number of independent resources to apply at the same time (porter.yaml v2beta1 only)",
+	)
+
+	applyCmd.Flags().BoolVar(
+		&applyContinueOnError,
+		"continue-on-error",
+		false,
+		"keep applying resources which do not depend on a failed resource (porter.yaml v2beta1 only)",
+	)
+
+	applyCmd.Flags().BoolVar(
+		&applyDryRun,
+		"dry-run",
+		false,
+		"print the changes that would be made to each release without applying them",
+	)
+}
+
+// applyDrivers are the switchboard drivers available to resources in a porter.yaml
+var applyDrivers = map[string]func(*switchboardModels.Resource, *drivers.SharedDriverOpts) (drivers.Driver, error){
+	"deploy":        NewDeployDriver,
+	"build-image":   preview.NewBuildDriver,
+	"push-image":    preview.NewPushDriver,
+	"update-config": preview.NewUpdateConfigDriver,
+	"random-string": preview.NewRandomStringDriver,
+	"env-group":     preview.NewEnvGroupDriver,
+	"os-env":        preview.NewOSEnvDriver,
+}
+
+// getApplyDryRunDrivers returns the drivers used for a dry run, which do not build or push
+// images, update configs or create env groups
+func getApplyDryRunDrivers(
+	report *preview.DryRunReport,
+) map[string]func(*switchboardModels.Resource, *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	return map[string]func(*switchboardModels.Resource, *drivers.SharedDriverOpts) (drivers.Driver, error){
+		"deploy":        NewDeployDryRunDriver(report),
+		"build-image":   preview.NewDryRunImageDriver,
+		"push-image":    preview.NewDryRunImageDriver,
+		"update-config": preview.NewSkipDriver,
+		"random-string": preview.NewDryRunRandomStringDriver,
+		"env-group":     preview.NewEnvGroupDryRunDriver,
+		"os-env":        preview.NewOSEnvDriver,
+	}
 }
 
 func apply(_ *types.GetAuthenticatedUserResponse, client *api.Client, _ []string) error {
@@ -124,16 +187,19 @@ func apply(_ *types.GetAuthenticatedUserResponse, client *api.Client, _ []string
 	}
 
 	var resGroup *switchboardTypes.ResourceGroup
+	var applier *previewV2Beta1.PreviewApplier
 
 	if previewVersion.Version == "v2beta1" {
 		ns := os.Getenv("PORTER_NAMESPACE")
 
-		applier, err := previewV2Beta1.NewApplier(client, fileBytes, ns)
+		applier, err = previewV2Beta1.NewApplier(client, fileBytes, ns)
 
 		if err != nil {
 			return err
 		}
 
+		applier.SetDryRun(applyDryRun)
+
 		resGroup, err = applier.DowngradeToV1()
 
 		if err != nil {
@@ -163,42 +229,118 @@ func apply(_ *types.GetAuthenticatedUserResponse, client *api.Client, _ []string
 		return fmt.Errorf("error getting working directory: %w", err)
 	}
 
-	worker := switchboardWorker.NewWorker()
-	worker.RegisterDriver("deploy", NewDeployDriver)
-	worker.RegisterDriver("build-image", preview.NewBuildDriver)
-	worker.RegisterDriver("push-image", preview.NewPushDriver)
-	worker.RegisterDriver("update-config", preview.NewUpdateConfigDriver)
-	worker.RegisterDriver("random-string", preview.NewRandomStringDriver)
-	worker.RegisterDriver("env-group", preview.NewEnvGroupDriver)
-	worker.RegisterDriver("os-env", preview.NewOSEnvDriver)
+	resourceDrivers := applyDrivers
+
+	var report *preview.DryRunReport
 
-	worker.SetDefaultDriver("deploy")
+	if applyDryRun {
+		report = preview.NewDryRunReport()
+		resourceDrivers = getApplyDryRunDrivers(report)
+	}
+
+	var deploymentHook *DeploymentHook
+	var gitlabDeploymentHook *GitlabDeploymentHook
 
-	if hasDeploymentHookEnvVars() {
+	// a dry run does not create deployments or clone env groups
+	if hasGitlabDeploymentHookEnvVars() && !applyDryRun {
 		deplNamespace := os.Getenv("PORTER_NAMESPACE")
 
 		if deplNamespace == "" {
 			return fmt.Errorf("namespace must be set by PORTER_NAMESPACE")
 		}
 
-		deploymentHook, err := NewDeploymentHook(client, resGroup, deplNamespace)
+		gitlabDeploymentHook, err = NewGitlabDeploymentHook(client, resGroup, deplNamespace)
 
 		if err != nil {
-			return fmt.Errorf("error creating deployment hook: %w", err)
+			return fmt.Errorf("error creating gitlab deployment hook: %w", err)
 		}
+	} else if hasDeploymentHookEnvVars() && !applyDryRun {
+		deplNamespace := os.Getenv("PORTER_NAMESPACE")
 
-		worker.RegisterHook("deployment", deploymentHook)
+		if deplNamespace == "" {
+			return fmt.Errorf("namespace must be set by PORTER_NAMESPACE")
+		}
+
+		deploymentHook, err = NewDeploymentHook(client, resGroup, deplNamespace)
+
+		if err != nil {
+			return fmt.Errorf("error creating deployment hook: %w", err)
+		}
 	}
 
 	errorEmitterHook := NewErrorEmitterHook(client, resGroup)
-	worker.RegisterHook("erroremitter", errorEmitterHook)
 
-	cloneEnvGroupHook := NewCloneEnvGroupHook(client, resGroup)
-	worker.RegisterHook("cloneenvgroup", cloneEnvGroupHook)
+	var cloneEnvGroupHook *CloneEnvGroupHook
 
-	return worker.Apply(resGroup, &switchboardTypes.ApplyOpts{
-		BasePath: basePath,
-	})
+	if !applyDryRun {
+		cloneEnvGroupHook = NewCloneEnvGroupHook(client, resGroup)
+	}
+
+	if applier != nil {
+		var hooks []previewV2Beta1.Hook
+
+		if deploymentHook != nil {
+			hooks = append(hooks, deploymentHook)
+		}
+
+		if gitlabDeploymentHook != nil {
+			hooks = append(hooks, gitlabDeploymentHook)
+		}
+
+		hooks = append(hooks, errorEmitterHook)
+
+		if cloneEnvGroupHook != nil {
+			hooks = append(hooks, cloneEnvGroupHook)
+		}
+
+		err = applier.ApplyV1Parallel(resGroup, &previewV2Beta1.ParallelApplyOpts{
+			BasePath:        basePath,
+			Concurrency:     applyConcurrency,
+			ContinueOnError: applyContinueOnError,
+			Drivers:         resourceDrivers,
+			DefaultDriver:   "deploy",
+			Hooks:           hooks,
+		})
+	} else {
+		worker := switchboardWorker.NewWorker()
+
+		for name, driver := range resourceDrivers {
+			worker.RegisterDriver(name, driver)
+		}
+
+		worker.SetDefaultDriver("deploy")
+
+		if deploymentHook != nil {
+			worker.RegisterHook("deployment", deploymentHook)
+		}
+
+		if gitlabDeploymentHook != nil {
+			worker.RegisterHook("gitlabdeployment", gitlabDeploymentHook)
+		}
+
+		worker.RegisterHook("erroremitter", errorEmitterHook)
+
+		if cloneEnvGroupHook != nil {
+			worker.RegisterHook("cloneenvgroup", cloneEnvGroupHook)
+		}
+
+		err = worker.Apply(resGroup, &switchboardTypes.ApplyOpts{
+			BasePath: basePath,
+		})
+	}
+
+	if err != nil || report == nil {
+		return err
+	}
+
+	if drifted := report.Print(os.Stdout); drifted > 0 {
+		color.New(color.FgYellow).Printf("%d release(s) would be changed\n", drifted)
+		return ErrDriftDetected
+	}
+
+	color.New(color.FgGreen).Println("All releases are up to date")
+
+	return nil
 }
 
 func applyValidate() error {
@@ -259,12 +401,28 @@ func hasDeploymentHookEnvVars() bool {
 	return true
 }
 
+func hasGitlabDeploymentHookEnvVars() bool {
+	for _, key := range []string{
+		"PORTER_ENVIRONMENT_ID", "PORTER_MERGE_REQUEST_ID", "PORTER_PIPELINE_ID", "PORTER_BRANCH_FROM",
+		"PORTER_BRANCH_INTO", "PORTER_REPO_NAME", "PORTER_REPO_OWNER",
+	} {
+		if os.Getenv(key) == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
 type DeployDriver struct {
 	source      *previewInt.Source
 	target      *previewInt.Target
 	output      map[string]interface{}
 	lookupTable *map[string]drivers.Driver
 	logger      *zerolog.Logger
+
+	// dryRunReport is set if the driver should only render the changes to the release
+	dryRunReport *preview.DryRunReport
 }
 
 func NewDeployDriver(resource *switchboardModels.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
@@ -293,6 +451,24 @@ func NewDeployDriver(resource *switchboardModels.Resource, opts *drivers.SharedD
 	return driver, nil
 }
 
+// NewDeployDryRunDriver returns a constructor for deploy drivers which add the diff of each
+// release to the given report instead of applying it
+func NewDeployDryRunDriver(
+	report *preview.DryRunReport,
+) func(*switchboardModels.Resource, *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	return func(resource *switchboardModels.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+		driver, err := NewDeployDriver(resource, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		driver.(*DeployDriver).dryRunReport = report
+
+		return driver, nil
+	}
+}
+
 func (d *DeployDriver) ShouldApply(_ *switchboardModels.Resource) bool {
 	return true
 }
@@ -314,6 +490,10 @@ func (d *DeployDriver) Apply(resource *switchboardModels.Resource) (*switchboard
 		color.New(color.FgYellow).Printf("Could not read release %s/%s (%s): attempting creation\n", d.target.Namespace, resource.Name, err.Error())
 	}
 
+	if d.dryRunReport != nil {
+		return d.applyDryRun(resource, client, shouldCreate)
+	}
+
 	if d.source.IsApplication {
 		return d.applyApplication(resource, client, shouldCreate)
 	}
@@ -671,6 +851,164 @@ func (d *DeployDriver) assignOutput(resource *switchboardModels.Resource, client
 	return nil
 }
 
+// applyDryRun renders the release that applying the resource would produce, and adds the
+// diff against the currently deployed revision to the dry run report
+func (d *DeployDriver) applyDryRun(resource *switchboardModels.Resource, client *api.Client, shouldCreate bool) (*switchboardModels.Resource, error) {
+	var resp *types.DryRunReleaseResponse
+	var err error
+
+	if d.source.IsApplication {
+		resp, err = d.dryRunApplication(resource, client, shouldCreate)
+	} else {
+		resp, err = d.dryRunAddon(resource, client, shouldCreate)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error running dry run for resource %s: %w", resource.Name, err)
+	}
+
+	if resp == nil {
+		return resource, d.assignOutput(resource, client)
+	}
+
+	diff, err := preview.NewReleaseDiff(d.target.Namespace, resource.Name, resp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	d.dryRunReport.Add(diff)
+
+	// dependent resources see the values that the release would have after applying
+	d.output = utils.CoalesceValues(d.source.SourceValues, resp.Values)
+
+	return resource, nil
+}
+
+func (d *DeployDriver) dryRunAddon(resource *switchboardModels.Resource, client *api.Client, shouldCreate bool) (*types.DryRunReleaseResponse, error) {
+	addonConfig, err := d.getAddonConfig(resource)
+
+	if err != nil {
+		return nil, fmt.Errorf("error getting addon config for resource %s: %w", resource.Name, err)
+	}
+
+	if shouldCreate {
+		return client.CreateReleaseDryRun(
+			context.Background(),
+			d.target.Project,
+			d.target.Cluster,
+			d.target.Namespace,
+			&types.CreateReleaseBaseRequest{
+				RepoURL:         d.source.Repo,
+				TemplateName:    d.source.Name,
+				TemplateVersion: d.source.Version,
+				Values:          addonConfig,
+				Name:            resource.Name,
+			},
+		)
+	}
+
+	bytes, err := json.Marshal(addonConfig)
+
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling addon config from resource %s: %w", resource.Name, err)
+	}
+
+	return client.UpgradeReleaseDryRun(
+		context.Background(),
+		d.target.Project,
+		d.target.Cluster,
+		d.target.Namespace,
+		resource.Name,
+		&types.UpgradeReleaseRequest{
+			Values: string(bytes),
+		},
+	)
+}
+
+// dryRunApplication renders an application release without building or pushing an image.
+// It returns a nil response if the release would not be changed because of onlyCreate.
+func (d *DeployDriver) dryRunApplication(resource *switchboardModels.Resource, client *api.Client, shouldCreate bool) (*types.DryRunReleaseResponse, error) {
+	appConfig, err := d.getApplicationConfig(resource)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !shouldCreate && appConfig.OnlyCreate {
+		color.New(color.FgYellow).Printf("Skipping dry run for resource %s as onlyCreate is set to true\n", resource.Name)
+		return nil, nil
+	}
+
+	tag := preview.GetDryRunImageTag()
+	image := fmt.Sprintf("%s:%s", preview.DryRunImageRepo, tag)
+
+	if appConfig.Build.Method == "registry" {
+		image = appConfig.Build.Image
+	}
+
+	imageSpl := strings.Split(image, ":")
+
+	if len(imageSpl) == 2 {
+		tag = imageSpl[1]
+	}
+
+	sharedOpts := &deploy.SharedOpts{
+		ProjectID:   d.target.Project,
+		ClusterID:   d.target.Cluster,
+		Namespace:   d.target.Namespace,
+		OverrideTag: tag,
+		Method:      deploy.DeployBuildType(appConfig.Build.Method),
+		EnvGroups:   appConfig.EnvGroups,
+	}
+
+	if !shouldCreate {
+		updateAgent, err := deploy.NewDeployAgent(client, resource.Name, &deploy.DeployOpts{
+			SharedOpts: sharedOpts,
+			Local:      appConfig.Build.Method != "registry",
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return updateAgent.UpdateImageAndValuesDryRun(appConfig.Values)
+	}
+
+	createAgent := &deploy.CreateAgent{
+		Client: client,
+		CreateOpts: &deploy.CreateOpts{
+			SharedOpts:  sharedOpts,
+			Kind:        d.source.Name,
+			ReleaseName: resource.Name,
+		},
+	}
+
+	latestVersion, mergedValues, err := createAgent.GetMergedValues(appConfig.Values)
+
+	if err != nil {
+		return nil, err
+	}
+
+	mergedValues["image"] = map[string]interface{}{
+		"repository": imageSpl[0],
+		"tag":        tag,
+	}
+
+	return client.CreateReleaseDryRun(
+		context.Background(),
+		d.target.Project,
+		d.target.Cluster,
+		d.target.Namespace,
+		&types.CreateReleaseBaseRequest{
+			TemplateName:    d.source.Name,
+			TemplateVersion: latestVersion,
+			Values:          mergedValues,
+			Name:            resource.Name,
+		},
+	)
+}
+
 func (d *DeployDriver) Output() (map[string]interface{}, error) {
 	return d.output, nil
 }
@@ -823,46 +1161,10 @@ func (t *DeploymentHook) PreApply() error {
 		return fmt.Errorf("could not find environment for deployment")
 	}
 
-	nsList, err := t.client.GetK8sNamespaces(
-		context.Background(), t.projectID, t.clusterID,
-	)
+	err = createDeploymentNamespace(t.client, t.projectID, t.clusterID, t.namespace, deplEnv.NamespaceLabels)
 
 	if err != nil {
-		return fmt.Errorf("error fetching namespaces: %w", err)
-	}
-
-	found := false
-
-	for _, ns := range *nsList {
-		if ns.Name == t.namespace {
-			found = true
-			break
-		}
-	}
-
-	if !found {
-		if isSystemNamespace(t.namespace) {
-			return fmt.Errorf("attempting to deploy to system namespace '%s' which does not exist, please create it "+
-				"to continue", t.namespace)
-		}
-
-		createNS := &types.CreateNamespaceRequest{
-			Name: t.namespace,
-		}
-
-		if len(deplEnv.NamespaceLabels) > 0 {
-			createNS.Labels = deplEnv.NamespaceLabels
-		}
-
-		// create the new namespace
-		_, err := t.client.CreateNewK8sNamespace(context.Background(), t.projectID, t.clusterID, createNS)
-
-		if err != nil && !strings.Contains(err.Error(), "namespace already exists") {
-			// ignore the error if the namespace already exists
-			//
-			// this might happen if someone creates the namespace in between this operation
-			return fmt.Errorf("error creating namespace: %w", err)
-		}
+		return err
 	}
 
 	var deplErr error
@@ -998,22 +1300,8 @@ func (t *DeploymentHook) DataQueries() map[string]interface{} {
 }
 
 func (t *DeploymentHook) PostApply(populatedData map[string]interface{}) error {
-	subdomains := make([]string, 0)
-
-	for _, data := range populatedData {
-		domain, ok := data.(string)
-
-		if !ok {
-			continue
-		}
-
-		if _, err := url.Parse("https://" + domain); err == nil {
-			subdomains = append(subdomains, "https://"+domain)
-		}
-	}
-
 	req := &types.FinalizeDeploymentRequest{
-		Subdomain: strings.Join(subdomains, ", "),
+		Subdomain: getDeploymentSubdomains(populatedData),
 	}
 
 	if t.isBranchDeploy() {
@@ -1146,6 +1434,258 @@ func (t *DeploymentHook) OnConsolidatedErrors(allErrors map[string]error) {
 	}
 }
 
+// createDeploymentNamespace creates the namespace of a preview deployment if it does not exist yet
+func createDeploymentNamespace(
+	client *api.Client,
+	projectID, clusterID uint,
+	namespace string,
+	labels map[string]string,
+) error {
+	nsList, err := client.GetK8sNamespaces(
+		context.Background(), projectID, clusterID,
+	)
+
+	if err != nil {
+		return fmt.Errorf("error fetching namespaces: %w", err)
+	}
+
+	for _, ns := range *nsList {
+		if ns.Name == namespace {
+			return nil
+		}
+	}
+
+	if isSystemNamespace(namespace) {
+		return fmt.Errorf("attempting to deploy to system namespace '%s' which does not exist, please create it "+
+			"to continue", namespace)
+	}
+
+	createNS := &types.CreateNamespaceRequest{
+		Name: namespace,
+	}
+
+	if len(labels) > 0 {
+		createNS.Labels = labels
+	}
+
+	// create the new namespace
+	_, err = client.CreateNewK8sNamespace(context.Background(), projectID, clusterID, createNS)
+
+	if err != nil && !strings.Contains(err.Error(), "namespace already exists") {
+		// ignore the error if the namespace already exists
+		//
+		// this might happen if someone creates the namespace in between this operation
+		return fmt.Errorf("error creating namespace: %w", err)
+	}
+
+	return nil
+}
+
+// getDeploymentSubdomains joins the subdomains of the web applications of a deployment
+func getDeploymentSubdomains(populatedData map[string]interface{}) string {
+	subdomains := make([]string, 0)
+
+	for _, data := range populatedData {
+		domain, ok := data.(string)
+
+		if !ok {
+			continue
+		}
+
+		if _, err := url.Parse("https://" + domain); err == nil {
+			subdomains = append(subdomains, "https://"+domain)
+		}
+	}
+
+	return strings.Join(subdomains, ", ")
+}
+
+// GitlabDeploymentHook creates and finalizes the deployment of a GitLab merge request. It is
+// used in place of the DeploymentHook when porter apply runs in a GitLab preview pipeline.
+type GitlabDeploymentHook struct {
+	*DeploymentHook
+
+	pipelineID uint
+}
+
+func NewGitlabDeploymentHook(client *api.Client, resourceGroup *switchboardTypes.ResourceGroup, namespace string) (*GitlabDeploymentHook, error) {
+	res := &GitlabDeploymentHook{
+		DeploymentHook: &DeploymentHook{
+			client:        client,
+			resourceGroup: resourceGroup,
+			namespace:     namespace,
+		},
+	}
+
+	envIDStr := os.Getenv("PORTER_ENVIRONMENT_ID")
+	envID, err := strconv.Atoi(envIDStr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.envID = uint(envID)
+
+	mrIDStr := os.Getenv("PORTER_MERGE_REQUEST_ID")
+	mrID, err := strconv.Atoi(mrIDStr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.prID = uint(mrID)
+
+	pipelineIDStr := os.Getenv("PORTER_PIPELINE_ID")
+	pipelineID, err := strconv.Atoi(pipelineIDStr)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res.pipelineID = uint(pipelineID)
+
+	res.projectID = cliConf.Project
+
+	if res.projectID == 0 {
+		return nil, fmt.Errorf("project id must be set")
+	}
+
+	res.clusterID = cliConf.Cluster
+
+	if res.clusterID == 0 {
+		return nil, fmt.Errorf("cluster id must be set")
+	}
+
+	res.branchFrom = os.Getenv("PORTER_BRANCH_FROM")
+	res.branchInto = os.Getenv("PORTER_BRANCH_INTO")
+	res.repoName = os.Getenv("PORTER_REPO_NAME")
+	res.repoOwner = os.Getenv("PORTER_REPO_OWNER")
+	res.prName = os.Getenv("PORTER_PR_NAME")
+	res.commitSHA = os.Getenv("PORTER_COMMIT_SHA")
+
+	if res.commitSHA == "" {
+		commit, err := git.LastCommit()
+
+		if err != nil {
+			return nil, fmt.Errorf(err.Error())
+		}
+
+		res.commitSHA = commit.Sha
+	}
+
+	return res, nil
+}
+
+func (t *GitlabDeploymentHook) PreApply() error {
+	if isSystemNamespace(t.namespace) {
+		color.New(color.FgYellow).Printf("attempting to deploy to system namespace '%s'\n", t.namespace)
+	}
+
+	envList, err := t.client.ListEnvironments(
+		context.Background(), t.projectID, t.clusterID,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	var deplEnv *types.Environment
+
+	for _, env := range *envList {
+		if env.ID == t.envID {
+			deplEnv = env
+			break
+		}
+	}
+
+	if deplEnv == nil {
+		return fmt.Errorf("could not find environment for deployment")
+	}
+
+	err = createDeploymentNamespace(t.client, t.projectID, t.clusterID, t.namespace, deplEnv.NamespaceLabels)
+
+	if err != nil {
+		return err
+	}
+
+	_, err = t.client.CreateGitlabDeployment(
+		context.Background(),
+		t.projectID, t.clusterID, t.envID,
+		&types.CreateGitlabDeploymentRequest{
+			Namespace:      t.namespace,
+			MergeRequestID: t.prID,
+			PipelineID:     t.pipelineID,
+			MRName:         t.prName,
+			CommitSHA:      t.commitSHA,
+			MRBranchFrom:   t.branchFrom,
+			MRBranchInto:   t.branchInto,
+		},
+	)
+
+	return err
+}
+
+func (t *GitlabDeploymentHook) PostApply(populatedData map[string]interface{}) error {
+	req := &types.FinalizeDeploymentRequest{
+		PRNumber:  t.prID,
+		Subdomain: getDeploymentSubdomains(populatedData),
+	}
+
+	for _, res := range t.resourceGroup.Resources {
+		releaseType := getReleaseType(t.projectID, res)
+		releaseName := getReleaseName(res)
+
+		if releaseType != "" && releaseName != "" {
+			req.SuccessfulResources = append(req.SuccessfulResources, &types.SuccessfullyDeployedResource{
+				ReleaseName: releaseName,
+				ReleaseType: releaseType,
+			})
+		}
+	}
+
+	// finalize the deployment
+	_, err := t.client.FinalizeGitlabDeployment(
+		context.Background(),
+		t.projectID, t.clusterID, t.envID,
+		req,
+	)
+
+	return err
+}
+
+// OnError is a no-op: errors are reported together once the apply finishes, in OnConsolidatedErrors
+func (t *GitlabDeploymentHook) OnError(error) {}
+
+func (t *GitlabDeploymentHook) OnConsolidatedErrors(allErrors map[string]error) {
+	req := &types.FinalizeDeploymentWithErrorsRequest{
+		PRNumber: t.prID,
+		Errors:   make(map[string]string),
+	}
+
+	for _, res := range t.resourceGroup.Resources {
+		if _, ok := allErrors[res.Name]; !ok {
+			req.SuccessfulResources = append(req.SuccessfulResources, &types.SuccessfullyDeployedResource{
+				ReleaseName: getReleaseName(res),
+				ReleaseType: getReleaseType(t.projectID, res),
+			})
+		}
+	}
+
+	for res, err := range allErrors {
+		req.Errors[res] = err.Error()
+	}
+
+	_, err := t.client.FinalizeGitlabDeploymentWithErrors(
+		context.Background(),
+		t.projectID, t.clusterID, t.envID,
+		req,
+	)
+
+	if err != nil {
+		color.New(color.FgRed).Fprintf(os.Stderr, "Error finalizing GitLab deployment: %s\n", err.Error())
+	}
+}
+
 type CloneEnvGroupHook struct {
 	client   *api.Client
 	resGroup *switchboardTypes.ResourceGroup
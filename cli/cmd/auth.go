@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/fatih/color"
 
@@ -197,7 +198,7 @@ func loginManual() error {
 		return err
 	}
 
-	_, err = client.Login(context.Background(), &types.LoginUserRequest{
+	loginResp, err := client.Login(context.Background(), &types.LoginUserRequest{
 		Email:    username,
 		Password: pw,
 	})
@@ -206,6 +207,22 @@ func loginManual() error {
 		return err
 	}
 
+	if loginResp.TwoFactorRequired {
+		code, err := utils.PromptPlaintext("Two-factor code (or recovery code): ")
+
+		if err != nil {
+			return err
+		}
+
+		_, err = client.LoginTwoFactor(context.Background(), &types.LoginTwoFactorRequest{
+			Code: strings.TrimSpace(code),
+		})
+
+		if err != nil {
+			return err
+		}
+	}
+
 	// set the token to empty since this is manual (cookie-based) login
 	cliConf.SetToken("")
 
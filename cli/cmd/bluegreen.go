@@ -58,6 +58,41 @@ func init() {
 		"",
 		"The namespace of the jobs.",
 	)
+
+	bluegreenCmd.PersistentFlags().BoolVar(
+		&canary,
+		"canary",
+		false,
+		"Shift traffic to the new image tag in steps, checking the error rate and latency after each step.",
+	)
+
+	bluegreenCmd.PersistentFlags().IntSliceVar(
+		&canarySteps,
+		"canary-steps",
+		[]int{10, 25, 50, 100},
+		"The percentages of traffic to shift to the new image tag, used with --canary.",
+	)
+
+	bluegreenCmd.PersistentFlags().DurationVar(
+		&canaryInterval,
+		"canary-interval",
+		2*time.Minute,
+		"The time to wait after each step before checking the metrics, used with --canary.",
+	)
+
+	bluegreenCmd.PersistentFlags().Float64Var(
+		&canaryMaxErrorRate,
+		"canary-max-error-rate",
+		5,
+		"The maximum percentage of 5xx responses served by the new image tag, used with --canary. Set to 0 to disable.",
+	)
+
+	bluegreenCmd.PersistentFlags().DurationVar(
+		&canaryMaxLatency,
+		"canary-max-latency",
+		0,
+		"The maximum p99 latency of the requests served by the new image tag, used with --canary. Disabled by default.",
+	)
 }
 
 func bluegreenSwitch(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
@@ -129,40 +164,24 @@ func bluegreenSwitch(_ *types.GetAuthenticatedUserResponse, client *api.Client,
 				// if the number of ready replicas is greater than the number of min unavailable,
 				// the controller is ready for a traffic switch
 				if minUnavailable <= depl.Status.ReadyReplicas {
-					// push the deployment
-					color.New(color.FgGreen).Printf("Switching traffic for app %s\n", app)
+					switchTraffic := func() error {
+						// push the deployment
+						color.New(color.FgGreen).Printf("Switching traffic for app %s\n", app)
 
-					deployAgent, err := updateGetAgent(client)
-
-					if err != nil {
-						return err
+						return switchBlueGreenTraffic(client, currActiveImage)
 					}
 
-					if currActiveImage == "" {
-						err = deployAgent.UpdateImageAndValues(map[string]interface{}{
-							"bluegreen": map[string]interface{}{
-								"enabled":                  true,
-								"disablePrimaryDeployment": true,
-								"activeImageTag":           tag,
-								"imageTags":                []string{tag},
-							},
-						})
+					if canary {
+						err = runCanaryRollout(sharedConf, depl, switchTraffic)
 					} else {
-						err = deployAgent.UpdateImageAndValues(map[string]interface{}{
-							"bluegreen": map[string]interface{}{
-								"enabled":                  true,
-								"disablePrimaryDeployment": true,
-								"activeImageTag":           tag,
-								"imageTags":                []string{currActiveImage, tag},
-							},
-						})
+						err = switchTraffic()
 					}
 
 					if err != nil {
 						return err
-					} else {
-						success = true
 					}
+
+					success = true
 				}
 			}
 		}
@@ -204,6 +223,31 @@ func bluegreenSwitch(_ *types.GetAuthenticatedUserResponse, client *api.Client,
 	return nil
 }
 
+// switchBlueGreenTraffic sets the new image tag as the active image tag, keeping the deployment
+// of the currently active image tag
+func switchBlueGreenTraffic(client *api.Client, currActiveImage string) error {
+	deployAgent, err := updateGetAgent(client)
+
+	if err != nil {
+		return err
+	}
+
+	imageTags := []string{tag}
+
+	if currActiveImage != "" {
+		imageTags = []string{currActiveImage, tag}
+	}
+
+	return deployAgent.UpdateImageAndValues(map[string]interface{}{
+		"bluegreen": map[string]interface{}{
+			"enabled":                  true,
+			"disablePrimaryDeployment": true,
+			"activeImageTag":           tag,
+			"imageTags":                imageTags,
+		},
+	})
+}
+
 func getMaxUnavailable(deployment appsv1.Deployment) int32 {
 	if deployment.Spec.Strategy.Type != appsv1.RollingUpdateDeploymentStrategyType || *(deployment.Spec.Replicas) == 0 {
 		return int32(0)
@@ -0,0 +1,395 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/porter-dev/porter/internal/kubernetes/prometheus"
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	canaryLabel            = "porter.run/canary"
+	nginxCanaryAnnotation  = "nginx.ingress.kubernetes.io/canary"
+	nginxWeightAnnotation  = "nginx.ingress.kubernetes.io/canary-weight"
+	ingressClassAnnotation = "kubernetes.io/ingress.class"
+)
+
+var canary bool
+var canarySteps []int
+var canaryInterval time.Duration
+var canaryMaxErrorRate float64
+var canaryMaxLatency time.Duration
+
+// canaryRollout shifts traffic from the active deployment of a web chart to the deployment
+// of a new image tag, by creating NGINX canary ingresses which mirror the ingresses of the
+// application and point to a service selecting the pods of the new deployment
+type canaryRollout struct {
+	clientset  kubernetes.Interface
+	promSvc    *v1.Service
+	deployment appsv1.Deployment
+
+	// the names of the canary ingresses
+	ingresses []string
+}
+
+// runCanaryRollout shifts traffic to the given deployment in the configured steps, checking the
+// error rate and latency of the requests served by the new deployment after each step. If a
+// threshold is exceeded, all traffic is sent back to the active deployment and an error is
+// returned. Once all traffic is sent to the new deployment through the canary ingresses, the
+// active image tag is switched with switchTraffic and the canary is removed.
+func runCanaryRollout(sharedConf *PorterRunSharedConfig, deployment appsv1.Deployment, switchTraffic func() error) error {
+	steps, err := getCanarySteps(canarySteps)
+
+	if err != nil {
+		return err
+	}
+
+	promSvc, found, err := prometheus.GetPrometheusService(sharedConf.Clientset)
+
+	if err != nil {
+		return fmt.Errorf("error getting prometheus service: %w", err)
+	} else if !found {
+		return fmt.Errorf("canary deployments require the prometheus add-on to be installed in the cluster")
+	}
+
+	c := &canaryRollout{
+		clientset:  sharedConf.Clientset,
+		promSvc:    promSvc,
+		deployment: deployment,
+	}
+
+	err = c.createCanaryResources()
+
+	if err != nil {
+		c.revert()
+		return err
+	}
+
+	for _, weight := range steps {
+		color.New(color.FgGreen).Printf("Shifting %d%% of the traffic of app %s to tag %s\n", weight, app, tag)
+
+		// the canary can run for longer than the lifetime of the kube credentials
+		err = sharedConf.setSharedConfig()
+
+		if err != nil {
+			c.revert()
+			return fmt.Errorf("could not retrieve kube credentials: %w", err)
+		}
+
+		c.clientset = sharedConf.Clientset
+
+		err = c.setWeight(weight)
+
+		if err != nil {
+			c.revert()
+			return err
+		}
+
+		time.Sleep(canaryInterval)
+
+		err = c.checkMetrics()
+
+		if err != nil {
+			c.revert()
+			return fmt.Errorf("aborted canary deployment at %d%% of traffic: %w", weight, err)
+		}
+	}
+
+	return c.finish(switchTraffic)
+}
+
+// getCanarySteps validates the traffic percentages, and ensures that the last step sends all
+// traffic to the new deployment
+func getCanarySteps(steps []int) ([]int, error) {
+	res := make([]int, 0)
+	prev := 0
+
+	for _, step := range steps {
+		if step <= prev || step > 100 {
+			return nil, fmt.Errorf("canary steps must be increasing percentages between 1 and 100")
+		}
+
+		res = append(res, step)
+		prev = step
+	}
+
+	if prev != 100 {
+		res = append(res, 100)
+	}
+
+	return res, nil
+}
+
+func (c *canaryRollout) createCanaryResources() error {
+	clientset := c.clientset
+
+	// clean up the resources of a previous canary deployment which was interrupted
+	err := cleanupCanary(clientset)
+
+	if err != nil {
+		return err
+	}
+
+	ingressList, err := clientset.NetworkingV1().Ingresses(namespace).List(
+		context.Background(),
+		metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("app.kubernetes.io/instance=%s", app),
+		},
+	)
+
+	if err != nil {
+		return fmt.Errorf("could not get ingresses for app %s: %w", app, err)
+	}
+
+	var primaryIngresses []networkingv1.Ingress
+
+	for _, ingress := range ingressList.Items {
+		if ingress.Annotations[nginxCanaryAnnotation] != "true" {
+			primaryIngresses = append(primaryIngresses, ingress)
+		}
+	}
+
+	if len(primaryIngresses) == 0 {
+		return fmt.Errorf("canary deployments require app %s to be exposed through an ingress", app)
+	}
+
+	primarySvcName := getIngressServiceName(primaryIngresses[0])
+
+	if primarySvcName == "" {
+		return fmt.Errorf("could not find the service of ingress %s", primaryIngresses[0].Name)
+	}
+
+	primarySvc, err := clientset.CoreV1().Services(namespace).Get(context.Background(), primarySvcName, metav1.GetOptions{})
+
+	if err != nil {
+		return fmt.Errorf("could not get service %s: %w", primarySvcName, err)
+	}
+
+	canarySvc := &v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-canary", app),
+			Namespace: namespace,
+			Labels:    getCanaryLabels(),
+		},
+		Spec: v1.ServiceSpec{
+			Type:     v1.ServiceTypeClusterIP,
+			Selector: c.deployment.Spec.Selector.MatchLabels,
+		},
+	}
+
+	for _, port := range primarySvc.Spec.Ports {
+		canarySvc.Spec.Ports = append(canarySvc.Spec.Ports, v1.ServicePort{
+			Name:       port.Name,
+			Protocol:   port.Protocol,
+			Port:       port.Port,
+			TargetPort: port.TargetPort,
+		})
+	}
+
+	_, err = clientset.CoreV1().Services(namespace).Create(context.Background(), canarySvc, metav1.CreateOptions{})
+
+	if err != nil {
+		return fmt.Errorf("could not create canary service: %w", err)
+	}
+
+	for _, ingress := range primaryIngresses {
+		canaryIngress := &networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-canary", ingress.Name),
+				Namespace: namespace,
+				Labels:    getCanaryLabels(),
+				Annotations: map[string]string{
+					nginxCanaryAnnotation: "true",
+					nginxWeightAnnotation: "0",
+				},
+			},
+			Spec: networkingv1.IngressSpec{
+				IngressClassName: ingress.Spec.IngressClassName,
+			},
+		}
+
+		if class, ok := ingress.Annotations[ingressClassAnnotation]; ok {
+			canaryIngress.Annotations[ingressClassAnnotation] = class
+		}
+
+		// TLS is terminated by the primary ingress of the same host, so only the rules are copied
+		for _, rule := range ingress.Spec.Rules {
+			canaryRule := *rule.DeepCopy()
+
+			if canaryRule.HTTP != nil {
+				for i, path := range canaryRule.HTTP.Paths {
+					if path.Backend.Service != nil && path.Backend.Service.Name == primarySvcName {
+						canaryRule.HTTP.Paths[i].Backend.Service.Name = canarySvc.Name
+					}
+				}
+			}
+
+			canaryIngress.Spec.Rules = append(canaryIngress.Spec.Rules, canaryRule)
+		}
+
+		_, err = clientset.NetworkingV1().Ingresses(namespace).Create(context.Background(), canaryIngress, metav1.CreateOptions{})
+
+		if err != nil {
+			return fmt.Errorf("could not create canary ingress for ingress %s: %w", ingress.Name, err)
+		}
+
+		c.ingresses = append(c.ingresses, canaryIngress.Name)
+	}
+
+	return nil
+}
+
+func (c *canaryRollout) setWeight(weight int) error {
+	for _, name := range c.ingresses {
+		ingress, err := c.clientset.NetworkingV1().Ingresses(namespace).Get(
+			context.Background(), name, metav1.GetOptions{},
+		)
+
+		if err != nil {
+			return fmt.Errorf("could not get canary ingress %s: %w", name, err)
+		}
+
+		ingress.Annotations[nginxWeightAnnotation] = strconv.Itoa(weight)
+
+		_, err = c.clientset.NetworkingV1().Ingresses(namespace).Update(
+			context.Background(), ingress, metav1.UpdateOptions{},
+		)
+
+		if err != nil {
+			return fmt.Errorf("could not update canary ingress %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *canaryRollout) checkMetrics() error {
+	metrics, err := prometheus.QueryIngressMetrics(
+		c.clientset,
+		c.promSvc,
+		namespace,
+		c.ingresses,
+		canaryInterval,
+	)
+
+	if err != nil {
+		return fmt.Errorf("error querying prometheus: %w", err)
+	}
+
+	latencyStr := "no requests"
+
+	if metrics.LatencyP99 != nil {
+		latencyStr = metrics.LatencyP99.Round(time.Millisecond).String()
+	}
+
+	color.New(color.FgBlue).Printf("Error rate: %.2f%%, p99 latency: %s\n", metrics.ErrorPct, latencyStr)
+
+	if canaryMaxErrorRate > 0 && metrics.ErrorPct > canaryMaxErrorRate {
+		return fmt.Errorf("error rate of %.2f%% exceeded the maximum of %.2f%%", metrics.ErrorPct, canaryMaxErrorRate)
+	}
+
+	if canaryMaxLatency > 0 && metrics.LatencyP99 != nil && *metrics.LatencyP99 > canaryMaxLatency {
+		return fmt.Errorf("p99 latency of %s exceeded the maximum of %s", latencyStr, canaryMaxLatency)
+	}
+
+	return nil
+}
+
+// finish switches the active image tag once the canary serves all traffic, and then removes the
+// canary. If the switch fails, all traffic is sent back to the active deployment, as the canary
+// ingresses would otherwise keep sending traffic to a deployment which is not active.
+func (c *canaryRollout) finish(switchTraffic func() error) error {
+	err := switchTraffic()
+
+	if err != nil {
+		c.revert()
+		return err
+	}
+
+	// the active deployment now serves all traffic, so the canary can be removed
+	return cleanupCanary(c.clientset)
+}
+
+// revert sends all traffic back to the active deployment
+func (c *canaryRollout) revert() {
+	color.New(color.FgYellow).Printf("Reverting all traffic of app %s to the active deployment\n", app)
+
+	err := cleanupCanary(c.clientset)
+
+	if err != nil {
+		color.New(color.FgRed).Printf("Error reverting canary deployment: %s\n", err.Error())
+	}
+}
+
+// cleanupCanary deletes the canary ingresses and service of the app
+func cleanupCanary(clientset kubernetes.Interface) error {
+	selector := metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("app.kubernetes.io/instance=%s,%s=true", app, canaryLabel),
+	}
+
+	ingresses, err := clientset.NetworkingV1().Ingresses(namespace).List(context.Background(), selector)
+
+	if err != nil {
+		return fmt.Errorf("could not list canary ingresses: %w", err)
+	}
+
+	for _, ingress := range ingresses.Items {
+		err := clientset.NetworkingV1().Ingresses(namespace).Delete(
+			context.Background(), ingress.Name, metav1.DeleteOptions{},
+		)
+
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			return fmt.Errorf("could not delete canary ingress %s: %w", ingress.Name, err)
+		}
+	}
+
+	services, err := clientset.CoreV1().Services(namespace).List(context.Background(), selector)
+
+	if err != nil {
+		return fmt.Errorf("could not list canary services: %w", err)
+	}
+
+	for _, svc := range services.Items {
+		err := clientset.CoreV1().Services(namespace).Delete(
+			context.Background(), svc.Name, metav1.DeleteOptions{},
+		)
+
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			return fmt.Errorf("could not delete canary service %s: %w", svc.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func getCanaryLabels() map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/instance": app,
+		canaryLabel:                  "true",
+	}
+}
+
+func getIngressServiceName(ingress networkingv1.Ingress) string {
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+
+		for _, path := range rule.HTTP.Paths {
+			if path.Backend.Service != nil {
+				return path.Backend.Service.Name
+			}
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,334 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	v1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// fakePromResponse is the response of a prometheus query proxied through the fake clientset
+type fakePromResponse struct {
+	body []byte
+}
+
+func (r *fakePromResponse) DoRaw(context.Context) ([]byte, error) {
+	return r.body, nil
+}
+
+func (r *fakePromResponse) Stream(context.Context) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(string(r.body))), nil
+}
+
+func setCanaryGlobals(t *testing.T, maxErrorRate float64, maxLatency time.Duration) {
+	t.Helper()
+
+	prevApp, prevNamespace, prevMaxErrorRate, prevMaxLatency, prevInterval :=
+		app, namespace, canaryMaxErrorRate, canaryMaxLatency, canaryInterval
+
+	app, namespace, canaryMaxErrorRate, canaryMaxLatency, canaryInterval = "web", "default", maxErrorRate, maxLatency, time.Minute
+
+	t.Cleanup(func() {
+		app, namespace, canaryMaxErrorRate, canaryMaxLatency, canaryInterval =
+			prevApp, prevNamespace, prevMaxErrorRate, prevMaxLatency, prevInterval
+	})
+}
+
+// newCanaryClientset returns a clientset with the primary ingress and service of the web app,
+// where prometheus reports the given error percentage and p99 latency in seconds
+func newCanaryClientset(errorPct, latency string) *fake.Clientset {
+	pathType := networkingv1.PathTypePrefix
+
+	clientset := fake.NewSimpleClientset(
+		&v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+			Spec: v1.ServiceSpec{
+				Ports: []v1.ServicePort{{Name: "http", Port: 80}},
+			},
+		},
+		&networkingv1.Ingress{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "web",
+				Namespace: "default",
+				Labels:    map[string]string{"app.kubernetes.io/instance": "web"},
+				Annotations: map[string]string{
+					ingressClassAnnotation: "nginx",
+				},
+			},
+			Spec: networkingv1.IngressSpec{
+				Rules: []networkingv1.IngressRule{{
+					Host: "web.example.com",
+					IngressRuleValue: networkingv1.IngressRuleValue{
+						HTTP: &networkingv1.HTTPIngressRuleValue{
+							Paths: []networkingv1.HTTPIngressPath{{
+								Path:     "/",
+								PathType: &pathType,
+								Backend: networkingv1.IngressBackend{
+									Service: &networkingv1.IngressServiceBackend{
+										Name: "web",
+										Port: networkingv1.ServiceBackendPort{Number: 80},
+									},
+								},
+							}},
+						},
+					},
+				}},
+			},
+		},
+	)
+
+	clientset.PrependProxyReactor("services", func(action k8stesting.Action) (bool, restclient.ResponseWrapper, error) {
+		value := errorPct
+
+		if strings.Contains(action.(k8stesting.ProxyGetAction).GetParams()["query"], "histogram_quantile") {
+			value = latency
+		}
+
+		return true, &fakePromResponse{
+			body: []byte(fmt.Sprintf(`{"data":{"result":[{"value":[1666000000,"%s"]}]}}`, value)),
+		}, nil
+	})
+
+	return clientset
+}
+
+func newTestCanaryRollout(t *testing.T, clientset *fake.Clientset) *canaryRollout {
+	t.Helper()
+
+	c := &canaryRollout{
+		clientset: clientset,
+		promSvc: &v1.Service{
+			ObjectMeta: metav1.ObjectMeta{Name: "prometheus-server", Namespace: "monitoring"},
+			Spec:       v1.ServiceSpec{Ports: []v1.ServicePort{{Port: 80}}},
+		},
+		deployment: appsv1.Deployment{
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{
+					MatchLabels: map[string]string{"app.kubernetes.io/name": "web-green"},
+				},
+			},
+		},
+	}
+
+	if err := c.createCanaryResources(); err != nil {
+		t.Fatalf("unexpected error creating canary resources: %v", err)
+	}
+
+	return c
+}
+
+func TestGetCanarySteps(t *testing.T) {
+	tests := []struct {
+		steps    []int
+		expected []int
+		err      bool
+	}{
+		{[]int{10, 50}, []int{10, 50, 100}, false},
+		{[]int{25, 100}, []int{25, 100}, false},
+		{nil, []int{100}, false},
+		{[]int{50, 10}, nil, true},
+		{[]int{0, 50}, nil, true},
+		{[]int{50, 150}, nil, true},
+	}
+
+	for _, test := range tests {
+		res, err := getCanarySteps(test.steps)
+
+		if (err != nil) != test.err {
+			t.Errorf("%v: expected error to be %t, got %v", test.steps, test.err, err)
+			continue
+		}
+
+		if !test.err && !reflect.DeepEqual(res, test.expected) {
+			t.Errorf("%v: expected steps %v, got %v", test.steps, test.expected, res)
+		}
+	}
+}
+
+func TestCanaryRolloutCreatesCanaryResources(t *testing.T) {
+	setCanaryGlobals(t, 0, 0)
+
+	clientset := newCanaryClientset("0", "0.1")
+	c := newTestCanaryRollout(t, clientset)
+
+	svc, err := clientset.CoreV1().Services("default").Get(context.Background(), "web-canary", metav1.GetOptions{})
+
+	if err != nil {
+		t.Fatalf("expected the canary service to be created: %v", err)
+	}
+
+	if svc.Spec.Selector["app.kubernetes.io/name"] != "web-green" || len(svc.Spec.Ports) != 1 {
+		t.Errorf("expected the canary service to select the new deployment on the ports of the primary service, got %v", svc.Spec)
+	}
+
+	if !reflect.DeepEqual(c.ingresses, []string{"web-canary"}) {
+		t.Fatalf("expected a canary ingress for the primary ingress, got %v", c.ingresses)
+	}
+
+	ingress, err := clientset.NetworkingV1().Ingresses("default").Get(context.Background(), "web-canary", metav1.GetOptions{})
+
+	if err != nil {
+		t.Fatalf("expected the canary ingress to be created: %v", err)
+	}
+
+	if ingress.Annotations[nginxCanaryAnnotation] != "true" || ingress.Annotations[nginxWeightAnnotation] != "0" ||
+		ingress.Annotations[ingressClassAnnotation] != "nginx" {
+		t.Errorf("expected a canary ingress without traffic, got annotations %v", ingress.Annotations)
+	}
+
+	if backend := ingress.Spec.Rules[0].HTTP.Paths[0].Backend.Service.Name; backend != "web-canary" {
+		t.Errorf("expected the canary ingress to point to the canary service, got %s", backend)
+	}
+}
+
+func TestCanaryRolloutSetWeight(t *testing.T) {
+	setCanaryGlobals(t, 0, 0)
+
+	clientset := newCanaryClientset("0", "0.1")
+	c := newTestCanaryRollout(t, clientset)
+
+	for _, weight := range []int{10, 50, 100} {
+		if err := c.setWeight(weight); err != nil {
+			t.Fatalf("unexpected error setting weight %d: %v", weight, err)
+		}
+
+		ingress, err := clientset.NetworkingV1().Ingresses("default").Get(context.Background(), "web-canary", metav1.GetOptions{})
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if ingress.Annotations[nginxWeightAnnotation] != fmt.Sprintf("%d", weight) {
+			t.Errorf("expected weight %d, got %s", weight, ingress.Annotations[nginxWeightAnnotation])
+		}
+	}
+}
+
+func TestCanaryRolloutCheckMetrics(t *testing.T) {
+	tests := []struct {
+		name         string
+		errorPct     string
+		latency      string
+		maxErrorRate float64
+		maxLatency   time.Duration
+		err          string
+	}{
+		{"within thresholds", "0.5", "0.2", 1, time.Second, ""},
+		{"error rate exceeded", "5", "0.2", 1, time.Second, "error rate of 5.00% exceeded the maximum of 1.00%"},
+		{"latency exceeded", "0", "1.5", 1, time.Second, "p99 latency of 1.5s exceeded the maximum of 1s"},
+		{"no thresholds", "50", "10", 0, 0, ""},
+		{"no requests", "0", "NaN", 1, time.Second, ""},
+	}
+
+	for _, test := range tests {
+		setCanaryGlobals(t, test.maxErrorRate, test.maxLatency)
+
+		c := newTestCanaryRollout(t, newCanaryClientset(test.errorPct, test.latency))
+
+		err := c.checkMetrics()
+
+		if test.err == "" && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		} else if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+			t.Errorf("%s: expected error containing %q, got %v", test.name, test.err, err)
+		}
+	}
+}
+
+func TestCanaryRolloutRevert(t *testing.T) {
+	setCanaryGlobals(t, 1, 0)
+
+	clientset := newCanaryClientset("5", "0.1")
+	c := newTestCanaryRollout(t, clientset)
+
+	if err := c.setWeight(10); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := c.checkMetrics(); err == nil {
+		t.Fatalf("expected the error rate to abort the canary")
+	}
+
+	c.revert()
+
+	ingresses, err := clientset.NetworkingV1().Ingresses("default").List(context.Background(), metav1.ListOptions{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(ingresses.Items) != 1 || ingresses.Items[0].Name != "web" {
+		t.Errorf("expected only the primary ingress to remain, got %v", ingresses.Items)
+	}
+
+	services, err := clientset.CoreV1().Services("default").List(context.Background(), metav1.ListOptions{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(services.Items) != 1 || services.Items[0].Name != "web" {
+		t.Errorf("expected only the primary service to remain, got %v", services.Items)
+	}
+}
+
+func TestCanaryRolloutFinish(t *testing.T) {
+	tests := []struct {
+		name      string
+		switchErr error
+	}{
+		{"traffic switched", nil},
+		{"traffic switch failed", fmt.Errorf("could not update the active image tag")},
+	}
+
+	for _, test := range tests {
+		setCanaryGlobals(t, 0, 0)
+
+		clientset := newCanaryClientset("0", "0.1")
+		c := newTestCanaryRollout(t, clientset)
+
+		if err := c.setWeight(100); err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		err := c.finish(func() error {
+			return test.switchErr
+		})
+
+		if err != test.switchErr {
+			t.Errorf("%s: expected error %v, got %v", test.name, test.switchErr, err)
+		}
+
+		// the canary is removed in both cases, so that the active deployment serves all traffic
+		ingresses, err := clientset.NetworkingV1().Ingresses("default").List(context.Background(), metav1.ListOptions{})
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		if len(ingresses.Items) != 1 || ingresses.Items[0].Name != "web" {
+			t.Errorf("%s: expected only the primary ingress to remain, got %v", test.name, ingresses.Items)
+		}
+
+		services, err := clientset.CoreV1().Services("default").List(context.Background(), metav1.ListOptions{})
+
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", test.name, err)
+		}
+
+		if len(services.Items) != 1 || services.Items[0].Name != "web" {
+			t.Errorf("%s: expected only the primary service to remain, got %v", test.name, services.Items)
+		}
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"io/ioutil"
 	"os"
-	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -180,7 +179,7 @@ func init() {
 }
 
 func printConfig() error {
-	config, err := ioutil.ReadFile(filepath.Join(home, ".porter", "porter.yaml"))
+	config, err := ioutil.ReadFile(cliConfig.ProfileConfigPath(cliConfig.ActiveProfile()))
 
 	if err != nil {
 		return err
@@ -37,6 +37,10 @@ type CLIConfig struct {
 	Registry   uint   `yaml:"registry"`
 	HelmRepo   uint   `yaml:"helm_repo"`
 	Kubeconfig string `yaml:"kubeconfig"`
+
+	// CredentialHelper is the name of a docker credential helper, such as "osxkeychain",
+	// which stores the token instead of the config file
+	CredentialHelper string `yaml:"credential_helper"`
 }
 
 // InitAndLoadConfig populates the config object with the following precedence rules:
@@ -88,7 +92,37 @@ func initAndLoadConfig(_config *CLIConfig) {
 	viper.BindEnv("cluster")
 	viper.BindEnv("token")
 
-	err := viper.ReadInConfig()
+	profile, explicit, err := resolveProfile(os.Args[1:])
+
+	if err != nil {
+		color.New(color.FgRed).Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	if err := ValidateProfileName(profile); err != nil {
+		color.New(color.FgRed).Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	if !ProfileExists(profile) {
+		if explicit {
+			color.New(color.FgRed).Fprintf(os.Stderr, "profile %s does not exist\n", profile)
+			os.Exit(1)
+		}
+
+		// the current profile was deleted without using the CLI, so fall back to the
+		// default profile
+		color.New(color.FgYellow).Fprintf(os.Stderr, "Profile %s does not exist, using the %s profile\n", profile, DefaultProfile)
+		profile = DefaultProfile
+	}
+
+	activeProfile = profile
+
+	if profile != DefaultProfile {
+		viper.SetConfigFile(ProfileConfigPath(profile))
+	}
+
+	err = viper.ReadInConfig()
 
 	if err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
@@ -108,6 +142,20 @@ func initAndLoadConfig(_config *CLIConfig) {
 
 	// unmarshal the config into the shared config struct
 	viper.Unmarshal(_config)
+
+	_config.CredentialHelper = viper.GetString("credential_helper")
+
+	// tokens set through a flag or an env variable take precedence over the credential helper
+	if _config.Token == "" && _config.CredentialHelper != "" {
+		token, err := getHelperToken(_config.CredentialHelper)
+
+		if err != nil {
+			color.New(color.FgRed).Fprintf(os.Stderr, "error reading token from credential helper: %v\n", err)
+			os.Exit(1)
+		}
+
+		_config.Token = token
+	}
 }
 
 // initFlagSet initializes the shared flags used by multiple commands
@@ -205,6 +253,12 @@ func (c *CLIConfig) SetHost(host string) error {
 	viper.Set("cluster", 0)
 	viper.Set("token", "")
 
+	if config.CredentialHelper != "" {
+		if err := storeHelperToken(config.CredentialHelper, ""); err != nil {
+			return err
+		}
+	}
+
 	err := viper.WriteConfig()
 
 	if err != nil {
@@ -262,7 +316,17 @@ func (c *CLIConfig) SetCluster(clusterID uint) error {
 }
 
 func (c *CLIConfig) SetToken(token string) error {
-	viper.Set("token", token)
+	if config.CredentialHelper != "" {
+		if err := storeHelperToken(config.CredentialHelper, token); err != nil {
+			return err
+		}
+
+		// the token is only stored in the credential helper
+		viper.Set("token", "")
+	} else {
+		viper.Set("token", token)
+	}
+
 	err := viper.WriteConfig()
 
 	if err != nil {
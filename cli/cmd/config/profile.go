@@ -0,0 +1,342 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker-credential-helpers/client"
+	"github.com/docker/docker-credential-helpers/credentials"
+	"github.com/spf13/viper"
+)
+
+// DefaultProfile is the profile stored in ~/.porter/porter.yaml. It is used when no other
+// profile is selected, which keeps configurations from before profiles were added working.
+const DefaultProfile = "default"
+
+// ProfileEnvVar is the environment variable which selects the profile to use
+const ProfileEnvVar = "PORTER_PROFILE"
+
+// ProfileFlag is the name of the global flag which selects the profile to use
+const ProfileFlag = "profile"
+
+var profileNameRegex = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9_.-]*[a-zA-Z0-9])?$`)
+
+// activeProfile is the profile which the shared viper config was loaded from
+var activeProfile = DefaultProfile
+
+// ActiveProfile returns the name of the profile which the CLI config was loaded from
+func ActiveProfile() string {
+	return activeProfile
+}
+
+// ValidateProfileName checks that a profile name can be used as a file name
+func ValidateProfileName(name string) error {
+	if !profileNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid profile name %q: profile names may only contain letters, numbers, "+
+			"'-', '_' and '.', and must start and end with a letter or number", name)
+	}
+
+	return nil
+}
+
+// ProfileConfigPath returns the path of the config file of a profile
+func ProfileConfigPath(name string) string {
+	if name == DefaultProfile {
+		return filepath.Join(home, ".porter", "porter.yaml")
+	}
+
+	return filepath.Join(profilesDir(), name+".yaml")
+}
+
+func profilesDir() string {
+	return filepath.Join(home, ".porter", "profiles")
+}
+
+// ProfileExists returns whether a profile with the given name exists. The default profile
+// always exists.
+func ProfileExists(name string) bool {
+	if name == DefaultProfile {
+		return true
+	}
+
+	_, err := os.Stat(ProfileConfigPath(name))
+
+	return err == nil
+}
+
+// ListProfiles returns the names of all profiles, starting with the default profile
+func ListProfiles() ([]string, error) {
+	res := []string{DefaultProfile}
+
+	files, err := ioutil.ReadDir(profilesDir())
+
+	if err != nil && os.IsNotExist(err) {
+		return res, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0)
+
+	for _, file := range files {
+		if file.IsDir() || filepath.Ext(file.Name()) != ".yaml" {
+			continue
+		}
+
+		name := strings.TrimSuffix(file.Name(), ".yaml")
+
+		if name != DefaultProfile && ValidateProfileName(name) == nil {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return append(res, names...), nil
+}
+
+// GetProfile reads the config of a profile from its config file, without applying flags
+// or environment variables. Tokens stored in a credential helper are not read.
+func GetProfile(name string) (*CLIConfig, error) {
+	if !ProfileExists(name) {
+		return nil, fmt.Errorf("profile %s does not exist", name)
+	}
+
+	v, err := readProfileConfig(name)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &CLIConfig{
+		Driver:           v.GetString("driver"),
+		Host:             v.GetString("host"),
+		Project:          v.GetUint("project"),
+		Cluster:          v.GetUint("cluster"),
+		Token:            v.GetString("token"),
+		Registry:         v.GetUint("registry"),
+		HelmRepo:         v.GetUint("helm_repo"),
+		Kubeconfig:       v.GetString("kubeconfig"),
+		CredentialHelper: v.GetString("credential_helper"),
+	}, nil
+}
+
+// CreateProfile creates a new profile pointing at the given host. If credentialHelper is set,
+// the token of the profile is stored using the docker credential helper
+// docker-credential-<credentialHelper> instead of the config file.
+func CreateProfile(name, host, credentialHelper string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("the %s profile already exists", DefaultProfile)
+	}
+
+	if err := ValidateProfileName(name); err != nil {
+		return err
+	}
+
+	if ProfileExists(name) {
+		return fmt.Errorf("profile %s already exists", name)
+	}
+
+	if err := os.MkdirAll(profilesDir(), 0700); err != nil {
+		return err
+	}
+
+	v := viper.New()
+
+	v.SetConfigType("yaml")
+	v.Set("host", strings.TrimRight(host, "/"))
+	v.Set("project", 0)
+	v.Set("cluster", 0)
+	v.Set("token", "")
+
+	if credentialHelper != "" {
+		v.Set("credential_helper", credentialHelper)
+	}
+
+	return v.WriteConfigAs(ProfileConfigPath(name))
+}
+
+// DeleteProfile deletes a profile along with its token. If the profile is the current
+// profile, the default profile becomes the current profile.
+func DeleteProfile(name string) error {
+	if name == DefaultProfile {
+		return fmt.Errorf("the %s profile cannot be deleted", DefaultProfile)
+	}
+
+	profile, err := GetProfile(name)
+
+	if err != nil {
+		return err
+	}
+
+	if profile.CredentialHelper != "" {
+		err := client.Erase(credentialHelperProgram(profile.CredentialHelper), credentialHelperServerURL(name))
+
+		if err != nil && !credentials.IsErrCredentialsNotFound(err) {
+			return fmt.Errorf("error erasing token of profile %s: %w", name, err)
+		}
+	}
+
+	if err := os.Remove(ProfileConfigPath(name)); err != nil {
+		return err
+	}
+
+	current, err := GetCurrentProfile()
+
+	if err != nil {
+		return err
+	}
+
+	if current == name {
+		return SetCurrentProfile(DefaultProfile)
+	}
+
+	return nil
+}
+
+// GetCurrentProfile returns the profile which is used when neither the --profile flag nor
+// the PORTER_PROFILE environment variable is set
+func GetCurrentProfile() (string, error) {
+	v, err := readProfileConfig(DefaultProfile)
+
+	if err != nil {
+		return "", err
+	}
+
+	if current := v.GetString("current_profile"); current != "" {
+		return current, nil
+	}
+
+	return DefaultProfile, nil
+}
+
+// SetCurrentProfile sets the profile which is used when neither the --profile flag nor
+// the PORTER_PROFILE environment variable is set
+func SetCurrentProfile(name string) error {
+	if !ProfileExists(name) {
+		return fmt.Errorf("profile %s does not exist", name)
+	}
+
+	// the current profile is stored in the config file of the default profile, which is
+	// written by the shared viper config if the default profile is active
+	if activeProfile == DefaultProfile && viper.ConfigFileUsed() != "" {
+		viper.Set("current_profile", name)
+
+		return viper.WriteConfig()
+	}
+
+	v, err := readProfileConfig(DefaultProfile)
+
+	if err != nil {
+		return err
+	}
+
+	v.Set("current_profile", name)
+
+	return v.WriteConfig()
+}
+
+func readProfileConfig(name string) (*viper.Viper, error) {
+	v := viper.New()
+
+	v.SetConfigFile(ProfileConfigPath(name))
+	v.SetConfigType("yaml")
+
+	if err := v.ReadInConfig(); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// resolveProfile returns the profile to load, with the following precedence rules:
+// 1. --profile flag
+// 2. PORTER_PROFILE env
+// 3. current profile stored in the default config file
+// 4. default profile
+//
+// The second return value is false if the profile was only selected as the current profile.
+func resolveProfile(args []string) (string, bool, error) {
+	if name := profileFromArgs(args); name != "" {
+		return name, true, nil
+	}
+
+	if name := os.Getenv(ProfileEnvVar); name != "" {
+		return name, true, nil
+	}
+
+	name, err := GetCurrentProfile()
+
+	return name, false, err
+}
+
+// profileFromArgs returns the value of the --profile flag. The config is loaded before the
+// command line flags are parsed by cobra, so the flag is read from the arguments directly.
+func profileFromArgs(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+
+		if arg == "--"+ProfileFlag && i+1 < len(args) {
+			return args[i+1]
+		}
+
+		if strings.HasPrefix(arg, "--"+ProfileFlag+"=") {
+			return strings.TrimPrefix(arg, "--"+ProfileFlag+"=")
+		}
+	}
+
+	return ""
+}
+
+func credentialHelperProgram(helper string) client.ProgramFunc {
+	return client.NewShellProgramFunc("docker-credential-" + helper)
+}
+
+// credentialHelperServerURL returns the server URL under which the token of a profile is
+// stored in a credential helper
+func credentialHelperServerURL(profile string) string {
+	return fmt.Sprintf("porter://%s", profile)
+}
+
+// getHelperToken reads the token of the active profile from its credential helper
+func getHelperToken(helper string) (string, error) {
+	creds, err := client.Get(credentialHelperProgram(helper), credentialHelperServerURL(activeProfile))
+
+	if err != nil && credentials.IsErrCredentialsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	return creds.Secret, nil
+}
+
+// storeHelperToken stores the token of the active profile in its credential helper. An empty
+// token erases the stored token.
+func storeHelperToken(helper, token string) error {
+	serverURL := credentialHelperServerURL(activeProfile)
+
+	if token == "" {
+		err := client.Erase(credentialHelperProgram(helper), serverURL)
+
+		if err != nil && !credentials.IsErrCredentialsNotFound(err) {
+			return err
+		}
+
+		return nil
+	}
+
+	return client.Store(credentialHelperProgram(helper), &credentials.Credentials{
+		ServerURL: serverURL,
+		Username:  "porter",
+		Secret:    token,
+	})
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProfileFromArgs(t *testing.T) {
+	tests := []struct {
+		args     []string
+		expected string
+	}{
+		{[]string{"config"}, ""},
+		{[]string{"--profile", "staging", "config"}, "staging"},
+		{[]string{"apply", "-f", "porter.yaml", "--profile=production"}, "production"},
+		{[]string{"run", "web", "--", "--profile", "staging"}, ""},
+		{[]string{"config", "--profile"}, ""},
+	}
+
+	for _, test := range tests {
+		if res := profileFromArgs(test.args); res != test.expected {
+			t.Errorf("args %v: expected profile %q, got %q", test.args, test.expected, res)
+		}
+	}
+}
+
+func TestCreateListDeleteProfiles(t *testing.T) {
+	home = t.TempDir()
+	activeProfile = DefaultProfile
+
+	if err := CreateProfile("staging", "https://staging.example.com/", ""); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if err := CreateProfile("production", "https://example.com", ""); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if err := CreateProfile("staging", "https://example.com", ""); err == nil {
+		t.Errorf("expected error when creating existing profile")
+	}
+
+	if err := CreateProfile("../staging", "https://example.com", ""); err == nil {
+		t.Errorf("expected error for invalid profile name")
+	}
+
+	names, err := ListProfiles()
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if expected := []string{"default", "production", "staging"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected profiles %v, got %v", expected, names)
+	}
+
+	profile, err := GetProfile("staging")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if profile.Host != "https://staging.example.com" {
+		t.Errorf("expected host without trailing slash, got %s", profile.Host)
+	}
+
+	if err := SetCurrentProfile("staging"); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if current, _ := GetCurrentProfile(); current != "staging" {
+		t.Errorf("expected current profile staging, got %s", current)
+	}
+
+	if err := DeleteProfile("staging"); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// deleting the current profile resets the current profile
+	if current, _ := GetCurrentProfile(); current != DefaultProfile {
+		t.Errorf("expected current profile %s, got %s", DefaultProfile, current)
+	}
+
+	if ProfileExists("staging") {
+		t.Errorf("expected profile staging to be deleted")
+	}
+
+	if err := DeleteProfile(DefaultProfile); err == nil {
+		t.Errorf("expected error when deleting the default profile")
+	}
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	cliConfig "github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	profileCredentialHelper string
+	profileUse              bool
+)
+
+var configProfileCmd = &cobra.Command{
+	Use:     "profile",
+	Aliases: []string{"profiles"},
+	Short:   "Commands that manage configuration profiles",
+	Long: fmt.Sprintf(`
+%s
+
+Profiles store separate hosts, projects, clusters and tokens, for example to switch between
+staging and production Porter instances. The profile to use is selected with the --profile
+flag, the PORTER_PROFILE environment variable, or otherwise the current profile set with:
+
+  %s
+
+The "default" profile is stored in ~/.porter/porter.yaml, other profiles are stored in
+~/.porter/profiles.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter config profile\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter config profile use [name]"),
+	),
+}
+
+var configProfileCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Creates a new profile",
+	Long: fmt.Sprintf(`
+%s
+
+Creates a new profile which points at the host given by the --host flag, or at the host of
+the active profile. Log in with the new profile using:
+
+  %s
+
+If --credential-helper is set, the token of the profile is stored using the docker credential
+helper with that name (for example "osxkeychain", "wincred", "secretservice" or "pass")
+instead of the config file.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter config profile create\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter auth login --profile [name]"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := createProfile(args[0]); err != nil {
+			color.New(color.FgRed).Fprintf(os.Stderr, "An error occurred: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var configProfileUseCmd = &cobra.Command{
+	Use:   "use [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Sets the current profile",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := cliConfig.SetCurrentProfile(args[0]); err != nil {
+			color.New(color.FgRed).Fprintf(os.Stderr, "An error occurred: %v\n", err)
+			os.Exit(1)
+		}
+
+		color.New(color.FgGreen).Printf("Set the current profile as %s\n", args[0])
+	},
+}
+
+var configProfileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the configuration profiles",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := listProfiles(); err != nil {
+			color.New(color.FgRed).Fprintf(os.Stderr, "An error occurred: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var configProfileDeleteCmd = &cobra.Command{
+	Use:   "delete [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Deletes a profile along with its stored token",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := deleteProfile(args[0]); err != nil {
+			color.New(color.FgRed).Fprintf(os.Stderr, "An error occurred: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configProfileCmd)
+
+	configProfileCmd.AddCommand(configProfileCreateCmd)
+	configProfileCmd.AddCommand(configProfileUseCmd)
+	configProfileCmd.AddCommand(configProfileListCmd)
+	configProfileCmd.AddCommand(configProfileDeleteCmd)
+
+	configProfileCreateCmd.PersistentFlags().StringVar(
+		&profileCredentialHelper,
+		"credential-helper",
+		"",
+		"name of the docker credential helper to store the token of the profile in",
+	)
+
+	configProfileCreateCmd.PersistentFlags().BoolVar(
+		&profileUse,
+		"use",
+		false,
+		"set the new profile as the current profile",
+	)
+}
+
+func createProfile(name string) error {
+	if err := cliConfig.CreateProfile(name, cliConf.Host, profileCredentialHelper); err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Created profile %s with host %s\n", name, strings.TrimRight(cliConf.Host, "/"))
+
+	if profileUse {
+		if err := cliConfig.SetCurrentProfile(name); err != nil {
+			return err
+		}
+
+		color.New(color.FgGreen).Printf("Set the current profile as %s\n", name)
+	}
+
+	return nil
+}
+
+func listProfiles() error {
+	names, err := cliConfig.ListProfiles()
+
+	if err != nil {
+		return err
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", "NAME", "HOST", "PROJECT", "CLUSTER")
+
+	for _, name := range names {
+		profile, err := cliConfig.GetProfile(name)
+
+		if err != nil {
+			return err
+		}
+
+		if name == cliConfig.ActiveProfile() {
+			color.New(color.FgGreen).Fprintf(w, "%s\t%s\t%d\t%d (active profile)\n", name, profile.Host, profile.Project, profile.Cluster)
+		} else {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", name, profile.Host, profile.Project, profile.Cluster)
+		}
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func deleteProfile(name string) error {
+	userResp, err := utils.PromptPlaintext(
+		fmt.Sprintf(
+			`Deleting profile %s will also delete its stored token. Continue? %s `,
+			name,
+			color.New(color.FgCyan).Sprintf("[y/n]"),
+		),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if userResp := strings.ToLower(userResp); userResp != "y" && userResp != "yes" {
+		return nil
+	}
+
+	if err := cliConfig.DeleteProfile(name); err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Deleted profile %s\n", name)
+
+	return nil
+}
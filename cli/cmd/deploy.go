@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -15,6 +16,7 @@ import (
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/config"
 	"github.com/porter-dev/porter/cli/cmd/deploy"
+	"github.com/porter-dev/porter/cli/cmd/deploy/wait"
 	"github.com/porter-dev/porter/cli/cmd/docker"
 	"github.com/porter-dev/porter/cli/cmd/utils"
 	templaterUtils "github.com/porter-dev/porter/internal/templater/utils"
@@ -60,6 +62,13 @@ in your remote Git repository. For example, if a Dockerfile is found at ./docker
 specify it as follows:
 
   %s
+
+To roll back automatically if the new revision does not become healthy, pass the --rollback-on-failure
+flag. Porter will watch the pods of the new revision until they are ready and stay ready for the
+duration given by --health-check-window, and will roll back to the previous revision if a pod crashes,
+fails to start, restarts more than --max-restarts times or does not become ready in time:
+
+  %s
 `,
 		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter update\":"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter update --app example-app"),
@@ -67,6 +76,7 @@ specify it as follows:
 		color.New(color.FgGreen, color.Bold).Sprintf("porter update --app remote-git-app --source github"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter update --app example-app --values my-values.yaml"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter update --app example-app --method docker --dockerfile ./docker/prod.Dockerfile"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter update --app example-app --rollback-on-failure --health-check-timeout 10m"),
 	),
 	Run: func(cmd *cobra.Command, args []string) {
 		err := checkLoginAndRun(args, updateFull)
@@ -271,6 +281,10 @@ var varType string
 var normalEnvGroupVars []string
 var secretEnvGroupVars []string
 var waitForSuccessfulDeploy bool
+var rollbackOnFailure bool
+var healthCheckTimeout time.Duration
+var healthCheckWindow time.Duration
+var healthCheckMaxRestarts int32
 
 func init() {
 	buildFlagsEnv = []string{}
@@ -382,6 +396,34 @@ func init() {
 		"set this to wait and be notified when a deployment is successful, otherwise time out",
 	)
 
+	updateCmd.PersistentFlags().BoolVar(
+		&rollbackOnFailure,
+		"rollback-on-failure",
+		false,
+		"set this to watch the pods of the new revision and roll back to the previous revision if they are unhealthy",
+	)
+
+	updateCmd.PersistentFlags().DurationVar(
+		&healthCheckTimeout,
+		"health-check-timeout",
+		5*time.Minute,
+		"maximum time to wait for the pods of the new revision to become ready, used with --rollback-on-failure",
+	)
+
+	updateCmd.PersistentFlags().DurationVar(
+		&healthCheckWindow,
+		"health-check-window",
+		time.Minute,
+		"time that the pods of the new revision need to stay ready, used with --rollback-on-failure",
+	)
+
+	updateCmd.PersistentFlags().Int32Var(
+		&healthCheckMaxRestarts,
+		"max-restarts",
+		2,
+		"number of container restarts after which a pod of the new revision is unhealthy, used with --rollback-on-failure",
+	)
+
 	updateCmd.AddCommand(updateGetEnvCmd)
 
 	updateGetEnvCmd.PersistentFlags().StringVar(
@@ -490,6 +532,14 @@ func updateFull(_ *types.GetAuthenticatedUserResponse, client *api.Client, args
 		return err
 	}
 
+	if rollbackOnFailure {
+		err := checkHealthOrRollback(client, updateAgent)
+
+		if err != nil {
+			return err
+		}
+	}
+
 	if waitForSuccessfulDeploy {
 		// solves timing issue where replicasets were not on the cluster, before our initial check
 		time.Sleep(10 * time.Second)
@@ -615,6 +665,14 @@ func updateUpgrade(_ *types.GetAuthenticatedUserResponse, client *api.Client, ar
 		return err
 	}
 
+	if rollbackOnFailure {
+		err := checkHealthOrRollback(client, updateAgent)
+
+		if err != nil {
+			return err
+		}
+	}
+
 	if waitForSuccessfulDeploy {
 		// solves timing issue where replicasets were not on the cluster, before our initial check
 		time.Sleep(10 * time.Second)
@@ -1068,6 +1126,106 @@ func updateUpgradeWithAgent(updateAgent *deploy.DeployAgent) error {
 	return nil
 }
 
+// checkHealthOrRollback waits for the pods of the revision created by the upgrade to become
+// healthy, and rolls back to the previous revision if they do not
+func checkHealthOrRollback(client *api.Client, updateAgent *deploy.DeployAgent) error {
+	// the agent holds the release as it was before the upgrade
+	prevRevision := updateAgent.Release.Version
+
+	if updateAgent.Release.Chart != nil && updateAgent.Release.Chart.Name() == "job" {
+		color.New(color.FgYellow).Println("Skipping health check since jobs do not have long-running pods")
+		return nil
+	}
+
+	release, err := client.GetRelease(
+		context.Background(),
+		updateAgent.Opts.ProjectID,
+		updateAgent.Opts.ClusterID,
+		updateAgent.Opts.Namespace,
+		app,
+	)
+
+	if err != nil {
+		return fmt.Errorf("error fetching upgraded release %s: %w", app, err)
+	}
+
+	err = wait.WaitForHealthyRelease(client, &wait.HealthCheckOpts{
+		ProjectID:   updateAgent.Opts.ProjectID,
+		ClusterID:   updateAgent.Opts.ClusterID,
+		Namespace:   updateAgent.Opts.Namespace,
+		Name:        app,
+		Revision:    release.Version,
+		Timeout:     healthCheckTimeout,
+		Window:      healthCheckWindow,
+		MaxRestarts: healthCheckMaxRestarts,
+	})
+
+	var unhealthyErr *wait.UnhealthyError
+
+	if !errors.As(err, &unhealthyErr) {
+		if err == nil {
+			color.New(color.FgGreen).Printf("Revision %d of %s is healthy\n", release.Version, app)
+		}
+
+		return err
+	}
+
+	color.New(color.FgRed).Printf("Revision %d of %s is unhealthy: %s\n", release.Version, app, unhealthyErr.Reason)
+
+	if prevRevision == 0 {
+		return fmt.Errorf("%w, and there is no previous revision to roll back to", err)
+	}
+
+	color.New(color.FgYellow).Printf("Rolling back %s to revision %d\n", app, prevRevision)
+
+	if stream {
+		updateAgent.StreamEvent(types.SubEvent{
+			EventID: "rollback",
+			Name:    "Rollback",
+			Index:   400,
+			Status:  types.EventStatusInProgress,
+			Info:    unhealthyErr.Reason,
+		})
+	}
+
+	rollbackErr := client.RollbackRelease(
+		context.Background(),
+		updateAgent.Opts.ProjectID,
+		updateAgent.Opts.ClusterID,
+		updateAgent.Opts.Namespace,
+		app,
+		&types.RollbackReleaseRequest{
+			Revision: prevRevision,
+		},
+	)
+
+	if rollbackErr != nil {
+		if stream {
+			updateAgent.StreamEvent(types.SubEvent{
+				EventID: "rollback",
+				Name:    "Rollback",
+				Index:   410,
+				Status:  types.EventStatusFailed,
+				Info:    rollbackErr.Error(),
+			})
+		}
+
+		return fmt.Errorf("%w, and rolling back to revision %d failed: %s", err, prevRevision, rollbackErr.Error())
+	}
+
+	if stream {
+		updateAgent.StreamEvent(types.SubEvent{
+			EventID: "rollback",
+			Name:    "Rollback",
+			Index:   420,
+			Status:  types.EventStatusSuccess,
+			Info:    fmt.Sprintf("rolled back to revision %d", prevRevision),
+		})
+	}
+
+	return fmt.Errorf("%w, rolled back to revision %d", err, prevRevision)
+}
+
 func checkDeploymentStatus(client *api.Client) error {
 	color.New(color.FgBlue).Println("waiting for deployment to be ready, this may take a few minutes and will time out if it takes longer than 30 minutes")
 
@@ -345,11 +345,65 @@ func (d *DeployAgent) Push() error {
 // reuses the configuration set for the application. If overrideValues is not nil,
 // it will merge the overriding values with the existing configuration.
 func (d *DeployAgent) UpdateImageAndValues(overrideValues map[string]interface{}) error {
+	mergedValues, err := d.getUpgradeValues(overrideValues)
+
+	if err != nil {
+		return err
+	}
+
+	bytes, err := json.Marshal(mergedValues)
+
+	if err != nil {
+		return err
+	}
+
+	return d.Client.UpgradeRelease(
+		context.Background(),
+		d.Opts.ProjectID,
+		d.Opts.ClusterID,
+		d.Release.Namespace,
+		d.Release.Name,
+		&types.UpgradeReleaseRequest{
+			Values: string(bytes),
+		},
+	)
+}
+
+// UpdateImageAndValuesDryRun renders the upgrade that UpdateImageAndValues would perform,
+// without applying it
+func (d *DeployAgent) UpdateImageAndValuesDryRun(overrideValues map[string]interface{}) (*types.DryRunReleaseResponse, error) {
+	mergedValues, err := d.getUpgradeValues(overrideValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	bytes, err := json.Marshal(mergedValues)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return d.Client.UpgradeReleaseDryRun(
+		context.Background(),
+		d.Opts.ProjectID,
+		d.Opts.ClusterID,
+		d.Release.Namespace,
+		d.Release.Name,
+		&types.UpgradeReleaseRequest{
+			Values: string(bytes),
+		},
+	)
+}
+
+// getUpgradeValues merges the override values into the values of the latest release and
+// sets the new image tag
+func (d *DeployAgent) getUpgradeValues(overrideValues map[string]interface{}) (map[string]interface{}, error) {
 	// we should fetch the latest release and its config
 	release, err := d.Client.GetRelease(context.TODO(), d.Opts.ProjectID, d.Opts.ClusterID, d.Opts.Namespace, d.App)
 
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	d.Release = release
@@ -384,7 +438,7 @@ func (d *DeployAgent) UpdateImageAndValues(overrideValues map[string]interface{}
 		newImage, err := d.getReleaseImage()
 
 		if err != nil {
-			return fmt.Errorf("could not overwrite hello-porter image: %s", err.Error())
+			return nil, fmt.Errorf("could not overwrite hello-porter image: %s", err.Error())
 		}
 
 		currImageSection["repository"] = newImage
@@ -398,22 +452,7 @@ func (d *DeployAgent) UpdateImageAndValues(overrideValues map[string]interface{}
 		currImageSection["tag"] = d.tag
 	}
 
-	bytes, err := json.Marshal(mergedValues)
-
-	if err != nil {
-		return err
-	}
-
-	return d.Client.UpgradeRelease(
-		context.Background(),
-		d.Opts.ProjectID,
-		d.Opts.ClusterID,
-		d.Release.Namespace,
-		d.Release.Name,
-		&types.UpgradeReleaseRequest{
-			Values: string(bytes),
-		},
-	)
+	return mergedValues, nil
 }
 
 type SyncedEnvSection struct {
@@ -0,0 +1,186 @@
+package wait
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	v1 "k8s.io/api/core/v1"
+)
+
+// waiting reasons of a container which will not recover without a new deploy
+var failedWaitingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ErrImagePull":               true,
+	"ImagePullBackOff":           true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+type HealthCheckOpts struct {
+	ProjectID, ClusterID uint
+	Namespace, Name      string
+
+	// Revision is the revision of the release whose pods are checked
+	Revision int
+
+	// Timeout is the maximum time to wait for all pods of the revision to become ready
+	Timeout time.Duration
+
+	// Window is the time that the pods need to stay ready, without restarting more than
+	// MaxRestarts times, after they have all become ready
+	Window time.Duration
+
+	MaxRestarts int32
+}
+
+// UnhealthyError is returned when the pods of a revision fail the health check
+type UnhealthyError struct {
+	Reason string
+}
+
+func (e *UnhealthyError) Error() string {
+	return fmt.Sprintf("release is unhealthy: %s", e.Reason)
+}
+
+// WaitForHealthyRelease waits for all pods of a release revision to become ready, and to stay
+// ready for the health check window. It returns an *UnhealthyError if a pod crashes, fails to
+// start, restarts too often or does not become ready in time.
+func WaitForHealthyRelease(client *api.Client, opts *HealthCheckOpts) error {
+	color.New(color.FgBlue).Printf("Waiting for the pods of revision %d to become healthy, timing out after %s\n",
+		opts.Revision, opts.Timeout)
+
+	timeWait := time.Now().Add(opts.Timeout)
+
+	var readySince time.Time
+
+	for {
+		pods, err := client.GetK8sAllPods(context.Background(), opts.ProjectID, opts.ClusterID, opts.Namespace, opts.Name)
+
+		if err != nil {
+			return fmt.Errorf("error fetching pods for release %s: %w", opts.Name, err)
+		}
+
+		ready, reason := evaluatePods(getPodsMatchingRevision(opts.Revision, *pods), opts.MaxRestarts)
+
+		if reason != "" {
+			return &UnhealthyError{Reason: reason}
+		}
+
+		if ready && readySince.IsZero() {
+			color.New(color.FgBlue).Printf("All pods are ready, checking that they stay healthy for %s\n", opts.Window)
+			readySince = time.Now()
+		} else if !ready {
+			readySince = time.Time{}
+		}
+
+		if !readySince.IsZero() && time.Since(readySince) >= opts.Window {
+			return nil
+		}
+
+		if readySince.IsZero() && time.Now().After(timeWait) {
+			return &UnhealthyError{
+				Reason: fmt.Sprintf("pods did not become ready within %s", opts.Timeout),
+			}
+		}
+
+		time.Sleep(5 * time.Second)
+	}
+}
+
+// getPodsMatchingRevision returns the pods created for the given revision. Pods which are not
+// annotated with a revision, such as pods of charts that do not set the annotation, are always
+// included.
+func getPodsMatchingRevision(revision int, pods []v1.Pod) []v1.Pod {
+	res := make([]v1.Pod, 0)
+
+	for _, pod := range pods {
+		revisionAnn, ok := pod.Annotations["helm.sh/revision"]
+
+		if !ok {
+			res = append(res, pod)
+			continue
+		}
+
+		podRevision, err := strconv.Atoi(revisionAnn)
+
+		if err == nil && podRevision == revision {
+			res = append(res, pod)
+		}
+	}
+
+	return res
+}
+
+// evaluatePods returns whether all pods are ready, or a non-empty reason if any of the pods
+// has failed
+func evaluatePods(pods []v1.Pod, maxRestarts int32) (bool, string) {
+	if len(pods) == 0 {
+		return false, ""
+	}
+
+	allReady := true
+
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		if pod.Status.Phase == v1.PodFailed {
+			return false, fmt.Sprintf("pod %s failed: %s", pod.Name, pod.Status.Reason)
+		}
+
+		statuses := append([]v1.ContainerStatus{}, pod.Status.InitContainerStatuses...)
+		statuses = append(statuses, pod.Status.ContainerStatuses...)
+
+		for _, status := range statuses {
+			if reason := getContainerFailure(status, maxRestarts); reason != "" {
+				return false, fmt.Sprintf("container %s of pod %s %s", status.Name, pod.Name, reason)
+			}
+		}
+
+		if pod.Status.Phase == v1.PodSucceeded {
+			continue
+		}
+
+		if !isPodReady(pod) {
+			allReady = false
+		}
+	}
+
+	return allReady, ""
+}
+
+func getContainerFailure(status v1.ContainerStatus, maxRestarts int32) string {
+	if waiting := status.State.Waiting; waiting != nil && failedWaitingReasons[waiting.Reason] {
+		return fmt.Sprintf("is in state %s: %s", waiting.Reason, strings.TrimSpace(waiting.Message))
+	}
+
+	if terminated := status.LastTerminationState.Terminated; terminated != nil &&
+		status.RestartCount > maxRestarts {
+		return fmt.Sprintf("restarted %d times, last exited with code %d (%s)",
+			status.RestartCount, terminated.ExitCode, terminated.Reason)
+	}
+
+	if status.RestartCount > maxRestarts {
+		return fmt.Sprintf("restarted %d times", status.RestartCount)
+	}
+
+	return ""
+}
+
+func isPodReady(pod v1.Pod) bool {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == v1.PodReady {
+			return cond.Status == v1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,171 @@
+package wait
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	api "github.com/porter-dev/porter/api/client"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func getPod(name, revision string, ready bool, statuses ...v1.ContainerStatus) v1.Pod {
+	readyStatus := v1.ConditionFalse
+
+	if ready {
+		readyStatus = v1.ConditionTrue
+	}
+
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{},
+		},
+		Status: v1.PodStatus{
+			Phase: v1.PodRunning,
+			Conditions: []v1.PodCondition{
+				{Type: v1.PodReady, Status: readyStatus},
+			},
+			ContainerStatuses: statuses,
+		},
+	}
+
+	if revision != "" {
+		pod.Annotations["helm.sh/revision"] = revision
+	}
+
+	return pod
+}
+
+func TestEvaluatePods(t *testing.T) {
+	crashing := v1.ContainerStatus{
+		Name: "web",
+		State: v1.ContainerState{
+			Waiting: &v1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off restarting"},
+		},
+	}
+
+	restarted := v1.ContainerStatus{
+		Name:         "web",
+		RestartCount: 3,
+		LastTerminationState: v1.ContainerState{
+			Terminated: &v1.ContainerStateTerminated{ExitCode: 1, Reason: "Error"},
+		},
+	}
+
+	failed := getPod("web-2", "2", false)
+	failed.Status.Phase = v1.PodFailed
+	failed.Status.Reason = "Evicted"
+
+	succeeded := getPod("migrate", "2", false)
+	succeeded.Status.Phase = v1.PodSucceeded
+
+	terminating := getPod("web-1", "1", false, crashing)
+	terminating.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	tests := []struct {
+		name   string
+		pods   []v1.Pod
+		ready  bool
+		reason string
+	}{
+		{"no pods", nil, false, ""},
+		{"all ready", []v1.Pod{getPod("web-1", "2", true), getPod("web-2", "2", true)}, true, ""},
+		{"not ready yet", []v1.Pod{getPod("web-1", "2", true), getPod("web-2", "2", false)}, false, ""},
+		{"crash loop", []v1.Pod{getPod("web-1", "2", false, crashing)}, false, "is in state CrashLoopBackOff"},
+		{"too many restarts", []v1.Pod{getPod("web-1", "2", true, restarted)}, false, "restarted 3 times, last exited with code 1"},
+		{"failed pod", []v1.Pod{failed}, false, "pod web-2 failed: Evicted"},
+		{"succeeded pods are ignored", []v1.Pod{getPod("web-1", "2", true), succeeded}, true, ""},
+		{"terminating pods are ignored", []v1.Pod{getPod("web-2", "2", true), terminating}, true, ""},
+	}
+
+	for _, test := range tests {
+		ready, reason := evaluatePods(test.pods, 2)
+
+		if ready != test.ready {
+			t.Errorf("%s: expected ready to be %t, got %t", test.name, test.ready, ready)
+		}
+
+		if (test.reason == "") != (reason == "") || !strings.Contains(reason, test.reason) {
+			t.Errorf("%s: expected reason containing %q, got %q", test.name, test.reason, reason)
+		}
+	}
+}
+
+func TestGetPodsMatchingRevision(t *testing.T) {
+	pods := getPodsMatchingRevision(2, []v1.Pod{
+		getPod("old", "1", true),
+		getPod("new", "2", true),
+		getPod("unannotated", "", true),
+		getPod("invalid", "abc", true),
+	})
+
+	var names []string
+
+	for _, pod := range pods {
+		names = append(names, pod.Name)
+	}
+
+	if strings.Join(names, ",") != "new,unannotated" {
+		t.Errorf("expected the pods of revision 2 and unannotated pods, got %v", names)
+	}
+}
+
+func newPodsServer(t *testing.T, pods []v1.Pod) *api.Client {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/releases/web/0/pods/all") {
+			http.NotFound(w, r)
+			return
+		}
+
+		json.NewEncoder(w).Encode(pods)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return api.NewClientWithToken(server.URL, "token")
+}
+
+func TestWaitForHealthyRelease(t *testing.T) {
+	client := newPodsServer(t, []v1.Pod{getPod("web-old", "1", false), getPod("web-new", "2", true)})
+
+	err := WaitForHealthyRelease(client, &HealthCheckOpts{
+		Namespace: "default",
+		Name:      "web",
+		Revision:  2,
+		Timeout:   time.Minute,
+	})
+
+	if err != nil {
+		t.Errorf("expected the revision to be healthy, got %v", err)
+	}
+}
+
+func TestWaitForHealthyReleaseUnhealthy(t *testing.T) {
+	client := newPodsServer(t, []v1.Pod{getPod("web-new", "2", false, v1.ContainerStatus{
+		Name: "web",
+		State: v1.ContainerState{
+			Waiting: &v1.ContainerStateWaiting{Reason: "ImagePullBackOff"},
+		},
+	})})
+
+	err := WaitForHealthyRelease(client, &HealthCheckOpts{
+		Namespace: "default",
+		Name:      "web",
+		Revision:  2,
+		Timeout:   time.Minute,
+	})
+
+	var unhealthyErr *UnhealthyError
+
+	if !errors.As(err, &unhealthyErr) || !strings.Contains(unhealthyErr.Reason, "ImagePullBackOff") {
+		t.Errorf("expected an UnhealthyError for the image pull failure, got %v", err)
+	}
+}
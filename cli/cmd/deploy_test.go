@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/deploy"
+	"github.com/stefanmcshane/helm/pkg/release"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// rollbackServer serves the upgraded release and its pods, and records rollback requests
+type rollbackServer struct {
+	mu        sync.Mutex
+	pods      []v1.Pod
+	rollbacks []int
+}
+
+func (s *rollbackServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case strings.HasSuffix(r.URL.Path, "/releases/web/0/pods/all"):
+		json.NewEncoder(w).Encode(s.pods)
+	case strings.HasSuffix(r.URL.Path, "/releases/web/0/rollback"):
+		req := &types.RollbackReleaseRequest{}
+		json.NewDecoder(r.Body).Decode(req)
+
+		s.rollbacks = append(s.rollbacks, req.Revision)
+	case strings.HasSuffix(r.URL.Path, "/releases/web/0"):
+		json.NewEncoder(w).Encode(&types.GetReleaseResponse{
+			Release: &release.Release{Name: "web", Namespace: "default", Version: 3},
+		})
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func runCheckHealthOrRollback(t *testing.T, server *rollbackServer, prevRevision int) error {
+	t.Helper()
+
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	prevApp, prevTimeout, prevWindow := app, healthCheckTimeout, healthCheckWindow
+
+	app, healthCheckTimeout, healthCheckWindow = "web", time.Minute, 0
+
+	defer func() {
+		app, healthCheckTimeout, healthCheckWindow = prevApp, prevTimeout, prevWindow
+	}()
+
+	return checkHealthOrRollback(api.NewClientWithToken(httpServer.URL, "token"), &deploy.DeployAgent{
+		App: "web",
+		Opts: &deploy.DeployOpts{
+			SharedOpts: &deploy.SharedOpts{ProjectID: 1, ClusterID: 1, Namespace: "default"},
+		},
+		Release: &types.GetReleaseResponse{
+			Release: &release.Release{Name: "web", Namespace: "default", Version: prevRevision},
+		},
+	})
+}
+
+func getRevisionPod(ready bool, waitingReason string) v1.Pod {
+	status := v1.ConditionFalse
+
+	if ready {
+		status = v1.ConditionTrue
+	}
+
+	pod := v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web-abc",
+			Annotations: map[string]string{"helm.sh/revision": "3"},
+		},
+		Status: v1.PodStatus{
+			Phase:      v1.PodRunning,
+			Conditions: []v1.PodCondition{{Type: v1.PodReady, Status: status}},
+		},
+	}
+
+	if waitingReason != "" {
+		pod.Status.ContainerStatuses = []v1.ContainerStatus{{
+			Name:  "web",
+			State: v1.ContainerState{Waiting: &v1.ContainerStateWaiting{Reason: waitingReason}},
+		}}
+	}
+
+	return pod
+}
+
+func TestCheckHealthOrRollbackHealthy(t *testing.T) {
+	server := &rollbackServer{pods: []v1.Pod{getRevisionPod(true, "")}}
+
+	if err := runCheckHealthOrRollback(t, server, 2); err != nil {
+		t.Fatalf("expected the revision to be healthy, got %v", err)
+	}
+
+	if len(server.rollbacks) != 0 {
+		t.Errorf("expected no rollback, got %v", server.rollbacks)
+	}
+}
+
+func TestCheckHealthOrRollbackUnhealthy(t *testing.T) {
+	server := &rollbackServer{pods: []v1.Pod{getRevisionPod(false, "CrashLoopBackOff")}}
+
+	err := runCheckHealthOrRollback(t, server, 2)
+
+	if err == nil || !strings.Contains(err.Error(), "rolled back to revision 2") {
+		t.Fatalf("expected an error reporting the rollback, got %v", err)
+	}
+
+	if len(server.rollbacks) != 1 || server.rollbacks[0] != 2 {
+		t.Errorf("expected a rollback to revision 2, got %v", server.rollbacks)
+	}
+}
+
+func TestCheckHealthOrRollbackWithoutPreviousRevision(t *testing.T) {
+	server := &rollbackServer{pods: []v1.Pod{getRevisionPod(false, "CrashLoopBackOff")}}
+
+	err := runCheckHealthOrRollback(t, server, 0)
+
+	if err == nil || !strings.Contains(err.Error(), "no previous revision") {
+		t.Fatalf("expected an error without a previous revision, got %v", err)
+	}
+
+	if len(server.rollbacks) != 0 {
+		t.Errorf("expected no rollback, got %v", server.rollbacks)
+	}
+}
@@ -0,0 +1,427 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	envGroupNamespace   string
+	envGroupFromVersion uint
+	envGroupToVersion   uint
+	envGroupVersion     uint
+
+	envGroupFile           string
+	envGroupSecretFile     string
+	envGroupSecretPrefix   string
+	envGroupImportFormat   string
+	envGroupExportFormat   string
+	envGroupReplace        bool
+	envGroupIncludeSecrets bool
+	envGroupOutput         string
+)
+
+// envGroupCmd represents the "porter env-group" base command when called
+// without any subcommands
+var envGroupCmd = &cobra.Command{
+	Use:     "env-group",
+	Aliases: []string{"env-groups", "eg"},
+	Short:   "Commands that manage the env groups of the current cluster",
+}
+
+var envGroupDiffCmd = &cobra.Command{
+	Use:   "diff [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Shows the variables that changed between two versions of an env group",
+	Long: fmt.Sprintf(`%s
+
+Shows the variables that were added, removed or changed between two versions of an env group.
+The values of secret variables are not shown. By default, the version given by --from is compared
+with the latest version:
+
+  %s`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env-group diff\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env-group diff [name] --from 3 --to 5"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, diffEnvGroup)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envGroupRollbackCmd = &cobra.Command{
+	Use:   "rollback [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Rolls back an env group to an older version",
+	Long: fmt.Sprintf(`%s
+
+Creates a new version of an env group with the variables of an older version, and redeploys the
+applications synced to the env group:
+
+  %s`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env-group rollback\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env-group rollback [name] --version 3"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, rollbackEnvGroup)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envGroupImportCmd = &cobra.Command{
+	Use:   "import [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Imports variables from a dotenv, JSON or YAML file into an env group",
+	Long: fmt.Sprintf(`%s
+
+Imports variables from a file into an env group, creating the env group if it does not exist.
+The format of the file is detected from its extension, unless --format is set:
+
+  %s
+
+Secret variables can either be imported from a separate file, or marked with a prefix which
+is removed from their name:
+
+  %s
+
+By default, the imported variables are merged into the env group. Use --replace to remove
+the variables of the env group which are not in the imported files. The applications synced
+to the env group are redeployed.`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env-group import\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env-group import [name] --file .env"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env-group import [name] --file .env --secret-prefix SECRET_"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, importEnvGroup)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var envGroupExportCmd = &cobra.Command{
+	Use:   "export [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Exports the variables of an env group as a dotenv, JSON or YAML file",
+	Long: fmt.Sprintf(`%s
+
+Exports the variables of an env group to stdout, or to a file with --output. The values of
+secret variables are left out unless --include-secrets is set, which requires permission to
+update the project settings:
+
+  %s`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter env-group export\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter env-group export [name] --format json --output env.json"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, exportEnvGroup)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(envGroupCmd)
+
+	envGroupCmd.AddCommand(envGroupDiffCmd)
+	envGroupCmd.AddCommand(envGroupRollbackCmd)
+	envGroupCmd.AddCommand(envGroupImportCmd)
+	envGroupCmd.AddCommand(envGroupExportCmd)
+
+	envGroupCmd.PersistentFlags().StringVar(
+		&envGroupNamespace,
+		"namespace",
+		"default",
+		"The namespace of the env group.",
+	)
+
+	envGroupDiffCmd.PersistentFlags().UintVar(
+		&envGroupFromVersion,
+		"from",
+		0,
+		"The version to compare from.",
+	)
+
+	envGroupDiffCmd.PersistentFlags().UintVar(
+		&envGroupToVersion,
+		"to",
+		0,
+		"The version to compare to. Defaults to the latest version.",
+	)
+
+	envGroupDiffCmd.MarkPersistentFlagRequired("from")
+
+	envGroupRollbackCmd.PersistentFlags().UintVar(
+		&envGroupVersion,
+		"version",
+		0,
+		"The version to roll back to.",
+	)
+
+	envGroupRollbackCmd.MarkPersistentFlagRequired("version")
+
+	envGroupImportCmd.PersistentFlags().StringVarP(
+		&envGroupFile,
+		"file",
+		"f",
+		"",
+		"The file to import variables from.",
+	)
+
+	envGroupImportCmd.PersistentFlags().StringVar(
+		&envGroupSecretFile,
+		"secret-file",
+		"",
+		"A file in the same format whose variables are all imported as secrets.",
+	)
+
+	envGroupImportCmd.PersistentFlags().StringVar(
+		&envGroupSecretPrefix,
+		"secret-prefix",
+		"",
+		"Variables starting with this prefix are imported as secrets, with the prefix removed.",
+	)
+
+	envGroupImportCmd.PersistentFlags().StringVar(
+		&envGroupImportFormat,
+		"format",
+		"",
+		"The format of the files: dotenv, json or yaml. Detected from the file extension by default.",
+	)
+
+	envGroupImportCmd.PersistentFlags().BoolVar(
+		&envGroupReplace,
+		"replace",
+		false,
+		"Remove the variables of the env group which are not in the imported files.",
+	)
+
+	envGroupImportCmd.MarkPersistentFlagRequired("file")
+
+	envGroupExportCmd.PersistentFlags().StringVar(
+		&envGroupExportFormat,
+		"format",
+		"dotenv",
+		"The format to export: dotenv, json or yaml.",
+	)
+
+	envGroupExportCmd.PersistentFlags().UintVar(
+		&envGroupVersion,
+		"version",
+		0,
+		"The version to export. Defaults to the latest version.",
+	)
+
+	envGroupExportCmd.PersistentFlags().BoolVar(
+		&envGroupIncludeSecrets,
+		"include-secrets",
+		false,
+		"Include the values of secret variables.",
+	)
+
+	envGroupExportCmd.PersistentFlags().StringVarP(
+		&envGroupOutput,
+		"output",
+		"o",
+		"",
+		"The file to write to. Defaults to stdout.",
+	)
+}
+
+func diffEnvGroup(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.DiffEnvGroup(
+		context.Background(), cliConf.Project, cliConf.Cluster, envGroupNamespace,
+		&types.DiffEnvGroupRequest{
+			Name:        args[0],
+			FromVersion: envGroupFromVersion,
+			ToVersion:   envGroupToVersion,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Comparing version %d with version %d of env group %s\n\n", resp.FromVersion, resp.ToVersion, resp.Name)
+
+	if len(resp.Added) == 0 && len(resp.Removed) == 0 && len(resp.Changed) == 0 {
+		fmt.Println("No changes")
+		return nil
+	}
+
+	for _, diff := range resp.Added {
+		color.New(color.FgGreen).Printf("+ %s=%s\n", diff.Key, formatEnvGroupValue(diff, diff.NewValue))
+	}
+
+	for _, diff := range resp.Removed {
+		color.New(color.FgRed).Printf("- %s=%s\n", diff.Key, formatEnvGroupValue(diff, diff.OldValue))
+	}
+
+	for _, diff := range resp.Changed {
+		color.New(color.FgYellow).Printf(
+			"~ %s: %s -> %s\n",
+			diff.Key, formatEnvGroupValue(diff, diff.OldValue), formatEnvGroupValue(diff, diff.NewValue),
+		)
+	}
+
+	return nil
+}
+
+func formatEnvGroupValue(diff *types.EnvGroupVariableDiff, val string) string {
+	if diff.Secret {
+		return "********"
+	}
+
+	return val
+}
+
+func rollbackEnvGroup(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	userResp, err := utils.PromptPlaintext(
+		fmt.Sprintf(
+			`Rolling back env group %s to version %d will redeploy all applications synced to it. Continue? %s `,
+			args[0],
+			envGroupVersion,
+			color.New(color.FgCyan).Sprintf("[y/n]"),
+		),
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if userResp := strings.ToLower(userResp); userResp != "y" && userResp != "yes" {
+		return nil
+	}
+
+	envGroup, err := client.RollbackEnvGroup(
+		context.Background(), cliConf.Project, cliConf.Cluster, envGroupNamespace,
+		&types.RollbackEnvGroupRequest{
+			Name:    args[0],
+			Version: envGroupVersion,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf(
+		"Rolled back env group %s to version %d, created version %d\n",
+		envGroup.Name, envGroupVersion, envGroup.Version,
+	)
+
+	return nil
+}
+
+func importEnvGroup(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	format := envGroupImportFormat
+
+	if format == "" {
+		format = getEnvFileFormat(envGroupFile)
+	}
+
+	content, err := os.ReadFile(envGroupFile)
+
+	if err != nil {
+		return fmt.Errorf("error reading %s: %w", envGroupFile, err)
+	}
+
+	req := &types.ImportEnvGroupRequest{
+		Format:       types.EnvGroupFileFormat(format),
+		Content:      string(content),
+		SecretPrefix: envGroupSecretPrefix,
+		Replace:      envGroupReplace,
+	}
+
+	if envGroupSecretFile != "" {
+		secretContent, err := os.ReadFile(envGroupSecretFile)
+
+		if err != nil {
+			return fmt.Errorf("error reading %s: %w", envGroupSecretFile, err)
+		}
+
+		req.SecretContent = string(secretContent)
+	}
+
+	envGroup, err := client.ImportEnvGroup(
+		context.Background(), cliConf.Project, cliConf.Cluster, envGroupNamespace, args[0], req,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf(
+		"Imported variables into env group %s, created version %d\n",
+		envGroup.Name, envGroup.Version,
+	)
+
+	return nil
+}
+
+// getEnvFileFormat returns the format of a file based on its extension
+func getEnvFileFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return string(types.EnvGroupFileFormatJSON)
+	case ".yaml", ".yml":
+		return string(types.EnvGroupFileFormatYAML)
+	}
+
+	return string(types.EnvGroupFileFormatDotenv)
+}
+
+func exportEnvGroup(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.ExportEnvGroup(
+		context.Background(), cliConf.Project, cliConf.Cluster, envGroupNamespace, args[0],
+		envGroupIncludeSecrets,
+		&types.ExportEnvGroupRequest{
+			Format:  types.EnvGroupFileFormat(envGroupExportFormat),
+			Version: envGroupVersion,
+		},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	if !envGroupIncludeSecrets && len(resp.SecretKeys) > 0 {
+		color.New(color.FgYellow).Fprintf(
+			os.Stderr, "Left out the secret variables %s, use --include-secrets to export them\n",
+			strings.Join(resp.SecretKeys, ", "),
+		)
+	}
+
+	if envGroupOutput == "" {
+		fmt.Print(resp.Content)
+		return nil
+	}
+
+	if err := os.WriteFile(envGroupOutput, []byte(resp.Content), 0600); err != nil {
+		return fmt.Errorf("error writing %s: %w", envGroupOutput, err)
+	}
+
+	color.New(color.FgGreen).Fprintf(
+		os.Stderr, "Exported version %d of env group %s to %s\n", resp.Version, args[0], envGroupOutput,
+	)
+
+	return nil
+}
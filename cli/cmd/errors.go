@@ -40,7 +40,10 @@ func checkLoginAndRun(args []string, runner func(user *types.GetAuthenticatedUse
 
 	err = runner(user, client, args)
 
-	if err != nil {
+	if errors.Is(err, ErrDriftDetected) {
+		// not a failure, the caller sets the exit code
+		return err
+	} else if err != nil {
 		red := color.New(color.FgRed)
 
 		if strings.Contains(err.Error(), "403") {
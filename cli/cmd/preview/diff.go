@@ -0,0 +1,403 @@
+package preview
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/porter-dev/porter/api/types"
+	"sigs.k8s.io/yaml"
+)
+
+// ReleaseDiff is the difference between the currently deployed revision of a release and
+// the revision that applying a porter.yaml would produce
+type ReleaseDiff struct {
+	Namespace string
+	Name      string
+
+	// Exists is false if the release would be created
+	Exists bool
+
+	// ValuesDiff is a unified diff of the Helm values, empty if the values are unchanged
+	ValuesDiff string
+
+	// ObjectDiffs is a map from an identifier of each changed Kubernetes object, of the
+	// form <kind>/<name>, to a unified diff of the object
+	ObjectDiffs map[string]string
+
+	// Regenerated lists the values and object fields which are set from a random value
+	// that is regenerated on every apply. They are left out of the diffs.
+	Regenerated []string
+}
+
+// DryRunRegeneratedValue stands in for random values during a dry run, since a new random
+// value is generated on every apply and would otherwise always show up as changed
+const DryRunRegeneratedValue = "porter-dry-run-regenerated"
+
+// NewReleaseDiff computes the diff of the values and each of the Kubernetes objects from the
+// response of a release dry run
+func NewReleaseDiff(namespace, name string, resp *types.DryRunReleaseResponse) (*ReleaseDiff, error) {
+	res := &ReleaseDiff{
+		Namespace:   namespace,
+		Name:        name,
+		Exists:      resp.Exists,
+		ObjectDiffs: make(map[string]string),
+	}
+
+	currValues, err := marshalValues(resp.CurrentValues)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading current values of release %s: %w", name, err)
+	}
+
+	values, regenerated := keepRegeneratedValues(resp.CurrentValues, resp.Values, "values")
+
+	if len(regenerated) > 0 {
+		res.Regenerated = append(res.Regenerated, regenerated...)
+	}
+
+	newValues, err := marshalValues(values.(map[string]interface{}))
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading new values of release %s: %w", name, err)
+	}
+
+	res.ValuesDiff, err = unifiedDiff(currValues, newValues, "values")
+
+	if err != nil {
+		return nil, err
+	}
+
+	currObjects, err := parseManifest(resp.CurrentManifest)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading current manifest of release %s: %w", name, err)
+	}
+
+	newObjects, err := parseManifest(resp.Manifest)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading new manifest of release %s: %w", name, err)
+	}
+
+	ids := make(map[string]bool)
+
+	for id := range currObjects {
+		ids[id] = true
+	}
+
+	for id := range newObjects {
+		ids[id] = true
+	}
+
+	for id := range ids {
+		if newObj, ok := newObjects[id]; ok {
+			obj, regenerated := keepRegeneratedValues(currObjects[id], newObj, id)
+
+			newObjects[id] = obj.(map[string]interface{})
+			res.Regenerated = append(res.Regenerated, regenerated...)
+		}
+
+		currObj, err := marshalObject(currObjects[id])
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading current manifest of release %s: %w", name, err)
+		}
+
+		newObj, err := marshalObject(newObjects[id])
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading new manifest of release %s: %w", name, err)
+		}
+
+		objDiff, err := unifiedDiff(currObj, newObj, id)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if objDiff != "" {
+			res.ObjectDiffs[id] = objDiff
+		}
+	}
+
+	sort.Strings(res.Regenerated)
+
+	return res, nil
+}
+
+// HasDrift returns true if applying the porter.yaml would change the release
+func (d *ReleaseDiff) HasDrift() bool {
+	return !d.Exists || d.ValuesDiff != "" || len(d.ObjectDiffs) > 0
+}
+
+// Print writes the diff to the given writer, coloring added and removed lines
+func (d *ReleaseDiff) Print(w io.Writer) {
+	header := color.New(color.FgBlue, color.Bold)
+
+	if !d.HasDrift() {
+		color.New(color.FgGreen).Fprintf(w, "Release %s/%s is up to date\n", d.Namespace, d.Name)
+		return
+	}
+
+	if d.Exists {
+		header.Fprintf(w, "Release %s/%s would be upgraded\n", d.Namespace, d.Name)
+	} else {
+		header.Fprintf(w, "Release %s/%s would be created\n", d.Namespace, d.Name)
+	}
+
+	if d.ValuesDiff != "" {
+		printUnifiedDiff(w, d.ValuesDiff)
+	}
+
+	var ids []string
+
+	for id := range d.ObjectDiffs {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		printUnifiedDiff(w, d.ObjectDiffs[id])
+	}
+
+	if len(d.Regenerated) > 0 {
+		color.New(color.FgYellow).Fprintf(w, "The following are regenerated randomly on every apply and are not shown: %s\n",
+			strings.Join(d.Regenerated, ", "))
+	}
+}
+
+// DryRunReport collects the diffs of all releases in a porter.yaml. It is safe for
+// concurrent use by multiple drivers.
+type DryRunReport struct {
+	mu    sync.Mutex
+	diffs []*ReleaseDiff
+}
+
+func NewDryRunReport() *DryRunReport {
+	return &DryRunReport{}
+}
+
+func (r *DryRunReport) Add(diff *ReleaseDiff) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.diffs = append(r.diffs, diff)
+}
+
+// Print writes all diffs to the given writer, and returns the number of releases that
+// would be changed
+func (r *DryRunReport) Print(w io.Writer) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	sort.SliceStable(r.diffs, func(i, j int) bool {
+		return r.diffs[i].Name < r.diffs[j].Name
+	})
+
+	drifted := 0
+
+	for _, diff := range r.diffs {
+		diff.Print(w)
+
+		if diff.HasDrift() {
+			drifted++
+		}
+	}
+
+	return drifted
+}
+
+func marshalValues(values map[string]interface{}) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	bytes, err := yaml.Marshal(values)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
+
+// parseManifest splits a rendered Helm manifest into its Kubernetes objects, returning a map
+// from <kind>/<name> to the object
+func parseManifest(manifest string) (map[string]map[string]interface{}, error) {
+	res := make(map[string]map[string]interface{})
+
+	for _, doc := range strings.Split(manifest, "\n---") {
+		obj := make(map[string]interface{})
+
+		err := yaml.Unmarshal([]byte(doc), &obj)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if len(obj) == 0 {
+			continue
+		}
+
+		kind, _ := obj["kind"].(string)
+		name := ""
+
+		if metadata, ok := obj["metadata"].(map[string]interface{}); ok {
+			name, _ = metadata["name"].(string)
+		}
+
+		res[fmt.Sprintf("%s/%s", kind, name)] = obj
+	}
+
+	return res, nil
+}
+
+// marshalObject returns the normalized YAML of a Kubernetes object, with the values of
+// Secrets masked
+func marshalObject(obj map[string]interface{}) (string, error) {
+	if obj == nil {
+		return "", nil
+	}
+
+	if kind, _ := obj["kind"].(string); kind == "Secret" {
+		maskSecretData(obj)
+	}
+
+	bytes, err := yaml.Marshal(obj)
+
+	if err != nil {
+		return "", err
+	}
+
+	return string(bytes), nil
+}
+
+// keepRegeneratedValues returns a copy of newVal where every string which contains a regenerated
+// random value is replaced by the value at the same path in currVal, so that it does not show up
+// in the diff. It also returns the paths of those strings.
+func keepRegeneratedValues(currVal, newVal interface{}, path string) (interface{}, []string) {
+	var regenerated []string
+
+	switch val := newVal.(type) {
+	case map[string]interface{}:
+		currMap, _ := currVal.(map[string]interface{})
+		res := make(map[string]interface{}, len(val))
+
+		for key, child := range val {
+			var childRegenerated []string
+
+			res[key], childRegenerated = keepRegeneratedValues(currMap[key], child, path+"."+key)
+			regenerated = append(regenerated, childRegenerated...)
+		}
+
+		return res, regenerated
+	case []interface{}:
+		currSlice, _ := currVal.([]interface{})
+		res := make([]interface{}, len(val))
+
+		for i, child := range val {
+			var currChild interface{}
+
+			if i < len(currSlice) {
+				currChild = currSlice[i]
+			}
+
+			var childRegenerated []string
+
+			res[i], childRegenerated = keepRegeneratedValues(currChild, child, fmt.Sprintf("%s[%d]", path, i))
+			regenerated = append(regenerated, childRegenerated...)
+		}
+
+		return res, regenerated
+	case string:
+		if !isRegeneratedValue(val) {
+			return val, nil
+		}
+
+		if currVal != nil {
+			return currVal, []string{path}
+		}
+
+		return val, []string{path}
+	}
+
+	return newVal, nil
+}
+
+// isRegeneratedValue returns true if the string contains a regenerated random value, either
+// directly or base64 encoded as in the data of a Secret
+func isRegeneratedValue(val string) bool {
+	if strings.Contains(val, DryRunRegeneratedValue) {
+		return true
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(val)
+
+	return err == nil && strings.Contains(string(decoded), DryRunRegeneratedValue)
+}
+
+// maskSecretData replaces the values of a Secret with a hash, so that changed values can
+// be detected without printing them
+func maskSecretData(obj map[string]interface{}) {
+	for _, field := range []string{"data", "stringData"} {
+		data, ok := obj[field].(map[string]interface{})
+
+		if !ok {
+			continue
+		}
+
+		for key, val := range data {
+			sum := sha256.Sum256([]byte(fmt.Sprintf("%v", val)))
+			data[key] = fmt.Sprintf("(sensitive value, sha256:%x)", sum[:8])
+		}
+	}
+}
+
+func unifiedDiff(a, b, name string) (string, error) {
+	if a == b {
+		return "", nil
+	}
+
+	fromFile, toFile := "current/"+name, "new/"+name
+
+	if a == "" {
+		fromFile = "/dev/null"
+	}
+
+	if b == "" {
+		toFile = "/dev/null"
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(a),
+		B:        difflib.SplitLines(b),
+		FromFile: fromFile,
+		ToFile:   toFile,
+		Context:  3,
+	})
+}
+
+func printUnifiedDiff(w io.Writer, diff string) {
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"):
+			color.New(color.Bold).Fprint(w, line)
+		case strings.HasPrefix(line, "+"):
+			color.New(color.FgGreen).Fprint(w, line)
+		case strings.HasPrefix(line, "-"):
+			color.New(color.FgRed).Fprint(w, line)
+		case strings.HasPrefix(line, "@@"):
+			color.New(color.FgCyan).Fprint(w, line)
+		default:
+			fmt.Fprint(w, line)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package preview
+
+import (
+	"encoding/base64"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+const diffCurrentManifest = `apiVersion: v1
+kind: Secret
+metadata:
+  name: web-env
+data:
+  TOKEN: Y3VycmVudA==
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 1
+`
+
+func TestNewReleaseDiff(t *testing.T) {
+	diff, err := NewReleaseDiff("default", "web", &types.DryRunReleaseResponse{
+		Exists:          true,
+		CurrentValues:   map[string]interface{}{"replicas": 1},
+		CurrentManifest: diffCurrentManifest,
+		Values:          map[string]interface{}{"replicas": 2},
+		Manifest:        strings.Replace(diffCurrentManifest, "replicas: 1", "replicas: 2", 1),
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !diff.HasDrift() {
+		t.Errorf("expected the diff to have drift")
+	}
+
+	if !strings.Contains(diff.ValuesDiff, "-replicas: 1") || !strings.Contains(diff.ValuesDiff, "+replicas: 2") {
+		t.Errorf("expected the values diff to contain the changed replicas, got:\n%s", diff.ValuesDiff)
+	}
+
+	if _, ok := diff.ObjectDiffs["Deployment/web"]; !ok || len(diff.ObjectDiffs) != 1 {
+		t.Errorf("expected only Deployment/web to be changed, got %v", diff.ObjectDiffs)
+	}
+}
+
+func TestNewReleaseDiffWithoutChanges(t *testing.T) {
+	diff, err := NewReleaseDiff("default", "web", &types.DryRunReleaseResponse{
+		Exists:          true,
+		CurrentValues:   map[string]interface{}{"replicas": 1},
+		CurrentManifest: diffCurrentManifest,
+		Values:          map[string]interface{}{"replicas": 1},
+		Manifest:        diffCurrentManifest,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if diff.HasDrift() {
+		t.Errorf("expected no drift, got values diff %q and object diffs %v", diff.ValuesDiff, diff.ObjectDiffs)
+	}
+}
+
+func TestNewReleaseDiffMasksSecrets(t *testing.T) {
+	diff, err := NewReleaseDiff("default", "web", &types.DryRunReleaseResponse{
+		Exists:          true,
+		CurrentManifest: diffCurrentManifest,
+		Manifest:        strings.Replace(diffCurrentManifest, "Y3VycmVudA==", base64.StdEncoding.EncodeToString([]byte("changed")), 1),
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	secretDiff, ok := diff.ObjectDiffs["Secret/web-env"]
+
+	if !ok {
+		t.Fatalf("expected the changed secret to be in the diff, got %v", diff.ObjectDiffs)
+	}
+
+	if strings.Contains(secretDiff, "Y3VycmVudA==") || !strings.Contains(secretDiff, "sensitive value") {
+		t.Errorf("expected the secret values to be masked, got:\n%s", secretDiff)
+	}
+}
+
+func TestNewReleaseDiffIgnoresRegeneratedValues(t *testing.T) {
+	regenerated := base64.StdEncoding.EncodeToString([]byte(DryRunRegeneratedValue))
+
+	diff, err := NewReleaseDiff("default", "web", &types.DryRunReleaseResponse{
+		Exists: true,
+		CurrentValues: map[string]interface{}{
+			"env": map[string]interface{}{"TOKEN": "current", "NAME": "web"},
+		},
+		CurrentManifest: diffCurrentManifest,
+		Values: map[string]interface{}{
+			"env": map[string]interface{}{
+				"TOKEN": DryRunRegeneratedValue,
+				"NAME":  "web",
+				"URL":   "postgres://app:" + DryRunRegeneratedValue + "@db",
+			},
+		},
+		Manifest: strings.Replace(diffCurrentManifest, "Y3VycmVudA==", regenerated, 1),
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := diff.ObjectDiffs["Secret/web-env"]; ok {
+		t.Errorf("expected the regenerated secret value to be left out of the diff, got %v", diff.ObjectDiffs)
+	}
+
+	// the new URL value has no current value to keep, so it is still shown as added
+	if strings.Contains(diff.ValuesDiff, "+  TOKEN") || !strings.Contains(diff.ValuesDiff, "+  URL") {
+		t.Errorf("expected only the new URL value in the values diff, got:\n%s", diff.ValuesDiff)
+	}
+
+	expected := []string{"Secret/web-env.data.TOKEN", "values.env.TOKEN", "values.env.URL"}
+
+	if !reflect.DeepEqual(diff.Regenerated, expected) {
+		t.Errorf("expected regenerated values %v, got %v", expected, diff.Regenerated)
+	}
+}
+
+func TestNewReleaseDiffForNewRelease(t *testing.T) {
+	diff, err := NewReleaseDiff("default", "web", &types.DryRunReleaseResponse{
+		Exists:   false,
+		Values:   map[string]interface{}{"replicas": 1},
+		Manifest: diffCurrentManifest,
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !diff.HasDrift() {
+		t.Errorf("expected a new release to have drift")
+	}
+
+	for id, objDiff := range diff.ObjectDiffs {
+		if !strings.Contains(objDiff, "--- /dev/null") {
+			t.Errorf("%s: expected the object to be added, got:\n%s", id, objDiff)
+		}
+	}
+}
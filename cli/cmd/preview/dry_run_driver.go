@@ -0,0 +1,107 @@
+package preview
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/cli/cli/git"
+	"github.com/fatih/color"
+	"github.com/porter-dev/switchboard/pkg/drivers"
+	"github.com/porter-dev/switchboard/pkg/models"
+)
+
+// DryRunImageRepo is the image repository output by DryRunImageDriver, since the repository
+// of an image is only known once it has been built and pushed
+const DryRunImageRepo = "porter-dry-run"
+
+// DryRunImageDriver stands in for the build-image and push-image drivers during a dry run.
+// It does not build or push anything, and outputs the image that would be deployed.
+type DryRunImageDriver struct {
+	output map[string]interface{}
+}
+
+func NewDryRunImageDriver(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	return &DryRunImageDriver{
+		output: make(map[string]interface{}),
+	}, nil
+}
+
+func (d *DryRunImageDriver) ShouldApply(resource *models.Resource) bool {
+	return true
+}
+
+func (d *DryRunImageDriver) Apply(resource *models.Resource) (*models.Resource, error) {
+	tag := GetDryRunImageTag()
+
+	d.output["registry_url"] = ""
+	d.output["image_repo"] = DryRunImageRepo
+	d.output["image_tag"] = tag
+	d.output["image"] = fmt.Sprintf("%s:%s", DryRunImageRepo, tag)
+
+	return resource, nil
+}
+
+func (d *DryRunImageDriver) Output() (map[string]interface{}, error) {
+	return d.output, nil
+}
+
+// DryRunRandomStringDriver stands in for the random-string driver during a dry run. It outputs
+// DryRunRegeneratedValue, so that the diff does not report the random value as changed.
+type DryRunRandomStringDriver struct {
+	output map[string]interface{}
+}
+
+func NewDryRunRandomStringDriver(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	return &DryRunRandomStringDriver{
+		output: make(map[string]interface{}),
+	}, nil
+}
+
+func (d *DryRunRandomStringDriver) ShouldApply(resource *models.Resource) bool {
+	return true
+}
+
+func (d *DryRunRandomStringDriver) Apply(resource *models.Resource) (*models.Resource, error) {
+	d.output["value"] = DryRunRegeneratedValue
+
+	return resource, nil
+}
+
+func (d *DryRunRandomStringDriver) Output() (map[string]interface{}, error) {
+	return d.output, nil
+}
+
+// SkipDriver stands in for drivers which cannot be run during a dry run
+type SkipDriver struct{}
+
+func NewSkipDriver(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	return &SkipDriver{}, nil
+}
+
+func (d *SkipDriver) ShouldApply(resource *models.Resource) bool {
+	return true
+}
+
+func (d *SkipDriver) Apply(resource *models.Resource) (*models.Resource, error) {
+	color.New(color.FgYellow).Printf("Skipping resource %s during dry run: changes to it are not shown\n", resource.Name)
+
+	return resource, nil
+}
+
+func (d *SkipDriver) Output() (map[string]interface{}, error) {
+	return make(map[string]interface{}), nil
+}
+
+// GetDryRunImageTag returns the image tag that an apply would build, which is read from
+// PORTER_TAG and falls back to the git SHA of the current directory
+func GetDryRunImageTag() string {
+	if tag := os.Getenv("PORTER_TAG"); tag != "" {
+		return tag
+	}
+
+	if commit, err := git.LastCommit(); err == nil {
+		return commit.Sha[:7]
+	}
+
+	return "latest"
+}
@@ -18,6 +18,9 @@ type EnvGroupDriver struct {
 	lookupTable *map[string]drivers.Driver
 	target      *preview.Target
 	config      *preview.EnvGroupDriverConfig
+
+	// if set, env groups which do not exist are not created
+	dryRun bool
 }
 
 func NewEnvGroupDriver(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
@@ -37,6 +40,20 @@ func NewEnvGroupDriver(resource *models.Resource, opts *drivers.SharedDriverOpts
 	return driver, nil
 }
 
+// NewEnvGroupDryRunDriver creates an env group driver which reads existing env groups but
+// does not create the ones which do not exist yet
+func NewEnvGroupDryRunDriver(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	driver, err := NewEnvGroupDriver(resource, opts)
+
+	if err != nil {
+		return nil, err
+	}
+
+	driver.(*EnvGroupDriver).dryRun = true
+
+	return driver, nil
+}
+
 func (d *EnvGroupDriver) ShouldApply(resource *models.Resource) bool {
 	return true
 }
@@ -74,7 +91,16 @@ func (d *EnvGroupDriver) Apply(resource *models.Resource) (*models.Resource, err
 			},
 		)
 
-		if err != nil && err.Error() == "env group not found" {
+		if err != nil && err.Error() == "env group not found" && d.dryRun {
+			color.New(color.FgYellow).Printf("env group %s/%s would be created\n", group.Namespace, group.Name)
+
+			envGroupResp = &types.GetEnvGroupResponse{
+				EnvGroup: &types.EnvGroup{
+					Name:      group.Name,
+					Variables: group.Variables,
+				},
+			}
+		} else if err != nil && err.Error() == "env group not found" {
 			newEnvGroup, err := client.CreateEnvGroup(
 				context.Background(), d.target.Project, d.target.Cluster, group.Namespace,
 				&types.CreateEnvGroupRequest{
@@ -3,18 +3,23 @@ package v2beta1
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	api "github.com/porter-dev/porter/api/client"
+	apiTypes "github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/porter-dev/porter/cli/cmd/preview"
 	"github.com/porter-dev/switchboard/pkg/types"
 	"gopkg.in/yaml.v3"
 )
 
-// const (
-// 	constantsEnvGroup = "preview-env-constants"
+const (
+	// constantsEnvGroup is the env group in the preview namespace which persists the
+	// values of variables marked with once: true across re-applies
+	constantsEnvGroup = "preview-env-constants"
 
-// 	defaultCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789~`!@#$%^&*()_+-={}[]"
-// )
+	defaultCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
 
 type PreviewApplier struct {
 	apiClient *api.Client
@@ -22,9 +27,11 @@ type PreviewApplier struct {
 	namespace string
 	parsed    *PorterYAML
 
-	// variablesMap map[string]string
-	// osEnv        map[string]string
-	// envGroups    map[string]*apiTypes.EnvGroup
+	variablesMap map[string]string
+	envGroups    map[string]*apiTypes.EnvGroup
+
+	// dryRun prevents any changes to the namespace, constants and env groups
+	dryRun bool
 }
 
 func NewApplier(client *api.Client, raw []byte, namespace string) (*PreviewApplier, error) {
@@ -50,11 +57,17 @@ func NewApplier(client *api.Client, raw []byte, namespace string) (*PreviewAppli
 		return nil, fmt.Errorf("%s: %w", errMsg, err)
 	}
 
+	if namespace == "" {
+		namespace = "default"
+	}
+
 	return &PreviewApplier{
-		apiClient: client,
-		rawBytes:  raw,
-		namespace: namespace,
-		parsed:    parsed,
+		apiClient:    client,
+		rawBytes:     raw,
+		namespace:    namespace,
+		parsed:       parsed,
+		variablesMap: make(map[string]string),
+		envGroups:    make(map[string]*apiTypes.EnvGroup),
 	}, nil
 }
 
@@ -78,6 +91,12 @@ func validateCLIEnvironment(namespace string) error {
 	return nil
 }
 
+// SetDryRun sets whether the applier should only read the state of the namespace. If set, the
+// namespace, constants and env groups which would be created are resolved but not stored.
+func (a *PreviewApplier) SetDryRun(dryRun bool) {
+	a.dryRun = dryRun
+}
+
 func (a *PreviewApplier) Apply() error {
 	// for v2beta1, check if the namespace exists in the current project-cluster pair
 	//
@@ -105,10 +124,24 @@ func (a *PreviewApplier) Apply() error {
 		}
 	}
 
-	if !nsFound {
-		// 	errMsg := composePreviewMessage(fmt.Sprintf("namespace '%s' does not exist in project '%d', cluster '%d'",
-		// 		a.namespace, config.GetCLIConfig().Project, config.GetCLIConfig().Cluster), Error)
-		// 	return fmt.Errorf("%s: %w", errMsg, err)
+	if !nsFound && !a.dryRun && (len(a.parsed.Variables) > 0 || len(a.parsed.EnvGroups) > 0) {
+		// variables and env groups are stored in the namespace before any resource is applied,
+		// so the namespace needs to exist at this point
+		printInfoMessage(fmt.Sprintf("Creating namespace '%s'", a.namespace))
+
+		_, err := a.apiClient.CreateNewK8sNamespace(
+			context.Background(),
+			config.GetCLIConfig().Project,
+			config.GetCLIConfig().Cluster,
+			&apiTypes.CreateNamespaceRequest{
+				Name: a.namespace,
+			},
+		)
+
+		if err != nil && !strings.Contains(err.Error(), "namespace already exists") {
+			errMsg := composePreviewMessage(fmt.Sprintf("error creating namespace '%s'", a.namespace), Error)
+			return fmt.Errorf("%s: %w", errMsg, err)
+		}
 	}
 
 	printInfoMessage(fmt.Sprintf("Applying porter.yaml with the following attributes:\n"+
@@ -119,24 +152,23 @@ func (a *PreviewApplier) Apply() error {
 		a.namespace),
 	)
 
-	// err = a.readOSEnv()
+	err = a.processVariables()
 
-	// if err != nil {
-	// 	errMsg := composePreviewMessage("error reading OS environment variables", Error)
-	// 	return fmt.Errorf("%s: %w", errMsg, err)
-	// }
+	if err != nil {
+		return err
+	}
 
-	// err = a.processVariables()
+	err = a.processEnvGroups()
 
-	// if err != nil {
-	// 	return err
-	// }
+	if err != nil {
+		return err
+	}
 
-	// err = a.processEnvGroups()
+	err = a.resolveReferences()
 
-	// if err != nil {
-	// 	return err
-	// }
+	if err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -196,11 +228,15 @@ func (a *PreviewApplier) DowngradeToV1() (*types.ResourceGroup, error) {
 		v1File.Resources = append(v1File.Resources, ai)
 	}
 
+	addonRefs := make(map[string]*AddonResource)
+
 	for _, addon := range a.parsed.Addons {
 		if addon == nil {
 			continue
 		}
 
+		addonRefs[addon.GetName()] = addon
+
 		ai, err := addon.getV1Addon()
 
 		if err != nil {
@@ -210,205 +246,248 @@ func (a *PreviewApplier) DowngradeToV1() (*types.ResourceGroup, error) {
 		v1File.Resources = append(v1File.Resources, ai)
 	}
 
+	if seed := a.parsed.Seed; seed != nil {
+		addon, ok := addonRefs[seed.GetAddon()]
+
+		if !ok {
+			return nil, fmt.Errorf("%s", composePreviewMessage(fmt.Sprintf("addon '%s' referenced by seed '%s' "+
+				"does not exist", seed.GetAddon(), seed.GetName()), Error))
+		}
+
+		si, err := seed.getV1Resources(addon, buildRefs)
+
+		if err != nil {
+			return nil, err
+		}
+
+		v1File.Resources = append(v1File.Resources, si...)
+	}
+
 	return v1File, nil
 }
 
-// func (a *PreviewApplier) readOSEnv() error {
-// 	printInfoMessage("Reading OS environment variables")
-
-// 	env := os.Environ()
-// 	osEnv := make(map[string]string)
-
-// 	for _, e := range env {
-// 		k, v, _ := strings.Cut(e, "=")
-// 		kCopy := k
-
-// 		if k != "" && v != "" && strings.HasPrefix(k, "PORTER_APPLY_") {
-// 			// we only read in env variables that start with PORTER_APPLY_
-// 			for strings.HasPrefix(k, "PORTER_APPLY_") {
-// 				k = strings.TrimPrefix(k, "PORTER_APPLY_")
-// 			}
-
-// 			if k == "" {
-// 				printWarningMessage(fmt.Sprintf("Ignoring invalid OS environment variable '%s'", kCopy))
-// 			}
-
-// 			osEnv[k] = v
-// 		}
-// 	}
-
-// 	a.osEnv = osEnv
-
-// 	return nil
-// }
-
-// func (a *PreviewApplier) processVariables() error {
-// 	printInfoMessage("Processing variables")
-
-// 	constantsMap := make(map[string]string)
-// 	variablesMap := make(map[string]string)
-
-// 	for _, v := range a.parsed.Variables {
-// 		if v == nil {
-// 			continue
-// 		}
-
-// 		if v.Once != nil && *v.Once {
-// 			// a constant which should be stored in the env group on first run
-// 			if exists, err := a.constantExistsInEnvGroup(*v.Name); err == nil {
-// 				if exists == nil {
-// 					// this should not happen
-// 					return fmt.Errorf("internal error: please let the Porter team know about this and quote the following " +
-// 						"error:\n-----\nERROR: checking for constant existence in env group returned nil with no error")
-// 				}
-
-// 				val := *exists
-
-// 				if !val {
-// 					// create the constant in the env group
-// 					if *v.Value != "" {
-// 						constantsMap[*v.Name] = *v.Value
-// 					} else if v.Random != nil && *v.Random {
-// 						constantsMap[*v.Name] = randomString(*v.Length, defaultCharset)
-// 					} else {
-// 						// this should not happen
-// 						return fmt.Errorf("internal error: please let the Porter team know about this and quote the following "+
-// 							"error:\n-----\nERROR: for variable '%s', random is false and value is empty", *v.Name)
-// 					}
-// 				}
-// 			} else {
-// 				return fmt.Errorf("error checking for existence of constant %s: %w", *v.Name, err)
-// 			}
-// 		} else {
-// 			if v.Value != nil && *v.Value != "" {
-// 				variablesMap[*v.Name] = *v.Value
-// 			} else if v.Random != nil && *v.Random {
-// 				variablesMap[*v.Name] = randomString(*v.Length, defaultCharset)
-// 			} else {
-// 				// this should not happen
-// 				return fmt.Errorf("internal error: please let the Porter team know about this and quote the following "+
-// 					"error:\n-----\nERROR: for variable '%s', random is false and value is empty", *v.Name)
-// 			}
-// 		}
-// 	}
-
-// 	if len(constantsMap) > 0 {
-// 		// we need to create these constants in the env group
-// 		_, err := a.apiClient.CreateEnvGroup(
-// 			context.Background(),
-// 			config.GetCLIConfig().Project,
-// 			config.GetCLIConfig().Cluster,
-// 			a.namespace,
-// 			&apiTypes.CreateEnvGroupRequest{
-// 				Name:      constantsEnvGroup,
-// 				Variables: constantsMap,
-// 			},
-// 		)
-
-// 		if err != nil {
-// 			return fmt.Errorf("error creating constants (variables with once set to true) in env group: %w", err)
-// 		}
-
-// 		for k, v := range constantsMap {
-// 			variablesMap[k] = v
-// 		}
-// 	}
-
-// 	a.variablesMap = variablesMap
-
-// 	return nil
-// }
-
-// func (a *PreviewApplier) constantExistsInEnvGroup(name string) (*bool, error) {
-// 	apiResponse, err := a.apiClient.GetEnvGroup(
-// 		context.Background(),
-// 		config.GetCLIConfig().Project,
-// 		config.GetCLIConfig().Cluster,
-// 		a.namespace,
-// 		&apiTypes.GetEnvGroupRequest{
-// 			Name: constantsEnvGroup,
-// 			// we do not care about the version because it always needs to be the latest
-// 		},
-// 	)
-
-// 	if err != nil {
-// 		if strings.Contains(err.Error(), "env group not found") {
-// 			return booleanptr(false), nil
-// 		}
-
-// 		return nil, err
-// 	}
-
-// 	if _, ok := apiResponse.Variables[name]; ok {
-// 		return booleanptr(true), nil
-// 	}
-
-// 	return booleanptr(false), nil
-// }
-
-// func (a *PreviewApplier) processEnvGroups() error {
-// 	printInfoMessage("Processing env groups")
-
-// 	for _, eg := range a.parsed.EnvGroups {
-// 		if eg == nil {
-// 			continue
-// 		}
-
-// 		if eg.Name == nil || *eg.Name == "" {
-
-// 		}
-
-// 		envGroup, err := a.apiClient.GetEnvGroup(
-// 			context.Background(),
-// 			config.GetCLIConfig().Project,
-// 			config.GetCLIConfig().Cluster,
-// 			a.namespace,
-// 			&apiTypes.GetEnvGroupRequest{
-// 				Name: *eg.Name,
-// 			},
-// 		)
-
-// 		if err != nil && strings.Contains(err.Error(), "env group not found") {
-// 			if eg.CloneFrom == nil {
-// 				return fmt.Errorf(composePreviewMessage(fmt.Sprintf("empty clone_from for env group '%s'", *eg.Name), Error))
-// 			}
-
-// 			egNS, egName, found := strings.Cut(*eg.CloneFrom, "/")
-
-// 			if !found {
-// 				return fmt.Errorf("error parsing clone_from for env group '%s': invalid format", *eg.Name)
-// 			}
-
-// 			// clone the env group
-// 			envGroup, err := a.apiClient.CloneEnvGroup(
-// 				context.Background(),
-// 				config.GetCLIConfig().Project,
-// 				config.GetCLIConfig().Cluster,
-// 				egNS,
-// 				&apiTypes.CloneEnvGroupRequest{
-// 					SourceName:      egName,
-// 					TargetNamespace: a.namespace,
-// 					TargetName:      *eg.Name,
-// 				},
-// 			)
-
-// 			if err != nil {
-// 				return fmt.Errorf("error cloning env group '%s' from '%s': %w", egName, egNS, err)
-// 			}
-
-// 			a.envGroups[*eg.Name] = &apiTypes.EnvGroup{
-// 				Name:      envGroup.Name,
-// 				Variables: envGroup.Variables,
-// 			}
-// 		} else if err != nil {
-// 			return fmt.Errorf("error checking for env group '%s': %w", *eg.Name, err)
-// 		} else {
-// 			a.envGroups[*eg.Name] = &apiTypes.EnvGroup{
-// 				Name:      envGroup.Name,
-// 				Variables: envGroup.Variables,
-// 			}
-// 		}
-// 	}
-
-// 	return nil
-// }
+func (a *PreviewApplier) processVariables() error {
+	if len(a.parsed.Variables) == 0 {
+		return nil
+	}
+
+	printInfoMessage("Processing variables")
+
+	var existingConstants map[string]string
+
+	constantsMap := make(map[string]string)
+	newConstants := false
+
+	for _, v := range a.parsed.Variables {
+		if v == nil {
+			continue
+		}
+
+		if v.GetName() == "" {
+			return fmt.Errorf("%s", composePreviewMessage("variable name cannot be empty", Error))
+		}
+
+		if _, ok := a.variablesMap[v.GetName()]; ok {
+			return fmt.Errorf("%s", composePreviewMessage(fmt.Sprintf("duplicate variable '%s'", v.GetName()), Error))
+		}
+
+		if v.GetValue() == "" && !v.GetRandom() {
+			return fmt.Errorf("%s", composePreviewMessage(fmt.Sprintf("variable '%s' must either have a value "+
+				"or set random to true", v.GetName()), Error))
+		}
+
+		if !v.GetOnce() && v.GetValue() == "" && a.dryRun {
+			// the value is regenerated on every apply, so the dry run diff leaves it out
+			a.variablesMap[v.GetName()] = preview.DryRunRegeneratedValue
+			continue
+		} else if !v.GetOnce() {
+			a.variablesMap[v.GetName()] = v.getNewValue()
+			continue
+		}
+
+		// a constant which should be stored in the constants env group on first run
+		if existingConstants == nil {
+			constants, err := a.getConstants()
+
+			if err != nil {
+				errMsg := composePreviewMessage("error fetching constants (variables with once set to true)", Error)
+				return fmt.Errorf("%s: %w", errMsg, err)
+			}
+
+			existingConstants = constants
+
+			for k, val := range existingConstants {
+				constantsMap[k] = val
+			}
+		}
+
+		if val, ok := existingConstants[v.GetName()]; ok {
+			a.variablesMap[v.GetName()] = val
+			continue
+		}
+
+		val := v.getNewValue()
+
+		constantsMap[v.GetName()] = val
+		a.variablesMap[v.GetName()] = val
+		newConstants = true
+	}
+
+	if newConstants && a.dryRun {
+		printInfoMessage(fmt.Sprintf("Constants would be stored in env group '%s'", constantsEnvGroup))
+	} else if newConstants {
+		// the env group is replaced on every write, so all previously stored constants
+		// need to be written alongside the new ones
+		_, err := a.apiClient.CreateEnvGroup(
+			context.Background(),
+			config.GetCLIConfig().Project,
+			config.GetCLIConfig().Cluster,
+			a.namespace,
+			&apiTypes.CreateEnvGroupRequest{
+				Name:      constantsEnvGroup,
+				Variables: constantsMap,
+			},
+		)
+
+		if err != nil {
+			errMsg := composePreviewMessage("error storing constants (variables with once set to true) in env group", Error)
+			return fmt.Errorf("%s: %w", errMsg, err)
+		}
+	}
+
+	return nil
+}
+
+func (v *Variable) getNewValue() string {
+	if v.GetValue() != "" {
+		return v.GetValue()
+	}
+
+	return randomString(v.GetLength(), defaultCharset)
+}
+
+// getConstants returns the previously stored values of variables with once set to true,
+// or an empty map if they have never been stored
+func (a *PreviewApplier) getConstants() (map[string]string, error) {
+	envGroup, err := a.apiClient.GetEnvGroup(
+		context.Background(),
+		config.GetCLIConfig().Project,
+		config.GetCLIConfig().Cluster,
+		a.namespace,
+		&apiTypes.GetEnvGroupRequest{
+			Name: constantsEnvGroup,
+			// we do not care about the version because it always needs to be the latest
+		},
+	)
+
+	if err != nil {
+		if strings.Contains(err.Error(), "env group not found") {
+			return make(map[string]string), nil
+		}
+
+		return nil, err
+	}
+
+	if envGroup.Variables == nil {
+		return make(map[string]string), nil
+	}
+
+	return envGroup.Variables, nil
+}
+
+func (a *PreviewApplier) processEnvGroups() error {
+	if len(a.parsed.EnvGroups) == 0 {
+		return nil
+	}
+
+	printInfoMessage("Processing env groups")
+
+	for _, eg := range a.parsed.EnvGroups {
+		if eg == nil {
+			continue
+		}
+
+		if eg.GetName() == "" {
+			return fmt.Errorf("%s", composePreviewMessage("env group name cannot be empty", Error))
+		}
+
+		if _, ok := a.envGroups[eg.GetName()]; ok {
+			return fmt.Errorf("%s", composePreviewMessage(fmt.Sprintf("duplicate env group '%s'", eg.GetName()), Error))
+		}
+
+		envGroup, err := a.apiClient.GetEnvGroup(
+			context.Background(),
+			config.GetCLIConfig().Project,
+			config.GetCLIConfig().Cluster,
+			a.namespace,
+			&apiTypes.GetEnvGroupRequest{
+				Name: eg.GetName(),
+			},
+		)
+
+		if err == nil {
+			// the env group has already been cloned into this namespace in a previous run
+			a.envGroups[eg.GetName()] = envGroup.EnvGroup
+			continue
+		} else if !strings.Contains(err.Error(), "env group not found") {
+			errMsg := composePreviewMessage(fmt.Sprintf("error checking for env group '%s'", eg.GetName()), Error)
+			return fmt.Errorf("%s: %w", errMsg, err)
+		}
+
+		egNS, egName, ok := eg.GetCloneFromParts()
+
+		if !ok {
+			return fmt.Errorf("%s", composePreviewMessage(fmt.Sprintf("invalid clone_from '%s' for env group '%s', "+
+				"expected format <namespace>/<name>", eg.GetCloneFrom(), eg.GetName()), Error))
+		}
+
+		if a.dryRun {
+			// read the source env group so that references to it can still be resolved
+			printInfoMessage(fmt.Sprintf("Env group '%s' would be cloned from namespace '%s' as '%s'",
+				egName, egNS, eg.GetName()))
+
+			source, err := a.apiClient.GetEnvGroup(
+				context.Background(),
+				config.GetCLIConfig().Project,
+				config.GetCLIConfig().Cluster,
+				egNS,
+				&apiTypes.GetEnvGroupRequest{
+					Name: egName,
+				},
+			)
+
+			if err != nil {
+				errMsg := composePreviewMessage(fmt.Sprintf("error reading env group '%s' from namespace '%s'",
+					egName, egNS), Error)
+				return fmt.Errorf("%s: %w", errMsg, err)
+			}
+
+			a.envGroups[eg.GetName()] = source.EnvGroup
+			continue
+		}
+
+		printInfoMessage(fmt.Sprintf("Cloning env group '%s' from namespace '%s' as '%s'", egName, egNS, eg.GetName()))
+
+		cloned, err := a.apiClient.CloneEnvGroup(
+			context.Background(),
+			config.GetCLIConfig().Project,
+			config.GetCLIConfig().Cluster,
+			egNS,
+			&apiTypes.CloneEnvGroupRequest{
+				SourceName:      egName,
+				TargetNamespace: a.namespace,
+				TargetName:      eg.GetName(),
+			},
+		)
+
+		if err != nil {
+			errMsg := composePreviewMessage(fmt.Sprintf("error cloning env group '%s' from namespace '%s'",
+				egName, egNS), Error)
+			return fmt.Errorf("%s: %w", errMsg, err)
+		}
+
+		a.envGroups[eg.GetName()] = cloned
+	}
+
+	return nil
+}
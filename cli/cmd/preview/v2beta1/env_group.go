@@ -0,0 +1,31 @@
+package v2beta1
+
+import "strings"
+
+func (e *EnvGroup) GetName() string {
+	if e == nil || e.Name == nil {
+		return ""
+	}
+
+	return *e.Name
+}
+
+func (e *EnvGroup) GetCloneFrom() string {
+	if e == nil || e.CloneFrom == nil {
+		return ""
+	}
+
+	return *e.CloneFrom
+}
+
+// GetCloneFromParts splits clone_from, which is of the form <namespace>/<name>,
+// into its namespace and name
+func (e *EnvGroup) GetCloneFromParts() (string, string, bool) {
+	ns, name, found := strings.Cut(e.GetCloneFrom(), "/")
+
+	if !found || ns == "" || name == "" {
+		return "", "", false
+	}
+
+	return ns, name, true
+}
@@ -0,0 +1,395 @@
+package v2beta1
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/porter-dev/porter/internal/integrations/preview"
+	"github.com/porter-dev/switchboard/pkg/drivers"
+	"github.com/porter-dev/switchboard/pkg/models"
+	"github.com/porter-dev/switchboard/pkg/types"
+	"github.com/porter-dev/switchboard/pkg/worker"
+)
+
+const DefaultConcurrency = 4
+
+type resourceStatus string
+
+const (
+	resourceStatusPending   resourceStatus = "pending"
+	resourceStatusSucceeded resourceStatus = "succeeded"
+	resourceStatusFailed    resourceStatus = "failed"
+	resourceStatusSkipped   resourceStatus = "skipped"
+)
+
+// Hook mirrors the switchboard worker hooks, which are run once for the whole
+// resource group instead of once per resource
+type Hook interface {
+	PreApply() error
+	DataQueries() map[string]interface{}
+	PostApply(populatedData map[string]interface{}) error
+	OnError(err error)
+	OnConsolidatedErrors(allErrors map[string]error)
+}
+
+type ParallelApplyOpts struct {
+	BasePath string
+
+	// Concurrency is the maximum number of resources that are applied at the same time
+	Concurrency int
+
+	// ContinueOnError keeps applying resources which do not depend on a failed resource,
+	// instead of stopping as soon as the first resource fails
+	ContinueOnError bool
+
+	// Drivers is a map from driver name to the driver constructor, as registered with a
+	// switchboard worker
+	Drivers       map[string]func(*models.Resource, *drivers.SharedDriverOpts) (drivers.Driver, error)
+	DefaultDriver string
+
+	Hooks []Hook
+}
+
+type resourceResult struct {
+	name     string
+	status   resourceStatus
+	duration time.Duration
+	err      error
+}
+
+// parallelApplier applies the resources of a v1 resource group as a DAG, running each resource
+// in its own switchboard worker as soon as all of its dependencies have been applied
+type parallelApplier struct {
+	opts      *ParallelApplyOpts
+	resources []*types.Resource
+	graph     map[string][]string
+
+	// the drivers and resources constructed by the switchboard workers, used to resolve
+	// references to the outputs of resources applied by other workers
+	mu          sync.Mutex
+	lookupTable map[string]drivers.Driver
+	applied     map[string]*models.Resource
+}
+
+// ApplyV1Parallel applies the given resource group, which is expected to be the output of
+// DowngradeToV1, applying independent resources concurrently
+func (a *PreviewApplier) ApplyV1Parallel(resGroup *types.ResourceGroup, opts *ParallelApplyOpts) error {
+	graph, err := preview.ResolveDependencies(resGroup.Resources)
+
+	if err != nil {
+		errMsg := composePreviewMessage("error resolving dependencies", Error)
+		return fmt.Errorf("%s: %w", errMsg, err)
+	}
+
+	if opts.Concurrency < 1 {
+		opts.Concurrency = 1
+	}
+
+	p := &parallelApplier{
+		opts:        opts,
+		resources:   resGroup.Resources,
+		graph:       graph,
+		lookupTable: make(map[string]drivers.Driver),
+		applied:     make(map[string]*models.Resource),
+	}
+
+	for _, hook := range opts.Hooks {
+		err := hook.PreApply()
+
+		if err != nil {
+			for _, h := range opts.Hooks {
+				h.OnError(err)
+			}
+
+			return err
+		}
+	}
+
+	printInfoMessage(fmt.Sprintf("Applying %d resources with a concurrency of %d", len(p.resources), opts.Concurrency))
+
+	results := p.run()
+
+	printSummary(p.resources, results)
+
+	allErrors := make(map[string]error)
+
+	for name, res := range results {
+		if res.status != resourceStatusSucceeded {
+			allErrors[name] = res.err
+		}
+	}
+
+	if len(allErrors) > 0 {
+		for _, hook := range opts.Hooks {
+			hook.OnConsolidatedErrors(allErrors)
+		}
+
+		var names []string
+
+		for name := range allErrors {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		return fmt.Errorf("%s", composePreviewMessage(fmt.Sprintf("error applying resources: %s",
+			strings.Join(names, ", ")), Error))
+	}
+
+	for _, hook := range opts.Hooks {
+		data, err := p.resolveDataQueries(hook.DataQueries())
+
+		if err == nil {
+			err = hook.PostApply(data)
+		}
+
+		if err != nil {
+			for _, h := range opts.Hooks {
+				h.OnError(err)
+			}
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *parallelApplier) run() map[string]*resourceResult {
+	results := make(map[string]*resourceResult)
+	remaining := make(map[string]int)
+	dependents := make(map[string][]string)
+	byName := make(map[string]*types.Resource)
+
+	var ready []string
+
+	for _, res := range p.resources {
+		byName[res.Name] = res
+		results[res.Name] = &resourceResult{name: res.Name, status: resourceStatusPending}
+		remaining[res.Name] = len(p.graph[res.Name])
+
+		for _, dep := range p.graph[res.Name] {
+			dependents[dep] = append(dependents[dep], res.Name)
+		}
+
+		if remaining[res.Name] == 0 {
+			ready = append(ready, res.Name)
+		}
+	}
+
+	done := make(chan *resourceResult)
+	inFlight := 0
+	stopped := false
+
+	for {
+		for len(ready) > 0 && inFlight < p.opts.Concurrency && !stopped {
+			name := ready[0]
+			ready = ready[1:]
+			inFlight++
+
+			go func(res *types.Resource) {
+				start := time.Now()
+				err := p.applyResource(res)
+				result := &resourceResult{name: res.Name, duration: time.Since(start), err: err}
+
+				if err != nil {
+					result.status = resourceStatusFailed
+				} else {
+					result.status = resourceStatusSucceeded
+				}
+
+				done <- result
+			}(byName[name])
+		}
+
+		if inFlight == 0 {
+			break
+		}
+
+		result := <-done
+		inFlight--
+		results[result.name] = result
+
+		if result.status == resourceStatusFailed {
+			printErrorMessage(fmt.Sprintf("Resource '%s' failed after %s", result.name, result.duration.Round(time.Second)))
+
+			if !p.opts.ContinueOnError {
+				stopped = true
+			}
+
+			continue
+		}
+
+		printSuccessMessage(fmt.Sprintf("Resource '%s' applied in %s", result.name, result.duration.Round(time.Second)))
+
+		for _, dependent := range dependents[result.name] {
+			remaining[dependent]--
+
+			if remaining[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	// anything still pending either depends on a failed resource or was never started
+	// because an earlier resource failed
+	for _, res := range p.resources {
+		result := results[res.Name]
+
+		if result.status != resourceStatusPending {
+			continue
+		}
+
+		result.status = resourceStatusSkipped
+
+		var failedDeps []string
+
+		for _, dep := range p.graph[res.Name] {
+			if results[dep].status != resourceStatusSucceeded {
+				failedDeps = append(failedDeps, dep)
+			}
+		}
+
+		if len(failedDeps) > 0 {
+			result.err = fmt.Errorf("skipped because dependencies did not succeed: %s", strings.Join(failedDeps, ", "))
+		} else {
+			result.err = fmt.Errorf("skipped because another resource failed")
+		}
+	}
+
+	return results
+}
+
+func (p *parallelApplier) applyResource(res *types.Resource) error {
+	config, err := p.resolveConfig(res)
+
+	if err != nil {
+		return fmt.Errorf("error resolving config: %w", err)
+	}
+
+	// the dependencies have already been applied by other workers, so the resource is
+	// applied on its own with all references to their outputs already resolved
+	single := &types.Resource{
+		Name:   res.Name,
+		Driver: res.Driver,
+		Source: res.Source,
+		Target: res.Target,
+		Config: config,
+	}
+
+	w := worker.NewWorker()
+
+	for name, constructor := range p.opts.Drivers {
+		w.RegisterDriver(name, p.recordDriver(constructor))
+	}
+
+	if p.opts.DefaultDriver != "" {
+		w.SetDefaultDriver(p.opts.DefaultDriver)
+	}
+
+	return w.Apply(&types.ResourceGroup{
+		Version:   "v1",
+		Resources: []*types.Resource{single},
+	}, &types.ApplyOpts{
+		BasePath: p.opts.BasePath,
+	})
+}
+
+// recordDriver wraps a driver constructor so that every constructed driver is added to the
+// shared lookup table
+func (p *parallelApplier) recordDriver(
+	constructor func(*models.Resource, *drivers.SharedDriverOpts) (drivers.Driver, error),
+) func(*models.Resource, *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	return func(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+		driver, err := constructor(resource, opts)
+
+		if err != nil {
+			return nil, err
+		}
+
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		p.lookupTable[resource.Name] = driver
+		p.applied[resource.Name] = resource
+
+		return driver, nil
+	}
+}
+
+func (p *parallelApplier) resolveConfig(res *types.Resource) (map[string]interface{}, error) {
+	if len(p.graph[res.Name]) == 0 {
+		return res.Config, nil
+	}
+
+	lookupTable, dependencies := p.snapshot(p.graph[res.Name])
+
+	return drivers.ConstructConfig(&drivers.ConstructConfigOpts{
+		RawConf:      res.Config,
+		LookupTable:  lookupTable,
+		Dependencies: dependencies,
+	})
+}
+
+func (p *parallelApplier) resolveDataQueries(queries map[string]interface{}) (map[string]interface{}, error) {
+	if len(queries) == 0 {
+		return queries, nil
+	}
+
+	var names []string
+
+	for _, res := range p.resources {
+		names = append(names, res.Name)
+	}
+
+	lookupTable, dependencies := p.snapshot(names)
+
+	return drivers.ConstructConfig(&drivers.ConstructConfigOpts{
+		RawConf:      queries,
+		LookupTable:  lookupTable,
+		Dependencies: dependencies,
+	})
+}
+
+func (p *parallelApplier) snapshot(names []string) (map[string]drivers.Driver, []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	lookupTable := make(map[string]drivers.Driver)
+
+	for name, driver := range p.lookupTable {
+		lookupTable[name] = driver
+	}
+
+	var dependencies []string
+
+	for _, name := range names {
+		if _, ok := p.applied[name]; ok {
+			dependencies = append(dependencies, name)
+		}
+	}
+
+	return lookupTable, dependencies
+}
+
+func printSummary(resources []*types.Resource, results map[string]*resourceResult) {
+	printInfoMessage("Summary of applied resources:")
+
+	for _, res := range resources {
+		result := results[res.Name]
+
+		switch result.status {
+		case resourceStatusSucceeded:
+			color.New(color.FgGreen).Printf("  %-9s %s (%s)\n", result.status, res.Name, result.duration.Round(time.Second))
+		case resourceStatusFailed:
+			color.New(color.FgRed).Printf("  %-9s %s (%s): %s\n", result.status, res.Name,
+				result.duration.Round(time.Second), result.err.Error())
+		default:
+			color.New(color.FgYellow).Printf("  %-9s %s: %s\n", result.status, res.Name, result.err.Error())
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package v2beta1
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/switchboard/pkg/drivers"
+	"github.com/porter-dev/switchboard/pkg/models"
+	"github.com/porter-dev/switchboard/pkg/types"
+)
+
+// fakeDriver applies a resource by recording it, and fails if its config sets "fail"
+type fakeDriver struct {
+	recorder *applyRecorder
+	config   map[string]interface{}
+}
+
+func (d *fakeDriver) ShouldApply(resource *models.Resource) bool {
+	return true
+}
+
+func (d *fakeDriver) Apply(resource *models.Resource) (*models.Resource, error) {
+	d.config = resource.Config
+
+	if wait, ok := resource.Config["wait"].(chan struct{}); ok {
+		d.recorder.started <- resource.Name
+
+		select {
+		case <-wait:
+		case <-time.After(5 * time.Second):
+			return nil, fmt.Errorf("timed out waiting for other resources")
+		}
+	}
+
+	d.recorder.record(resource.Name)
+
+	if fail, _ := resource.Config["fail"].(bool); fail {
+		return nil, fmt.Errorf("resource %s failed", resource.Name)
+	}
+
+	return resource, nil
+}
+
+func (d *fakeDriver) Output() (map[string]interface{}, error) {
+	return d.config, nil
+}
+
+type applyRecorder struct {
+	mu      sync.Mutex
+	applied []string
+	configs map[string]map[string]interface{}
+	started chan string
+}
+
+func newApplyRecorder() *applyRecorder {
+	return &applyRecorder{
+		configs: make(map[string]map[string]interface{}),
+		started: make(chan string, 10),
+	}
+}
+
+func (r *applyRecorder) record(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.applied = append(r.applied, name)
+}
+
+func (r *applyRecorder) getApplied() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string{}, r.applied...)
+}
+
+func (r *applyRecorder) driver(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	return &fakeDriver{recorder: r}, nil
+}
+
+func applyParallel(t *testing.T, recorder *applyRecorder, resources []*types.Resource, concurrency int, continueOnError bool) error {
+	t.Helper()
+
+	applier := &PreviewApplier{}
+
+	return applier.ApplyV1Parallel(&types.ResourceGroup{
+		Version:   "v1",
+		Resources: resources,
+	}, &ParallelApplyOpts{
+		Concurrency:     concurrency,
+		ContinueOnError: continueOnError,
+		Drivers: map[string]func(*models.Resource, *drivers.SharedDriverOpts) (drivers.Driver, error){
+			"fake": recorder.driver,
+		},
+		DefaultDriver: "fake",
+	})
+}
+
+func indexOf(names []string, name string) int {
+	for i, n := range names {
+		if n == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func TestApplyV1ParallelRunsIndependentResourcesConcurrently(t *testing.T) {
+	recorder := newApplyRecorder()
+	wait := make(chan struct{})
+
+	// both resources block until the other one has started, which only happens if they are
+	// applied at the same time
+	go func() {
+		<-recorder.started
+		<-recorder.started
+		close(wait)
+	}()
+
+	err := applyParallel(t, recorder, []*types.Resource{
+		{Name: "web", Config: map[string]interface{}{"wait": wait}},
+		{Name: "worker", Config: map[string]interface{}{"wait": wait}},
+	}, 2, false)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if applied := recorder.getApplied(); len(applied) != 2 {
+		t.Errorf("expected 2 applied resources, got %v", applied)
+	}
+}
+
+func TestApplyV1ParallelAppliesDependenciesFirst(t *testing.T) {
+	recorder := newApplyRecorder()
+
+	err := applyParallel(t, recorder, []*types.Resource{
+		{Name: "web", DependsOn: []string{"postgres"}, Config: map[string]interface{}{"host": "{ .postgres.host }"}},
+		{Name: "postgres", Config: map[string]interface{}{"host": "postgres.default.svc"}},
+		{Name: "migrate", DependsOn: []string{"web"}},
+	}, 4, false)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	applied := recorder.getApplied()
+
+	if indexOf(applied, "postgres") > indexOf(applied, "web") || indexOf(applied, "web") > indexOf(applied, "migrate") {
+		t.Errorf("expected resources to be applied after their dependencies, got %v", applied)
+	}
+}
+
+func TestApplyV1ParallelResolvesReferencesToDependencies(t *testing.T) {
+	recorder := newApplyRecorder()
+
+	var webConfig map[string]interface{}
+
+	err := (&PreviewApplier{}).ApplyV1Parallel(&types.ResourceGroup{
+		Version: "v1",
+		Resources: []*types.Resource{
+			{Name: "postgres", Config: map[string]interface{}{"host": "postgres.default.svc"}},
+			{Name: "web", DependsOn: []string{"postgres"}, Config: map[string]interface{}{"host": "{ .postgres.host }"}},
+		},
+	}, &ParallelApplyOpts{
+		Concurrency: 2,
+		Drivers: map[string]func(*models.Resource, *drivers.SharedDriverOpts) (drivers.Driver, error){
+			"fake": func(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+				if resource.Name == "web" {
+					webConfig = resource.Config
+				}
+
+				return recorder.driver(resource, opts)
+			},
+		},
+		DefaultDriver: "fake",
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if webConfig["host"] != "postgres.default.svc" {
+		t.Errorf("expected the reference to be resolved, got %v", webConfig["host"])
+	}
+}
+
+func TestApplyV1ParallelSkipsDependentsOfFailedResources(t *testing.T) {
+	recorder := newApplyRecorder()
+
+	err := applyParallel(t, recorder, []*types.Resource{
+		{Name: "postgres", Config: map[string]interface{}{"fail": true}},
+		{Name: "web", DependsOn: []string{"postgres"}},
+		{Name: "redis"},
+	}, 1, true)
+
+	if err == nil {
+		t.Fatalf("expected an error when a resource fails")
+	}
+
+	if !strings.Contains(err.Error(), "postgres, web") || strings.Contains(err.Error(), "redis") {
+		t.Errorf("expected the failed and skipped resources in the error, got %v", err)
+	}
+
+	applied := recorder.getApplied()
+
+	if indexOf(applied, "web") != -1 {
+		t.Errorf("expected the dependent of a failed resource to be skipped, got %v", applied)
+	}
+
+	if indexOf(applied, "redis") == -1 {
+		t.Errorf("expected independent resources to be applied with ContinueOnError, got %v", applied)
+	}
+}
+
+func TestApplyV1ParallelStopsOnFirstError(t *testing.T) {
+	recorder := newApplyRecorder()
+
+	err := applyParallel(t, recorder, []*types.Resource{
+		{Name: "postgres", Config: map[string]interface{}{"fail": true}},
+		{Name: "redis"},
+	}, 1, false)
+
+	if err == nil || !strings.Contains(err.Error(), "postgres, redis") {
+		t.Fatalf("expected the failed and skipped resources in the error, got %v", err)
+	}
+
+	if applied := recorder.getApplied(); indexOf(applied, "redis") != -1 {
+		t.Errorf("expected no resources to be started after a failure, got %v", applied)
+	}
+}
+
+func TestApplyV1ParallelRejectsCycles(t *testing.T) {
+	recorder := newApplyRecorder()
+
+	err := applyParallel(t, recorder, []*types.Resource{
+		{Name: "web", DependsOn: []string{"worker"}},
+		{Name: "worker", DependsOn: []string{"web"}},
+	}, 2, false)
+
+	if err == nil || !strings.Contains(err.Error(), "circular") {
+		t.Fatalf("expected a circular dependency error, got %v", err)
+	}
+
+	if applied := recorder.getApplied(); len(applied) != 0 {
+		t.Errorf("expected no resources to be applied, got %v", applied)
+	}
+}
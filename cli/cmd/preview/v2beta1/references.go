@@ -0,0 +1,203 @@
+package v2beta1
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// referenceRegex matches references to declared variables and env groups, which
+// take the form { .variables.<name> } or { .env_groups.<name>.<key> }
+var referenceRegex = regexp.MustCompile(`\{\s*\.(variables|env_groups)\.([^\s{}]+)\s*\}`)
+
+// resolveReferences replaces all references to variables and env groups in the
+// helm_values of apps and addons, in the env of builds and in the database of the seed
+// with their values
+func (a *PreviewApplier) resolveReferences() error {
+	var errs []string
+
+	for _, b := range a.parsed.Builds {
+		if b == nil || b.Env == nil {
+			continue
+		}
+
+		for k, v := range b.Env.Raw {
+			if k == nil || v == nil {
+				continue
+			}
+
+			resolved, err := a.resolveString(*v)
+
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("build '%s', env '%s': %s", b.GetName(), *k, err.Error()))
+				continue
+			}
+
+			b.Env.Raw[k] = stringptr(resolved)
+		}
+	}
+
+	for _, app := range a.parsed.Apps {
+		if app == nil {
+			continue
+		}
+
+		resolved, err := a.resolveValue(app.HelmValues)
+
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("app '%s', helm_values: %s", app.GetName(), err.Error()))
+			continue
+		}
+
+		if app.HelmValues != nil {
+			app.HelmValues = resolved.(map[string]any)
+		}
+	}
+
+	for _, addon := range a.parsed.Addons {
+		if addon == nil {
+			continue
+		}
+
+		resolved, err := a.resolveValue(addon.HelmValues)
+
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("addon '%s', helm_values: %s", addon.GetName(), err.Error()))
+			continue
+		}
+
+		if addon.HelmValues != nil {
+			addon.HelmValues = resolved.(map[string]any)
+		}
+	}
+
+	if seed := a.parsed.Seed; seed != nil && seed.Database != nil {
+		for key, v := range map[string]*string{
+			"host":     seed.Database.Host,
+			"port":     seed.Database.Port,
+			"user":     seed.Database.User,
+			"password": seed.Database.Password,
+			"name":     seed.Database.Name,
+		} {
+			if v == nil {
+				continue
+			}
+
+			resolved, err := a.resolveString(*v)
+
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("seed '%s', database %s: %s", seed.GetName(), key, err.Error()))
+				continue
+			}
+
+			*v = resolved
+		}
+	}
+
+	if len(errs) > 0 {
+		errMsg := composePreviewMessage("error resolving references to variables and env groups", Error)
+		return fmt.Errorf("%s:\n- %s", errMsg, strings.Join(errs, "\n- "))
+	}
+
+	return nil
+}
+
+func (a *PreviewApplier) resolveValue(val any) (any, error) {
+	switch v := val.(type) {
+	case string:
+		return a.resolveString(v)
+	case map[string]any:
+		if v == nil {
+			return v, nil
+		}
+
+		res := make(map[string]any, len(v))
+
+		for key, nested := range v {
+			resolved, err := a.resolveValue(nested)
+
+			if err != nil {
+				return nil, err
+			}
+
+			res[key] = resolved
+		}
+
+		return res, nil
+	case []any:
+		res := make([]any, 0, len(v))
+
+		for _, nested := range v {
+			resolved, err := a.resolveValue(nested)
+
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, resolved)
+		}
+
+		return res, nil
+	}
+
+	return val, nil
+}
+
+func (a *PreviewApplier) resolveString(str string) (string, error) {
+	var resolveErr error
+
+	res := referenceRegex.ReplaceAllStringFunc(str, func(match string) string {
+		if resolveErr != nil {
+			return match
+		}
+
+		groups := referenceRegex.FindStringSubmatch(match)
+		kind, path := groups[1], groups[2]
+
+		if kind == "variables" {
+			val, ok := a.variablesMap[path]
+
+			if !ok {
+				resolveErr = fmt.Errorf("undeclared variable '%s'", path)
+				return match
+			}
+
+			return val
+		}
+
+		egName, key, found := strings.Cut(path, ".")
+
+		if !found || egName == "" || key == "" {
+			resolveErr = fmt.Errorf("invalid env group reference '%s', expected { .env_groups.<name>.<key> }", match)
+			return match
+		}
+
+		envGroup, ok := a.envGroups[egName]
+
+		if !ok || envGroup == nil {
+			resolveErr = fmt.Errorf("undeclared env group '%s'", egName)
+			return match
+		}
+
+		val, ok := envGroup.Variables[key]
+
+		if !ok {
+			resolveErr = fmt.Errorf("key '%s' not found in env group '%s'", key, egName)
+			return match
+		}
+
+		if strings.Contains(val, "PORTERSECRET") {
+			resolveErr = fmt.Errorf("key '%s' in env group '%s' is a secret and cannot be referenced directly, "+
+				"use import_from to inject it instead", key, egName)
+			return match
+		}
+
+		return val
+	})
+
+	if resolveErr != nil {
+		return "", resolveErr
+	}
+
+	return res, nil
+}
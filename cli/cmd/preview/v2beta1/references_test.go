@@ -0,0 +1,283 @@
+package v2beta1
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	apiTypes "github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/preview"
+)
+
+func getReferencesApplier() *PreviewApplier {
+	return &PreviewApplier{
+		variablesMap: map[string]string{
+			"db_name":  "app",
+			"password": "s3cr3t",
+		},
+		envGroups: map[string]*apiTypes.EnvGroup{
+			"shared": {
+				Name: "shared",
+				Variables: map[string]string{
+					"REDIS_HOST": "redis.default.svc",
+					"API_KEY":    "PORTERSECRET_shared.API_KEY",
+				},
+			},
+		},
+	}
+}
+
+func TestResolveString(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		err      string
+	}{
+		{"no references", "plain value", "plain value", ""},
+		{"variable", "{ .variables.db_name }", "app", ""},
+		{"variable without spaces", "{.variables.db_name}", "app", ""},
+		{"embedded references", "postgres://{ .variables.db_name }:{ .variables.password }@db", "postgres://app:s3cr3t@db", ""},
+		{"env group key", "{ .env_groups.shared.REDIS_HOST }", "redis.default.svc", ""},
+		{"other references are kept", "{ .web.image }", "{ .web.image }", ""},
+		{"undeclared variable", "{ .variables.missing }", "", "undeclared variable 'missing'"},
+		{"undeclared env group", "{ .env_groups.missing.KEY }", "", "undeclared env group 'missing'"},
+		{"env group without key", "{ .env_groups.shared }", "", "invalid env group reference"},
+		{"missing env group key", "{ .env_groups.shared.MISSING }", "", "key 'MISSING' not found in env group 'shared'"},
+		{"secret env group key", "{ .env_groups.shared.API_KEY }", "", "is a secret and cannot be referenced directly"},
+	}
+
+	a := getReferencesApplier()
+
+	for _, test := range tests {
+		res, err := a.resolveString(test.input)
+
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%s: expected error containing %q, got %v", test.name, test.err, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		if res != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, res)
+		}
+	}
+}
+
+func TestResolveValueNested(t *testing.T) {
+	a := getReferencesApplier()
+
+	res, err := a.resolveValue(map[string]any{
+		"replicas": 2,
+		"env": map[string]any{
+			"DB_NAME": "{ .variables.db_name }",
+		},
+		"hosts": []any{"{ .env_groups.shared.REDIS_HOST }", "example.com"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]any{
+		"replicas": 2,
+		"env": map[string]any{
+			"DB_NAME": "app",
+		},
+		"hosts": []any{"redis.default.svc", "example.com"},
+	}
+
+	if !reflect.DeepEqual(res, expected) {
+		t.Errorf("expected %v, got %v", expected, res)
+	}
+}
+
+func TestResolveReferences(t *testing.T) {
+	a := getReferencesApplier()
+
+	a.parsed = &PorterYAML{
+		Builds: []*Build{
+			{
+				Name: stringptr("web"),
+				Env: &BuildEnv{
+					Raw: map[*string]*string{
+						stringptr("DB_NAME"): stringptr("{ .variables.db_name }"),
+					},
+				},
+			},
+		},
+		Apps: []*AppResource{
+			{
+				Name:       stringptr("web"),
+				HelmValues: map[string]any{"password": "{ .variables.password }"},
+			},
+		},
+		Addons: []*AddonResource{
+			{
+				Name:       stringptr("redis"),
+				HelmValues: map[string]any{"host": "{ .env_groups.shared.REDIS_HOST }"},
+			},
+		},
+		Seed: &Seed{
+			Addon:    stringptr("postgres"),
+			Database: &SeedDatabase{Name: stringptr("{ .variables.db_name }")},
+		},
+	}
+
+	if err := a.resolveReferences(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, v := range a.parsed.Builds[0].Env.Raw {
+		if *v != "app" {
+			t.Errorf("expected build env to be resolved, got %q", *v)
+		}
+	}
+
+	if a.parsed.Apps[0].HelmValues["password"] != "s3cr3t" {
+		t.Errorf("expected app helm_values to be resolved, got %v", a.parsed.Apps[0].HelmValues)
+	}
+
+	if a.parsed.Addons[0].HelmValues["host"] != "redis.default.svc" {
+		t.Errorf("expected addon helm_values to be resolved, got %v", a.parsed.Addons[0].HelmValues)
+	}
+
+	if *a.parsed.Seed.Database.Name != "app" {
+		t.Errorf("expected seed database to be resolved, got %q", *a.parsed.Seed.Database.Name)
+	}
+}
+
+func TestResolveReferencesCollectsAllErrors(t *testing.T) {
+	a := getReferencesApplier()
+
+	a.parsed = &PorterYAML{
+		Apps: []*AppResource{
+			{Name: stringptr("web"), HelmValues: map[string]any{"a": "{ .variables.missing }"}},
+		},
+		Addons: []*AddonResource{
+			{Name: stringptr("redis"), HelmValues: map[string]any{"b": "{ .env_groups.missing.KEY }"}},
+		},
+	}
+
+	err := a.resolveReferences()
+
+	if err == nil {
+		t.Fatalf("expected an error for undeclared references")
+	}
+
+	if !strings.Contains(err.Error(), "app 'web'") || !strings.Contains(err.Error(), "addon 'redis'") {
+		t.Errorf("expected errors for both resources, got %v", err)
+	}
+}
+
+func TestProcessVariablesWithoutOnce(t *testing.T) {
+	a := &PreviewApplier{
+		variablesMap: make(map[string]string),
+		parsed: &PorterYAML{
+			Variables: []*Variable{
+				{Name: stringptr("db_name"), Value: stringptr("app")},
+				{Name: stringptr("token"), Random: boolptr(true), Length: uintp(16)},
+			},
+		},
+	}
+
+	if err := a.processVariables(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if a.variablesMap["db_name"] != "app" {
+		t.Errorf("expected variable value to be used, got %q", a.variablesMap["db_name"])
+	}
+
+	if token := a.variablesMap["token"]; len(token) != 16 || strings.Trim(token, defaultCharset) != "" {
+		t.Errorf("expected a random value of length 16, got %q", token)
+	}
+}
+
+func TestProcessVariablesDryRun(t *testing.T) {
+	a := &PreviewApplier{
+		variablesMap: make(map[string]string),
+		dryRun:       true,
+		parsed: &PorterYAML{
+			Variables: []*Variable{
+				{Name: stringptr("db_name"), Value: stringptr("app")},
+				{Name: stringptr("token"), Random: boolptr(true), Length: uintp(16)},
+			},
+		},
+	}
+
+	if err := a.processVariables(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if a.variablesMap["db_name"] != "app" {
+		t.Errorf("expected variable value to be used, got %q", a.variablesMap["db_name"])
+	}
+
+	if a.variablesMap["token"] != preview.DryRunRegeneratedValue {
+		t.Errorf("expected random variables to be marked as regenerated, got %q", a.variablesMap["token"])
+	}
+}
+
+func TestProcessVariablesValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		variables []*Variable
+		err       string
+	}{
+		{"empty name", []*Variable{{Value: stringptr("a")}}, "variable name cannot be empty"},
+		{"duplicate", []*Variable{
+			{Name: stringptr("a"), Value: stringptr("a")},
+			{Name: stringptr("a"), Value: stringptr("b")},
+		}, "duplicate variable 'a'"},
+		{"no value", []*Variable{{Name: stringptr("a")}}, "must either have a value or set random to true"},
+	}
+
+	for _, test := range tests {
+		a := &PreviewApplier{
+			variablesMap: make(map[string]string),
+			parsed:       &PorterYAML{Variables: test.variables},
+		}
+
+		if err := a.processVariables(); err == nil || !strings.Contains(err.Error(), test.err) {
+			t.Errorf("%s: expected error containing %q, got %v", test.name, test.err, err)
+		}
+	}
+}
+
+func TestGetCloneFromParts(t *testing.T) {
+	tests := []struct {
+		cloneFrom string
+		ns        string
+		name      string
+		ok        bool
+	}{
+		{"default/shared", "default", "shared", true},
+		{"shared", "", "", false},
+		{"/shared", "", "", false},
+		{"default/", "", "", false},
+	}
+
+	for _, test := range tests {
+		ns, name, ok := (&EnvGroup{CloneFrom: stringptr(test.cloneFrom)}).GetCloneFromParts()
+
+		if ns != test.ns || name != test.name || ok != test.ok {
+			t.Errorf("%q: expected (%q, %q, %t), got (%q, %q, %t)", test.cloneFrom, test.ns, test.name, test.ok, ns, name, ok)
+		}
+	}
+}
+
+func boolptr(b bool) *bool {
+	return &b
+}
+
+func uintp(u uint) *uint {
+	return &u
+}
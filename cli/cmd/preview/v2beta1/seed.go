@@ -0,0 +1,533 @@
+package v2beta1
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	apiTypes "github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/integrations/preview"
+	"github.com/porter-dev/switchboard/pkg/types"
+)
+
+const (
+	defaultSeedName  = "seed"
+	defaultSeedImage = "postgres:15"
+	defaultSeedPort  = "5432"
+
+	// seedScriptEnv is the environment variable holding the script which is run by the seed jobs,
+	// so that the script does not have to be quoted inside the job command
+	seedScriptEnv = "PORTER_SEED_SCRIPT"
+
+	// seedMarkerTable is created in the seeded database once a snapshot has been restored, so
+	// that subsequent applies to the same preview environment do not restore it again
+	seedMarkerTable = "public._porter_seed"
+)
+
+var seedImageTagRegex = regexp.MustCompile(`^\d+`)
+
+func (s *Seed) GetName() string {
+	if s == nil || s.Name == nil || *s.Name == "" {
+		return defaultSeedName
+	}
+
+	return *s.Name
+}
+
+func (s *Seed) GetAddon() string {
+	if s == nil || s.Addon == nil {
+		return ""
+	}
+
+	return *s.Addon
+}
+
+func (s *Seed) GetDependsOn() []string {
+	var dependsOn []string
+
+	if s == nil || s.DependsOn == nil {
+		return dependsOn
+	}
+
+	for _, d := range s.DependsOn {
+		if d == nil {
+			continue
+		}
+
+		dependsOn = append(dependsOn, *d)
+	}
+
+	return dependsOn
+}
+
+func (s *Seed) GetBuildRef() string {
+	if s == nil || s.BuildRef == nil {
+		return ""
+	}
+
+	return *s.BuildRef
+}
+
+func (s *Seed) GetCommands() []string {
+	var commands []string
+
+	if s == nil || s.Commands == nil {
+		return commands
+	}
+
+	for _, c := range s.Commands {
+		if c == nil || strings.TrimSpace(*c) == "" {
+			continue
+		}
+
+		commands = append(commands, *c)
+	}
+
+	return commands
+}
+
+func (s *Seed) GetRunOnce() bool {
+	if s == nil || s.RunOnce == nil {
+		return false
+	}
+
+	return *s.RunOnce
+}
+
+func (s *Seed) GetTimeout() uint {
+	if s == nil || s.Timeout == nil {
+		return 0
+	}
+
+	return *s.Timeout
+}
+
+func (s *SeedSnapshot) GetURL() string {
+	if s == nil || s.URL == nil {
+		return ""
+	}
+
+	return *s.URL
+}
+
+func (s *SeedSnapshot) GetFromNamespace() string {
+	if s == nil || s.FromNamespace == nil {
+		return ""
+	}
+
+	return *s.FromNamespace
+}
+
+func (s *SeedSnapshot) GetFromAddon() string {
+	if s == nil || s.FromAddon == nil {
+		return ""
+	}
+
+	return *s.FromAddon
+}
+
+func (s *SeedSnapshot) GetImage() string {
+	if s == nil || s.Image == nil {
+		return ""
+	}
+
+	return *s.Image
+}
+
+func (s *SeedSnapshot) GetEnvGroups() []string {
+	var envGroups []string
+
+	if s == nil || s.EnvGroups == nil {
+		return envGroups
+	}
+
+	for _, eg := range s.EnvGroups {
+		if eg == nil {
+			continue
+		}
+
+		envGroups = append(envGroups, *eg)
+	}
+
+	return envGroups
+}
+
+// seedConnection holds the connection details of the database which is seeded
+type seedConnection struct {
+	host, port, user, password, name string
+}
+
+func (c *seedConnection) env() map[string]any {
+	dbURL := &url.URL{
+		Scheme: "postgres",
+		User:   url.UserPassword(c.user, c.password),
+		Host:   fmt.Sprintf("%s:%s", c.host, c.port),
+		Path:   "/" + c.name,
+	}
+
+	return map[string]any{
+		"PGHOST":       c.host,
+		"PGPORT":       c.port,
+		"PGUSER":       c.user,
+		"PGPASSWORD":   c.password,
+		"PGDATABASE":   c.name,
+		"DATABASE_URL": dbURL.String(),
+	}
+}
+
+// getV1Resources converts the seed block into job resources: one restoring the snapshot into the
+// addon, and one running the seed commands using the image of the referenced build. Both jobs are
+// waited on by the deploy driver, so a failing seed fails the apply and therefore the deployment.
+func (s *Seed) getV1Resources(addon *AddonResource, builds map[string]*Build) ([]*types.Resource, error) {
+	if s.Snapshot == nil && len(s.GetCommands()) == 0 {
+		return nil, fmt.Errorf("%s", composePreviewMessage(fmt.Sprintf("seed '%s' must either have a snapshot "+
+			"or commands", s.GetName()), Error))
+	}
+
+	conn := s.getConnection(addon)
+
+	var resources []*types.Resource
+
+	dependsOn := append([]string{addon.GetName()}, s.GetDependsOn()...)
+
+	if s.Snapshot != nil {
+		restoreName := s.GetName()
+
+		if len(s.GetCommands()) > 0 {
+			restoreName = fmt.Sprintf("%s-restore", s.GetName())
+		}
+
+		restore, err := s.getV1RestoreJob(restoreName, addon, conn, dependsOn)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, restore)
+
+		// the seed commands run against the restored snapshot
+		dependsOn = []string{restoreName}
+	}
+
+	if len(s.GetCommands()) > 0 {
+		b, ok := builds[s.GetBuildRef()]
+
+		if !ok {
+			return nil, fmt.Errorf("%s", composePreviewMessage(fmt.Sprintf("build_ref '%s' referenced by seed '%s' "+
+				"does not exist", s.GetBuildRef(), s.GetName()), Error))
+		}
+
+		commands, err := s.getV1CommandsJob(b, conn, dependsOn)
+
+		if err != nil {
+			return nil, err
+		}
+
+		resources = append(resources, commands)
+	}
+
+	return resources, nil
+}
+
+// getConnection returns the connection details of the addon, preferring the values given in the
+// database block of the seed over the ones read from the helm_values of the addon
+func (s *Seed) getConnection(addon *AddonResource) *seedConnection {
+	conn := &seedConnection{
+		host: getSeedServiceName(addon.GetName(), addon.Chart.GetName()),
+		port: defaultSeedPort,
+		user: firstNonEmpty(
+			lookupHelmValue(addon.HelmValues, "auth", "username"),
+			lookupHelmValue(addon.HelmValues, "postgresqlUsername"),
+			"postgres",
+		),
+		password: firstNonEmpty(
+			lookupHelmValue(addon.HelmValues, "auth", "password"),
+			lookupHelmValue(addon.HelmValues, "auth", "postgresPassword"),
+			lookupHelmValue(addon.HelmValues, "postgresqlPassword"),
+		),
+		name: firstNonEmpty(
+			lookupHelmValue(addon.HelmValues, "auth", "database"),
+			lookupHelmValue(addon.HelmValues, "postgresqlDatabase"),
+			"postgres",
+		),
+	}
+
+	if db := s.Database; db != nil {
+		if db.Host != nil && *db.Host != "" {
+			conn.host = *db.Host
+		}
+
+		if db.Port != nil && *db.Port != "" {
+			conn.port = *db.Port
+		}
+
+		if db.User != nil && *db.User != "" {
+			conn.user = *db.User
+		}
+
+		if db.Password != nil && *db.Password != "" {
+			conn.password = *db.Password
+		}
+
+		if db.Name != nil && *db.Name != "" {
+			conn.name = *db.Name
+		}
+	}
+
+	return conn
+}
+
+func (s *Seed) getV1RestoreJob(name string, addon *AddonResource, conn *seedConnection, dependsOn []string) (*types.Resource, error) {
+	snapshotURL := s.Snapshot.GetURL()
+	fromNamespace := s.Snapshot.GetFromNamespace()
+
+	if (snapshotURL == "") == (fromNamespace == "") {
+		return nil, fmt.Errorf("%s", composePreviewMessage(fmt.Sprintf("snapshot of seed '%s' must set exactly one "+
+			"of url or from_namespace", s.GetName()), Error))
+	}
+
+	env := conn.env()
+
+	var restore string
+
+	if snapshotURL != "" {
+		fetch, err := getSnapshotFetchCommand(snapshotURL)
+
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", composePreviewMessage(fmt.Sprintf("invalid snapshot url for seed '%s'",
+				s.GetName()), Error), err)
+		}
+
+		env["SEED_SNAPSHOT_URL"] = snapshotURL
+		env["SEED_SNAPSHOT_SOURCE"] = snapshotURL
+
+		restore = fetch + getSnapshotRestoreCommand(snapshotURL)
+	} else {
+		fromAddon := s.Snapshot.GetFromAddon()
+
+		if fromAddon == "" {
+			fromAddon = addon.GetName()
+		}
+
+		env["SEED_SOURCE_PGHOST"] = fmt.Sprintf("%s.%s.svc.cluster.local",
+			getSeedServiceName(fromAddon, addon.Chart.GetName()), fromNamespace)
+		env["SEED_SOURCE_PGDATABASE"] = conn.name
+		env["SEED_SNAPSHOT_SOURCE"] = fmt.Sprintf("%s/%s", fromNamespace, fromAddon)
+
+		// the credentials of the source database default to the ones of the seeded addon, and can be
+		// overridden by setting SEED_SOURCE_PGUSER and SEED_SOURCE_PGPASSWORD in one of the env groups
+		restore = `PGPASSWORD="${SEED_SOURCE_PGPASSWORD:-$PGPASSWORD}" pg_dump --no-owner --no-acl ` +
+			`-h "$SEED_SOURCE_PGHOST" -p "$PGPORT" -U "${SEED_SOURCE_PGUSER:-$PGUSER}" -d "$SEED_SOURCE_PGDATABASE"` +
+			getSnapshotRestoreCommand("")
+	}
+
+	env[seedScriptEnv] = strings.Join([]string{
+		"set -eo pipefail",
+		`echo "Waiting for database $PGHOST:$PGPORT to accept connections"`,
+		"until pg_isready -q; do sleep 5; done",
+		fmt.Sprintf(`if [ "$(psql -tAc "SELECT to_regclass('%s') IS NOT NULL")" = "t" ]; then`, seedMarkerTable),
+		`  echo "Snapshot has already been restored into $PGDATABASE, skipping"`,
+		"  exit 0",
+		"fi",
+		`echo "Restoring snapshot $SEED_SNAPSHOT_SOURCE into $PGDATABASE"`,
+		restore,
+		fmt.Sprintf(`psql -v ON_ERROR_STOP=1 -q -v source="$SEED_SNAPSHOT_SOURCE" <<'SQL'
+CREATE TABLE %s (source text NOT NULL, restored_at timestamptz NOT NULL DEFAULT now());
+INSERT INTO %s (source) VALUES (:'source');
+SQL`, seedMarkerTable, seedMarkerTable),
+		`echo "Restored snapshot $SEED_SNAPSHOT_SOURCE"`,
+	}, "\n")
+
+	image := s.Snapshot.GetImage()
+
+	if image == "" {
+		image = getDefaultSeedImage(addon)
+	}
+
+	config := &preview.ApplicationConfig{}
+
+	config.Build.Method = "registry"
+	config.Build.Image = image
+	config.Values = s.getJobValues("/bin/bash", env)
+
+	for _, eg := range s.Snapshot.GetEnvGroups() {
+		ns, name, _ := strings.Cut(eg, "/")
+
+		config.EnvGroups = append(config.EnvGroups, apiTypes.EnvGroupMeta{
+			Name:      name,
+			Namespace: ns,
+		})
+	}
+
+	return getV1SeedJob(name, config, dependsOn)
+}
+
+func (s *Seed) getV1CommandsJob(b *Build, conn *seedConnection, dependsOn []string) (*types.Resource, error) {
+	env := conn.env()
+
+	env[seedScriptEnv] = strings.Join(append([]string{"set -e"}, s.GetCommands()...), "\n")
+
+	config := &preview.ApplicationConfig{}
+
+	config.Build.Method = "registry"
+	config.Build.Image = fmt.Sprintf("{ .%s.image }", b.GetName())
+	config.Build.Env = b.GetRawEnv()
+	config.Values = s.getJobValues("/bin/sh", env)
+
+	// commands which are not idempotent only run when the preview environment is first deployed
+	config.OnlyCreate = s.GetRunOnce()
+
+	for _, eg := range b.GetEnvGroups() {
+		ns, name, _ := strings.Cut(eg, "/")
+
+		config.EnvGroups = append(config.EnvGroups, apiTypes.EnvGroupMeta{
+			Name:      name,
+			Namespace: ns,
+		})
+	}
+
+	return getV1SeedJob(s.GetName(), config, append([]string{b.GetName()}, dependsOn...))
+}
+
+// getJobValues returns the helm values of a seed job running the seed script with the given shell.
+// The job is unpaused so that it runs again on every apply, not only when it is first created.
+func (s *Seed) getJobValues(shell string, env map[string]any) map[string]any {
+	values := map[string]any{
+		"paused": false,
+		"container": map[string]any{
+			"command": fmt.Sprintf("%s -c $(%s)", shell, seedScriptEnv),
+			"env": map[string]any{
+				"normal": env,
+			},
+		},
+	}
+
+	if timeout := s.GetTimeout(); timeout > 0 {
+		values["sidecar"] = map[string]any{
+			"timeout": timeout,
+		}
+	}
+
+	return values
+}
+
+func getV1SeedJob(name string, config *preview.ApplicationConfig, dependsOn []string) (*types.Resource, error) {
+	config.WaitForJob = true
+
+	rawConfig := make(map[string]any)
+
+	err := mapstructure.Decode(config, &rawConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &types.Resource{
+		Name:      name,
+		DependsOn: dependsOn,
+		Source: map[string]any{
+			"name": "job",
+		},
+		Config: rawConfig,
+	}, nil
+}
+
+// getSnapshotFetchCommand returns the command writing the snapshot at the given URL to stdout,
+// installing the tools it needs if they are missing from the image
+func getSnapshotFetchCommand(snapshotURL string) (string, error) {
+	parsed, err := url.Parse(snapshotURL)
+
+	if err != nil {
+		return "", err
+	}
+
+	switch parsed.Scheme {
+	case "s3":
+		return ensureSeedTool("aws", "awscli") + `aws s3 cp "$SEED_SNAPSHOT_URL" -`, nil
+	case "http", "https":
+		return ensureSeedTool("curl", "curl") + `curl -fsSL "$SEED_SNAPSHOT_URL"`, nil
+	}
+
+	return "", fmt.Errorf("unsupported scheme '%s', expected one of s3, http or https", parsed.Scheme)
+}
+
+func ensureSeedTool(binary, pkg string) string {
+	return fmt.Sprintf("if ! command -v %s >/dev/null 2>&1; then apt-get update -qq && "+
+		"apt-get install -y -qq %s >/dev/null; fi\n", binary, pkg)
+}
+
+// getSnapshotRestoreCommand returns the part of the restore pipeline which reads the snapshot
+// from stdin, based on the file extension of the snapshot
+func getSnapshotRestoreCommand(snapshotURL string) string {
+	path := snapshotURL
+
+	if parsed, err := url.Parse(snapshotURL); err == nil {
+		path = parsed.Path
+	}
+
+	var cmd string
+
+	if strings.HasSuffix(path, ".gz") {
+		cmd += " | gunzip"
+		path = strings.TrimSuffix(path, ".gz")
+	}
+
+	if strings.HasSuffix(path, ".dump") {
+		// custom format archives created with pg_dump -Fc
+		return cmd + ` | pg_restore --no-owner --no-acl -d "$PGDATABASE"`
+	}
+
+	return cmd + " | psql -v ON_ERROR_STOP=1 -q"
+}
+
+// getSeedServiceName returns the name of the service created for a release of the given chart,
+// following the naming of the bitnami charts
+func getSeedServiceName(release, chart string) string {
+	if chart == "" || strings.Contains(release, chart) {
+		return release
+	}
+
+	return fmt.Sprintf("%s-%s", release, chart)
+}
+
+// getDefaultSeedImage returns a postgres image matching the major version of the addon, so that
+// the client tools are compatible with the server
+func getDefaultSeedImage(addon *AddonResource) string {
+	if major := seedImageTagRegex.FindString(lookupHelmValue(addon.HelmValues, "image", "tag")); major != "" {
+		return fmt.Sprintf("postgres:%s", major)
+	}
+
+	return defaultSeedImage
+}
+
+func lookupHelmValue(values map[string]any, path ...string) string {
+	var curr any = values
+
+	for _, key := range path {
+		m, ok := curr.(map[string]any)
+
+		if !ok {
+			return ""
+		}
+
+		curr = m[key]
+	}
+
+	if curr == nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%v", curr)
+}
+
+func firstNonEmpty(vals ...string) string {
+	for _, v := range vals {
+		if v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
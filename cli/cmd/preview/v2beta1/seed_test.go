@@ -0,0 +1,413 @@
+package v2beta1
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/porter-dev/porter/internal/integrations/preview"
+	"github.com/porter-dev/switchboard/pkg/types"
+)
+
+func getSeedAddon() *AddonResource {
+	return &AddonResource{
+		Name:  stringptr("postgres"),
+		Chart: &HelmChart{Name: stringptr("postgresql")},
+		HelmValues: map[string]any{
+			"auth": map[string]any{
+				"username": "app",
+				"password": "s3cr3t",
+				"database": "app_db",
+			},
+			"image": map[string]any{
+				"tag": "14.5.0",
+			},
+		},
+	}
+}
+
+func getSeedBuilds() map[string]*Build {
+	return map[string]*Build{
+		"web": {Name: stringptr("web")},
+	}
+}
+
+// getSeedJobConfig returns the application config of a seed job along with the environment
+// variables of its container
+func getSeedJobConfig(t *testing.T, resource *types.Resource) (*preview.ApplicationConfig, map[string]any) {
+	t.Helper()
+
+	config := &preview.ApplicationConfig{}
+
+	if err := mapstructure.Decode(resource.Config, config); err != nil {
+		t.Fatalf("error decoding config of seed job %s: %v", resource.Name, err)
+	}
+
+	env := config.Values["container"].(map[string]any)["env"].(map[string]any)["normal"].(map[string]any)
+
+	return config, env
+}
+
+func TestGetConnection(t *testing.T) {
+	tests := []struct {
+		name     string
+		addon    *AddonResource
+		database *SeedDatabase
+		expected *seedConnection
+	}{
+		{
+			name:  "bitnami auth values",
+			addon: getSeedAddon(),
+			expected: &seedConnection{
+				host: "postgres-postgresql", port: "5432", user: "app", password: "s3cr3t", name: "app_db",
+			},
+		},
+		{
+			name: "legacy bitnami values",
+			addon: &AddonResource{
+				Name:  stringptr("my-postgresql"),
+				Chart: &HelmChart{Name: stringptr("postgresql")},
+				HelmValues: map[string]any{
+					"postgresqlUsername": "legacy",
+					"postgresqlPassword": "legacy-pw",
+					"postgresqlDatabase": "legacy_db",
+				},
+			},
+			expected: &seedConnection{
+				host: "my-postgresql", port: "5432", user: "legacy", password: "legacy-pw", name: "legacy_db",
+			},
+		},
+		{
+			name: "postgres password of the admin user",
+			addon: &AddonResource{
+				Name:       stringptr("db"),
+				Chart:      &HelmChart{Name: stringptr("postgresql")},
+				HelmValues: map[string]any{"auth": map[string]any{"postgresPassword": "admin-pw"}},
+			},
+			expected: &seedConnection{
+				host: "db-postgresql", port: "5432", user: "postgres", password: "admin-pw", name: "postgres",
+			},
+		},
+		{
+			name:  "database block overrides helm values",
+			addon: getSeedAddon(),
+			database: &SeedDatabase{
+				Host:     stringptr("db.example.com"),
+				Port:     stringptr("6543"),
+				User:     stringptr("seed user"),
+				Password: stringptr(`p@ss "word" 'with' $quotes`),
+				Name:     stringptr("my db"),
+			},
+			expected: &seedConnection{
+				host: "db.example.com", port: "6543", user: "seed user", password: `p@ss "word" 'with' $quotes`, name: "my db",
+			},
+		},
+		{
+			name:  "empty database values are ignored",
+			addon: getSeedAddon(),
+			database: &SeedDatabase{
+				Host:     stringptr(""),
+				Password: stringptr(""),
+			},
+			expected: &seedConnection{
+				host: "postgres-postgresql", port: "5432", user: "app", password: "s3cr3t", name: "app_db",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		conn := (&Seed{Database: test.database}).getConnection(test.addon)
+
+		if !reflect.DeepEqual(conn, test.expected) {
+			t.Errorf("%s: expected %+v, got %+v", test.name, test.expected, conn)
+		}
+	}
+}
+
+func TestSeedConnectionEnvEscapesDatabaseURL(t *testing.T) {
+	conn := &seedConnection{
+		host: "db", port: "5432", user: "seed user", password: `p@ss/"word"?#`, name: "my db",
+	}
+
+	env := conn.env()
+
+	parsed, err := url.Parse(env["DATABASE_URL"].(string))
+
+	if err != nil {
+		t.Fatalf("expected a valid DATABASE_URL, got %v", err)
+	}
+
+	password, _ := parsed.User.Password()
+
+	if parsed.User.Username() != "seed user" || password != `p@ss/"word"?#` || parsed.Path != "/my db" {
+		t.Errorf("expected the connection details to round trip through DATABASE_URL, got %s", env["DATABASE_URL"])
+	}
+
+	if env["PGPASSWORD"] != `p@ss/"word"?#` {
+		t.Errorf("expected PGPASSWORD to be passed as is, got %v", env["PGPASSWORD"])
+	}
+}
+
+func TestGetSnapshotRestoreCommand(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"", " | psql -v ON_ERROR_STOP=1 -q"},
+		{"s3://bucket/snapshot.sql", " | psql -v ON_ERROR_STOP=1 -q"},
+		{"s3://bucket/snapshot.sql.gz", " | gunzip | psql -v ON_ERROR_STOP=1 -q"},
+		{"https://example.com/snapshot.dump", ` | pg_restore --no-owner --no-acl -d "$PGDATABASE"`},
+		{"https://example.com/snapshot.dump.gz", ` | gunzip | pg_restore --no-owner --no-acl -d "$PGDATABASE"`},
+		{"https://example.com/snapshot.dump.gz?X-Amz-Signature=abc.sql", ` | gunzip | pg_restore --no-owner --no-acl -d "$PGDATABASE"`},
+		{"https://example.com/my%20snapshots/prod%20db.sql.gz", " | gunzip | psql -v ON_ERROR_STOP=1 -q"},
+		{`s3://bucket/it's "quoted".dump`, ` | pg_restore --no-owner --no-acl -d "$PGDATABASE"`},
+	}
+
+	for _, test := range tests {
+		if res := getSnapshotRestoreCommand(test.url); res != test.expected {
+			t.Errorf("%q: expected %q, got %q", test.url, test.expected, res)
+		}
+	}
+}
+
+func TestGetV1Resources(t *testing.T) {
+	tests := []struct {
+		name         string
+		seed         *Seed
+		expNames     []string
+		expDependsOn [][]string
+		expImages    []string
+		err          string
+	}{
+		{
+			name: "snapshot url",
+			seed: &Seed{
+				Snapshot: &SeedSnapshot{URL: stringptr("s3://bucket/prod.sql.gz")},
+			},
+			expNames:     []string{"seed"},
+			expDependsOn: [][]string{{"postgres"}},
+			expImages:    []string{"postgres:14"},
+		},
+		{
+			name: "snapshot from namespace with image",
+			seed: &Seed{
+				Name:      stringptr("restore"),
+				DependsOn: []*string{stringptr("redis")},
+				Snapshot: &SeedSnapshot{
+					FromNamespace: stringptr("staging"),
+					Image:         stringptr("postgres:15-alpine"),
+				},
+			},
+			expNames:     []string{"restore"},
+			expDependsOn: [][]string{{"postgres", "redis"}},
+			expImages:    []string{"postgres:15-alpine"},
+		},
+		{
+			name: "commands",
+			seed: &Seed{
+				BuildRef: stringptr("web"),
+				Commands: []*string{stringptr("npm run seed"), stringptr("  ")},
+			},
+			expNames:     []string{"seed"},
+			expDependsOn: [][]string{{"web", "postgres"}},
+			expImages:    []string{"{ .web.image }"},
+		},
+		{
+			name: "snapshot and commands",
+			seed: &Seed{
+				BuildRef: stringptr("web"),
+				Snapshot: &SeedSnapshot{URL: stringptr("https://example.com/prod.dump")},
+				Commands: []*string{stringptr("npm run migrate")},
+			},
+			expNames:     []string{"seed-restore", "seed"},
+			expDependsOn: [][]string{{"postgres"}, {"web", "seed-restore"}},
+			expImages:    []string{"postgres:14", "{ .web.image }"},
+		},
+		{
+			name: "neither snapshot nor commands",
+			seed: &Seed{},
+			err:  "must either have a snapshot or commands",
+		},
+		{
+			name: "snapshot with url and namespace",
+			seed: &Seed{
+				Snapshot: &SeedSnapshot{URL: stringptr("s3://bucket/prod.sql"), FromNamespace: stringptr("staging")},
+			},
+			err: "must set exactly one of url or from_namespace",
+		},
+		{
+			name: "snapshot without source",
+			seed: &Seed{Snapshot: &SeedSnapshot{}},
+			err:  "must set exactly one of url or from_namespace",
+		},
+		{
+			name: "unsupported snapshot url",
+			seed: &Seed{Snapshot: &SeedSnapshot{URL: stringptr("ftp://example.com/prod.sql")}},
+			err:  "unsupported scheme 'ftp'",
+		},
+		{
+			name: "missing build",
+			seed: &Seed{BuildRef: stringptr("worker"), Commands: []*string{stringptr("npm run seed")}},
+			err:  "build_ref 'worker' referenced by seed 'seed' does not exist",
+		},
+	}
+
+	for _, test := range tests {
+		resources, err := test.seed.getV1Resources(getSeedAddon(), getSeedBuilds())
+
+		if test.err != "" {
+			if err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("%s: expected error containing %q, got %v", test.name, test.err, err)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		if len(resources) != len(test.expNames) {
+			t.Errorf("%s: expected %d resources, got %d", test.name, len(test.expNames), len(resources))
+			continue
+		}
+
+		for i, resource := range resources {
+			config, _ := getSeedJobConfig(t, resource)
+
+			if resource.Name != test.expNames[i] {
+				t.Errorf("%s: expected resource %d to be named %s, got %s", test.name, i, test.expNames[i], resource.Name)
+			}
+
+			if !reflect.DeepEqual(resource.DependsOn, test.expDependsOn[i]) {
+				t.Errorf("%s: expected resource %s to depend on %v, got %v", test.name, resource.Name,
+					test.expDependsOn[i], resource.DependsOn)
+			}
+
+			if config.Build.Image != test.expImages[i] {
+				t.Errorf("%s: expected resource %s to use image %s, got %s", test.name, resource.Name,
+					test.expImages[i], config.Build.Image)
+			}
+
+			if !config.WaitForJob || resource.Source["name"] != "job" {
+				t.Errorf("%s: expected resource %s to be a job which is waited on", test.name, resource.Name)
+			}
+		}
+	}
+}
+
+// TestGetV1ResourcesQuoting makes sure that user supplied values are only passed to the seed
+// scripts through environment variables, so that quotes and spaces cannot break out of them
+func TestGetV1ResourcesQuoting(t *testing.T) {
+	snapshotURL := `https://example.com/it's a "snapshot"; rm -rf $HOME.sql.gz`
+	password := `p@ss "word" 'it''s' $(whoami) ` + "`id`"
+
+	tests := []struct {
+		name     string
+		snapshot *SeedSnapshot
+		expEnv   map[string]any
+	}{
+		{
+			name:     "snapshot url",
+			snapshot: &SeedSnapshot{URL: stringptr(snapshotURL)},
+			expEnv: map[string]any{
+				"SEED_SNAPSHOT_URL":    snapshotURL,
+				"SEED_SNAPSHOT_SOURCE": snapshotURL,
+				"PGPASSWORD":           password,
+				"PGDATABASE":           "my db",
+			},
+		},
+		{
+			name: "snapshot from namespace",
+			snapshot: &SeedSnapshot{
+				FromNamespace: stringptr("staging env"),
+				FromAddon:     stringptr(`db "primary"`),
+			},
+			expEnv: map[string]any{
+				"SEED_SOURCE_PGHOST":     `db "primary"-postgresql.staging env.svc.cluster.local`,
+				"SEED_SOURCE_PGDATABASE": "my db",
+				"SEED_SNAPSHOT_SOURCE":   `staging env/db "primary"`,
+				"PGPASSWORD":             password,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		seed := &Seed{
+			Database: &SeedDatabase{Password: stringptr(password), Name: stringptr("my db")},
+			Snapshot: test.snapshot,
+		}
+
+		resources, err := seed.getV1Resources(getSeedAddon(), getSeedBuilds())
+
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		config, env := getSeedJobConfig(t, resources[0])
+
+		for key, expValue := range test.expEnv {
+			if env[key] != expValue {
+				t.Errorf("%s: expected env %s to be %q, got %q", test.name, key, expValue, env[key])
+			}
+		}
+
+		script := env[seedScriptEnv].(string)
+
+		for _, value := range []string{password, "my db", `"snapshot"`, "rm -rf", "staging env", `db "primary"`} {
+			if strings.Contains(script, value) {
+				t.Errorf("%s: expected %q to only be passed through the environment, got script:\n%s", test.name, value, script)
+			}
+		}
+
+		command := config.Values["container"].(map[string]any)["command"]
+
+		if command != "/bin/bash -c $(PORTER_SEED_SCRIPT)" {
+			t.Errorf("%s: expected the job to run the seed script, got %v", test.name, command)
+		}
+	}
+}
+
+func TestGetV1ResourcesCommands(t *testing.T) {
+	seed := &Seed{
+		BuildRef: stringptr("web"),
+		Commands: []*string{
+			stringptr(`psql "$DATABASE_URL" -c "INSERT INTO users (name) VALUES ('it''s me')"`),
+			stringptr("npm run seed -- --name 'demo user'"),
+		},
+		RunOnce: boolptr(true),
+		Timeout: uintp(600),
+	}
+
+	resources, err := seed.getV1Resources(getSeedAddon(), getSeedBuilds())
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	config, env := getSeedJobConfig(t, resources[0])
+
+	expScript := "set -e\n" +
+		`psql "$DATABASE_URL" -c "INSERT INTO users (name) VALUES ('it''s me')"` + "\n" +
+		"npm run seed -- --name 'demo user'"
+
+	if env[seedScriptEnv] != expScript {
+		t.Errorf("expected the commands to be passed as is, got:\n%s", env[seedScriptEnv])
+	}
+
+	if !config.OnlyCreate {
+		t.Errorf("expected run_once to only create the seed job")
+	}
+
+	if command := config.Values["container"].(map[string]any)["command"]; command != "/bin/sh -c $(PORTER_SEED_SCRIPT)" {
+		t.Errorf("expected the job to run the seed script, got %v", command)
+	}
+
+	if timeout := config.Values["sidecar"].(map[string]any)["timeout"]; timeout != uint(600) {
+		t.Errorf("expected the timeout to be set, got %v", timeout)
+	}
+}
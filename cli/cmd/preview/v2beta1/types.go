@@ -1,17 +1,17 @@
 package v2beta1
 
-// type Variable struct {
-// 	Name   *string `yaml:"name" validate:"required,unique"`
-// 	Value  *string `yaml:"value" validate:"required_if=Random false"`
-// 	Once   *bool   `yaml:"once"`
-// 	Random *bool   `yaml:"random"`
-// 	Length *uint   `yaml:"length"`
-// }
-
-// type EnvGroup struct {
-// 	Name      *string `yaml:"name" validate:"required"`
-// 	CloneFrom *string `yaml:"clone_from" validate:"required"`
-// }
+type Variable struct {
+	Name   *string `yaml:"name" validate:"required,unique"`
+	Value  *string `yaml:"value" validate:"required_if=Random false"`
+	Once   *bool   `yaml:"once"`
+	Random *bool   `yaml:"random"`
+	Length *uint   `yaml:"length"`
+}
+
+type EnvGroup struct {
+	Name      *string `yaml:"name" validate:"required"`
+	CloneFrom *string `yaml:"clone_from" validate:"required"`
+}
 
 type BuildEnv struct {
 	Raw        map[*string]*string `yaml:"raw"`
@@ -53,11 +53,40 @@ type AddonResource struct {
 	HelmValues map[string]any `yaml:"helm_values"`
 }
 
+type SeedDatabase struct {
+	Host     *string `yaml:"host"`
+	Port     *string `yaml:"port"`
+	User     *string `yaml:"user"`
+	Password *string `yaml:"password"`
+	Name     *string `yaml:"name"`
+}
+
+type SeedSnapshot struct {
+	URL           *string   `yaml:"url"`
+	FromNamespace *string   `yaml:"from_namespace"`
+	FromAddon     *string   `yaml:"from_addon"`
+	Image         *string   `yaml:"image"`
+	EnvGroups     []*string `yaml:"env_groups"`
+}
+
+type Seed struct {
+	Name      *string       `yaml:"name"`
+	Addon     *string       `yaml:"addon" validate:"required"`
+	DependsOn []*string     `yaml:"depends_on"`
+	Database  *SeedDatabase `yaml:"database"`
+	Snapshot  *SeedSnapshot `yaml:"snapshot"`
+	BuildRef  *string       `yaml:"build_ref"`
+	Commands  []*string     `yaml:"commands"`
+	RunOnce   *bool         `yaml:"run_once"`
+	Timeout   *uint         `yaml:"timeout"`
+}
+
 type PorterYAML struct {
-	Version *string `yaml:"version"`
-	// Variables []*Variable `yaml:"variables"`
-	// EnvGroups []*EnvGroup `yaml:"env_groups"`
-	Builds []*Build         `yaml:"builds"`
-	Apps   []*AppResource   `yaml:"apps"`
-	Addons []*AddonResource `yaml:"addons"`
+	Version   *string          `yaml:"version"`
+	Variables []*Variable      `yaml:"variables"`
+	EnvGroups []*EnvGroup      `yaml:"env_groups"`
+	Builds    []*Build         `yaml:"builds"`
+	Apps      []*AppResource   `yaml:"apps"`
+	Addons    []*AddonResource `yaml:"addons"`
+	Seed      *Seed            `yaml:"seed"`
 }
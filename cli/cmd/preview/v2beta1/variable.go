@@ -0,0 +1,43 @@
+package v2beta1
+
+const defaultRandomLength = 8
+
+func (v *Variable) GetName() string {
+	if v == nil || v.Name == nil {
+		return ""
+	}
+
+	return *v.Name
+}
+
+func (v *Variable) GetValue() string {
+	if v == nil || v.Value == nil {
+		return ""
+	}
+
+	return *v.Value
+}
+
+func (v *Variable) GetOnce() bool {
+	if v == nil || v.Once == nil {
+		return false
+	}
+
+	return *v.Once
+}
+
+func (v *Variable) GetRandom() bool {
+	if v == nil || v.Random == nil {
+		return false
+	}
+
+	return *v.Random
+}
+
+func (v *Variable) GetLength() uint {
+	if v == nil || v.Length == nil || *v.Length == 0 {
+		return defaultRandomLength
+	}
+
+	return *v.Length
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/spf13/cobra"
+)
+
+// previewCmd represents the "porter preview" base command when called
+// without any subcommands
+var previewCmd = &cobra.Command{
+	Use:     "preview",
+	Aliases: []string{"previews"},
+	Short:   "Commands that manage the deployments of preview environments",
+}
+
+var previewWakeCmd = &cobra.Command{
+	Use:   "wake [deployment-id]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Wakes up a preview deployment which was put to sleep after being idle",
+	Long: fmt.Sprintf(`%s
+
+Scales the applications of a sleeping preview deployment back up. The ID of the
+deployment is included in the pull request comment posted when it was put to sleep:
+
+  %s
+
+Pushing a new commit to the pull request also wakes up its deployment.`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter preview wake\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter preview wake [deployment-id]"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, wakePreviewDeployment)
+
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(previewCmd)
+
+	previewCmd.AddCommand(previewWakeCmd)
+}
+
+func wakePreviewDeployment(_ *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	id, err := strconv.ParseUint(args[0], 10, 64)
+
+	if err != nil {
+		return fmt.Errorf("invalid deployment id %s: %w", args[0], err)
+	}
+
+	depl, err := client.WakeDeployment(context.Background(), cliConf.Project, cliConf.Cluster, uint(id))
+
+	if err != nil {
+		return err
+	}
+
+	if depl.Subdomain != "" {
+		color.New(color.FgGreen).Printf("Woke up deployment %d, available at %s\n", depl.ID, depl.Subdomain)
+	} else {
+		color.New(color.FgGreen).Printf("Woke up deployment %d\n", depl.ID)
+	}
+
+	return nil
+}
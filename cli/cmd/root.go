@@ -33,6 +33,13 @@ func Execute() {
 
 	rootCmd.PersistentFlags().AddFlagSet(utils.DefaultFlagSet)
 
+	// the profile is selected while loading the config, before the flags are parsed
+	rootCmd.PersistentFlags().String(
+		config.ProfileFlag,
+		"",
+		"name of the configuration profile to use (defaults to $PORTER_PROFILE or the current profile)",
+	)
+
 	if config.Version != "dev" {
 		ghClient := github.NewClient(nil)
 		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
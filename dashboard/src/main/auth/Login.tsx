@@ -20,7 +20,10 @@ type StateType = {
   hasBasic: boolean;
   hasGithub: boolean;
   hasGoogle: boolean;
+  hasOIDC: boolean;
   hasResetPassword: boolean;
+  twoFactorRequired: boolean;
+  twoFactorCode: string;
 };
 
 export default class Login extends Component<PropsType, StateType> {
@@ -32,11 +35,20 @@ export default class Login extends Component<PropsType, StateType> {
     hasBasic: true,
     hasGithub: true,
     hasGoogle: false,
+    hasOIDC: false,
     hasResetPassword: true,
+    twoFactorRequired: false,
+    twoFactorCode: "",
   };
 
   handleKeyDown = (e: any) => {
-    e.key === "Enter" ? this.handleLogin() : null;
+    if (e.key !== "Enter") {
+      return;
+    }
+
+    this.state.twoFactorRequired
+      ? this.handleTwoFactorLogin()
+      : this.handleLogin();
   };
 
   componentDidMount() {
@@ -54,6 +66,7 @@ export default class Login extends Component<PropsType, StateType> {
           hasBasic: res.data?.basic_login,
           hasGithub: res.data?.github_login,
           hasGoogle: res.data?.google_login,
+          hasOIDC: res.data?.oidc_login,
           hasResetPassword: res.data?.email,
         });
       })
@@ -84,6 +97,12 @@ export default class Login extends Component<PropsType, StateType> {
           {}
         )
         .then((res) => {
+          // the login is completed with a two-factor code
+          if (res?.data?.two_factor_required) {
+            this.setState({ twoFactorRequired: true });
+            return;
+          }
+
           // TODO: case and set credential error
           if (res?.data?.redirect) {
             window.location.href = res.data.redirect;
@@ -96,6 +115,24 @@ export default class Login extends Component<PropsType, StateType> {
     }
   };
 
+  handleTwoFactorLogin = (): void => {
+    let { twoFactorCode } = this.state;
+    let { authenticate } = this.props;
+    let { setUser } = this.context;
+
+    api
+      .logInUserTwoFactor("", { code: twoFactorCode.trim() }, {})
+      .then((res) => {
+        if (res?.data?.redirect) {
+          window.location.href = res.data.redirect;
+        } else {
+          setUser(res?.data?.id, res?.data?.email);
+          authenticate();
+        }
+      })
+      .catch((err) => this.context.setCurrentError(err.response.data.error));
+  };
+
   renderEmailError = () => {
     let { emailError } = this.state;
     if (emailError) {
@@ -130,6 +167,11 @@ export default class Login extends Component<PropsType, StateType> {
     window.location.href = redirectUrl;
   };
 
+  oidcRedirect = () => {
+    let redirectUrl = `/api/oauth/login/oidc`;
+    window.location.href = redirectUrl;
+  };
+
   renderGithubSection = () => {
     if (this.state.hasGithub) {
       return (
@@ -156,7 +198,41 @@ export default class Login extends Component<PropsType, StateType> {
     }
   };
 
+  renderOIDCSection = () => {
+    if (this.state.hasOIDC) {
+      return (
+        <OAuthButton onClick={this.oidcRedirect}>
+          <IconWrapper>Log in with SSO</IconWrapper>
+        </OAuthButton>
+      );
+    }
+  };
+
+  renderTwoFactorSection = () => {
+    return (
+      <div>
+        <InputWrapper>
+          <Input
+            type="text"
+            autoComplete="one-time-code"
+            placeholder="Two-factor code or recovery code"
+            value={this.state.twoFactorCode}
+            onChange={(e: ChangeEvent<HTMLInputElement>) =>
+              this.setState({ twoFactorCode: e.target.value })
+            }
+            valid={true}
+          />
+        </InputWrapper>
+        <Button onClick={this.handleTwoFactorLogin}>Verify</Button>
+      </div>
+    );
+  };
+
   renderBasicSection = () => {
+    if (this.state.twoFactorRequired) {
+      return this.renderTwoFactorSection();
+    }
+
     if (this.state.hasBasic) {
       let { email, password, credentialError, emailError } = this.state;
 
@@ -221,7 +297,9 @@ export default class Login extends Component<PropsType, StateType> {
       <StyledLogin>
         <LoginPanel
           hasBasic={this.state.hasBasic}
-          numOAuth={+this.state.hasGithub + +this.state.hasGoogle}
+          numOAuth={
+            +this.state.hasGithub + +this.state.hasGoogle + +this.state.hasOIDC
+          }
         >
           <OverflowWrapper>
             <GradientBg />
@@ -231,7 +309,10 @@ export default class Login extends Component<PropsType, StateType> {
             <Prompt>Log in to Porter</Prompt>
             {this.renderGithubSection()}
             {this.renderGoogleSection()}
-            {(this.state.hasGithub || this.state.hasGoogle) &&
+            {this.renderOIDCSection()}
+            {(this.state.hasGithub ||
+              this.state.hasGoogle ||
+              this.state.hasOIDC) &&
             this.state.hasBasic ? (
               <OrWrapper>
                 <Line />
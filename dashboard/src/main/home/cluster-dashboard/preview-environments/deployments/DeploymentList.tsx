@@ -30,6 +30,8 @@ const AvailableStatusFilters = [
   "failed",
   "timed_out",
   "updating",
+  "sleeping",
+  "expired",
 ];
 
 type AvailableStatusFiltersType = typeof AvailableStatusFilters[number];
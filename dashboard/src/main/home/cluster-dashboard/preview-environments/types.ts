@@ -5,6 +5,8 @@ export enum DeploymentStatus {
   Inactive = "inactive",
   TimedOut = "timed_out",
   Updating = "updating",
+  Sleeping = "sleeping",
+  Expired = "expired",
 }
 
 export type DeploymentStatusUnion = `${DeploymentStatus}`;
@@ -28,6 +30,7 @@ export type PRDeployment = {
   gh_pr_branch_from?: string;
   gh_pr_branch_into?: string;
   last_errors: string;
+  last_activity_at?: string;
 };
 
 export type EnvironmentDeploymentMode = "manual" | "auto";
@@ -47,6 +50,8 @@ export type Environment = {
   mode: EnvironmentDeploymentMode;
   namespace_labels: Record<string, string>;
   git_deploy_branches: string[];
+  idle_timeout_hours: number;
+  max_deployment_age_hours: number;
 };
 
 export type PullRequest = {
@@ -1314,6 +1314,35 @@ const logInUser = baseApi<{
   password: string;
 }>("POST", "/api/login");
 
+const logInUserTwoFactor = baseApi<{
+  code: string;
+}>("POST", "/api/login/2fa");
+
+const enrollTwoFactor = baseApi("POST", "/api/users/current/2fa/enroll");
+
+const activateTwoFactor = baseApi<{
+  code: string;
+}>("POST", "/api/users/current/2fa/activate");
+
+const disableTwoFactor = baseApi<{
+  code: string;
+}>("POST", "/api/users/current/2fa/disable");
+
+const regenerateTwoFactorRecoveryCodes = baseApi<{
+  code: string;
+}>("POST", "/api/users/current/2fa/recovery_codes");
+
+const updateTwoFactorSettings = baseApi<
+  {
+    two_factor_required: boolean;
+  },
+  {
+    project_id: number;
+  }
+>("POST", (pathParams) => {
+  return `/api/projects/${pathParams.project_id}/two_factor_settings`;
+});
+
 const logOutUser = baseApi("POST", "/api/logout");
 
 const registerUser = baseApi<{
@@ -1618,6 +1647,18 @@ const createPolicy = baseApi<
   { project_id: number }
 >("POST", ({ project_id }) => `/api/projects/${project_id}/policy`);
 
+const updatePolicy = baseApi<
+  {
+    name: string;
+    policy: PolicyDocType[];
+  },
+  { project_id: number; policy_id: string }
+>(
+  "PUT",
+  ({ project_id, policy_id }) =>
+    `/api/projects/${project_id}/policy/${policy_id}`
+);
+
 const getAvailableRoles = baseApi<{}, { project_id: number }>(
   "GET",
   ({ project_id }) => `/api/projects/${project_id}/roles`
@@ -1641,6 +1682,7 @@ const updateCollaborator = baseApi<
   {
     kind: string;
     user_id: number;
+    policy_uid?: string;
   },
   { project_id: number }
 >("POST", ({ project_id }) => `/api/projects/${project_id}/roles`);
@@ -2442,7 +2484,12 @@ export default {
   getGithubAccounts,
   listConfigMaps,
   logInUser,
+  logInUserTwoFactor,
   logOutUser,
+  enrollTwoFactor,
+  activateTwoFactor,
+  disableTwoFactor,
+  regenerateTwoFactorRecoveryCodes,
   registerUser,
   rollbackChart,
   uninstallTemplate,
@@ -2458,10 +2505,12 @@ export default {
   revokeAPIToken,
   createAPIToken,
   createPolicy,
+  updatePolicy,
   getAvailableRoles,
   getCollaborators,
   updateCollaborator,
   removeCollaborator,
+  updateTwoFactorSettings,
   getPolicyDocument,
   createWebhookToken,
   getUsage,
@@ -0,0 +1,231 @@
+package oidc
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	gooidc "github.com/coreos/go-oidc/v3/oidc"
+	"golang.org/x/oauth2"
+)
+
+// Config is the configuration of an OpenID Connect provider
+type Config struct {
+	// IssuerURL is the URL of the issuer, which must serve the discovery document at
+	// /.well-known/openid-configuration
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+
+	// Scopes are requested in addition to the openid scope
+	Scopes []string
+
+	// GroupsClaim is the name of the claim which lists the groups of the user
+	GroupsClaim string
+
+	// HTTPClient is used for all requests to the provider, and defaults to a client
+	// with a 10 second timeout
+	HTTPClient *http.Client
+}
+
+// Claims are the verified claims of an authenticated user
+type Claims struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Groups        []string
+}
+
+// Provider authenticates users against an OpenID Connect provider using the
+// authorization code flow. The discovery document of the provider is fetched lazily,
+// so that the server can start while the provider is unreachable.
+type Provider struct {
+	cfg *Config
+
+	mu               sync.Mutex
+	provider         *gooidc.Provider
+	verifier         *gooidc.IDTokenVerifier
+	userinfoEndpoint string
+}
+
+// NewProvider returns a Provider for the given configuration
+func NewProvider(cfg *Config) *Provider {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{
+			Timeout: 10 * time.Second,
+		}
+	}
+
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+
+	return &Provider{
+		cfg: cfg,
+	}
+}
+
+// AuthCodeURL returns the URL of the provider which the user is redirected to in order
+// to log in. The nonce is embedded in the ID token issued by the provider.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce string) (string, error) {
+	provider, _, err := p.getProvider(ctx)
+
+	if err != nil {
+		return "", err
+	}
+
+	return p.getOAuthConfig(provider).AuthCodeURL(state, gooidc.Nonce(nonce)), nil
+}
+
+// Exchange exchanges an authorization code for an ID token, and returns the claims of
+// the token once its signature, issuer, audience, expiry and nonce are verified
+func (p *Provider) Exchange(ctx context.Context, code, nonce string) (*Claims, error) {
+	provider, verifier, err := p.getProvider(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = gooidc.ClientContext(ctx, p.cfg.HTTPClient)
+
+	token, err := p.getOAuthConfig(provider).Exchange(ctx, code)
+
+	if err != nil {
+		return nil, fmt.Errorf("could not exchange code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+
+	if !ok || rawIDToken == "" {
+		return nil, fmt.Errorf("token response does not contain an id_token")
+	}
+
+	idToken, err := verifier.Verify(ctx, rawIDToken)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid id_token: %w", err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(nonce)) != 1 {
+		return nil, fmt.Errorf("id_token nonce does not match")
+	}
+
+	rawClaims := make(map[string]interface{})
+
+	if err := idToken.Claims(&rawClaims); err != nil {
+		return nil, fmt.Errorf("malformed id_token claims: %w", err)
+	}
+
+	// providers such as Okta only return a minimal set of claims in the ID token, in which
+	// case the remaining claims are read from the userinfo endpoint
+	if _, hasEmail := rawClaims["email"]; !hasEmail && p.userinfoEndpoint != "" {
+		userinfo, err := provider.UserInfo(ctx, oauth2.StaticTokenSource(token))
+
+		if err != nil {
+			return nil, fmt.Errorf("could not read userinfo: %w", err)
+		}
+
+		if userinfo.Subject != idToken.Subject {
+			return nil, fmt.Errorf("userinfo subject does not match the id_token subject")
+		}
+
+		userinfoClaims := make(map[string]interface{})
+
+		if err := userinfo.Claims(&userinfoClaims); err != nil {
+			return nil, fmt.Errorf("malformed userinfo claims: %w", err)
+		}
+
+		for key, val := range userinfoClaims {
+			if _, exists := rawClaims[key]; !exists {
+				rawClaims[key] = val
+			}
+		}
+	}
+
+	return p.getClaims(rawClaims)
+}
+
+// getProvider returns the discovered provider and an ID token verifier for the client,
+// performing discovery on the first call which succeeds
+func (p *Provider) getProvider(ctx context.Context) (*gooidc.Provider, *gooidc.IDTokenVerifier, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.provider != nil {
+		return p.provider, p.verifier, nil
+	}
+
+	provider, err := gooidc.NewProvider(
+		gooidc.ClientContext(ctx, p.cfg.HTTPClient),
+		strings.TrimSuffix(p.cfg.IssuerURL, "/"),
+	)
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not read discovery document: %w", err)
+	}
+
+	discovery := struct {
+		UserinfoEndpoint string `json:"userinfo_endpoint"`
+	}{}
+
+	if err := provider.Claims(&discovery); err != nil {
+		return nil, nil, fmt.Errorf("could not read discovery document: %w", err)
+	}
+
+	p.provider = provider
+	p.userinfoEndpoint = discovery.UserinfoEndpoint
+	p.verifier = provider.Verifier(&gooidc.Config{
+		ClientID: p.cfg.ClientID,
+	})
+
+	return p.provider, p.verifier, nil
+}
+
+func (p *Provider) getOAuthConfig(provider *gooidc.Provider) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     p.cfg.ClientID,
+		ClientSecret: p.cfg.ClientSecret,
+		Endpoint:     provider.Endpoint(),
+		RedirectURL:  p.cfg.RedirectURL,
+		Scopes:       append([]string{gooidc.ScopeOpenID}, p.cfg.Scopes...),
+	}
+}
+
+func (p *Provider) getClaims(rawClaims map[string]interface{}) (*Claims, error) {
+	claims := &Claims{}
+
+	claims.Issuer, _ = rawClaims["iss"].(string)
+	claims.Subject, _ = rawClaims["sub"].(string)
+	claims.Email, _ = rawClaims["email"].(string)
+
+	if claims.Subject == "" {
+		return nil, fmt.Errorf("id_token does not contain a subject")
+	}
+
+	// some providers send email_verified as a string
+	switch verified := rawClaims["email_verified"].(type) {
+	case bool:
+		claims.EmailVerified = verified
+	case string:
+		claims.EmailVerified = verified == "true"
+	}
+
+	switch groups := rawClaims[p.cfg.GroupsClaim].(type) {
+	case string:
+		claims.Groups = []string{groups}
+	case []interface{}:
+		for _, group := range groups {
+			if groupStr, ok := group.(string); ok {
+				claims.Groups = append(claims.Groups, groupStr)
+			}
+		}
+	}
+
+	return claims, nil
+}
@@ -0,0 +1,269 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/auth/oidc"
+)
+
+// mockIssuer is a minimal OpenID Connect provider which issues an ID token with the
+// configured claims for any authorization code
+type mockIssuer struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+	claims map[string]interface{}
+
+	userinfo map[string]interface{}
+}
+
+func newMockIssuer(t *testing.T) *mockIssuer {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	issuer := &mockIssuer{key: key}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer.server.URL,
+			"authorization_endpoint": issuer.server.URL + "/authorize",
+			"token_endpoint":         issuer.server.URL + "/token",
+			"userinfo_endpoint":      issuer.server.URL + "/userinfo",
+			"jwks_uri":               issuer.server.URL + "/keys",
+		})
+	})
+
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{
+				{
+					"kid": "test-key",
+					"kty": "RSA",
+					"use": "sig",
+					"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+				},
+			},
+		})
+	})
+
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"expires_in":   3600,
+			"id_token":     issuer.sign(t, issuer.claims),
+		})
+	})
+
+	mux.HandleFunc("/userinfo", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		json.NewEncoder(w).Encode(issuer.userinfo)
+	})
+
+	issuer.server = httptest.NewServer(mux)
+
+	return issuer
+}
+
+func (m *mockIssuer) sign(t *testing.T, claims map[string]interface{}) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "kid": "test-key", "typ": "JWT"})
+	payload, _ := json.Marshal(claims)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, m.key, crypto.SHA256, digest[:])
+
+	if err != nil {
+		t.Errorf("%v", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (m *mockIssuer) defaultClaims(nonce string) map[string]interface{} {
+	return map[string]interface{}{
+		"iss":            m.server.URL,
+		"sub":            "user-1",
+		"aud":            "porter",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          nonce,
+		"email":          "user@example.com",
+		"email_verified": true,
+		"groups":         []string{"platform", "developers"},
+	}
+}
+
+func newTestProvider(issuer *mockIssuer) *oidc.Provider {
+	return oidc.NewProvider(&oidc.Config{
+		IssuerURL:    issuer.server.URL,
+		ClientID:     "porter",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost/api/oauth/oidc/callback",
+		Scopes:       []string{"email", "profile"},
+	})
+}
+
+func TestAuthCodeURL(t *testing.T) {
+	issuer := newMockIssuer(t)
+	defer issuer.server.Close()
+
+	authURL, err := newTestProvider(issuer).AuthCodeURL(context.Background(), "state", "nonce")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	parsed, err := url.Parse(authURL)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	query := parsed.Query()
+
+	if !strings.HasPrefix(authURL, issuer.server.URL+"/authorize") ||
+		query.Get("state") != "state" ||
+		query.Get("nonce") != "nonce" ||
+		query.Get("scope") != "openid email profile" {
+		t.Errorf("unexpected auth code URL %s", authURL)
+	}
+}
+
+func TestExchange(t *testing.T) {
+	issuer := newMockIssuer(t)
+	defer issuer.server.Close()
+
+	issuer.claims = issuer.defaultClaims("nonce")
+
+	claims, err := newTestProvider(issuer).Exchange(context.Background(), "code", "nonce")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if claims.Subject != "user-1" || claims.Email != "user@example.com" || !claims.EmailVerified {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+
+	if len(claims.Groups) != 2 || claims.Groups[0] != "platform" {
+		t.Errorf("unexpected groups %v", claims.Groups)
+	}
+}
+
+func TestExchangeUserinfo(t *testing.T) {
+	issuer := newMockIssuer(t)
+	defer issuer.server.Close()
+
+	issuer.claims = issuer.defaultClaims("nonce")
+	delete(issuer.claims, "email")
+	delete(issuer.claims, "groups")
+
+	issuer.userinfo = map[string]interface{}{
+		"sub":            "user-1",
+		"email":          "user@example.com",
+		"email_verified": "true",
+		"groups":         "platform",
+	}
+
+	claims, err := newTestProvider(issuer).Exchange(context.Background(), "code", "nonce")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if claims.Email != "user@example.com" || !claims.EmailVerified || len(claims.Groups) != 1 {
+		t.Errorf("unexpected claims %+v", claims)
+	}
+}
+
+func TestExchangeInvalidTokens(t *testing.T) {
+	issuer := newMockIssuer(t)
+	defer issuer.server.Close()
+
+	tests := []struct {
+		name   string
+		modify func(claims map[string]interface{})
+		errMsg string
+	}{
+		{
+			name:   "wrong nonce",
+			modify: func(claims map[string]interface{}) { claims["nonce"] = "other" },
+			errMsg: "nonce",
+		},
+		{
+			name:   "wrong audience",
+			modify: func(claims map[string]interface{}) { claims["aud"] = []string{"other"} },
+			errMsg: "expected audience",
+		},
+		{
+			name:   "wrong issuer",
+			modify: func(claims map[string]interface{}) { claims["iss"] = "https://evil.example.com" },
+			errMsg: "different provider",
+		},
+		{
+			name:   "expired",
+			modify: func(claims map[string]interface{}) { claims["exp"] = time.Now().Add(-time.Minute).Unix() },
+			errMsg: "expired",
+		},
+	}
+
+	for _, test := range tests {
+		issuer.claims = issuer.defaultClaims("nonce")
+		test.modify(issuer.claims)
+
+		_, err := newTestProvider(issuer).Exchange(context.Background(), "code", "nonce")
+
+		if err == nil || !strings.Contains(err.Error(), test.errMsg) {
+			t.Errorf("%s: expected error containing %q, got %v", test.name, test.errMsg, err)
+		}
+	}
+}
+
+func TestExchangeInvalidSignature(t *testing.T) {
+	issuer := newMockIssuer(t)
+	defer issuer.server.Close()
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// sign tokens with a key which is not published by the issuer
+	issuer.key = otherKey
+	issuer.claims = issuer.defaultClaims("nonce")
+
+	_, err = newTestProvider(issuer).Exchange(context.Background(), "code", "nonce")
+
+	if err == nil || !strings.Contains(err.Error(), "signature") {
+		t.Errorf("expected signature error, got %v", err)
+	}
+}
@@ -0,0 +1,105 @@
+package oidc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// GroupRole grants a project role to the members of a group of the provider
+type GroupRole struct {
+	Group     string
+	ProjectID uint
+	Kind      types.RoleKind
+}
+
+// ParseGroupRoles parses group role mappings of the form <group>:<project_id>:<role>,
+// where role is one of admin, developer or viewer
+func ParseGroupRoles(mappings []string) ([]*GroupRole, error) {
+	res := make([]*GroupRole, 0)
+
+	for _, mapping := range mappings {
+		mapping = strings.TrimSpace(mapping)
+
+		if mapping == "" {
+			continue
+		}
+
+		// split from the right, since group names may contain colons
+		roleIndex := strings.LastIndex(mapping, ":")
+
+		if roleIndex <= 0 {
+			return nil, fmt.Errorf("invalid group role mapping %s", mapping)
+		}
+
+		projectIndex := strings.LastIndex(mapping[:roleIndex], ":")
+
+		if projectIndex <= 0 {
+			return nil, fmt.Errorf("invalid group role mapping %s", mapping)
+		}
+
+		projectID, err := strconv.ParseUint(mapping[projectIndex+1:roleIndex], 10, 64)
+
+		if err != nil || projectID == 0 {
+			return nil, fmt.Errorf("invalid project ID in group role mapping %s", mapping)
+		}
+
+		kind := types.RoleKind(mapping[roleIndex+1:])
+
+		if getRolePriority(kind) == 0 {
+			return nil, fmt.Errorf("invalid role in group role mapping %s", mapping)
+		}
+
+		res = append(res, &GroupRole{
+			Group:     mapping[:projectIndex],
+			ProjectID: uint(projectID),
+			Kind:      kind,
+		})
+	}
+
+	return res, nil
+}
+
+// GetProjectRoles returns the role which the groups are granted in each project. If
+// several groups are mapped to the same project, the most permissive role is granted.
+func GetProjectRoles(groupRoles []*GroupRole, groups []string) map[uint]types.RoleKind {
+	res := make(map[uint]types.RoleKind)
+
+	groupSet := make(map[string]bool)
+
+	for _, group := range groups {
+		groupSet[group] = true
+	}
+
+	for _, groupRole := range groupRoles {
+		if !groupSet[groupRole.Group] {
+			continue
+		}
+
+		if getRolePriority(groupRole.Kind) > getRolePriority(res[groupRole.ProjectID]) {
+			res[groupRole.ProjectID] = groupRole.Kind
+		}
+	}
+
+	return res
+}
+
+// IsHigherRole returns true if kind is more permissive than the other role kind
+func IsHigherRole(kind, other types.RoleKind) bool {
+	return getRolePriority(kind) > getRolePriority(other)
+}
+
+func getRolePriority(kind types.RoleKind) int {
+	switch kind {
+	case types.RoleAdmin:
+		return 3
+	case types.RoleDeveloper:
+		return 2
+	case types.RoleViewer:
+		return 1
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,65 @@
+package oidc_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/oidc"
+)
+
+func TestParseGroupRoles(t *testing.T) {
+	groupRoles, err := oidc.ParseGroupRoles([]string{
+		"platform:1:admin",
+		"org:developers:2:developer",
+		" ",
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if len(groupRoles) != 2 {
+		t.Fatalf("expected 2 group roles, got %d", len(groupRoles))
+	}
+
+	if groupRoles[1].Group != "org:developers" || groupRoles[1].ProjectID != 2 || groupRoles[1].Kind != types.RoleDeveloper {
+		t.Errorf("unexpected group role %+v", groupRoles[1])
+	}
+
+	for _, invalid := range []string{"platform", "platform:admin", "platform:0:admin", "platform:1:custom", ":1:admin"} {
+		if _, err := oidc.ParseGroupRoles([]string{invalid}); err == nil {
+			t.Errorf("expected error for mapping %s", invalid)
+		}
+	}
+}
+
+func TestGetProjectRoles(t *testing.T) {
+	groupRoles := []*oidc.GroupRole{
+		{Group: "viewers", ProjectID: 1, Kind: types.RoleViewer},
+		{Group: "platform", ProjectID: 1, Kind: types.RoleAdmin},
+		{Group: "developers", ProjectID: 2, Kind: types.RoleDeveloper},
+		{Group: "developers", ProjectID: 1, Kind: types.RoleDeveloper},
+	}
+
+	roles := oidc.GetProjectRoles(groupRoles, []string{"viewers", "developers"})
+
+	if len(roles) != 2 || roles[1] != types.RoleDeveloper || roles[2] != types.RoleDeveloper {
+		t.Errorf("unexpected roles %v", roles)
+	}
+
+	roles = oidc.GetProjectRoles(groupRoles, []string{"platform", "viewers"})
+
+	if len(roles) != 1 || roles[1] != types.RoleAdmin {
+		t.Errorf("unexpected roles %v", roles)
+	}
+}
+
+func TestIsHigherRole(t *testing.T) {
+	if !oidc.IsHigherRole(types.RoleAdmin, types.RoleDeveloper) || !oidc.IsHigherRole(types.RoleDeveloper, types.RoleViewer) {
+		t.Errorf("expected roles to be ordered admin > developer > viewer")
+	}
+
+	if oidc.IsHigherRole(types.RoleViewer, types.RoleAdmin) || oidc.IsHigherRole(types.RoleDeveloper, types.RoleDeveloper) {
+		t.Errorf("expected lower and equal roles not to be higher")
+	}
+}
@@ -0,0 +1,185 @@
+// Package totp implements time-based one-time passwords (RFC 6238) as used by
+// authenticator apps, along with single-use recovery codes.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	// Period is the number of seconds a code is valid for
+	Period = 30
+
+	// Digits is the number of digits of a code
+	Digits = 6
+
+	// secretSize is the size of generated secrets in bytes, as recommended by RFC 4226
+	secretSize = 20
+
+	// skew is the number of periods before and after the current one for which a code
+	// is accepted, to account for clock drift
+	skew = 1
+
+	recoveryCodeSize = 5
+)
+
+var b32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateSecret returns a new random base32-encoded secret
+func GenerateSecret() (string, error) {
+	secret := make([]byte, secretSize)
+
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return b32NoPadding.EncodeToString(secret), nil
+}
+
+// ProvisioningURI returns the otpauth:// URI that authenticator apps read from a QR code
+func ProvisioningURI(issuer, account, secret string) string {
+	v := url.Values{}
+	v.Set("secret", secret)
+	v.Set("issuer", issuer)
+	v.Set("algorithm", "SHA1")
+	v.Set("digits", fmt.Sprintf("%d", Digits))
+	v.Set("period", fmt.Sprintf("%d", Period))
+
+	u := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: v.Encode(),
+	}
+
+	return u.String()
+}
+
+// GenerateCode returns the code for a secret at the given time
+func GenerateCode(secret string, t time.Time) (string, error) {
+	key, err := decodeSecret(secret)
+
+	if err != nil {
+		return "", err
+	}
+
+	return generateCode(key, timeStep(t), Digits), nil
+}
+
+// ValidateCode checks a code against a secret at the given time. If the code is valid,
+// it returns the time step the code was generated for, which callers can store to
+// reject a code that has already been used.
+func ValidateCode(secret, code string, t time.Time) (int64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+
+	if len(code) != Digits {
+		return 0, false
+	}
+
+	key, err := decodeSecret(secret)
+
+	if err != nil {
+		return 0, false
+	}
+
+	step := timeStep(t)
+
+	for i := int64(-skew); i <= skew; i++ {
+		expCode := generateCode(key, step+i, Digits)
+
+		if subtle.ConstantTimeCompare([]byte(expCode), []byte(code)) == 1 {
+			return step + i, true
+		}
+	}
+
+	return 0, false
+}
+
+// GenerateRecoveryCodes returns n random recovery codes, along with their hashes in the
+// same order. Only the hashes should be stored.
+func GenerateRecoveryCodes(n int) ([]string, []string, error) {
+	codes := make([]string, 0, n)
+	hashes := make([]string, 0, n)
+
+	for i := 0; i < n; i++ {
+		b := make([]byte, recoveryCodeSize)
+
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+
+		encoded := strings.ToLower(b32NoPadding.EncodeToString(b))
+		code := encoded[:4] + "-" + encoded[4:]
+
+		codes = append(codes, code)
+		hashes = append(hashes, HashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode returns the hash of a recovery code. Recovery codes are random, so a
+// fast hash is sufficient.
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+
+	sum := sha256.Sum256([]byte(code))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// MatchRecoveryCode returns the index of the hash in hashes that matches the code,
+// or -1 if there is none
+func MatchRecoveryCode(hashes []string, code string) int {
+	hashed := HashRecoveryCode(code)
+
+	for i, hash := range hashes {
+		if subtle.ConstantTimeCompare([]byte(hash), []byte(hashed)) == 1 {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func decodeSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.TrimRight(strings.TrimSpace(secret), "="))
+
+	return b32NoPadding.DecodeString(secret)
+}
+
+func timeStep(t time.Time) int64 {
+	return t.Unix() / Period
+}
+
+// generateCode implements HOTP (RFC 4226) with HMAC-SHA1
+func generateCode(key []byte, counter int64, digits int) string {
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, uint64(counter))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+
+	return fmt.Sprintf("%0*d", digits, value%mod)
+}
@@ -0,0 +1,134 @@
+package totp_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/auth/totp"
+)
+
+// base32 encoding of the RFC 6238 test secret "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+// the test vectors of RFC 6238 for SHA1, truncated to 6 digits
+var rfcVectors = []struct {
+	unix int64
+	code string
+}{
+	{59, "287082"},
+	{1111111109, "081804"},
+	{1111111111, "050471"},
+	{1234567890, "005924"},
+	{2000000000, "279037"},
+	{20000000000, "353130"},
+}
+
+func TestGenerateCode(t *testing.T) {
+	for _, v := range rfcVectors {
+		code, err := totp.GenerateCode(rfcSecret, time.Unix(v.unix, 0))
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		if code != v.code {
+			t.Errorf("code at %d: expected %s, got %s", v.unix, v.code, code)
+		}
+	}
+}
+
+func TestValidateCode(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+
+	step, ok := totp.ValidateCode(rfcSecret, "050471", now)
+
+	if !ok {
+		t.Fatalf("expected current code to be valid")
+	}
+
+	if step != now.Unix()/totp.Period {
+		t.Errorf("expected step %d, got %d", now.Unix()/totp.Period, step)
+	}
+
+	// codes from the previous and next periods are accepted to allow for clock drift
+	if _, ok := totp.ValidateCode(rfcSecret, "081804", now); !ok {
+		t.Errorf("expected code from the previous period to be valid")
+	}
+
+	prevPrevCode, _ := totp.GenerateCode(rfcSecret, now.Add(-2*totp.Period*time.Second))
+
+	if _, ok := totp.ValidateCode(rfcSecret, prevPrevCode, now); ok {
+		t.Errorf("expected code from two periods ago to be invalid")
+	}
+
+	for _, code := range []string{"", "05047", "0504711", "abcdef", "050472"} {
+		if _, ok := totp.ValidateCode(rfcSecret, code, now); ok {
+			t.Errorf("expected code %q to be invalid", code)
+		}
+	}
+
+	if _, ok := totp.ValidateCode(rfcSecret, " 050 471 ", now); !ok {
+		t.Errorf("expected code with spaces to be valid")
+	}
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := totp.GenerateSecret()
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	code, err := totp.GenerateCode(secret, time.Now())
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, ok := totp.ValidateCode(secret, code, time.Now()); !ok {
+		t.Errorf("expected code for generated secret to be valid")
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := totp.ProvisioningURI("Porter", "test@test.it", rfcSecret)
+
+	if !strings.HasPrefix(uri, "otpauth://totp/Porter:test@test.it?") {
+		t.Errorf("unexpected provisioning uri %s", uri)
+	}
+
+	if !strings.Contains(uri, "secret="+rfcSecret) || !strings.Contains(uri, "issuer=Porter") {
+		t.Errorf("provisioning uri %s is missing the secret or issuer", uri)
+	}
+}
+
+func TestRecoveryCodes(t *testing.T) {
+	codes, hashes, err := totp.GenerateRecoveryCodes(10)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(codes) != 10 || len(hashes) != 10 {
+		t.Fatalf("expected 10 codes and hashes, got %d and %d", len(codes), len(hashes))
+	}
+
+	for i, code := range codes {
+		if code == hashes[i] {
+			t.Errorf("recovery code should not be stored in plaintext")
+		}
+
+		if idx := totp.MatchRecoveryCode(hashes, code); idx != i {
+			t.Errorf("expected code %d to match hash %d", i, idx)
+		}
+
+		if idx := totp.MatchRecoveryCode(hashes, strings.ToUpper(strings.ReplaceAll(code, "-", ""))); idx != i {
+			t.Errorf("expected code %d to match regardless of case and dashes", i)
+		}
+	}
+
+	if idx := totp.MatchRecoveryCode(hashes, "aaaa-aaaa"); idx != -1 {
+		t.Errorf("expected unknown code not to match")
+	}
+}
@@ -0,0 +1,246 @@
+// Package envfile reads and writes sets of environment variables in the dotenv, JSON
+// and YAML file formats.
+package envfile
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	FormatDotenv = "dotenv"
+	FormatJSON   = "json"
+	FormatYAML   = "yaml"
+)
+
+// keyRegex matches the keys that are valid in a configmap or secret
+var keyRegex = regexp.MustCompile(`^[-._a-zA-Z0-9]+$`)
+
+// Parse reads the variables from the contents of a file in the given format
+func Parse(format, content string) (map[string]string, error) {
+	var res map[string]string
+	var err error
+
+	switch format {
+	case FormatDotenv:
+		res, err = parseDotenv(content)
+	case FormatJSON:
+		res, err = parseJSON([]byte(content))
+	case FormatYAML:
+		var jsonBytes []byte
+
+		jsonBytes, err = yaml.YAMLToJSON([]byte(content))
+
+		if err == nil {
+			res, err = parseJSON(jsonBytes)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported format %s", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	for key := range res {
+		if !keyRegex.MatchString(key) {
+			return nil, fmt.Errorf("invalid variable name %q", key)
+		}
+	}
+
+	return res, nil
+}
+
+// Format writes the variables as the contents of a file in the given format. Variables
+// are sorted by name.
+func Format(format string, vars map[string]string) (string, error) {
+	switch format {
+	case FormatDotenv:
+		return formatDotenv(vars), nil
+	case FormatJSON:
+		res, err := json.MarshalIndent(vars, "", "  ")
+
+		if err != nil {
+			return "", err
+		}
+
+		return string(res) + "\n", nil
+	case FormatYAML:
+		res, err := yaml.Marshal(vars)
+
+		if err != nil {
+			return "", err
+		}
+
+		return string(res), nil
+	}
+
+	return "", fmt.Errorf("unsupported format %s", format)
+}
+
+func parseJSON(content []byte) (map[string]string, error) {
+	raw := make(map[string]interface{})
+
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("file must contain an object of variables: %w", err)
+	}
+
+	res := make(map[string]string)
+
+	for key, val := range raw {
+		switch v := val.(type) {
+		case string:
+			res[key] = v
+		case json.Number:
+			res[key] = v.String()
+		case bool:
+			res[key] = fmt.Sprintf("%t", v)
+		case nil:
+			res[key] = ""
+		default:
+			return nil, fmt.Errorf("value of variable %s must be a string, number or boolean", key)
+		}
+	}
+
+	return res, nil
+}
+
+func parseDotenv(content string) (map[string]string, error) {
+	res := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	lineNum := 0
+
+	for scanner.Scan() {
+		lineNum++
+
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+
+		key, val, found := strings.Cut(line, "=")
+
+		if !found {
+			return nil, fmt.Errorf("line %d: expected KEY=VALUE", lineNum)
+		}
+
+		key = strings.TrimSpace(key)
+		val, err := parseDotenvValue(strings.TrimSpace(val))
+
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNum, err)
+		}
+
+		res[key] = val
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func parseDotenvValue(val string) (string, error) {
+	if val == "" {
+		return "", nil
+	}
+
+	switch val[0] {
+	case '\'':
+		end := strings.IndexByte(val[1:], '\'')
+
+		if end < 0 {
+			return "", fmt.Errorf("unterminated single-quoted value")
+		}
+
+		return val[1 : end+1], nil
+	case '"':
+		var sb strings.Builder
+
+		for i := 1; i < len(val); i++ {
+			switch val[i] {
+			case '"':
+				return sb.String(), nil
+			case '\\':
+				if i+1 == len(val) {
+					return "", fmt.Errorf("unterminated double-quoted value")
+				}
+
+				i++
+
+				switch val[i] {
+				case 'n':
+					sb.WriteByte('\n')
+				case 'r':
+					sb.WriteByte('\r')
+				case 't':
+					sb.WriteByte('\t')
+				default:
+					sb.WriteByte(val[i])
+				}
+			default:
+				sb.WriteByte(val[i])
+			}
+		}
+
+		return "", fmt.Errorf("unterminated double-quoted value")
+	}
+
+	// unquoted values end at an inline comment
+	if idx := strings.Index(val, " #"); idx >= 0 {
+		val = strings.TrimSpace(val[:idx])
+	}
+
+	return val, nil
+}
+
+func formatDotenv(vars map[string]string) string {
+	keys := make([]string, 0, len(vars))
+
+	for key := range vars {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var sb strings.Builder
+
+	for _, key := range keys {
+		sb.WriteString(key)
+		sb.WriteByte('=')
+		sb.WriteString(quoteDotenvValue(vars[key]))
+		sb.WriteByte('\n')
+	}
+
+	return sb.String()
+}
+
+func quoteDotenvValue(val string) string {
+	if val != "" && !strings.ContainsAny(val, " \t\r\n\"'\\#=$`") {
+		return val
+	}
+
+	replacer := strings.NewReplacer(
+		`\`, `\\`,
+		`"`, `\"`,
+		"\n", `\n`,
+		"\r", `\r`,
+		"\t", `\t`,
+	)
+
+	return `"` + replacer.Replace(val) + `"`
+}
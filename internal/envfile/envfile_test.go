@@ -0,0 +1,126 @@
+package envfile_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/envfile"
+)
+
+func TestParseDotenv(t *testing.T) {
+	content := `
+# database settings
+DB_HOST=localhost
+export DB_PORT=5432
+DB_NAME = porter # inline comment
+EMPTY=
+SINGLE='literal \n $HOME'
+DOUBLE="line one\nline \"two\""
+HASH=abc#def
+`
+
+	expected := map[string]string{
+		"DB_HOST": "localhost",
+		"DB_PORT": "5432",
+		"DB_NAME": "porter",
+		"EMPTY":   "",
+		"SINGLE":  `literal \n $HOME`,
+		"DOUBLE":  "line one\nline \"two\"",
+		"HASH":    "abc#def",
+	}
+
+	vars, err := envfile.Parse(envfile.FormatDotenv, content)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !reflect.DeepEqual(vars, expected) {
+		t.Errorf("expected %v, got %v", expected, vars)
+	}
+
+	if _, err := envfile.Parse(envfile.FormatDotenv, "NO_VALUE"); err == nil {
+		t.Errorf("expected error for line without a value")
+	}
+
+	if _, err := envfile.Parse(envfile.FormatDotenv, `OPEN="abc`); err == nil {
+		t.Errorf("expected error for unterminated quote")
+	}
+
+	if _, err := envfile.Parse(envfile.FormatDotenv, "BAD KEY=1"); err == nil {
+		t.Errorf("expected error for invalid variable name")
+	}
+}
+
+func TestParseJSONAndYAML(t *testing.T) {
+	expected := map[string]string{
+		"PORT":    "8080",
+		"DEBUG":   "true",
+		"NAME":    "porter",
+		"LARGE":   "12345678901234567890",
+		"NOTHING": "",
+	}
+
+	vars, err := envfile.Parse(envfile.FormatJSON, `{"PORT": 8080, "DEBUG": true, "NAME": "porter", "LARGE": 12345678901234567890, "NOTHING": null}`)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !reflect.DeepEqual(vars, expected) {
+		t.Errorf("expected %v, got %v", expected, vars)
+	}
+
+	vars, err = envfile.Parse(envfile.FormatYAML, "PORT: 8080\nDEBUG: true\nNAME: porter\nLARGE: 12345678901234567890\nNOTHING:\n")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !reflect.DeepEqual(vars, expected) {
+		t.Errorf("expected %v, got %v", expected, vars)
+	}
+
+	if _, err := envfile.Parse(envfile.FormatJSON, `{"NESTED": {"a": "b"}}`); err == nil {
+		t.Errorf("expected error for nested value")
+	}
+
+	if _, err := envfile.Parse("toml", ""); err == nil {
+		t.Errorf("expected error for unsupported format")
+	}
+}
+
+func TestFormatRoundTrip(t *testing.T) {
+	vars := map[string]string{
+		"PLAIN":     "value",
+		"SPACES":    "hello world",
+		"QUOTES":    `say "hi"`,
+		"MULTILINE": "a\nb",
+		"EMPTY":     "",
+		"BACKSLASH": `C:\path`,
+	}
+
+	for _, format := range []string{envfile.FormatDotenv, envfile.FormatJSON, envfile.FormatYAML} {
+		content, err := envfile.Format(format, vars)
+
+		if err != nil {
+			t.Fatalf("%s: %v\n", format, err)
+		}
+
+		parsed, err := envfile.Parse(format, content)
+
+		if err != nil {
+			t.Fatalf("%s: %v\n", format, err)
+		}
+
+		if !reflect.DeepEqual(parsed, vars) {
+			t.Errorf("%s: expected %v, got %v", format, vars, parsed)
+		}
+	}
+
+	content, _ := envfile.Format(envfile.FormatDotenv, map[string]string{"B": "2", "A": "1"})
+
+	if content != "A=1\nB=2\n" {
+		t.Errorf("expected sorted dotenv output, got %q", content)
+	}
+}
@@ -173,6 +173,9 @@ type UpgradeReleaseConfig struct {
 	// Optional, if chart is part of a Porter Stack
 	StackName     string
 	StackRevision uint
+
+	// Optional, if set the upgrade is only rendered and is not applied to the cluster
+	DryRun bool
 }
 
 // UpgradeRelease upgrades a specific release with new values.yaml
@@ -214,6 +217,7 @@ func (a *Agent) UpgradeReleaseByValues(
 
 	cmd := action.NewUpgrade(a.ActionConfig)
 	cmd.Namespace = rel.Namespace
+	cmd.DryRun = conf.DryRun
 
 	cmd.PostRenderer, err = NewPorterPostrenderer(
 		conf.Cluster,
@@ -223,6 +227,7 @@ func (a *Agent) UpgradeReleaseByValues(
 		conf.Registries,
 		doAuth,
 		disablePullSecretsInjection,
+		conf.DryRun,
 	)
 
 	if err != nil {
@@ -239,7 +244,10 @@ func (a *Agent) UpgradeReleaseByValues(
 
 	res, err := cmd.Run(conf.Name, ch, conf.Values)
 
-	if err != nil {
+	if err != nil && conf.DryRun {
+		// the recovery steps below modify the stored release, which a dry run should never do
+		return nil, fmt.Errorf("Upgrade dry run failed: %w", err)
+	} else if err != nil {
 		// refer: https://github.com/helm/helm/blob/release-3.8/pkg/action/action.go#L62
 		// issue tracker: https://github.com/helm/helm/issues/4558
 		if err.Error() == "another operation (install/upgrade/rollback) is in progress" {
@@ -379,6 +387,9 @@ type InstallChartConfig struct {
 	Cluster    *models.Cluster
 	Repo       repository.Repository
 	Registries []*models.Registry
+
+	// Optional, if set the chart is only rendered and is not installed in the cluster
+	DryRun bool
 }
 
 // InstallChartFromValuesBytes reads the raw values and calls Agent.InstallChart
@@ -414,6 +425,7 @@ func (a *Agent) InstallChart(
 	cmd.ReleaseName = conf.Name
 	cmd.Namespace = conf.Namespace
 	cmd.Timeout = 300 * time.Second
+	cmd.DryRun = conf.DryRun
 
 	if err := checkIfInstallable(conf.Chart); err != nil {
 		return nil, err
@@ -429,6 +441,7 @@ func (a *Agent) InstallChart(
 		conf.Registries,
 		doAuth,
 		disablePullSecretsInjection,
+		conf.DryRun,
 	)
 
 	if err != nil {
@@ -33,6 +33,7 @@ func NewPorterPostrenderer(
 	regs []*models.Registry,
 	doAuth *oauth2.Config,
 	disablePullSecretsInjection bool,
+	dryRun bool,
 ) (postrender.PostRenderer, error) {
 	var dockerSecretsPostrenderer *DockerSecretsPostRenderer
 	var err error
@@ -43,6 +44,8 @@ func NewPorterPostrenderer(
 		if err != nil {
 			return nil, err
 		}
+
+		dockerSecretsPostrenderer.DryRun = dryRun
 	}
 
 	envVarPostrenderer, err := NewEnvironmentVariablePostrenderer()
@@ -87,6 +90,10 @@ type DockerSecretsPostRenderer struct {
 	Namespace string
 	DOAuth    *oauth2.Config
 
+	// DryRun adds the image pull secrets to the pod specs without creating or
+	// updating them in the cluster
+	DryRun bool
+
 	registries map[string]*models.Registry
 
 	podSpecs  []resource
@@ -203,6 +210,7 @@ func (d *DockerSecretsPostRenderer) Run(
 					Agent:      d.Agent,
 					Namespace:  d.Namespace,
 					DOAuth:     d.DOAuth,
+					DryRun:     d.DryRun,
 					registries: d.registries,
 					podSpecs:   make([]resource, 0),
 					resources:  make([]resource, 0),
@@ -221,16 +229,22 @@ func (d *DockerSecretsPostRenderer) Run(
 		}
 	}
 
-	// create the necessary secrets
-	secrets, err := d.Agent.CreateImagePullSecrets(
-		d.Repo,
-		d.Namespace,
-		linkedRegs,
-		d.DOAuth,
-	)
+	var secrets map[string]string
 
-	if err != nil {
-		return renderedManifests, nil
+	if d.DryRun {
+		secrets = getImagePullSecretNames(linkedRegs)
+	} else {
+		// create the necessary secrets
+		secrets, err = d.Agent.CreateImagePullSecrets(
+			d.Repo,
+			d.Namespace,
+			linkedRegs,
+			d.DOAuth,
+		)
+
+		if err != nil {
+			return renderedManifests, nil
+		}
 	}
 
 	d.updatePodSpecs(secrets)
@@ -254,6 +268,18 @@ func (d *DockerSecretsPostRenderer) Run(
 	return modifiedManifests, nil
 }
 
+// getImagePullSecretNames returns the names of the image pull secrets for the linked registries,
+// without creating them
+func getImagePullSecretNames(linkedRegs map[string]*models.Registry) map[string]string {
+	res := make(map[string]string)
+
+	for key, reg := range linkedRegs {
+		res[key] = kubernetes.ImagePullSecretName(reg)
+	}
+
+	return res
+}
+
 func (d *DockerSecretsPostRenderer) getRegistriesToLink(renderedManifests *bytes.Buffer) (map[string]*models.Registry, error) {
 	// create a map of registry names to registries: these are the registries
 	// that a secret will be generated for, if it does not exist
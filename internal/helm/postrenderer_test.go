@@ -0,0 +1,54 @@
+package helm_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+const postrendererManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+spec:
+  template:
+    spec:
+      containers:
+      - name: web
+        image: registry.digitalocean.com/porter/web:latest
+`
+
+func TestDockerSecretsPostRendererDryRun(t *testing.T) {
+	reg := &models.Registry{
+		Model:           gorm.Model{ID: 4},
+		URL:             "registry.digitalocean.com/porter",
+		DOIntegrationID: 1,
+	}
+
+	// the agent is left unset, so the post-renderer would panic if it tried to create the
+	// image pull secret in the cluster
+	renderer, err := helm.NewDockerSecretsPostRenderer(&models.Cluster{}, nil, nil, "default", []*models.Registry{reg}, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	renderer.DryRun = true
+
+	res, err := renderer.Run(bytes.NewBufferString(postrendererManifest))
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "name: " + kubernetes.ImagePullSecretName(reg)
+
+	if !strings.Contains(res.String(), "imagePullSecrets") || !strings.Contains(res.String(), expected) {
+		t.Errorf("expected the image pull secret %q to be added to the pod spec, got:\n%s", expected, res.String())
+	}
+}
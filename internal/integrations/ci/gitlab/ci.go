@@ -8,6 +8,7 @@ import (
 
 	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
 	"github.com/porter-dev/porter/internal/oauth"
 	"github.com/porter-dev/porter/internal/repository"
 	"github.com/xanzy/go-gitlab"
@@ -68,127 +69,7 @@ func (g *GitlabCI) Setup() error {
 
 	jobName := getGitlabStageJobName(g.ReleaseName)
 
-	ciFile, resp, err := client.RepositoryFiles.GetRawFile(g.pID, ".gitlab-ci.yml", &gitlab.GetRawFileOptions{
-		Ref: gitlab.String(g.defaultGitBranch),
-	})
-
-	if resp.StatusCode == http.StatusNotFound {
-		// create .gitlab-ci.yml
-		contentsMap := make(map[string]interface{})
-		contentsMap["stages"] = []string{
-			jobName,
-		}
-		contentsMap[jobName] = g.getCIJob(jobName)
-
-		contentsYAML, _ := yaml.Marshal(contentsMap)
-
-		_, _, err = client.RepositoryFiles.CreateFile(g.pID, ".gitlab-ci.yml", &gitlab.CreateFileOptions{
-			Branch:        gitlab.String(g.defaultGitBranch),
-			AuthorName:    gitlab.String("Porter Bot"),
-			AuthorEmail:   gitlab.String("contact@getporter.dev"),
-			Content:       gitlab.String(string(contentsYAML)),
-			CommitMessage: gitlab.String("Create .gitlab-ci.yml file"),
-		})
-
-		if err != nil {
-			return fmt.Errorf("error creating .gitlab-ci.yml file: %w", err)
-		}
-	} else if err != nil {
-		return fmt.Errorf("error getting .gitlab-ci.yml file: %w", err)
-	} else {
-		// update .gitlab-ci.yml if needed
-
-		// to preserve the order of the YAML, we use a MapSlice
-		ciFileContentsMap := yaml.MapSlice{}
-		err = yaml.Unmarshal(ciFile, &ciFileContentsMap)
-
-		if err != nil {
-			return fmt.Errorf("error unmarshalling existing .gitlab-ci.yml: %w", err)
-		}
-
-		var stagesInt []interface{}
-		stagesIdx := -1
-
-		for idx, elem := range ciFileContentsMap {
-			if key, ok := elem.Key.(string); ok {
-				if key == "stages" {
-					stages, ok := elem.Value.([]interface{})
-
-					if !ok {
-						return fmt.Errorf("error converting stages to interface slice")
-					}
-
-					stagesInt = stages
-					stagesIdx = idx
-
-					break
-				}
-			} else {
-				return fmt.Errorf("invalid key '%v' in .gitlab-ci.yml", elem.Key)
-			}
-		}
-
-		// two cases can happen here:
-		// 1: "stages" exists
-		// 2: "stages" does not exist
-
-		if stagesIdx >= 0 { // 1: "stages" exists
-			stageExists := false
-
-			for _, stage := range stagesInt {
-				stageStr, ok := stage.(string)
-				if !ok {
-					return fmt.Errorf("error converting from interface to string")
-				}
-
-				if stageStr == jobName {
-					stageExists = true
-					break
-				}
-			}
-
-			if !stageExists {
-				stagesInt = append(stagesInt, jobName)
-
-				ciFileContentsMap[stagesIdx] = yaml.MapItem{
-					Key:   "stages",
-					Value: stagesInt,
-				}
-			}
-		} else { // 2: "stages" does not exist
-			stagesInt = append(stagesInt, jobName)
-
-			ciFileContentsMap = append(ciFileContentsMap, yaml.MapItem{
-				Key:   "stages",
-				Value: stagesInt,
-			})
-		}
-
-		ciFileContentsMap = append(ciFileContentsMap, yaml.MapItem{
-			Key:   jobName,
-			Value: g.getCIJob(jobName),
-		})
-
-		contentsYAML, err := yaml.Marshal(ciFileContentsMap)
-
-		if err != nil {
-			return fmt.Errorf("error marshalling contents of .gitlab-ci.yml while updating to add porter job")
-		}
-
-		_, _, err = client.RepositoryFiles.UpdateFile(g.pID, ".gitlab-ci.yml", &gitlab.UpdateFileOptions{
-			Branch:        gitlab.String(g.defaultGitBranch),
-			AuthorName:    gitlab.String("Porter Bot"),
-			AuthorEmail:   gitlab.String("contact@getporter.dev"),
-			Content:       gitlab.String(string(contentsYAML)),
-			CommitMessage: gitlab.String("Update .gitlab-ci.yml file"),
-		})
-
-		if err != nil {
-			return fmt.Errorf("error updating .gitlab-ci.yml file to add porter job: %w", err)
-		}
-	}
-
-	return nil
+	return addCIJob(client, g.pID, g.defaultGitBranch, jobName, g.getCIJob(jobName))
 }
 
 func (g *GitlabCI) Cleanup() error {
@@ -219,138 +100,73 @@ func (g *GitlabCI) Cleanup() error {
 		return err
 	}
 
-	jobName := getGitlabStageJobName(g.ReleaseName)
-
-	ciFile, resp, err := client.RepositoryFiles.GetRawFile(g.pID, ".gitlab-ci.yml", &gitlab.GetRawFileOptions{
-		Ref: gitlab.String(g.defaultGitBranch),
-	})
-
-	if resp.StatusCode == http.StatusNotFound {
-		return nil
-	} else if err != nil {
-		return fmt.Errorf("error getting .gitlab-ci.yml file: %w", err)
-	}
+	return removeCIJob(client, g.pID, g.defaultGitBranch, getGitlabStageJobName(g.ReleaseName))
+}
 
-	ciFileContentsMap := yaml.MapSlice{}
-	err = yaml.Unmarshal(ciFile, &ciFileContentsMap)
+func (g *GitlabCI) getClient() (*gitlab.Client, error) {
+	client, gi, err := newClient(g.Repo, g.PorterConf, g.UserID, g.ProjectID, g.IntegrationID)
 
 	if err != nil {
-		return fmt.Errorf("error unmarshalling existing .gitlab-ci.yml: %w", err)
-	}
-
-	var stagesInt []interface{}
-	stagesIdx := -1
-
-	for idx, elem := range ciFileContentsMap {
-		if key, ok := elem.Key.(string); ok {
-			if key == "stages" {
-				stages, ok := elem.Value.([]interface{})
-
-				if !ok {
-					return fmt.Errorf("error converting stages to interface slice")
-				}
-
-				stagesInt = stages
-				stagesIdx = idx
-
-				break
-			}
-		} else {
-			return fmt.Errorf("invalid key '%v' in .gitlab-ci.yml", elem.Key)
-		}
-	}
-
-	if stagesIdx >= 0 { // "stages" exists
-		var newStages []string
-
-		for _, stage := range stagesInt {
-			stageStr, ok := stage.(string)
-			if !ok {
-				return fmt.Errorf("error converting from interface to string")
-			}
-
-			if stageStr != jobName {
-				newStages = append(newStages, stageStr)
-			}
-		}
-
-		ciFileContentsMap[stagesIdx] = yaml.MapItem{
-			Key:   "stages",
-			Value: newStages,
-		}
-	}
-
-	newCIFileContentsMap := yaml.MapSlice{}
-
-	for _, elem := range ciFileContentsMap {
-		if key, ok := elem.Key.(string); ok {
-			if key != jobName {
-				newCIFileContentsMap = append(newCIFileContentsMap, elem)
-			}
-		} else {
-			return fmt.Errorf("invalid key '%v' in .gitlab-ci.yml", elem.Key)
-		}
+		return nil, err
 	}
 
-	contentsYAML, err := yaml.Marshal(newCIFileContentsMap)
-
-	if err != nil {
-		return fmt.Errorf("error unmarshalling contents of .gitlab-ci.yml while updating to remove porter job")
-	}
+	g.gitlabInstanceURL = gi.InstanceURL
 
-	_, _, err = client.RepositoryFiles.UpdateFile(g.pID, ".gitlab-ci.yml", &gitlab.UpdateFileOptions{
-		Branch:        gitlab.String(g.defaultGitBranch),
-		AuthorName:    gitlab.String("Porter Bot"),
-		AuthorEmail:   gitlab.String("contact@getporter.dev"),
-		Content:       gitlab.String(string(contentsYAML)),
-		CommitMessage: gitlab.String("Update .gitlab-ci.yml file"),
-	})
+	return client, nil
+}
 
-	if err != nil {
-		return fmt.Errorf("error updating .gitlab-ci.yml file to remove porter job: %w", err)
-	}
+// NewClient returns a client for the GitLab instance of a GitLab integration, authenticated
+// with the GitLab OAuth token of a user
+func NewClient(
+	repo repository.Repository,
+	conf *config.Config,
+	userID, projectID, integrationID uint,
+) (*gitlab.Client, error) {
+	client, _, err := newClient(repo, conf, userID, projectID, integrationID)
 
-	return nil
+	return client, err
 }
 
-func (g *GitlabCI) getClient() (*gitlab.Client, error) {
-	gi, err := g.Repo.GitlabIntegration().ReadGitlabIntegration(g.ProjectID, g.IntegrationID)
+func newClient(
+	repo repository.Repository,
+	conf *config.Config,
+	userID, projectID, integrationID uint,
+) (*gitlab.Client, *ints.GitlabIntegration, error) {
+	gi, err := repo.GitlabIntegration().ReadGitlabIntegration(projectID, integrationID)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	giOAuthInt, err := g.Repo.GitlabAppOAuthIntegration().ReadGitlabAppOAuthIntegration(g.UserID, g.ProjectID, g.IntegrationID)
+	giOAuthInt, err := repo.GitlabAppOAuthIntegration().ReadGitlabAppOAuthIntegration(userID, projectID, integrationID)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	oauthInt, err := g.Repo.OAuthIntegration().ReadOAuthIntegration(g.ProjectID, giOAuthInt.OAuthIntegrationID)
+	oauthInt, err := repo.OAuthIntegration().ReadOAuthIntegration(projectID, giOAuthInt.OAuthIntegrationID)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	accessToken, _, err := oauth.GetAccessToken(
 		oauthInt.SharedOAuthModel,
-		commonutils.GetGitlabOAuthConf(g.PorterConf, gi),
-		oauth.MakeUpdateGitlabAppOAuthIntegrationFunction(g.ProjectID, giOAuthInt, g.Repo),
+		commonutils.GetGitlabOAuthConf(conf, gi),
+		oauth.MakeUpdateGitlabAppOAuthIntegrationFunction(projectID, giOAuthInt, repo),
 	)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	client, err := gitlab.NewOAuthClient(accessToken, gitlab.WithBaseURL(gi.InstanceURL))
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	g.gitlabInstanceURL = gi.InstanceURL
-
-	return client, nil
+	return client, gi, nil
 }
 
 func (g *GitlabCI) getCIJob(jobName string) yaml.MapSlice {
@@ -0,0 +1,241 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/xanzy/go-gitlab"
+	"gopkg.in/yaml.v2"
+)
+
+const ciFileName = ".gitlab-ci.yml"
+
+// addCIJob adds a job, along with a stage of the same name, to the .gitlab-ci.yml file on
+// the given branch. The file is created if it does not exist, and an existing job with the
+// same name is replaced.
+func addCIJob(client *gitlab.Client, pID, branch, jobName string, job yaml.MapSlice) error {
+	ciFile, resp, err := client.RepositoryFiles.GetRawFile(pID, ciFileName, &gitlab.GetRawFileOptions{
+		Ref: gitlab.String(branch),
+	})
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		// create .gitlab-ci.yml
+		contentsMap := make(map[string]interface{})
+		contentsMap["stages"] = []string{
+			jobName,
+		}
+		contentsMap[jobName] = job
+
+		contentsYAML, _ := yaml.Marshal(contentsMap)
+
+		_, _, err = client.RepositoryFiles.CreateFile(pID, ciFileName, &gitlab.CreateFileOptions{
+			Branch:        gitlab.String(branch),
+			AuthorName:    gitlab.String("Porter Bot"),
+			AuthorEmail:   gitlab.String("contact@getporter.dev"),
+			Content:       gitlab.String(string(contentsYAML)),
+			CommitMessage: gitlab.String("Create .gitlab-ci.yml file"),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error creating .gitlab-ci.yml file: %w", err)
+		}
+
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error getting .gitlab-ci.yml file: %w", err)
+	}
+
+	// update .gitlab-ci.yml if needed
+
+	// to preserve the order of the YAML, we use a MapSlice
+	ciFileContentsMap := yaml.MapSlice{}
+	err = yaml.Unmarshal(ciFile, &ciFileContentsMap)
+
+	if err != nil {
+		return fmt.Errorf("error unmarshalling existing .gitlab-ci.yml: %w", err)
+	}
+
+	stagesInt, stagesIdx, err := getCIStages(ciFileContentsMap)
+
+	if err != nil {
+		return err
+	}
+
+	// two cases can happen here:
+	// 1: "stages" exists
+	// 2: "stages" does not exist
+
+	if stagesIdx >= 0 { // 1: "stages" exists
+		stageExists := false
+
+		for _, stage := range stagesInt {
+			stageStr, ok := stage.(string)
+			if !ok {
+				return fmt.Errorf("error converting from interface to string")
+			}
+
+			if stageStr == jobName {
+				stageExists = true
+				break
+			}
+		}
+
+		if !stageExists {
+			stagesInt = append(stagesInt, jobName)
+
+			ciFileContentsMap[stagesIdx] = yaml.MapItem{
+				Key:   "stages",
+				Value: stagesInt,
+			}
+		}
+	} else { // 2: "stages" does not exist
+		stagesInt = append(stagesInt, jobName)
+
+		ciFileContentsMap = append(ciFileContentsMap, yaml.MapItem{
+			Key:   "stages",
+			Value: stagesInt,
+		})
+	}
+
+	jobExists := false
+
+	for idx, elem := range ciFileContentsMap {
+		if key, _ := elem.Key.(string); key == jobName {
+			ciFileContentsMap[idx] = yaml.MapItem{
+				Key:   jobName,
+				Value: job,
+			}
+
+			jobExists = true
+			break
+		}
+	}
+
+	if !jobExists {
+		ciFileContentsMap = append(ciFileContentsMap, yaml.MapItem{
+			Key:   jobName,
+			Value: job,
+		})
+	}
+
+	contentsYAML, err := yaml.Marshal(ciFileContentsMap)
+
+	if err != nil {
+		return fmt.Errorf("error marshalling contents of .gitlab-ci.yml while updating to add porter job")
+	}
+
+	_, _, err = client.RepositoryFiles.UpdateFile(pID, ciFileName, &gitlab.UpdateFileOptions{
+		Branch:        gitlab.String(branch),
+		AuthorName:    gitlab.String("Porter Bot"),
+		AuthorEmail:   gitlab.String("contact@getporter.dev"),
+		Content:       gitlab.String(string(contentsYAML)),
+		CommitMessage: gitlab.String("Update .gitlab-ci.yml file"),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error updating .gitlab-ci.yml file to add porter job: %w", err)
+	}
+
+	return nil
+}
+
+// removeCIJob removes a job, along with the stage of the same name, from the .gitlab-ci.yml
+// file on the given branch
+func removeCIJob(client *gitlab.Client, pID, branch, jobName string) error {
+	ciFile, resp, err := client.RepositoryFiles.GetRawFile(pID, ciFileName, &gitlab.GetRawFileOptions{
+		Ref: gitlab.String(branch),
+	})
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error getting .gitlab-ci.yml file: %w", err)
+	}
+
+	ciFileContentsMap := yaml.MapSlice{}
+	err = yaml.Unmarshal(ciFile, &ciFileContentsMap)
+
+	if err != nil {
+		return fmt.Errorf("error unmarshalling existing .gitlab-ci.yml: %w", err)
+	}
+
+	stagesInt, stagesIdx, err := getCIStages(ciFileContentsMap)
+
+	if err != nil {
+		return err
+	}
+
+	if stagesIdx >= 0 { // "stages" exists
+		var newStages []string
+
+		for _, stage := range stagesInt {
+			stageStr, ok := stage.(string)
+			if !ok {
+				return fmt.Errorf("error converting from interface to string")
+			}
+
+			if stageStr != jobName {
+				newStages = append(newStages, stageStr)
+			}
+		}
+
+		ciFileContentsMap[stagesIdx] = yaml.MapItem{
+			Key:   "stages",
+			Value: newStages,
+		}
+	}
+
+	newCIFileContentsMap := yaml.MapSlice{}
+
+	for _, elem := range ciFileContentsMap {
+		if key, ok := elem.Key.(string); ok {
+			if key != jobName {
+				newCIFileContentsMap = append(newCIFileContentsMap, elem)
+			}
+		} else {
+			return fmt.Errorf("invalid key '%v' in .gitlab-ci.yml", elem.Key)
+		}
+	}
+
+	contentsYAML, err := yaml.Marshal(newCIFileContentsMap)
+
+	if err != nil {
+		return fmt.Errorf("error unmarshalling contents of .gitlab-ci.yml while updating to remove porter job")
+	}
+
+	_, _, err = client.RepositoryFiles.UpdateFile(pID, ciFileName, &gitlab.UpdateFileOptions{
+		Branch:        gitlab.String(branch),
+		AuthorName:    gitlab.String("Porter Bot"),
+		AuthorEmail:   gitlab.String("contact@getporter.dev"),
+		Content:       gitlab.String(string(contentsYAML)),
+		CommitMessage: gitlab.String("Update .gitlab-ci.yml file"),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error updating .gitlab-ci.yml file to remove porter job: %w", err)
+	}
+
+	return nil
+}
+
+// getCIStages returns the "stages" of a .gitlab-ci.yml file along with their index in the
+// file, or an index of -1 if the file has no stages
+func getCIStages(ciFileContentsMap yaml.MapSlice) ([]interface{}, int, error) {
+	for idx, elem := range ciFileContentsMap {
+		if key, ok := elem.Key.(string); ok {
+			if key == "stages" {
+				stages, ok := elem.Value.([]interface{})
+
+				if !ok {
+					return nil, -1, fmt.Errorf("error converting stages to interface slice")
+				}
+
+				return stages, idx, nil
+			}
+		} else {
+			return nil, -1, fmt.Errorf("invalid key '%v' in .gitlab-ci.yml", elem.Key)
+		}
+	}
+
+	return nil, -1, nil
+}
@@ -0,0 +1,217 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/xanzy/go-gitlab"
+	"gopkg.in/yaml.v2"
+)
+
+// fakeCIFileServer serves a .gitlab-ci.yml file through the GitLab repository files API and
+// records the contents which the file is created or updated with
+type fakeCIFileServer struct {
+	ciFile  string
+	written string
+	method  string
+}
+
+func (s *fakeCIFileServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.Contains(r.URL.Path, "/repository/files/.gitlab-ci.yml") {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		if s.ciFile == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write([]byte(s.ciFile))
+		return
+	}
+
+	body := struct {
+		Content string `json:"content"`
+	}{}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	s.method = r.Method
+	s.written = body.Content
+
+	w.Write([]byte(`{"file_path":".gitlab-ci.yml","branch":"main"}`))
+}
+
+func newFakeCIFileClient(t *testing.T, ciFile string) (*gitlab.Client, *fakeCIFileServer) {
+	fake := &fakeCIFileServer{ciFile: ciFile}
+	server := httptest.NewServer(fake)
+
+	t.Cleanup(server.Close)
+
+	client, err := gitlab.NewClient("token", gitlab.WithBaseURL(server.URL))
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return client, fake
+}
+
+func getWrittenCIFile(t *testing.T, fake *fakeCIFileServer) yaml.MapSlice {
+	res := yaml.MapSlice{}
+
+	if err := yaml.Unmarshal([]byte(fake.written), &res); err != nil {
+		t.Fatalf("error unmarshalling written .gitlab-ci.yml: %v", err)
+	}
+
+	return res
+}
+
+func getCIFileKeys(contents yaml.MapSlice) []string {
+	var res []string
+
+	for _, item := range contents {
+		res = append(res, item.Key.(string))
+	}
+
+	return res
+}
+
+func TestAddCIJobCreatesFile(t *testing.T) {
+	client, fake := newFakeCIFileClient(t, "")
+
+	err := addCIJob(client, "porter-dev/porter", "main", "porter-preview-web", yaml.MapSlice{
+		{Key: "stage", Value: "porter-preview-web"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fake.method != http.MethodPost {
+		t.Errorf("expected .gitlab-ci.yml to be created, got method %q", fake.method)
+	}
+
+	contents := getWrittenCIFile(t, fake)
+	stages, _, _ := getCIStages(contents)
+
+	if len(stages) != 1 || stages[0] != "porter-preview-web" {
+		t.Errorf("expected the job stage to be added, got %v", stages)
+	}
+
+	if keys := getCIFileKeys(contents); len(keys) != 2 {
+		t.Errorf("expected stages and the job, got %v", keys)
+	}
+}
+
+func TestAddCIJobPreservesExistingJobs(t *testing.T) {
+	client, fake := newFakeCIFileClient(t, `stages:
+- test
+- porter-preview-web
+test:
+  stage: test
+  script:
+  - go test ./...
+porter-preview-web:
+  stage: porter-preview-web
+  script:
+  - old
+`)
+
+	err := addCIJob(client, "porter-dev/porter", "main", "porter-preview-web", yaml.MapSlice{
+		{Key: "stage", Value: "porter-preview-web"},
+		{Key: "script", Value: []string{"new"}},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fake.method != http.MethodPut {
+		t.Errorf("expected .gitlab-ci.yml to be updated, got method %q", fake.method)
+	}
+
+	contents := getWrittenCIFile(t, fake)
+
+	if keys := strings.Join(getCIFileKeys(contents), ","); keys != "stages,test,porter-preview-web" {
+		t.Errorf("expected the order of the file to be preserved without duplicate jobs, got %s", keys)
+	}
+
+	stages, _, _ := getCIStages(contents)
+
+	if len(stages) != 2 {
+		t.Errorf("expected the existing stage not to be duplicated, got %v", stages)
+	}
+
+	if !strings.Contains(fake.written, "- new") || strings.Contains(fake.written, "- old") {
+		t.Errorf("expected the existing job to be replaced, got:\n%s", fake.written)
+	}
+}
+
+func TestAddCIJobWithoutStages(t *testing.T) {
+	client, fake := newFakeCIFileClient(t, `test:
+  script:
+  - go test ./...
+`)
+
+	err := addCIJob(client, "porter-dev/porter", "main", "porter-preview-web", yaml.MapSlice{
+		{Key: "stage", Value: "porter-preview-web"},
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	contents := getWrittenCIFile(t, fake)
+
+	if keys := strings.Join(getCIFileKeys(contents), ","); keys != "test,stages,porter-preview-web" {
+		t.Errorf("expected stages and the job to be appended, got %s", keys)
+	}
+}
+
+func TestRemoveCIJob(t *testing.T) {
+	client, fake := newFakeCIFileClient(t, `stages:
+- test
+- porter-preview-web
+test:
+  stage: test
+porter-preview-web:
+  stage: porter-preview-web
+`)
+
+	if err := removeCIJob(client, "porter-dev/porter", "main", "porter-preview-web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	contents := getWrittenCIFile(t, fake)
+
+	if keys := strings.Join(getCIFileKeys(contents), ","); keys != "stages,test" {
+		t.Errorf("expected the job to be removed, got %s", keys)
+	}
+
+	stages, _, _ := getCIStages(contents)
+
+	if len(stages) != 1 || stages[0] != "test" {
+		t.Errorf("expected the job stage to be removed, got %v", stages)
+	}
+}
+
+func TestRemoveCIJobWithoutFile(t *testing.T) {
+	client, fake := newFakeCIFileClient(t, "")
+
+	if err := removeCIJob(client, "porter-dev/porter", "main", "porter-preview-web"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if fake.method != "" {
+		t.Errorf("expected no file to be written, got method %q", fake.method)
+	}
+}
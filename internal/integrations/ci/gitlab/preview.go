@@ -0,0 +1,322 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+	"gopkg.in/yaml.v2"
+)
+
+// PreviewEnvOpts are the options for setting up a preview environment driven by the merge
+// requests of a GitLab project
+type PreviewEnvOpts struct {
+	Client                              *gitlab.Client
+	ServerURL                           string
+	PorterToken                         string
+	GitRepoOwner, GitRepoName           string
+	EnvironmentName                     string
+	InstanceName                        string
+	ProjectID, ClusterID, EnvironmentID uint
+}
+
+func (opts *PreviewEnvOpts) pID() string {
+	return fmt.Sprintf("%s/%s", opts.GitRepoOwner, opts.GitRepoName)
+}
+
+// SetupPreviewEnv stores the Porter token as a masked CI/CD variable of the GitLab project and
+// adds a job to .gitlab-ci.yml which deploys merge requests. The job only runs in pipelines
+// created by TriggerPreviewPipeline.
+func SetupPreviewEnv(opts *PreviewEnvOpts) error {
+	defaultBranch, err := getDefaultBranch(opts.Client, opts.pID())
+
+	if err != nil {
+		return err
+	}
+
+	err = upsertProjectVariable(
+		opts.Client, opts.pID(),
+		getPreviewEnvSecretName(opts.ProjectID, opts.ClusterID, opts.InstanceName),
+		opts.PorterToken,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	instanceURL := opts.Client.BaseURL()
+	jobName := getPreviewJobName(opts.EnvironmentName)
+
+	return addCIJob(opts.Client, opts.pID(), defaultBranch, jobName, getPreviewCIJob(opts, instanceURL, jobName))
+}
+
+// DeletePreviewEnv removes the job which deploys merge requests from .gitlab-ci.yml
+func DeletePreviewEnv(opts *PreviewEnvOpts) error {
+	defaultBranch, err := getDefaultBranch(opts.Client, opts.pID())
+
+	if err != nil {
+		return err
+	}
+
+	return removeCIJob(opts.Client, opts.pID(), defaultBranch, getPreviewJobName(opts.EnvironmentName))
+}
+
+// PreviewPipelineOpts are the merge request details passed to a preview pipeline
+type PreviewPipelineOpts struct {
+	EnvironmentID  uint
+	MergeRequestID int
+	MRName         string
+	MRBranchFrom   string
+	MRBranchInto   string
+}
+
+// TriggerPreviewPipeline creates a pipeline on the source branch of a merge request which
+// deploys the merge request to its preview environment
+func TriggerPreviewPipeline(client *gitlab.Client, owner, name string, opts *PreviewPipelineOpts) (*gitlab.Pipeline, error) {
+	variables := getPreviewPipelineVariables(opts)
+
+	pipeline, _, err := client.Pipelines.CreatePipeline(fmt.Sprintf("%s/%s", owner, name), &gitlab.CreatePipelineOptions{
+		Ref:       gitlab.String(opts.MRBranchFrom),
+		Variables: &variables,
+	})
+
+	if err != nil {
+		return nil, fmt.Errorf("error creating preview pipeline: %w", err)
+	}
+
+	return pipeline, nil
+}
+
+// CancelPreviewPipeline cancels a preview pipeline if it has not finished yet
+func CancelPreviewPipeline(client *gitlab.Client, owner, name string, pipelineID int) error {
+	pID := fmt.Sprintf("%s/%s", owner, name)
+
+	pipeline, resp, err := client.Pipelines.GetPipeline(pID, pipelineID)
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error getting preview pipeline %d: %w", pipelineID, err)
+	}
+
+	switch pipeline.Status {
+	case "created", "waiting_for_resource", "preparing", "pending", "running", "scheduled", "manual":
+		_, _, err = client.Pipelines.CancelPipelineBuild(pID, pipelineID)
+
+		if err != nil {
+			return fmt.Errorf("error cancelling preview pipeline %d: %w", pipelineID, err)
+		}
+	}
+
+	return nil
+}
+
+// GetPreviewNamespace returns the namespace which a merge request is deployed to
+func GetPreviewNamespace(repoName string, mergeRequestID int) string {
+	return fmt.Sprintf("mr-%d-%s", mergeRequestID, getNamespaceRepoName(repoName))
+}
+
+func getNamespaceRepoName(repoName string) string {
+	return strings.ToLower(strings.NewReplacer("_", "-", ".", "-").Replace(repoName))
+}
+
+func getDefaultBranch(client *gitlab.Client, pID string) (string, error) {
+	project, _, err := client.Projects.GetProject(pID, &gitlab.GetProjectOptions{})
+
+	if err != nil {
+		return "", fmt.Errorf("error getting gitlab project %s: %w", pID, err)
+	}
+
+	return project.DefaultBranch, nil
+}
+
+func upsertProjectVariable(client *gitlab.Client, pID, key, value string) error {
+	_, resp, err := client.ProjectVariables.GetVariable(pID, key, &gitlab.GetProjectVariableOptions{})
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		_, _, err = client.ProjectVariables.CreateVariable(pID, &gitlab.CreateProjectVariableOptions{
+			Key:    gitlab.String(key),
+			Value:  gitlab.String(value),
+			Masked: gitlab.Bool(true),
+		})
+
+		if err != nil {
+			return fmt.Errorf("error creating porter token variable: %w", err)
+		}
+
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error getting porter token variable: %w", err)
+	}
+
+	_, _, err = client.ProjectVariables.UpdateVariable(pID, key, &gitlab.UpdateProjectVariableOptions{
+		Value:  gitlab.String(value),
+		Masked: gitlab.Bool(true),
+	})
+
+	if err != nil {
+		return fmt.Errorf("error updating porter token variable: %w", err)
+	}
+
+	return nil
+}
+
+func getPreviewEnvSecretName(projectID, clusterID uint, instanceName string) string {
+	if instanceName != "" {
+		return fmt.Sprintf("PORTER_PREVIEW_%s_%d_%d", strings.ToUpper(instanceName), projectID, clusterID)
+	}
+
+	return fmt.Sprintf("PORTER_PREVIEW_%d_%d", projectID, clusterID)
+}
+
+func getPreviewJobName(envName string) string {
+	return fmt.Sprintf("porter-preview-%s", strings.ToLower(strings.ReplaceAll(envName, "_", "-")))
+}
+
+func getPreviewCIJob(opts *PreviewEnvOpts, instanceURL *url.URL, jobName string) yaml.MapSlice {
+	res := yaml.MapSlice{
+		yaml.MapItem{
+			Key: "rules",
+			Value: []map[string]string{
+				{
+					"if": fmt.Sprintf("$CI_PIPELINE_SOURCE == \"api\" && $PORTER_ENVIRONMENT_ID == \"%d\"", opts.EnvironmentID),
+				},
+			},
+		},
+	}
+
+	if instanceURL.Hostname() == "gitlab.com" || instanceURL.Hostname() == "www.gitlab.com" {
+		res = append(res,
+			yaml.MapItem{
+				Key:   "image",
+				Value: "docker:latest",
+			},
+			yaml.MapItem{
+				Key: "services",
+				Value: []string{
+					"docker:dind",
+				},
+			},
+			yaml.MapItem{
+				Key: "script",
+				Value: []string{
+					"docker run --rm --workdir=\"/app\" " +
+						"-v /var/run/docker.sock:/var/run/docker.sock " +
+						"-v $(pwd):/app " +
+						getPreviewEnvVarFlags() +
+						"public.ecr.aws/o1j4x7p4/porter-cli:latest " +
+						"apply -f porter.yaml",
+				},
+			},
+			yaml.MapItem{
+				Key: "tags",
+				Value: []string{
+					"docker",
+				},
+			},
+		)
+	} else {
+		res = append(res,
+			yaml.MapItem{
+				Key: "image",
+				Value: map[string]interface{}{
+					"name": "public.ecr.aws/o1j4x7p4/porter-cli:latest",
+					"entrypoint": []string{
+						"",
+					},
+				},
+			},
+			yaml.MapItem{
+				Key: "script",
+				Value: []string{
+					"porter apply -f porter.yaml",
+				},
+			},
+			yaml.MapItem{
+				Key: "tags",
+				Value: []string{
+					"porter-runner",
+				},
+			},
+		)
+	}
+
+	res = append(res,
+		yaml.MapItem{
+			Key:   "stage",
+			Value: jobName,
+		},
+		yaml.MapItem{
+			Key:   "timeout",
+			Value: "30 minutes",
+		},
+		// only deploy a merge request once at a time
+		yaml.MapItem{
+			Key:   "resource_group",
+			Value: "$PORTER_ENVIRONMENT_ID-$PORTER_MERGE_REQUEST_ID",
+		},
+		yaml.MapItem{
+			Key: "variables",
+			Value: yaml.MapSlice{
+				{Key: "GIT_STRATEGY", Value: "clone"},
+				{Key: "PORTER_HOST", Value: opts.ServerURL},
+				{Key: "PORTER_PROJECT", Value: fmt.Sprintf("%d", opts.ProjectID)},
+				{Key: "PORTER_CLUSTER", Value: fmt.Sprintf("%d", opts.ClusterID)},
+				{Key: "PORTER_TOKEN", Value: fmt.Sprintf("$%s", getPreviewEnvSecretName(opts.ProjectID, opts.ClusterID, opts.InstanceName))},
+				{Key: "PORTER_NAMESPACE", Value: fmt.Sprintf("mr-$PORTER_MERGE_REQUEST_ID-%s", getNamespaceRepoName(opts.GitRepoName))},
+				{Key: "PORTER_PIPELINE_ID", Value: "$CI_PIPELINE_ID"},
+				{Key: "PORTER_COMMIT_SHA", Value: "$CI_COMMIT_SHA"},
+				{Key: "PORTER_TAG", Value: "$CI_COMMIT_SHORT_SHA"},
+				{Key: "PORTER_REPO_OWNER", Value: opts.GitRepoOwner},
+				{Key: "PORTER_REPO_NAME", Value: opts.GitRepoName},
+			},
+		},
+	)
+
+	return res
+}
+
+// getPreviewEnvVarFlags returns the flags which pass the preview variables of the job to the
+// porter-cli container on gitlab.com shared runners
+func getPreviewEnvVarFlags() string {
+	var res string
+
+	for _, name := range []string{
+		"PORTER_HOST", "PORTER_PROJECT", "PORTER_CLUSTER", "PORTER_TOKEN", "PORTER_NAMESPACE",
+		"PORTER_ENVIRONMENT_ID", "PORTER_MERGE_REQUEST_ID", "PORTER_PIPELINE_ID", "PORTER_PR_NAME",
+		"PORTER_BRANCH_FROM", "PORTER_BRANCH_INTO", "PORTER_COMMIT_SHA", "PORTER_TAG",
+		"PORTER_REPO_OWNER", "PORTER_REPO_NAME",
+	} {
+		res += fmt.Sprintf("-e %s ", name)
+	}
+
+	return res
+}
+
+// getPreviewPipelineVariables returns the CI variables which the preview job reads the merge
+// request details from
+func getPreviewPipelineVariables(opts *PreviewPipelineOpts) []*gitlab.PipelineVariable {
+	values := []struct {
+		key, value string
+	}{
+		{"PORTER_ENVIRONMENT_ID", fmt.Sprintf("%d", opts.EnvironmentID)},
+		{"PORTER_MERGE_REQUEST_ID", fmt.Sprintf("%d", opts.MergeRequestID)},
+		{"PORTER_PR_NAME", opts.MRName},
+		{"PORTER_BRANCH_FROM", opts.MRBranchFrom},
+		{"PORTER_BRANCH_INTO", opts.MRBranchInto},
+	}
+
+	res := make([]*gitlab.PipelineVariable, 0, len(values))
+
+	for _, v := range values {
+		res = append(res, &gitlab.PipelineVariable{
+			Key:          v.key,
+			Value:        v.value,
+			VariableType: "env_var",
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,129 @@
+package gitlab
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func getCIJobValue(job yaml.MapSlice, key string) interface{} {
+	for _, item := range job {
+		if item.Key == key {
+			return item.Value
+		}
+	}
+
+	return nil
+}
+
+func TestGetPreviewCIJob(t *testing.T) {
+	opts := &PreviewEnvOpts{
+		ServerURL:     "https://dashboard.getporter.dev",
+		GitRepoOwner:  "porter-dev",
+		GitRepoName:   "My_Repo.v2",
+		InstanceName:  "staging",
+		ProjectID:     1,
+		ClusterID:     2,
+		EnvironmentID: 3,
+	}
+
+	tests := []struct {
+		name        string
+		instanceURL string
+		expTag      string
+		expScript   string
+	}{
+		{"gitlab.com", "https://gitlab.com/api/v4/", "docker", "docker run"},
+		{"self-hosted", "https://gitlab.example.com/api/v4/", "porter-runner", "porter apply -f porter.yaml"},
+	}
+
+	for _, test := range tests {
+		instanceURL, _ := url.Parse(test.instanceURL)
+		job := getPreviewCIJob(opts, instanceURL, "porter-preview-web")
+
+		rules := getCIJobValue(job, "rules").([]map[string]string)
+
+		if len(rules) != 1 || rules[0]["if"] != `$CI_PIPELINE_SOURCE == "api" && $PORTER_ENVIRONMENT_ID == "3"` {
+			t.Errorf("%s: expected the job to only run in preview pipelines, got %v", test.name, rules)
+		}
+
+		if tags := getCIJobValue(job, "tags").([]string); len(tags) != 1 || tags[0] != test.expTag {
+			t.Errorf("%s: expected tag %s, got %v", test.name, test.expTag, tags)
+		}
+
+		if script := getCIJobValue(job, "script").([]string); len(script) != 1 || !strings.HasPrefix(script[0], test.expScript) {
+			t.Errorf("%s: expected script starting with %q, got %v", test.name, test.expScript, script)
+		}
+
+		if stage := getCIJobValue(job, "stage"); stage != "porter-preview-web" {
+			t.Errorf("%s: expected stage porter-preview-web, got %v", test.name, stage)
+		}
+
+		variables := getCIJobValue(job, "variables").(yaml.MapSlice)
+
+		expVariables := map[string]string{
+			"PORTER_HOST":      "https://dashboard.getporter.dev",
+			"PORTER_PROJECT":   "1",
+			"PORTER_CLUSTER":   "2",
+			"PORTER_TOKEN":     "$PORTER_PREVIEW_STAGING_1_2",
+			"PORTER_NAMESPACE": "mr-$PORTER_MERGE_REQUEST_ID-my-repo-v2",
+			"PORTER_REPO_NAME": "My_Repo.v2",
+		}
+
+		for key, expValue := range expVariables {
+			if value := getCIJobValue(variables, key); value != expValue {
+				t.Errorf("%s: expected variable %s to be %q, got %v", test.name, key, expValue, value)
+			}
+		}
+	}
+}
+
+func TestGetPreviewEnvVarFlagsPassesPipelineVariables(t *testing.T) {
+	flags := getPreviewEnvVarFlags()
+
+	for _, v := range getPreviewPipelineVariables(&PreviewPipelineOpts{}) {
+		if !strings.Contains(flags, "-e "+v.Key+" ") {
+			t.Errorf("expected pipeline variable %s to be passed to the porter-cli container", v.Key)
+		}
+	}
+}
+
+func TestGetPreviewPipelineVariables(t *testing.T) {
+	variables := getPreviewPipelineVariables(&PreviewPipelineOpts{
+		EnvironmentID:  3,
+		MergeRequestID: 42,
+		MRName:         "Add \"preview\" environments",
+		MRBranchFrom:   "feature/preview",
+		MRBranchInto:   "main",
+	})
+
+	expected := map[string]string{
+		"PORTER_ENVIRONMENT_ID":   "3",
+		"PORTER_MERGE_REQUEST_ID": "42",
+		"PORTER_PR_NAME":          "Add \"preview\" environments",
+		"PORTER_BRANCH_FROM":      "feature/preview",
+		"PORTER_BRANCH_INTO":      "main",
+	}
+
+	if len(variables) != len(expected) {
+		t.Fatalf("expected %d variables, got %d", len(expected), len(variables))
+	}
+
+	for _, v := range variables {
+		if v.Value != expected[v.Key] {
+			t.Errorf("expected variable %s to be %q, got %q", v.Key, expected[v.Key], v.Value)
+		}
+
+		if v.VariableType != "env_var" {
+			t.Errorf("expected variable %s to be an env_var, got %s", v.Key, v.VariableType)
+		}
+	}
+}
+
+func TestGetPreviewNamespace(t *testing.T) {
+	if ns := GetPreviewNamespace("My_Repo.v2", 42); ns != "mr-42-my-repo-v2" {
+		t.Errorf("expected namespace mr-42-my-repo-v2, got %s", ns)
+	}
+}
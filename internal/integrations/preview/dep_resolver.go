@@ -70,3 +70,18 @@ func (r *dependencyResolver) depResolve(name string) error {
 
 	return nil
 }
+
+// ResolveDependencies validates that the dependencies between the given resources form a
+// directed acyclic graph, and returns the graph as a map from each resource name to the
+// names of the resources it depends on
+func ResolveDependencies(resources []*types.Resource) (map[string][]string, error) {
+	r := newDependencyResolver(resources)
+
+	err := r.Resolve()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return r.graph, nil
+}
@@ -113,6 +113,26 @@ func (a *Agent) CreateConfigMap(name string, namespace string, configMap map[str
 }
 
 func (a *Agent) CreateVersionedConfigMap(name, namespace string, version uint, configMap map[string]string, apps ...string) (*v1.ConfigMap, error) {
+	return a.CreateVersionedConfigMapWithAnnotations(name, namespace, version, configMap, nil, apps...)
+}
+
+// CreateVersionedConfigMapWithAnnotations creates a versioned configmap with additional
+// annotations set alongside the list of applications
+func (a *Agent) CreateVersionedConfigMapWithAnnotations(
+	name, namespace string,
+	version uint,
+	configMap map[string]string,
+	annotations map[string]string,
+	apps ...string,
+) (*v1.ConfigMap, error) {
+	annons := map[string]string{
+		PorterAppAnnotationName: strings.Join(apps, ","),
+	}
+
+	for key, val := range annotations {
+		annons[key] = val
+	}
+
 	return a.Clientset.CoreV1().ConfigMaps(namespace).Create(
 		context.TODO(),
 		&v1.ConfigMap{
@@ -124,9 +144,7 @@ func (a *Agent) CreateVersionedConfigMap(name, namespace string, version uint, c
 					"envgroup": name,
 					"version":  fmt.Sprintf("%d", version),
 				},
-				Annotations: map[string]string{
-					PorterAppAnnotationName: strings.Join(apps, ","),
-				},
+				Annotations: annons,
 			},
 			Data: configMap,
 		},
@@ -517,6 +535,31 @@ func (a *Agent) GetLatestVersionedConfigMap(name, namespace string) (*v1.ConfigM
 	return res, latestVersion, nil
 }
 
+// GetVersionedSecret returns the secret linked to a version of an env group
+func (a *Agent) GetVersionedSecret(name, namespace string, version uint) (*v1.Secret, error) {
+	listResp, err := a.Clientset.CoreV1().Secrets(namespace).List(
+		context.Background(),
+		metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("envgroup=%s,version=%d", name, version),
+		},
+	)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if listResp.Items == nil || len(listResp.Items) == 0 {
+		return nil, IsNotFoundError
+	}
+
+	// if the length of the list is greater than 1, return an error -- this shouldn't happen
+	if len(listResp.Items) > 1 {
+		return nil, fmt.Errorf("multiple secrets found while searching for %s/%s and version %d", namespace, name, version)
+	}
+
+	return &listResp.Items[0], nil
+}
+
 func (a *Agent) GetLatestVersionedSecret(name, namespace string) (*v1.Secret, uint, error) {
 	listResp, err := a.Clientset.CoreV1().Secrets(namespace).List(
 		context.Background(),
@@ -1924,6 +1967,12 @@ func (a *Agent) StreamPorterAgentLokiLog(
 	return a.RunWebsocketTask(run)
 }
 
+// ImagePullSecretName returns the name of the image pull secret which Porter creates
+// for the registry.
+func ImagePullSecretName(reg *models.Registry) string {
+	return fmt.Sprintf("porter-%s-%d", reg.ToRegistryType().Service, reg.ID)
+}
+
 // CreateImagePullSecrets will create the required image pull secrets and
 // return a map from the registry name to the name of the secret.
 func (a *Agent) CreateImagePullSecrets(
@@ -1943,7 +1992,7 @@ func (a *Agent) CreateImagePullSecrets(
 			return nil, err
 		}
 
-		secretName := fmt.Sprintf("porter-%s-%d", val.ToRegistryType().Service, val.ID)
+		secretName := ImagePullSecretName(val)
 
 		secret, err := a.Clientset.CoreV1().Secrets(namespace).Get(
 			context.TODO(),
@@ -1,6 +1,7 @@
 package envgroup
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"strconv"
@@ -95,6 +96,11 @@ func CreateEnvGroup(agent *kubernetes.Agent, input types.ConfigMapInput) (*v1.Co
 		// In this case, we find all old variables referencing a secret value, and add those
 		// values to the new secret variables. The frontend will only send **new** secret values.
 		for key1, val1 := range input.Variables {
+			// values of external secrets are always read from the secret store
+			if _, external := input.ExternalSecretVariables[key1]; external {
+				continue
+			}
+
 			if strings.Contains(val1, "PORTERSECRET") {
 				// get that value from the secret
 				for key2, val2 := range oldSecret.Data {
@@ -111,7 +117,19 @@ func CreateEnvGroup(agent *kubernetes.Agent, input types.ConfigMapInput) (*v1.Co
 		input.Variables[key] = fmt.Sprintf("PORTERSECRET_%s.v%d", input.Name, latestVersion)
 	}
 
-	cm, err := agent.CreateVersionedConfigMap(input.Name, input.Namespace, latestVersion, input.Variables, apps...)
+	annotations := make(map[string]string)
+
+	if len(input.ExternalSecretVariables) > 0 {
+		refBytes, err := json.Marshal(input.ExternalSecretVariables)
+
+		if err != nil {
+			return nil, err
+		}
+
+		annotations[ExternalSecretsAnnotationName] = string(refBytes)
+	}
+
+	cm, err := agent.CreateVersionedConfigMapWithAnnotations(input.Name, input.Namespace, latestVersion, input.Variables, annotations, apps...)
 
 	if err != nil {
 		return nil, err
@@ -179,6 +197,12 @@ func ToEnvGroup(configMap *v1.ConfigMap) (*types.EnvGroup, error) {
 		res.Applications = []string{}
 	}
 
+	res.ExternalSecretVariables, err = getExternalSecretReferences(configMap)
+
+	if err != nil {
+		return nil, err
+	}
+
 	return res, nil
 }
 
@@ -0,0 +1,149 @@
+package envgroup
+
+import (
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	v1 "k8s.io/api/core/v1"
+)
+
+// versionedVariables holds the variables of a single env group version, with the values
+// of secret variables read from the linked secret
+type versionedVariables struct {
+	version   uint
+	configMap *v1.ConfigMap
+	variables map[string]string
+	secrets   map[string]string
+}
+
+func getVersionedVariables(agent *kubernetes.Agent, name, namespace string, version uint) (*versionedVariables, error) {
+	var configMap *v1.ConfigMap
+	var err error
+
+	if version == 0 {
+		configMap, version, err = agent.GetLatestVersionedConfigMap(name, namespace)
+	} else {
+		configMap, err = agent.GetVersionedConfigMap(name, namespace, version)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := &versionedVariables{
+		version:   version,
+		configMap: configMap,
+		variables: make(map[string]string),
+		secrets:   make(map[string]string),
+	}
+
+	secret, err := agent.GetVersionedSecret(name, namespace, version)
+
+	if err != nil && !errors.Is(err, kubernetes.IsNotFoundError) {
+		return nil, err
+	}
+
+	for key, val := range configMap.Data {
+		if !strings.Contains(val, "PORTERSECRET") {
+			res.variables[key] = val
+			continue
+		}
+
+		if secret != nil {
+			res.secrets[key] = string(secret.Data[key])
+		} else {
+			res.secrets[key] = ""
+		}
+	}
+
+	return res, nil
+}
+
+// DiffEnvGroup compares two versions of an env group. A to version of 0 compares against
+// the latest version. Secret variables are compared by value, but their values are not
+// part of the result.
+func DiffEnvGroup(agent *kubernetes.Agent, name, namespace string, fromVersion, toVersion uint) (*types.DiffEnvGroupResponse, error) {
+	from, err := getVersionedVariables(agent, name, namespace, fromVersion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	to, err := getVersionedVariables(agent, name, namespace, toVersion)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := &types.DiffEnvGroupResponse{
+		Name:        name,
+		FromVersion: from.version,
+		ToVersion:   to.version,
+		Added:       make([]*types.EnvGroupVariableDiff, 0),
+		Removed:     make([]*types.EnvGroupVariableDiff, 0),
+		Changed:     make([]*types.EnvGroupVariableDiff, 0),
+	}
+
+	for key := range to.configMap.Data {
+		newVal, newSecret := to.get(key)
+		oldVal, oldSecret, existed := from.lookup(key)
+
+		if !existed {
+			res.Added = append(res.Added, newVariableDiff(key, newSecret, "", newVal))
+		} else if oldVal != newVal || oldSecret != newSecret {
+			res.Changed = append(res.Changed, newVariableDiff(key, oldSecret || newSecret, oldVal, newVal))
+		}
+	}
+
+	for key := range from.configMap.Data {
+		if _, exists := to.configMap.Data[key]; !exists {
+			oldVal, oldSecret := from.get(key)
+			res.Removed = append(res.Removed, newVariableDiff(key, oldSecret, oldVal, ""))
+		}
+	}
+
+	sortVariableDiffs(res.Added)
+	sortVariableDiffs(res.Removed)
+	sortVariableDiffs(res.Changed)
+
+	return res, nil
+}
+
+func (v *versionedVariables) get(key string) (string, bool) {
+	val, secret, _ := v.lookup(key)
+	return val, secret
+}
+
+func (v *versionedVariables) lookup(key string) (string, bool, bool) {
+	if val, ok := v.secrets[key]; ok {
+		return val, true, true
+	}
+
+	val, ok := v.variables[key]
+
+	return val, false, ok
+}
+
+func newVariableDiff(key string, secret bool, oldVal, newVal string) *types.EnvGroupVariableDiff {
+	res := &types.EnvGroupVariableDiff{
+		Key:    key,
+		Secret: secret,
+	}
+
+	// secret values are masked, the diff only shows that they changed
+	if !secret {
+		res.OldValue = oldVal
+		res.NewValue = newVal
+	}
+
+	return res
+}
+
+func sortVariableDiffs(diffs []*types.EnvGroupVariableDiff) {
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Key < diffs[j].Key
+	})
+}
@@ -0,0 +1,108 @@
+package envgroup_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+)
+
+func createTestEnvGroupVersions(t *testing.T, agent *kubernetes.Agent) {
+	t.Helper()
+
+	inputs := []types.ConfigMapInput{
+		{
+			Name:            "test",
+			Namespace:       "default",
+			Variables:       map[string]string{"PORT": "8080", "LOG_LEVEL": "info"},
+			SecretVariables: map[string]string{"API_KEY": "abcd", "DB_PASSWORD": "hunter2"},
+		},
+		{
+			Name:            "test",
+			Namespace:       "default",
+			Variables:       map[string]string{"PORT": "9090", "REGION": "us-east-1"},
+			SecretVariables: map[string]string{"API_KEY": "efgh", "DB_PASSWORD": "hunter2"},
+		},
+	}
+
+	for _, input := range inputs {
+		if _, err := envgroup.CreateEnvGroup(agent, input); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+}
+
+func TestDiffEnvGroup(t *testing.T) {
+	agent := kubernetes.GetAgentTesting()
+	createTestEnvGroupVersions(t, agent)
+
+	diff, err := envgroup.DiffEnvGroup(agent, "test", "default", 1, 0)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if diff.FromVersion != 1 || diff.ToVersion != 2 {
+		t.Errorf("expected diff between versions 1 and 2, got %d and %d", diff.FromVersion, diff.ToVersion)
+	}
+
+	if len(diff.Added) != 1 || diff.Added[0].Key != "REGION" || diff.Added[0].NewValue != "us-east-1" {
+		t.Errorf("unexpected added variables %v", diff.Added)
+	}
+
+	if len(diff.Removed) != 1 || diff.Removed[0].Key != "LOG_LEVEL" || diff.Removed[0].OldValue != "info" {
+		t.Errorf("unexpected removed variables %v", diff.Removed)
+	}
+
+	// DB_PASSWORD has the same value in both versions, so only API_KEY and PORT changed
+	if len(diff.Changed) != 2 {
+		t.Fatalf("expected 2 changed variables, got %d", len(diff.Changed))
+	}
+
+	if key := diff.Changed[0]; key.Key != "API_KEY" || !key.Secret || key.OldValue != "" || key.NewValue != "" {
+		t.Errorf("expected masked change of secret API_KEY, got %v", key)
+	}
+
+	if key := diff.Changed[1]; key.Key != "PORT" || key.OldValue != "8080" || key.NewValue != "9090" {
+		t.Errorf("expected change of PORT from 8080 to 9090, got %v", key)
+	}
+
+	_, err = envgroup.DiffEnvGroup(agent, "test", "default", 5, 0)
+
+	if !errors.Is(err, kubernetes.IsNotFoundError) {
+		t.Errorf("expected not found error for missing version, got %v", err)
+	}
+}
+
+func TestRollbackEnvGroup(t *testing.T) {
+	agent := kubernetes.GetAgentTesting()
+	createTestEnvGroupVersions(t, agent)
+
+	cm, err := envgroup.RollbackEnvGroup(agent, "test", "default", 1)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	envGroup, err := envgroup.ToEnvGroup(cm)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if envGroup.Version != 3 {
+		t.Errorf("expected rollback to create version 3, got %d", envGroup.Version)
+	}
+
+	diff, err := envgroup.DiffEnvGroup(agent, "test", "default", 1, 3)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(diff.Added) != 0 || len(diff.Removed) != 0 || len(diff.Changed) != 0 {
+		t.Errorf("expected version 3 to match version 1, got diff %v", diff)
+	}
+}
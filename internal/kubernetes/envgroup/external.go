@@ -0,0 +1,164 @@
+package envgroup
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/secretstore"
+	v1 "k8s.io/api/core/v1"
+)
+
+// ExternalSecretsAnnotationName is the configmap annotation storing the references of
+// secret variables that are read from an external secret store
+const ExternalSecretsAnnotationName = "porter.run/external-secrets"
+
+// ErrNoSecretStore is returned when an env group references external secrets but no
+// external secret store is configured
+var ErrNoSecretStore = errors.New("no external secret store is configured")
+
+// ErrSecretKeyNotFound is returned when a secret in the external secret store does not
+// contain a referenced key
+var ErrSecretKeyNotFound = errors.New("key not found in secret")
+
+// IsExternalSecretError returns true if an error from resolving external secrets was
+// caused by the references themselves, rather than by the secret store being unreachable
+func IsExternalSecretError(err error) bool {
+	return errors.Is(err, ErrNoSecretStore) ||
+		errors.Is(err, ErrSecretKeyNotFound) ||
+		errors.Is(err, secretstore.ErrSecretNotFound) ||
+		errors.Is(err, secretstore.ErrInvalidSecretPath)
+}
+
+// ResolveExternalSecrets reads the values of the external secret variables of the input
+// from the secret store, and adds them to the secret variables of the input. The version
+// of every reference is set to the version of the secret that was read. Secret paths are
+// relative to the prefix of the project in the secret store, so that a project cannot read
+// the secrets of other projects.
+func ResolveExternalSecrets(
+	ctx context.Context,
+	store secretstore.Store,
+	projectID uint,
+	input *types.ConfigMapInput,
+) error {
+	if len(input.ExternalSecretVariables) == 0 {
+		return nil
+	}
+
+	if store == nil {
+		return ErrNoSecretStore
+	}
+
+	store = secretstore.NewProjectStore(store, projectID)
+
+	if input.SecretVariables == nil {
+		input.SecretVariables = make(map[string]string)
+	}
+
+	// several variables can reference the same secret, so each path is only read once
+	secrets := make(map[string]*secretstore.Secret)
+
+	for name, ref := range input.ExternalSecretVariables {
+		secret, ok := secrets[ref.Path]
+
+		if !ok {
+			var err error
+
+			secret, err = store.GetSecret(ctx, ref.Path)
+
+			if err != nil {
+				return fmt.Errorf("error reading secret %s for variable %s: %w", ref.Path, name, err)
+			}
+
+			secrets[ref.Path] = secret
+		}
+
+		val, ok := secret.Data[ref.Key]
+
+		if !ok {
+			return fmt.Errorf("error reading secret %s for variable %s: %w: %s", ref.Path, name, ErrSecretKeyNotFound, ref.Key)
+		}
+
+		input.SecretVariables[name] = val
+		ref.Version = secret.Version
+	}
+
+	return nil
+}
+
+// RefreshExternalSecrets reads the external secrets referenced by an env group again, and
+// creates a new version of the env group if any of the secrets changed. It returns the
+// configmap of the new version, or nil if the env group is up to date.
+func RefreshExternalSecrets(
+	ctx context.Context,
+	agent *kubernetes.Agent,
+	store secretstore.Store,
+	projectID uint,
+	configMap *v1.ConfigMap,
+) (*v1.ConfigMap, error) {
+	refs, err := getExternalSecretReferences(configMap)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(refs) == 0 {
+		return nil, nil
+	}
+
+	input := &types.ConfigMapInput{
+		Name:                    configMap.Labels["envgroup"],
+		Namespace:               configMap.Namespace,
+		Variables:               make(map[string]string),
+		ExternalSecretVariables: make(map[string]*types.ExternalSecretReference),
+	}
+
+	for key, val := range configMap.Data {
+		input.Variables[key] = val
+	}
+
+	for name, ref := range refs {
+		input.ExternalSecretVariables[name] = &types.ExternalSecretReference{
+			Path: ref.Path,
+			Key:  ref.Key,
+		}
+	}
+
+	if err := ResolveExternalSecrets(ctx, store, projectID, input); err != nil {
+		return nil, err
+	}
+
+	changed := false
+
+	for name, ref := range input.ExternalSecretVariables {
+		if ref.Version != refs[name].Version {
+			changed = true
+			break
+		}
+	}
+
+	if !changed {
+		return nil, nil
+	}
+
+	return CreateEnvGroup(agent, *input)
+}
+
+func getExternalSecretReferences(configMap *v1.ConfigMap) (map[string]*types.ExternalSecretReference, error) {
+	refStr, exists := configMap.Annotations[ExternalSecretsAnnotationName]
+
+	if !exists || refStr == "" {
+		return nil, nil
+	}
+
+	refs := make(map[string]*types.ExternalSecretReference)
+
+	if err := json.Unmarshal([]byte(refStr), &refs); err != nil {
+		return nil, fmt.Errorf("not a valid configmap, error parsing external secrets: %v", err)
+	}
+
+	return refs, nil
+}
@@ -0,0 +1,186 @@
+package envgroup_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/secretstore"
+)
+
+type fakeStore map[string]*secretstore.Secret
+
+func (f fakeStore) GetSecret(ctx context.Context, path string) (*secretstore.Secret, error) {
+	secret, ok := f[path]
+
+	if !ok {
+		return nil, secretstore.ErrSecretNotFound
+	}
+
+	return secret, nil
+}
+
+func TestResolveExternalSecrets(t *testing.T) {
+	store := fakeStore{
+		"projects/1/prod/db": {
+			Data:    map[string]string{"password": "hunter2", "user": "admin"},
+			Version: "3",
+		},
+	}
+
+	input := &types.ConfigMapInput{
+		Name:      "test",
+		Namespace: "default",
+		Variables: map[string]string{"PORT": "8080"},
+		ExternalSecretVariables: map[string]*types.ExternalSecretReference{
+			"DB_PASSWORD": {Path: "prod/db", Key: "password"},
+			"DB_USER":     {Path: "prod/db", Key: "user"},
+		},
+	}
+
+	if err := envgroup.ResolveExternalSecrets(context.Background(), store, 1, input); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if input.SecretVariables["DB_PASSWORD"] != "hunter2" || input.SecretVariables["DB_USER"] != "admin" {
+		t.Errorf("unexpected secret variables %v", input.SecretVariables)
+	}
+
+	for name, ref := range input.ExternalSecretVariables {
+		if ref.Version != "3" {
+			t.Errorf("expected version 3 for %s, got %s", name, ref.Version)
+		}
+	}
+
+	input.ExternalSecretVariables["DB_HOST"] = &types.ExternalSecretReference{Path: "prod/db", Key: "host"}
+
+	err := envgroup.ResolveExternalSecrets(context.Background(), store, 1, input)
+
+	if !errors.Is(err, envgroup.ErrSecretKeyNotFound) || !envgroup.IsExternalSecretError(err) {
+		t.Errorf("expected missing key error, got %v", err)
+	}
+
+	err = envgroup.ResolveExternalSecrets(context.Background(), nil, 1, input)
+
+	if !errors.Is(err, envgroup.ErrNoSecretStore) {
+		t.Errorf("expected no secret store error, got %v", err)
+	}
+}
+
+func TestResolveExternalSecretsOfOtherProjects(t *testing.T) {
+	store := fakeStore{
+		"projects/2/prod/db": {
+			Data:    map[string]string{"password": "hunter2"},
+			Version: "1",
+		},
+	}
+
+	for _, path := range []string{"prod/db", "../2/prod/db", "prod/../../2/prod/db", "/projects/2/prod/db", "prod//db"} {
+		input := &types.ConfigMapInput{
+			Name:      "test",
+			Namespace: "default",
+			ExternalSecretVariables: map[string]*types.ExternalSecretReference{
+				"DB_PASSWORD": {Path: path, Key: "password"},
+			},
+		}
+
+		err := envgroup.ResolveExternalSecrets(context.Background(), store, 1, input)
+
+		if err == nil || !envgroup.IsExternalSecretError(err) {
+			t.Errorf("expected project 1 not to read secret path %s, got %v", path, err)
+		}
+	}
+}
+
+func TestRefreshExternalSecrets(t *testing.T) {
+	agent := kubernetes.GetAgentTesting()
+
+	store := fakeStore{
+		"projects/1/prod/db": {
+			Data:    map[string]string{"password": "hunter2"},
+			Version: "1",
+		},
+	}
+
+	input := &types.ConfigMapInput{
+		Name:            "test",
+		Namespace:       "default",
+		Variables:       map[string]string{"PORT": "8080"},
+		SecretVariables: map[string]string{"API_KEY": "abcd"},
+		ExternalSecretVariables: map[string]*types.ExternalSecretReference{
+			"DB_PASSWORD": {Path: "prod/db", Key: "password"},
+		},
+	}
+
+	if err := envgroup.ResolveExternalSecrets(context.Background(), store, 1, input); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	cm, err := envgroup.CreateEnvGroup(agent, *input)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// nothing changed in the secret store
+	newCM, err := envgroup.RefreshExternalSecrets(context.Background(), agent, store, 1, cm)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if newCM != nil {
+		t.Fatalf("expected no new version when the external secrets did not change")
+	}
+
+	store["projects/1/prod/db"] = &secretstore.Secret{
+		Data:    map[string]string{"password": "correct-horse"},
+		Version: "2",
+	}
+
+	newCM, err = envgroup.RefreshExternalSecrets(context.Background(), agent, store, 1, cm)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if newCM == nil {
+		t.Fatalf("expected a new version when an external secret changed")
+	}
+
+	envGroup, err := envgroup.ToEnvGroup(newCM)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if envGroup.Version != 2 {
+		t.Errorf("expected env group version 2, got %d", envGroup.Version)
+	}
+
+	if ref := envGroup.ExternalSecretVariables["DB_PASSWORD"]; ref == nil || ref.Version != "2" {
+		t.Errorf("expected external secret reference with version 2, got %v", ref)
+	}
+
+	secret, _, err := agent.GetLatestVersionedSecret("test", "default")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(secret.Data["DB_PASSWORD"]) != "correct-horse" {
+		t.Errorf("expected updated external secret value, got %s", secret.Data["DB_PASSWORD"])
+	}
+
+	// secrets which are not external are carried over to the new version
+	if string(secret.Data["API_KEY"]) != "abcd" {
+		t.Errorf("expected secret API_KEY to be kept, got %s", secret.Data["API_KEY"])
+	}
+
+	if envGroup.Variables["PORT"] != "8080" {
+		t.Errorf("expected variable PORT to be kept, got %s", envGroup.Variables["PORT"])
+	}
+}
@@ -0,0 +1,118 @@
+package envgroup
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/envfile"
+	"github.com/porter-dev/porter/internal/kubernetes"
+)
+
+// ImportOpts are the options to import variables into an env group
+type ImportOpts struct {
+	Name            string
+	Namespace       string
+	Variables       map[string]string
+	SecretVariables map[string]string
+
+	// Replace removes the variables of the env group which are not imported, instead of
+	// merging the imported variables into the env group
+	Replace bool
+}
+
+// GetImportInput returns the input to create a new version of an env group with imported
+// variables. Unless the variables are replaced, the variables, secrets and external
+// secret references of the latest version which are not imported are kept.
+func GetImportInput(agent *kubernetes.Agent, opts *ImportOpts) (*types.ConfigMapInput, error) {
+	input := &types.ConfigMapInput{
+		Name:                    opts.Name,
+		Namespace:               opts.Namespace,
+		Variables:               make(map[string]string),
+		SecretVariables:         make(map[string]string),
+		ExternalSecretVariables: make(map[string]*types.ExternalSecretReference),
+	}
+
+	if !opts.Replace {
+		configMap, _, err := agent.GetLatestVersionedConfigMap(opts.Name, opts.Namespace)
+
+		if err != nil && !errors.Is(err, kubernetes.IsNotFoundError) {
+			return nil, err
+		} else if err == nil {
+			envGroup, err := ToEnvGroup(configMap)
+
+			if err != nil {
+				return nil, err
+			}
+
+			// existing secrets keep their placeholder, so that CreateEnvGroup copies their
+			// values from the latest version
+			for key, val := range configMap.Data {
+				input.Variables[key] = val
+			}
+
+			for key, ref := range envGroup.ExternalSecretVariables {
+				input.ExternalSecretVariables[key] = ref
+			}
+		}
+	}
+
+	for key, val := range opts.Variables {
+		delete(input.ExternalSecretVariables, key)
+		input.Variables[key] = val
+	}
+
+	for key, val := range opts.SecretVariables {
+		delete(input.ExternalSecretVariables, key)
+		delete(input.Variables, key)
+		input.SecretVariables[key] = val
+	}
+
+	return input, nil
+}
+
+// ExportEnvGroup writes the variables of a version of an env group as the contents of a file.
+// The values of secret variables are only included if includeSecrets is set.
+func ExportEnvGroup(
+	agent *kubernetes.Agent,
+	name, namespace string,
+	version uint,
+	format types.EnvGroupFileFormat,
+	includeSecrets bool,
+) (*types.ExportEnvGroupResponse, error) {
+	vars, err := getVersionedVariables(agent, name, namespace, version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	res := &types.ExportEnvGroupResponse{
+		Version:    vars.version,
+		Format:     format,
+		SecretKeys: make([]string, 0),
+	}
+
+	exported := make(map[string]string)
+
+	for key, val := range vars.variables {
+		exported[key] = val
+	}
+
+	for key, val := range vars.secrets {
+		res.SecretKeys = append(res.SecretKeys, key)
+
+		if includeSecrets {
+			exported[key] = val
+		}
+	}
+
+	sort.Strings(res.SecretKeys)
+
+	res.Content, err = envfile.Format(string(format), exported)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
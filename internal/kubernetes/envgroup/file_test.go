@@ -0,0 +1,103 @@
+package envgroup_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+)
+
+func TestImportEnvGroupMerge(t *testing.T) {
+	agent := kubernetes.GetAgentTesting()
+	createTestEnvGroupVersions(t, agent)
+
+	input, err := envgroup.GetImportInput(agent, &envgroup.ImportOpts{
+		Name:            "test",
+		Namespace:       "default",
+		Variables:       map[string]string{"PORT": "3000", "API_KEY": "now-public"},
+		SecretVariables: map[string]string{"TOKEN": "xyz"},
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := envgroup.CreateEnvGroup(agent, *input); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	res, err := envgroup.ExportEnvGroup(agent, "test", "default", 0, types.EnvGroupFileFormatDotenv, true)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the variables and secrets of version 2 which were not imported are kept
+	expected := "API_KEY=now-public\nDB_PASSWORD=hunter2\nPORT=3000\nREGION=us-east-1\nTOKEN=xyz\n"
+
+	if res.Content != expected {
+		t.Errorf("expected content %q, got %q", expected, res.Content)
+	}
+
+	if !reflect.DeepEqual(res.SecretKeys, []string{"DB_PASSWORD", "TOKEN"}) {
+		t.Errorf("unexpected secret keys %v", res.SecretKeys)
+	}
+}
+
+func TestImportEnvGroupReplace(t *testing.T) {
+	agent := kubernetes.GetAgentTesting()
+	createTestEnvGroupVersions(t, agent)
+
+	input, err := envgroup.GetImportInput(agent, &envgroup.ImportOpts{
+		Name:            "test",
+		Namespace:       "default",
+		Variables:       map[string]string{"PORT": "3000"},
+		SecretVariables: map[string]string{"TOKEN": "xyz"},
+		Replace:         true,
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if _, err := envgroup.CreateEnvGroup(agent, *input); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	res, err := envgroup.ExportEnvGroup(agent, "test", "default", 0, types.EnvGroupFileFormatDotenv, true)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if expected := "PORT=3000\nTOKEN=xyz\n"; res.Content != expected {
+		t.Errorf("expected content %q, got %q", expected, res.Content)
+	}
+}
+
+func TestExportEnvGroupWithoutSecrets(t *testing.T) {
+	agent := kubernetes.GetAgentTesting()
+	createTestEnvGroupVersions(t, agent)
+
+	res, err := envgroup.ExportEnvGroup(agent, "test", "default", 1, types.EnvGroupFileFormatJSON, false)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if res.Version != 1 {
+		t.Errorf("expected version 1, got %d", res.Version)
+	}
+
+	expected := "{\n  \"LOG_LEVEL\": \"info\",\n  \"PORT\": \"8080\"\n}\n"
+
+	if res.Content != expected {
+		t.Errorf("expected content %q, got %q", expected, res.Content)
+	}
+
+	if !reflect.DeepEqual(res.SecretKeys, []string{"API_KEY", "DB_PASSWORD"}) {
+		t.Errorf("unexpected secret keys %v", res.SecretKeys)
+	}
+}
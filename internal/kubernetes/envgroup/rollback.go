@@ -0,0 +1,32 @@
+package envgroup
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	v1 "k8s.io/api/core/v1"
+)
+
+// RollbackEnvGroup creates a new version of an env group with the variables and secrets of
+// an older version. The applications linked to the env group are kept as they are.
+func RollbackEnvGroup(agent *kubernetes.Agent, name, namespace string, version uint) (*v1.ConfigMap, error) {
+	target, err := getVersionedVariables(agent, name, namespace, version)
+
+	if err != nil {
+		return nil, err
+	}
+
+	envGroup, err := ToEnvGroup(target.configMap)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// secret values are passed explicitly, so that they are not copied from the latest version
+	return CreateEnvGroup(agent, types.ConfigMapInput{
+		Name:                    name,
+		Namespace:               namespace,
+		Variables:               target.variables,
+		SecretVariables:         target.secrets,
+		ExternalSecretVariables: envGroup.ExternalSecretVariables,
+	})
+}
@@ -0,0 +1,102 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PreviewSleepReplicasAnnotation stores the number of replicas of a deployment in a sleeping
+// preview environment, which are restored when the preview environment is woken up
+const PreviewSleepReplicasAnnotation = "porter.run/preview-sleep-replicas"
+
+// SleepNamespace scales all deployments in a namespace to zero replicas, storing the previous
+// number of replicas in an annotation. Horizontal pod autoscalers do not act on deployments
+// scaled to zero, so autoscaled deployments stay asleep as well.
+func (a *Agent) SleepNamespace(namespace string) error {
+	depls, err := a.Clientset.AppsV1().Deployments(namespace).List(
+		context.TODO(),
+		metav1.ListOptions{},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	for i := range depls.Items {
+		depl := &depls.Items[i]
+
+		if depl.Spec.Replicas == nil || *depl.Spec.Replicas == 0 {
+			continue
+		}
+
+		if depl.Annotations == nil {
+			depl.Annotations = make(map[string]string)
+		}
+
+		depl.Annotations[PreviewSleepReplicasAnnotation] = strconv.Itoa(int(*depl.Spec.Replicas))
+
+		var zero int32
+		depl.Spec.Replicas = &zero
+
+		_, err := a.Clientset.AppsV1().Deployments(namespace).Update(
+			context.TODO(),
+			depl,
+			metav1.UpdateOptions{},
+		)
+
+		if err != nil {
+			return fmt.Errorf("error scaling down deployment %s: %w", depl.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// WakeNamespace restores the replicas of the deployments in a namespace which were scaled to
+// zero by SleepNamespace
+func (a *Agent) WakeNamespace(namespace string) error {
+	depls, err := a.Clientset.AppsV1().Deployments(namespace).List(
+		context.TODO(),
+		metav1.ListOptions{},
+	)
+
+	if err != nil {
+		return err
+	}
+
+	for i := range depls.Items {
+		depl := &depls.Items[i]
+
+		replicasStr, ok := depl.Annotations[PreviewSleepReplicasAnnotation]
+
+		if !ok {
+			continue
+		}
+
+		replicas, err := strconv.Atoi(replicasStr)
+
+		if err != nil || replicas < 1 {
+			replicas = 1
+		}
+
+		replicas32 := int32(replicas)
+		depl.Spec.Replicas = &replicas32
+
+		delete(depl.Annotations, PreviewSleepReplicasAnnotation)
+
+		_, err = a.Clientset.AppsV1().Deployments(namespace).Update(
+			context.TODO(),
+			depl,
+			metav1.UpdateOptions{},
+		)
+
+		if err != nil {
+			return fmt.Errorf("error scaling up deployment %s: %w", depl.Name, err)
+		}
+	}
+
+	return nil
+}
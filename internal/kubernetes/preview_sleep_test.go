@@ -0,0 +1,75 @@
+package kubernetes_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/kubernetes"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newDeploymentFixture(name string, replicas int32) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "pr-1-app",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+	}
+}
+
+func getReplicas(t *testing.T, agent *kubernetes.Agent, name string) (int32, map[string]string) {
+	t.Helper()
+
+	depl, err := agent.Clientset.AppsV1().Deployments("pr-1-app").Get(context.TODO(), name, metav1.GetOptions{})
+
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	return *depl.Spec.Replicas, depl.Annotations
+}
+
+func TestSleepAndWakeNamespace(t *testing.T) {
+	agent := newAgentFixture(t,
+		newDeploymentFixture("web", 3),
+		newDeploymentFixture("worker", 1),
+		newDeploymentFixture("stopped", 0),
+	)
+
+	if err := agent.SleepNamespace("pr-1-app"); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	for _, name := range []string{"web", "worker", "stopped"} {
+		if replicas, _ := getReplicas(t, agent, name); replicas != 0 {
+			t.Errorf("expected deployment %s to have 0 replicas after sleeping, got %d", name, replicas)
+		}
+	}
+
+	if _, annotations := getReplicas(t, agent, "stopped"); annotations[kubernetes.PreviewSleepReplicasAnnotation] != "" {
+		t.Errorf("expected deployment with no replicas to be left untouched")
+	}
+
+	if err := agent.WakeNamespace("pr-1-app"); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	expected := map[string]int32{"web": 3, "worker": 1, "stopped": 0}
+
+	for name, expReplicas := range expected {
+		replicas, annotations := getReplicas(t, agent, name)
+
+		if replicas != expReplicas {
+			t.Errorf("expected deployment %s to have %d replicas after waking, got %d", name, expReplicas, replicas)
+		}
+
+		if _, ok := annotations[kubernetes.PreviewSleepReplicasAnnotation]; ok {
+			t.Errorf("expected sleep annotation to be removed from deployment %s", name)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package prometheus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// IngressMetrics are the error rate and latency of the requests served through a set of
+// NGINX ingresses over a time window
+type IngressMetrics struct {
+	// ErrorPct is the percentage of requests which returned a 5xx status code
+	ErrorPct float64
+
+	// LatencyP99 is the 99th percentile of the request duration. It is nil if no requests
+	// were served during the window.
+	LatencyP99 *time.Duration
+}
+
+// QueryIngressMetrics gets the error rate and latency of the requests served by the given
+// ingresses over the last window
+func QueryIngressMetrics(
+	clientset kubernetes.Interface,
+	service *v1.Service,
+	namespace string,
+	ingresses []string,
+	window time.Duration,
+) (*IngressMetrics, error) {
+	if len(service.Spec.Ports) == 0 {
+		return nil, fmt.Errorf("prometheus service has no exposed ports to query")
+	}
+
+	selector := fmt.Sprintf(`exported_namespace="%s",ingress=~"%s"`, namespace, strings.Join(ingresses, "|"))
+	rangeStr := fmt.Sprintf("%ds", int(window.Seconds()))
+
+	num := fmt.Sprintf(`sum(rate(nginx_ingress_controller_requests{status=~"5.*",%s}[%s]) OR on() vector(0))`, selector, rangeStr)
+	denom := fmt.Sprintf(`sum(rate(nginx_ingress_controller_requests{%s}[%s]) > 0)`, selector, rangeStr)
+
+	errorPct, found, err := queryPrometheusInstant(clientset, service, fmt.Sprintf(`%s / %s * 100 OR on() vector(0)`, num, denom))
+
+	if err != nil {
+		return nil, fmt.Errorf("error querying error rate: %w", err)
+	}
+
+	res := &IngressMetrics{}
+
+	if found {
+		res.ErrorPct = errorPct
+	}
+
+	latency, found, err := queryPrometheusInstant(clientset, service, fmt.Sprintf(
+		`histogram_quantile(0.99, sum(rate(nginx_ingress_controller_request_duration_seconds_bucket{%s}[%s])) by (le))`,
+		selector, rangeStr,
+	))
+
+	if err != nil {
+		return nil, fmt.Errorf("error querying latency: %w", err)
+	}
+
+	if found {
+		latencyDur := time.Duration(latency * float64(time.Second))
+		res.LatencyP99 = &latencyDur
+	}
+
+	return res, nil
+}
+
+type promRawInstantQuery struct {
+	Data struct {
+		Result []struct {
+			Value []interface{} `json:"value"`
+		} `json:"result"`
+	} `json:"data"`
+}
+
+// queryPrometheusInstant runs a query which returns a single value at the current time. It
+// returns false if the query did not return a number.
+func queryPrometheusInstant(
+	clientset kubernetes.Interface,
+	service *v1.Service,
+	query string,
+) (float64, bool, error) {
+	resp := clientset.CoreV1().Services(service.Namespace).ProxyGet(
+		"http",
+		service.Name,
+		fmt.Sprintf("%d", service.Spec.Ports[0].Port),
+		"/api/v1/query",
+		map[string]string{
+			"query": query,
+		},
+	)
+
+	rawQuery, err := resp.DoRaw(context.TODO())
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	rawQueryObj := &promRawInstantQuery{}
+
+	err = json.Unmarshal(rawQuery, rawQueryObj)
+
+	if err != nil {
+		return 0, false, err
+	}
+
+	if len(rawQueryObj.Data.Result) == 0 || len(rawQueryObj.Data.Result[0].Value) != 2 {
+		return 0, false, nil
+	}
+
+	valStr, ok := rawQueryObj.Data.Result[0].Value[1].(string)
+
+	if !ok {
+		return 0, false, nil
+	}
+
+	val, err := strconv.ParseFloat(valStr, 64)
+
+	if err != nil || math.IsNaN(val) || math.IsInf(val, 0) {
+		return 0, false, nil
+	}
+
+	return val, true, nil
+}
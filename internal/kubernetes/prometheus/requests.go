@@ -0,0 +1,37 @@
+package prometheus
+
+import (
+	"fmt"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// QueryNamespaceRequestCount gets the number of requests served by the NGINX ingresses of a
+// namespace over the last window. The count is not found if Prometheus has no NGINX ingress
+// metrics at all, for example because the cluster uses another ingress controller, in which
+// case the traffic of the namespace is unknown.
+func QueryNamespaceRequestCount(
+	clientset kubernetes.Interface,
+	service *v1.Service,
+	namespace string,
+	window time.Duration,
+) (float64, bool, error) {
+	if len(service.Spec.Ports) == 0 {
+		return 0, false, fmt.Errorf("prometheus service has no exposed ports to query")
+	}
+
+	// a namespace which served no requests has no series of its own, so the count falls back
+	// to zero as long as the metric is reported for other namespaces
+	count, found, err := queryPrometheusInstant(clientset, service, fmt.Sprintf(
+		`sum(increase(nginx_ingress_controller_requests{exported_namespace="%s"}[%ds])) OR on() (0 * count(nginx_ingress_controller_requests))`,
+		namespace, int(window.Seconds()),
+	))
+
+	if err != nil {
+		return 0, false, fmt.Errorf("error querying request count: %w", err)
+	}
+
+	return count, found, nil
+}
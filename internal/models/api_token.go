@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/porter-dev/porter/api/types"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
@@ -22,6 +23,14 @@ type APIToken struct {
 
 	// SecretKey is hashed like a password before storage
 	SecretKey []byte
+
+	// PreviousSecretKey is the hashed secret replaced by the last rotation, which is
+	// still accepted until PreviousSecretExpiry
+	PreviousSecretKey    []byte
+	PreviousSecretExpiry *time.Time
+
+	LastUsedAt *time.Time
+	LastUsedIP string
 }
 
 func (p *APIToken) IsExpired() bool {
@@ -29,6 +38,20 @@ func (p *APIToken) IsExpired() bool {
 	return timeLeft < 0
 }
 
+// IsValidSecret checks a secret against the secret key of the token, or against the
+// previous secret key while the grace period of the last rotation has not elapsed
+func (p *APIToken) IsValidSecret(secret string) bool {
+	if bcrypt.CompareHashAndPassword(p.SecretKey, []byte(secret)) == nil {
+		return true
+	}
+
+	if len(p.PreviousSecretKey) == 0 || p.PreviousSecretExpiry == nil || p.PreviousSecretExpiry.Before(time.Now()) {
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword(p.PreviousSecretKey, []byte(secret)) == nil
+}
+
 func (p *APIToken) ToAPITokenMetaType() *types.APITokenMeta {
 	return &types.APITokenMeta{
 		ID:         p.UniqueID,
@@ -37,6 +60,10 @@ func (p *APIToken) ToAPITokenMetaType() *types.APITokenMeta {
 		PolicyName: p.PolicyName,
 		PolicyUID:  p.PolicyUID,
 		Name:       p.Name,
+		LastUsedAt: p.LastUsedAt,
+		LastUsedIP: p.LastUsedIP,
+
+		PreviousSecretExpiresAt: p.PreviousSecretExpiry,
 	}
 }
 
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// AuditLog records a single API call made within a project which requires write access
+type AuditLog struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+	ClusterID uint
+	Namespace string
+
+	// the actor of the call: UserID is empty for calls made with an API token
+	UserID     uint
+	APITokenID string
+	IPAddress  string
+
+	Method string
+	Path   string
+	Route  string
+	Verb   types.APIVerb
+
+	ResourceType types.PermissionScope
+	ResourceName string
+
+	StatusCode int
+	Outcome    types.AuditLogOutcome
+}
+
+func (a *AuditLog) ToAuditLogType() *types.AuditLog {
+	return &types.AuditLog{
+		ID:           a.ID,
+		CreatedAt:    a.CreatedAt,
+		ProjectID:    a.ProjectID,
+		ClusterID:    a.ClusterID,
+		Namespace:    a.Namespace,
+		UserID:       a.UserID,
+		APITokenID:   a.APITokenID,
+		IPAddress:    a.IPAddress,
+		Method:       a.Method,
+		Path:         a.Path,
+		Route:        a.Route,
+		Verb:         a.Verb,
+		ResourceType: a.ResourceType,
+		ResourceName: a.ResourceName,
+		StatusCode:   a.StatusCode,
+		Outcome:      a.Outcome,
+	}
+}
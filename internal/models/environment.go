@@ -2,6 +2,7 @@ package models
 
 import (
 	"strings"
+	"time"
 
 	"github.com/porter-dev/porter/api/types"
 	"gorm.io/gorm"
@@ -32,6 +33,26 @@ type Environment struct {
 	WebhookID string `gorm:"unique"`
 
 	GithubWebhookID int64
+
+	// GitlabIntegrationID is set for environments driven by GitLab merge requests instead of
+	// GitHub pull requests
+	GitlabIntegrationID uint
+
+	// GitlabUserID is the ID of the Porter user who created a GitLab environment, whose GitLab
+	// OAuth token is used to talk to the GitLab API
+	GitlabUserID uint
+
+	GitlabWebhookID int
+
+	// IdleTimeoutHours and MaxDeploymentAgeHours control when deployments are put to sleep and
+	// expired, and are disabled when set to 0
+	IdleTimeoutHours      uint
+	MaxDeploymentAgeHours uint
+}
+
+// IsGitlabEnvironment returns true if the environment is driven by GitLab merge requests
+func (e *Environment) IsGitlabEnvironment() bool {
+	return e.GitlabIntegrationID != 0
 }
 
 func getGitRepoBranches(branches string) []string {
@@ -61,11 +82,16 @@ func (e *Environment) ToEnvironmentType() *types.Environment {
 		GitRepoOwner:      e.GitRepoOwner,
 		GitRepoName:       e.GitRepoName,
 
+		GitlabIntegrationID: e.GitlabIntegrationID,
+
 		NewCommentsDisabled: e.NewCommentsDisabled,
 		NamespaceLabels:     make(map[string]string),
 
 		Name: e.Name,
 		Mode: e.Mode,
+
+		IdleTimeoutHours:      e.IdleTimeoutHours,
+		MaxDeploymentAgeHours: e.MaxDeploymentAgeHours,
 	}
 
 	branches := getGitRepoBranches(e.GitRepoBranches)
@@ -117,6 +143,17 @@ type Deployment struct {
 	PRBranchFrom   string
 	PRBranchInto   string
 	LastErrors     string
+
+	GitlabDeploymentID int
+	GitlabNoteID       int
+	GitlabPipelineID   int
+
+	// StartedAt is the time the namespace of the deployment was first deployed, which is reset
+	// when an expired deployment is deployed again
+	StartedAt *time.Time
+
+	// LastActivityAt is the last time the deployment received a push, HTTP traffic or was woken up
+	LastActivityAt *time.Time
 }
 
 func (d *Deployment) ToDeploymentType() *types.Deployment {
@@ -141,9 +178,52 @@ func (d *Deployment) ToDeploymentType() *types.Deployment {
 		PullRequestID:  d.PullRequestID,
 		GitHubMetadata: ghMetadata,
 		LastErrors:     d.LastErrors,
+		LastActivityAt: d.LastActivityAt,
 	}
 }
 
+// RecordActivity marks the deployment as active. Expired deployments are left untouched, as
+// they have to be restarted with Restart when they are deployed again.
+func (d *Deployment) RecordActivity() {
+	if d.Status == types.DeploymentStatusExpired {
+		return
+	}
+
+	now := time.Now()
+
+	if d.StartedAt == nil {
+		d.StartedAt = &now
+	}
+
+	d.LastActivityAt = &now
+}
+
+// Restart restarts the age of a deployment which is deployed again after it expired
+func (d *Deployment) Restart() {
+	now := time.Now()
+
+	d.StartedAt = &now
+	d.LastActivityAt = &now
+}
+
+// GetStartedAt returns the time the namespace of the deployment was first deployed
+func (d *Deployment) GetStartedAt() time.Time {
+	if d.StartedAt != nil {
+		return *d.StartedAt
+	}
+
+	return d.CreatedAt
+}
+
+// GetLastActivityAt returns the last time the deployment was active
+func (d *Deployment) GetLastActivityAt() time.Time {
+	if d.LastActivityAt != nil {
+		return *d.LastActivityAt
+	}
+
+	return d.GetStartedAt()
+}
+
 func (d *Deployment) IsBranchDeploy() bool {
 	return d.PullRequestID == 0 && d.PRBranchFrom != "" && d.PRBranchInto != "" && d.PRBranchFrom == d.PRBranchInto
 }
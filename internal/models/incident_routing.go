@@ -0,0 +1,119 @@
+package models
+
+import (
+	"path"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// IncidentRoutingRule routes the incidents of a project which match the rule to
+// a set of notifier targets
+type IncidentRoutingRule struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+
+	Name string
+
+	// Rules are evaluated in ascending order of priority
+	Priority int
+
+	// Whether rules with a higher priority are evaluated once this rule matches
+	Continue bool
+
+	ReleaseName        string
+	Namespace          string
+	Severity           types.SeverityType
+	InvolvedObjectKind types.InvolvedObjectKind
+
+	Targets []IncidentRoutingTarget
+}
+
+// Matches returns true if the incident matches all of the non-empty fields of the rule
+func (r *IncidentRoutingRule) Matches(incident *types.Incident) bool {
+	if incident.IncidentMeta == nil {
+		return false
+	}
+
+	if r.ReleaseName != "" && !matchPattern(r.ReleaseName, incident.ReleaseName) {
+		return false
+	}
+
+	if r.Namespace != "" && !matchPattern(r.Namespace, incident.ReleaseNamespace) {
+		return false
+	}
+
+	if r.Severity != "" && r.Severity != incident.Severity {
+		return false
+	}
+
+	if r.InvolvedObjectKind != "" && r.InvolvedObjectKind != incident.InvolvedObjectKind {
+		return false
+	}
+
+	return true
+}
+
+func matchPattern(pattern, name string) bool {
+	matched, err := path.Match(pattern, name)
+
+	// a malformed pattern only matches the exact name
+	if err != nil {
+		return pattern == name
+	}
+
+	return matched
+}
+
+func (r *IncidentRoutingRule) ToIncidentRoutingRuleType() *types.IncidentRoutingRule {
+	targets := make([]*types.IncidentRoutingTarget, 0)
+
+	for _, target := range r.Targets {
+		targets = append(targets, target.ToIncidentRoutingTargetType())
+	}
+
+	return &types.IncidentRoutingRule{
+		ID:                 r.ID,
+		ProjectID:          r.ProjectID,
+		Name:               r.Name,
+		Priority:           r.Priority,
+		Continue:           r.Continue,
+		ReleaseName:        r.ReleaseName,
+		Namespace:          r.Namespace,
+		Severity:           r.Severity,
+		InvolvedObjectKind: r.InvolvedObjectKind,
+		Targets:            targets,
+	}
+}
+
+type IncidentRoutingTarget struct {
+	gorm.Model
+
+	IncidentRoutingRuleID uint `gorm:"index"`
+
+	Kind types.IncidentRoutingTargetKind
+
+	// The slack or webhook integration to notify, or 0 for all integrations of the kind
+	IntegrationID uint
+
+	URL              string
+	CriticalSeverity string
+	NormalSeverity   string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	RoutingKey []byte
+}
+
+func (t *IncidentRoutingTarget) ToIncidentRoutingTargetType() *types.IncidentRoutingTarget {
+	return &types.IncidentRoutingTarget{
+		Kind:             t.Kind,
+		IntegrationID:    t.IntegrationID,
+		URL:              t.URL,
+		CriticalSeverity: t.CriticalSeverity,
+		NormalSeverity:   t.NormalSeverity,
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 
@@ -46,6 +47,13 @@ type Infra struct {
 	// Status is the status of the infra
 	Status types.InfraStatus
 
+	// DriftStatus is the result of the last drift detection run against the infra, empty if
+	// drift has never been checked
+	DriftStatus types.InfraDriftStatus
+
+	// DriftCheckedAt is the time at which drift was last checked
+	DriftCheckedAt *time.Time
+
 	Operations []Operation
 
 	// The AWS integration that was used to create the infra
@@ -85,6 +93,13 @@ type Operation struct {
 	Error           string
 	TemplateVersion string
 
+	// DriftedResources is a JSON-encoded list of the resources which were changed outside of
+	// Porter since this operation was applied, as found by the last drift detection run
+	DriftedResources []byte
+
+	// DriftDetectedAt is the time at which drift was last detected
+	DriftDetectedAt *time.Time
+
 	// ------------------------------------------------------------------
 	// All fields below this line are encrypted before storage
 	// ------------------------------------------------------------------
@@ -93,6 +108,12 @@ type Operation struct {
 	LastApplied []byte
 }
 
+// IsPlan returns true if the operation only planned changes to the infra, which is the case
+// for plan and drift detection operations
+func (o *Operation) IsPlan() bool {
+	return o.Type == "plan" || o.Type == "detect_drift"
+}
+
 func (o *Operation) ToOperationMetaType() *types.OperationMeta {
 	return &types.OperationMeta{
 		LastUpdated: o.UpdatedAt,
@@ -115,10 +136,18 @@ func (o *Operation) ToOperationType() (*types.Operation, error) {
 		return nil, err
 	}
 
-	return &types.Operation{
+	res := &types.Operation{
 		OperationMeta: o.ToOperationMetaType(),
 		LastApplied:   lastApplied,
-	}, nil
+	}
+
+	if len(o.DriftedResources) > 0 {
+		if err := json.Unmarshal(o.DriftedResources, &res.DriftedResources); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
 }
 
 func GetOperationID() (string, error) {
@@ -182,6 +211,8 @@ func (i *Infra) ToInfraType() *types.Infra {
 		SourceVersion:    i.SourceVersion,
 		Kind:             i.Kind,
 		Status:           i.Status,
+		DriftStatus:      i.DriftStatus,
+		DriftCheckedAt:   i.DriftCheckedAt,
 		AWSIntegrationID: i.AWSIntegrationID,
 		DOIntegrationID:  i.DOIntegrationID,
 		GCPIntegrationID: i.GCPIntegrationID,
@@ -0,0 +1,95 @@
+package integrations
+
+import (
+	"net/url"
+
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// WebhookIntegration is an outgoing webhook which receives deployment and incident events
+// of a project as signed JSON payloads
+type WebhookIntegration struct {
+	gorm.Model
+
+	// The project that this integration belongs to
+	ProjectID uint `json:"project_id"`
+
+	// A name to identify the webhook
+	Name string
+
+	// Whether deployment notifications are sent to the webhook
+	DeploymentEvents bool
+
+	// Whether new and resolved incidents are sent to the webhook
+	IncidentEvents bool
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The URL to call, which may contain credentials
+	URL []byte
+
+	// The secret used to compute the HMAC signature of each payload
+	Secret []byte
+}
+
+func (w *WebhookIntegration) ToWebhookIntegrationType() *types.WebhookIntegration {
+	return &types.WebhookIntegration{
+		ID:               w.ID,
+		CreatedAt:        w.CreatedAt,
+		ProjectID:        w.ProjectID,
+		Name:             w.Name,
+		URL:              maskWebhookURL(string(w.URL)),
+		DeploymentEvents: w.DeploymentEvents,
+		IncidentEvents:   w.IncidentEvents,
+	}
+}
+
+// maskWebhookURL returns the scheme and host of a webhook URL, since the credentials of
+// a webhook are commonly part of its path or query
+func maskWebhookURL(rawURL string) string {
+	parsedURL, err := url.Parse(rawURL)
+
+	if err != nil || parsedURL.Host == "" {
+		return ""
+	}
+
+	res := parsedURL.Scheme + "://" + parsedURL.Host
+
+	if (parsedURL.Path != "" && parsedURL.Path != "/") || parsedURL.RawQuery != "" {
+		res += "/********"
+	}
+
+	return res
+}
+
+// WebhookDelivery records the attempts to deliver a single event to a webhook
+type WebhookDelivery struct {
+	gorm.Model
+
+	WebhookIntegrationID uint `gorm:"index"`
+
+	DeliveryID   string
+	Event        types.WebhookEvent
+	Status       types.WebhookDeliveryStatus
+	Attempts     uint
+	ResponseCode int
+	Error        string
+}
+
+func (w *WebhookDelivery) ToWebhookDeliveryType() *types.WebhookDelivery {
+	return &types.WebhookDelivery{
+		ID:                   w.ID,
+		CreatedAt:            w.CreatedAt,
+		WebhookIntegrationID: w.WebhookIntegrationID,
+		DeliveryID:           w.DeliveryID,
+		Event:                w.Event,
+		Status:               w.Status,
+		Attempts:             w.Attempts,
+		ResponseCode:         w.ResponseCode,
+		Error:                w.Error,
+	}
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// PolicyPackCategoryPrefix prefixes the category of the monitor test results created by
+// a policy pack, so that packs cannot collide with the built-in recommender categories
+const PolicyPackCategoryPrefix = "custom/"
+
+// PolicyPack is a collection of Rego policies uploaded to a project
+type PolicyPack struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+
+	Name string
+
+	Kind types.PolicyPackKind
+
+	// Match stores the JSON-encoded types.PolicyPackMatch of the pack
+	Match []byte
+
+	MustExist        bool
+	OverrideSeverity string
+
+	Policies []PolicyPackPolicy
+}
+
+// Category returns the category of the monitor test results created by the pack
+func (p *PolicyPack) Category() string {
+	return PolicyPackCategoryPrefix + p.Name
+}
+
+// GetMatch decodes the match parameters of the pack
+func (p *PolicyPack) GetMatch() (*types.PolicyPackMatch, error) {
+	match := &types.PolicyPackMatch{}
+
+	if len(p.Match) == 0 {
+		return match, nil
+	}
+
+	if err := json.Unmarshal(p.Match, match); err != nil {
+		return nil, err
+	}
+
+	return match, nil
+}
+
+func (p *PolicyPack) ToPolicyPackType() *types.PolicyPack {
+	policies := make([]*types.PolicyPackPolicy, 0)
+
+	for _, policy := range p.Policies {
+		policies = append(policies, &types.PolicyPackPolicy{
+			Name:   policy.Name,
+			Source: policy.Source,
+		})
+	}
+
+	match, err := p.GetMatch()
+
+	if err != nil {
+		match = &types.PolicyPackMatch{}
+	}
+
+	return &types.PolicyPack{
+		ID:               p.ID,
+		ProjectID:        p.ProjectID,
+		Name:             p.Name,
+		Category:         p.Category(),
+		Kind:             p.Kind,
+		Match:            match,
+		MustExist:        p.MustExist,
+		OverrideSeverity: p.OverrideSeverity,
+		Policies:         policies,
+	}
+}
+
+// PolicyPackPolicy is a single Rego module of a policy pack
+type PolicyPackPolicy struct {
+	gorm.Model
+
+	PolicyPackID uint `gorm:"index"`
+
+	// Name is the package of the module, such as "web.memory_limits"
+	Name string
+
+	Source string
+}
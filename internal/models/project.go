@@ -63,6 +63,13 @@ type Project struct {
 	ManagedInfraEnabled bool
 	StacksEnabled       bool
 	APITokensEnabled    bool
+
+	// APITokenMaxLifetimeDays limits the expiry of newly created api tokens, if set
+	APITokenMaxLifetimeDays uint
+
+	// TwoFactorRequired blocks access to the project for collaborators who log in with
+	// a password and have not enabled two-factor authentication
+	TwoFactorRequired bool
 }
 
 // ToProjectType generates an external types.Project to be shared over REST
@@ -82,5 +89,8 @@ func (p *Project) ToProjectType() *types.Project {
 		ManagedInfraEnabled: p.ManagedInfraEnabled,
 		StacksEnabled:       p.StacksEnabled,
 		APITokensEnabled:    p.APITokensEnabled,
+
+		APITokenMaxLifetimeDays: p.APITokenMaxLifetimeDays,
+		TwoFactorRequired:       p.TwoFactorRequired,
 	}
 }
@@ -1,6 +1,8 @@
 package models
 
 import (
+	"strings"
+
 	"github.com/porter-dev/porter/api/types"
 	"gorm.io/gorm"
 )
@@ -19,6 +21,41 @@ type User struct {
 	// The github user id used for login (optional)
 	GithubUserID int64
 	GoogleUserID string
+
+	// The subject of the user at the OpenID Connect provider used for login (optional)
+	OIDCUserID string
+
+	// TOTPSecret is the secret used to generate two-factor codes. It is set during
+	// enrollment and is only used once TOTPEnabled is true.
+	TOTPSecret  []byte
+	TOTPEnabled bool
+
+	// TOTPLastUsedStep is the time step of the last accepted code, so that a code
+	// cannot be used twice
+	TOTPLastUsedStep int64
+
+	// TOTPRecoveryCodes is a comma-separated list of hashed, single-use recovery codes
+	TOTPRecoveryCodes string
+}
+
+// HasPasswordLogin returns true if the user logs in with an email and password, rather
+// than through an external identity provider
+func (u *User) HasPasswordLogin() bool {
+	return u.Password != ""
+}
+
+// GetTOTPRecoveryCodes returns the hashed recovery codes of the user
+func (u *User) GetTOTPRecoveryCodes() []string {
+	if u.TOTPRecoveryCodes == "" {
+		return []string{}
+	}
+
+	return strings.Split(u.TOTPRecoveryCodes, ",")
+}
+
+// SetTOTPRecoveryCodes stores the hashed recovery codes of the user
+func (u *User) SetTOTPRecoveryCodes(hashes []string) {
+	u.TOTPRecoveryCodes = strings.Join(hashes, ",")
 }
 
 // ToUserType generates an external types.User to be shared over REST
@@ -27,5 +64,7 @@ func (u *User) ToUserType() *types.User {
 		ID:            u.ID,
 		Email:         u.Email,
 		EmailVerified: u.EmailVerified,
+
+		TwoFactorEnabled: u.TOTPEnabled,
 	}
 }
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// WorkerJob is a job in the persistent queue of the worker pool
+type WorkerJob struct {
+	gorm.Model
+
+	// The identifier of the kind of job, such as "recommender"
+	JobID string `gorm:"index"`
+
+	// The JSON input of the job
+	Input []byte
+
+	Status      types.WorkerJobStatus `gorm:"index"`
+	Attempts    uint
+	MaxAttempts uint
+
+	RunAt      time.Time `gorm:"index"`
+	StartedAt  *time.Time
+	FinishedAt *time.Time
+	LastError  string
+
+	// The worker which is running the job, which updates HeartbeatAt while the job is running
+	LockedBy    string
+	HeartbeatAt *time.Time
+}
+
+func (j *WorkerJob) ToWorkerJobType() *types.WorkerJob {
+	return &types.WorkerJob{
+		ID:          j.ID,
+		CreatedAt:   j.CreatedAt,
+		UpdatedAt:   j.UpdatedAt,
+		JobID:       j.JobID,
+		Status:      j.Status,
+		Attempts:    j.Attempts,
+		MaxAttempts: j.MaxAttempts,
+		RunAt:       j.RunAt,
+		StartedAt:   j.StartedAt,
+		FinishedAt:  j.FinishedAt,
+		LastError:   j.LastError,
+	}
+}
+
+// WorkerJobSchedule records the last run of a job which is enqueued on a cron schedule
+type WorkerJobSchedule struct {
+	gorm.Model
+
+	JobID string `gorm:"uniqueIndex"`
+
+	LastRunAt       *time.Time
+	LastResult      types.WorkerJobScheduleResult
+	LastError       string
+	LastWorkerJobID uint
+}
+
+// WorkerLease is held by at most one replica of the worker pool at a time, until it expires
+type WorkerLease struct {
+	Name      string `gorm:"primaryKey"`
+	Holder    string
+	ExpiresAt time.Time
+}
@@ -1,6 +1,10 @@
 package notifier
 
-import "time"
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+)
 
 type Notifier interface {
 	Notify(opts *NotifyOpts) error
@@ -43,3 +47,46 @@ type NotifyOpts struct {
 
 	Version int
 }
+
+// ShouldNotifyDeployment returns false if the notification config disables notifications for the
+// release, or for the given status
+func ShouldNotifyDeployment(conf *types.NotificationConfig, status DeploymentStatus) bool {
+	if conf == nil {
+		return true
+	}
+
+	if !conf.Enabled {
+		return false
+	}
+
+	switch status {
+	case StatusHelmDeployed:
+		return conf.Success
+	case StatusPodCrashed, StatusHelmFailed:
+		return conf.Failure
+	}
+
+	return true
+}
+
+type MultiNotifier struct {
+	notifiers []Notifier
+}
+
+// NewMultiNotifier returns a Notifier which notifies all of the given notifiers, even if
+// some of them fail
+func NewMultiNotifier(notifiers ...Notifier) Notifier {
+	return &MultiNotifier{notifiers}
+}
+
+func (m *MultiNotifier) Notify(opts *NotifyOpts) error {
+	var errs []error
+
+	for _, n := range m.notifiers {
+		if err := n.Notify(opts); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return newNotifyError(errs)
+}
@@ -0,0 +1,30 @@
+package notifier
+
+import (
+	"fmt"
+	"strings"
+)
+
+// NotifyError is returned by notifiers which fan out to other notifiers, when one or more of
+// them failed. The remaining notifiers are still notified.
+type NotifyError struct {
+	Errors []error
+}
+
+func (e *NotifyError) Error() string {
+	msgs := make([]string, 0, len(e.Errors))
+
+	for _, err := range e.Errors {
+		msgs = append(msgs, err.Error())
+	}
+
+	return fmt.Sprintf("%d notifier(s) failed: %s", len(e.Errors), strings.Join(msgs, "; "))
+}
+
+func newNotifyError(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	return &NotifyError{errs}
+}
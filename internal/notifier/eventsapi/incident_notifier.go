@@ -0,0 +1,187 @@
+package eventsapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// DefaultURL is the endpoint of the PagerDuty Events API v2
+const DefaultURL = "https://events.pagerduty.com/v2/enqueue"
+
+type eventAction string
+
+const (
+	actionTrigger eventAction = "trigger"
+	actionResolve eventAction = "resolve"
+)
+
+// Event is the body of a request to the Events API v2
+type Event struct {
+	RoutingKey  string      `json:"routing_key"`
+	EventAction eventAction `json:"event_action"`
+	DedupKey    string      `json:"dedup_key"`
+	Payload     *Payload    `json:"payload,omitempty"`
+	Client      string      `json:"client,omitempty"`
+	ClientURL   string      `json:"client_url,omitempty"`
+	Links       []*Link     `json:"links,omitempty"`
+}
+
+type Payload struct {
+	Summary       string            `json:"summary"`
+	Source        string            `json:"source"`
+	Severity      string            `json:"severity"`
+	Timestamp     string            `json:"timestamp,omitempty"`
+	Component     string            `json:"component,omitempty"`
+	Group         string            `json:"group,omitempty"`
+	Class         string            `json:"class,omitempty"`
+	CustomDetails map[string]string `json:"custom_details,omitempty"`
+}
+
+type Link struct {
+	Href string `json:"href"`
+	Text string `json:"text"`
+}
+
+type IncidentNotifierOpts struct {
+	// URL is the Events API v2 endpoint, which defaults to DefaultURL
+	URL        string
+	RoutingKey string
+
+	// ClusterID and ClusterName identify the source of the incidents
+	ClusterID   uint
+	ClusterName string
+
+	// SeverityMapping maps the severity of incidents to the severity of events. Incidents
+	// default to critical and normal incidents to warning.
+	SeverityMapping map[types.SeverityType]string
+}
+
+// IncidentNotifier triggers an alert for each new incident, and resolves the alert when the
+// incident is resolved. Both events share a dedup key derived from the incident.
+type IncidentNotifier struct {
+	opts   *IncidentNotifierOpts
+	client *http.Client
+}
+
+func NewIncidentNotifier(opts *IncidentNotifierOpts) *IncidentNotifier {
+	return &IncidentNotifier{
+		opts: opts,
+		client: &http.Client{
+			Timeout: time.Second * 10,
+		},
+	}
+}
+
+func (n *IncidentNotifier) NotifyNew(incident *types.Incident, url string) error {
+	summary := incident.ShortSummary
+
+	if summary == "" {
+		summary = incident.Summary
+	}
+
+	event := &Event{
+		RoutingKey:  n.opts.RoutingKey,
+		EventAction: actionTrigger,
+		DedupKey:    n.DedupKey(incident),
+		Payload: &Payload{
+			// the summary of an event is limited to 1024 characters
+			Summary:   truncate(fmt.Sprintf("%s/%s: %s", incident.ReleaseNamespace, incident.ReleaseName, summary), 1024),
+			Source:    n.opts.ClusterName,
+			Severity:  n.getSeverity(incident.Severity),
+			Timestamp: incident.CreatedAt.Format(time.RFC3339),
+			Component: incident.ReleaseName,
+			Group:     incident.ReleaseNamespace,
+			Class:     string(incident.InvolvedObjectKind),
+			CustomDetails: map[string]string{
+				"summary":                   incident.Summary,
+				"detail":                    incident.Detail,
+				"involved_object_name":      incident.InvolvedObjectName,
+				"involved_object_namespace": incident.InvolvedObjectNamespace,
+				"chart_name":                incident.ChartName,
+				"revision":                  incident.Revision,
+				"pods":                      strings.Join(incident.Pods, ", "),
+			},
+		},
+		Client:    "Porter",
+		ClientURL: url,
+		Links: []*Link{
+			{
+				Href: url,
+				Text: "View the incident on Porter",
+			},
+		},
+	}
+
+	return n.send(event)
+}
+
+func (n *IncidentNotifier) NotifyResolved(incident *types.Incident, url string) error {
+	return n.send(&Event{
+		RoutingKey:  n.opts.RoutingKey,
+		EventAction: actionResolve,
+		DedupKey:    n.DedupKey(incident),
+	})
+}
+
+// DedupKey returns the key which identifies the alert of an incident, so that resolving
+// the incident resolves the alert
+func (n *IncidentNotifier) DedupKey(incident *types.Incident) string {
+	return fmt.Sprintf("porter-%d-%s", n.opts.ClusterID, incident.ID)
+}
+
+func (n *IncidentNotifier) getSeverity(severity types.SeverityType) string {
+	if mapped, ok := n.opts.SeverityMapping[severity]; ok && mapped != "" {
+		return mapped
+	}
+
+	if severity == types.SeverityNormal {
+		return "warning"
+	}
+
+	return "critical"
+}
+
+func (n *IncidentNotifier) send(event *Event) error {
+	body, err := json.Marshal(event)
+
+	if err != nil {
+		return err
+	}
+
+	url := n.opts.URL
+
+	if url == "" {
+		url = DefaultURL
+	}
+
+	resp, err := n.client.Post(url, "application/json", bytes.NewReader(body))
+
+	if err != nil {
+		return fmt.Errorf("error sending %s event: %w", event.EventAction, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+
+		return fmt.Errorf("%s event was rejected with status code %d: %s", event.EventAction, resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+func truncate(str string, length int) string {
+	if len(str) <= length {
+		return str
+	}
+
+	return str[:length]
+}
@@ -0,0 +1,84 @@
+package eventsapi
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+func TestIncidentNotifierDedupKey(t *testing.T) {
+	var events []*Event
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		event := &Event{}
+
+		if err := json.NewDecoder(r.Body).Decode(event); err != nil {
+			t.Errorf("could not decode event: %s", err.Error())
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		events = append(events, event)
+
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	n := NewIncidentNotifier(&IncidentNotifierOpts{
+		URL:         server.URL,
+		RoutingKey:  "routing-key",
+		ClusterID:   1,
+		ClusterName: "cluster",
+	})
+
+	incident := &types.Incident{
+		IncidentMeta: &types.IncidentMeta{
+			ID:               "incident-1",
+			ReleaseName:      "api",
+			ReleaseNamespace: "default",
+			Severity:         types.SeverityNormal,
+		},
+	}
+
+	if err := n.NotifyNew(incident, "https://porter.run"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if err := n.NotifyResolved(incident, "https://porter.run"); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(events))
+	}
+
+	if events[0].EventAction != actionTrigger || events[1].EventAction != actionResolve {
+		t.Errorf("expected trigger and resolve events, got %s and %s", events[0].EventAction, events[1].EventAction)
+	}
+
+	if events[0].DedupKey != events[1].DedupKey {
+		t.Errorf("expected matching dedup keys, got %s and %s", events[0].DedupKey, events[1].DedupKey)
+	}
+
+	if events[0].Payload.Severity != "warning" {
+		t.Errorf("expected severity warning, got %s", events[0].Payload.Severity)
+	}
+}
+
+func TestIncidentNotifierRejected(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	n := NewIncidentNotifier(&IncidentNotifierOpts{URL: server.URL})
+
+	err := n.NotifyResolved(&types.Incident{IncidentMeta: &types.IncidentMeta{ID: "incident-1"}}, "")
+
+	if err == nil {
+		t.Errorf("expected an error for a rejected event")
+	}
+}
@@ -12,6 +12,8 @@ type MultiIncidentNotifier struct {
 	notifiers []IncidentNotifier
 }
 
+// NewMultiIncidentNotifier returns an IncidentNotifier which notifies all of the given notifiers.
+// A failing notifier does not prevent the remaining notifiers from being notified.
 func NewMultiIncidentNotifier(notifConf *types.NotificationConfig, notifiers ...IncidentNotifier) IncidentNotifier {
 	return &MultiIncidentNotifier{notifConf, notifiers}
 }
@@ -23,13 +25,15 @@ func (m *MultiIncidentNotifier) NotifyNew(incident *types.Incident, url string)
 		return nil
 	}
 
+	var errs []error
+
 	for _, n := range m.notifiers {
 		if err := n.NotifyNew(incident, url); err != nil {
-			return err
+			errs = append(errs, err)
 		}
 	}
 
-	return nil
+	return newNotifyError(errs)
 }
 
 func (m *MultiIncidentNotifier) NotifyResolved(incident *types.Incident, url string) error {
@@ -39,11 +43,84 @@ func (m *MultiIncidentNotifier) NotifyResolved(incident *types.Incident, url str
 		return nil
 	}
 
+	var errs []error
+
 	for _, n := range m.notifiers {
 		if err := n.NotifyResolved(incident, url); err != nil {
-			return err
+			errs = append(errs, err)
+		}
+	}
+
+	return newNotifyError(errs)
+}
+
+// IncidentRoute sends the incidents matched by a routing rule to the notifiers of the rule
+type IncidentRoute struct {
+	Matches   func(incident *types.Incident) bool
+	Notifiers []IncidentNotifier
+
+	// Whether the following routes are evaluated when this route matches
+	Continue bool
+}
+
+type RoutingIncidentNotifier struct {
+	routes   []*IncidentRoute
+	fallback []IncidentNotifier
+}
+
+// NewRoutingIncidentNotifier returns an IncidentNotifier which sends each incident to the
+// notifiers of the matching routes, evaluated in order. Incidents which do not match any
+// route are sent to the fallback notifiers.
+func NewRoutingIncidentNotifier(routes []*IncidentRoute, fallback ...IncidentNotifier) IncidentNotifier {
+	return &RoutingIncidentNotifier{routes, fallback}
+}
+
+func (n *RoutingIncidentNotifier) NotifyNew(incident *types.Incident, url string) error {
+	var errs []error
+
+	for _, notifier := range n.getNotifiers(incident) {
+		if err := notifier.NotifyNew(incident, url); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return newNotifyError(errs)
+}
+
+// NotifyResolved sends the resolved incident to the same notifiers as the new incident,
+// since routes only match on fields which do not change over the lifetime of an incident
+func (n *RoutingIncidentNotifier) NotifyResolved(incident *types.Incident, url string) error {
+	var errs []error
+
+	for _, notifier := range n.getNotifiers(incident) {
+		if err := notifier.NotifyResolved(incident, url); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return newNotifyError(errs)
+}
+
+func (n *RoutingIncidentNotifier) getNotifiers(incident *types.Incident) []IncidentNotifier {
+	var res []IncidentNotifier
+	matched := false
+
+	for _, route := range n.routes {
+		if !route.Matches(incident) {
+			continue
+		}
+
+		matched = true
+		res = append(res, route.Notifiers...)
+
+		if !route.Continue {
+			break
 		}
 	}
 
-	return nil
+	if !matched {
+		return n.fallback
+	}
+
+	return res
 }
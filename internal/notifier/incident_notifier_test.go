@@ -0,0 +1,92 @@
+package notifier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+type fakeIncidentNotifier struct {
+	err      error
+	notified int
+}
+
+func (f *fakeIncidentNotifier) NotifyNew(incident *types.Incident, url string) error {
+	f.notified++
+	return f.err
+}
+
+func (f *fakeIncidentNotifier) NotifyResolved(incident *types.Incident, url string) error {
+	f.notified++
+	return f.err
+}
+
+func matchSeverity(severity types.SeverityType) func(incident *types.Incident) bool {
+	return func(incident *types.Incident) bool {
+		return incident.Severity == severity
+	}
+}
+
+func TestRoutingIncidentNotifier(t *testing.T) {
+	critical := &fakeIncidentNotifier{}
+	all := &fakeIncidentNotifier{}
+	fallback := &fakeIncidentNotifier{}
+
+	n := NewRoutingIncidentNotifier([]*IncidentRoute{
+		{
+			Matches:   matchSeverity(types.SeverityCritical),
+			Notifiers: []IncidentNotifier{critical},
+		},
+		{
+			Matches:   func(incident *types.Incident) bool { return true },
+			Notifiers: []IncidentNotifier{all},
+		},
+	}, fallback)
+
+	criticalIncident := &types.Incident{IncidentMeta: &types.IncidentMeta{Severity: types.SeverityCritical}}
+
+	if err := n.NotifyNew(criticalIncident, ""); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if critical.notified != 1 || all.notified != 0 || fallback.notified != 0 {
+		t.Errorf("expected only the first matching route to be notified")
+	}
+
+	n = NewRoutingIncidentNotifier([]*IncidentRoute{
+		{
+			Matches:   matchSeverity(types.SeverityCritical),
+			Notifiers: []IncidentNotifier{critical},
+		},
+	}, fallback)
+
+	normalIncident := &types.Incident{IncidentMeta: &types.IncidentMeta{Severity: types.SeverityNormal}}
+
+	if err := n.NotifyResolved(normalIncident, ""); err != nil {
+		t.Fatalf("unexpected error: %s", err.Error())
+	}
+
+	if critical.notified != 1 || fallback.notified != 1 {
+		t.Errorf("expected unmatched incidents to be sent to the fallback notifiers")
+	}
+}
+
+func TestMultiIncidentNotifierContinuesOnError(t *testing.T) {
+	failing := &fakeIncidentNotifier{err: errors.New("failed")}
+	succeeding := &fakeIncidentNotifier{}
+
+	n := NewMultiIncidentNotifier(nil, failing, succeeding)
+
+	err := n.NotifyNew(&types.Incident{IncidentMeta: &types.IncidentMeta{}}, "")
+
+	var notifyErr *NotifyError
+
+	if !errors.As(err, &notifyErr) || len(notifyErr.Errors) != 1 {
+		t.Errorf("expected a NotifyError with 1 error, got %v", err)
+	}
+
+	if succeeding.notified != 1 {
+		t.Errorf("expected notifiers after a failing notifier to be notified")
+	}
+}
@@ -0,0 +1,29 @@
+package notifier
+
+import "github.com/porter-dev/porter/api/types"
+
+type InfraDriftNotifier interface {
+	NotifyDrift(infra *types.Infra, drift *types.InfraDrift, url string) error
+}
+
+type MultiInfraDriftNotifier struct {
+	notifiers []InfraDriftNotifier
+}
+
+// NewMultiInfraDriftNotifier returns an InfraDriftNotifier which notifies all of the given
+// notifiers. A failing notifier does not prevent the remaining notifiers from being notified.
+func NewMultiInfraDriftNotifier(notifiers ...InfraDriftNotifier) InfraDriftNotifier {
+	return &MultiInfraDriftNotifier{notifiers}
+}
+
+func (m *MultiInfraDriftNotifier) NotifyDrift(infra *types.Infra, drift *types.InfraDrift, url string) error {
+	var errs []error
+
+	for _, n := range m.notifiers {
+		if err := n.NotifyDrift(infra, drift, url); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return newNotifyError(errs)
+}
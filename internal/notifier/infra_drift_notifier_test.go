@@ -0,0 +1,45 @@
+package notifier
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+type fakeInfraDriftNotifier struct {
+	err      error
+	notified int
+}
+
+func (f *fakeInfraDriftNotifier) NotifyDrift(infra *types.Infra, drift *types.InfraDrift, url string) error {
+	f.notified++
+	return f.err
+}
+
+func TestMultiInfraDriftNotifierContinuesOnError(t *testing.T) {
+	failing := &fakeInfraDriftNotifier{err: errors.New("failed")}
+	succeeding := &fakeInfraDriftNotifier{}
+
+	n := NewMultiInfraDriftNotifier(failing, succeeding)
+
+	err := n.NotifyDrift(&types.Infra{}, &types.InfraDrift{}, "")
+
+	var notifyErr *NotifyError
+
+	if !errors.As(err, &notifyErr) || len(notifyErr.Errors) != 1 {
+		t.Errorf("expected a NotifyError with 1 error, got %v", err)
+	}
+
+	if succeeding.notified != 1 {
+		t.Errorf("expected notifiers after a failing notifier to be notified")
+	}
+}
+
+func TestMultiInfraDriftNotifierWithoutNotifiers(t *testing.T) {
+	n := NewMultiInfraDriftNotifier()
+
+	if err := n.NotifyDrift(&types.Infra{}, &types.InfraDrift{}, ""); err != nil {
+		t.Errorf("expected no error without notifiers, got %v", err)
+	}
+}
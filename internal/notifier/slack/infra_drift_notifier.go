@@ -0,0 +1,87 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// maxDriftedResources is the maximum number of drifted resources listed in a message
+const maxDriftedResources = 20
+
+type InfraDriftNotifier struct {
+	slackInts []*integrations.SlackIntegration
+}
+
+func NewInfraDriftNotifier(slackInts ...*integrations.SlackIntegration) *InfraDriftNotifier {
+	return &InfraDriftNotifier{
+		slackInts: slackInts,
+	}
+}
+
+func (s *InfraDriftNotifier) NotifyDrift(infra *types.Infra, drift *types.InfraDrift, url string) error {
+	res := []*SlackBlock{}
+
+	topSectionMarkdwn := fmt.Sprintf(
+		":warning: %d resource(s) of your infrastructure %s were changed outside of Porter. <%s|View the infrastructure.>",
+		len(drift.Resources),
+		"`"+infra.Name+"`",
+		url,
+	)
+
+	resources := make([]string, 0)
+
+	for i, resource := range drift.Resources {
+		if i == maxDriftedResources {
+			resources = append(resources, fmt.Sprintf("... and %d more", len(drift.Resources)-maxDriftedResources))
+			break
+		}
+
+		resources = append(resources, fmt.Sprintf("%s (%s)", resource.Address, resource.Action))
+	}
+
+	res = append(
+		res,
+		getMarkdownBlock(topSectionMarkdwn),
+		getDividerBlock(),
+		getMarkdownBlock(fmt.Sprintf("*Kind:* %s", "`"+string(infra.Kind)+"`")),
+		getMarkdownBlock(fmt.Sprintf("*Name:* %s", "`"+infra.Name+"`")),
+		getMarkdownBlock(fmt.Sprintf(
+			"*Detected at:* <!date^%d^ {date_num} {time_secs}| %s>",
+			drift.DetectedAt.Unix(),
+			drift.DetectedAt.Format("2006-01-02 15:04:05 UTC"),
+		)),
+		getMarkdownBlock("*Drifted resources:*"),
+		getMarkdownBlock(fmt.Sprintf("```\n%s\n```", strings.Join(resources, "\n"))),
+	)
+
+	slackPayload := &SlackPayload{
+		Blocks: res,
+	}
+
+	payload, err := json.Marshal(slackPayload)
+
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: time.Second * 5,
+	}
+
+	for _, slackInt := range s.slackInts {
+		_, err := client.Post(string(slackInt.Webhook), "application/json", bytes.NewReader(payload))
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+type DeploymentNotifier struct {
+	sender      *Sender
+	webhookInts []*integrations.WebhookIntegration
+	Config      *types.NotificationConfig
+}
+
+func NewDeploymentNotifier(
+	conf *types.NotificationConfig,
+	sender *Sender,
+	webhookInts ...*integrations.WebhookIntegration,
+) *DeploymentNotifier {
+	return &DeploymentNotifier{
+		sender:      sender,
+		webhookInts: webhookInts,
+		Config:      conf,
+	}
+}
+
+// Notify sends the deployment event to all webhooks which are subscribed to deployment
+// events. Deliveries happen in the background, and failures are recorded as deliveries.
+func (n *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
+	if !notifier.ShouldNotifyDeployment(n.Config, opts.Status) {
+		return nil
+	}
+
+	timestamp := time.Now()
+
+	if opts.Timestamp != nil {
+		timestamp = *opts.Timestamp
+	}
+
+	payload := &types.WebhookPayload{
+		Event:     types.WebhookEventDeployment,
+		ProjectID: opts.ProjectID,
+		Timestamp: timestamp,
+		Deployment: &types.WebhookDeploymentData{
+			ClusterID:   opts.ClusterID,
+			ClusterName: opts.ClusterName,
+			Name:        opts.Name,
+			Namespace:   opts.Namespace,
+			Status:      string(opts.Status),
+			Info:        opts.Info,
+			Version:     opts.Version,
+			URL:         opts.URL,
+		},
+	}
+
+	for _, webhookInt := range n.webhookInts {
+		if webhookInt.DeploymentEvents {
+			n.sender.SendAsync(webhookInt, payload)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type IncidentNotifier struct {
+	projectID   uint
+	sender      *Sender
+	webhookInts []*integrations.WebhookIntegration
+}
+
+func NewIncidentNotifier(
+	projectID uint,
+	sender *Sender,
+	webhookInts ...*integrations.WebhookIntegration,
+) *IncidentNotifier {
+	return &IncidentNotifier{
+		projectID:   projectID,
+		sender:      sender,
+		webhookInts: webhookInts,
+	}
+}
+
+func (n *IncidentNotifier) NotifyNew(incident *types.Incident, url string) error {
+	n.notify(types.WebhookEventIncidentNew, incident, url)
+
+	return nil
+}
+
+func (n *IncidentNotifier) NotifyResolved(incident *types.Incident, url string) error {
+	n.notify(types.WebhookEventIncidentResolved, incident, url)
+
+	return nil
+}
+
+func (n *IncidentNotifier) notify(event types.WebhookEvent, incident *types.Incident, url string) {
+	payload := &types.WebhookPayload{
+		Event:     event,
+		ProjectID: n.projectID,
+		Timestamp: time.Now(),
+		Incident: &types.WebhookIncidentData{
+			Incident: incident,
+			URL:      url,
+		},
+	}
+
+	for _, webhookInt := range n.webhookInts {
+		if webhookInt.IncidentEvents {
+			n.sender.SendAsync(webhookInt, payload)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package webhook
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type InfraDriftNotifier struct {
+	projectID   uint
+	sender      *Sender
+	webhookInts []*integrations.WebhookIntegration
+}
+
+func NewInfraDriftNotifier(
+	projectID uint,
+	sender *Sender,
+	webhookInts ...*integrations.WebhookIntegration,
+) *InfraDriftNotifier {
+	return &InfraDriftNotifier{
+		projectID:   projectID,
+		sender:      sender,
+		webhookInts: webhookInts,
+	}
+}
+
+// NotifyDrift sends the drift to the webhooks which receive incident events, as drifted
+// infra needs the same attention as a crashing application
+func (n *InfraDriftNotifier) NotifyDrift(infra *types.Infra, drift *types.InfraDrift, url string) error {
+	payload := &types.WebhookPayload{
+		Event:     types.WebhookEventInfraDrift,
+		ProjectID: n.projectID,
+		Timestamp: time.Now(),
+		InfraDrift: &types.WebhookInfraDriftData{
+			InfraID:    infra.ID,
+			InfraName:  infra.Name,
+			InfraKind:  infra.Kind,
+			InfraDrift: drift,
+			URL:        url,
+		},
+	}
+
+	for _, webhookInt := range n.webhookInts {
+		if webhookInt.IncidentEvents {
+			n.sender.SendAsync(webhookInt, payload)
+		}
+	}
+
+	return nil
+}
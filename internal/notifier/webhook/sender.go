@@ -0,0 +1,191 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/api/types"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+const (
+	// SignatureHeader contains the hex-encoded HMAC-SHA256 of the timestamp and the body,
+	// joined by a ".", prefixed by "sha256="
+	SignatureHeader = "X-Porter-Signature"
+	TimestampHeader = "X-Porter-Timestamp"
+	EventHeader     = "X-Porter-Event"
+	DeliveryHeader  = "X-Porter-Delivery"
+)
+
+const (
+	defaultMaxAttempts    = 4
+	defaultInitialBackoff = time.Second
+)
+
+// Sender delivers signed payloads to webhooks, retrying failed attempts with an exponential
+// backoff and recording the result of each delivery
+type Sender struct {
+	repo   repository.WebhookIntegrationRepository
+	client *http.Client
+
+	MaxAttempts    int
+	InitialBackoff time.Duration
+
+	wg sync.WaitGroup
+}
+
+func NewSender(repo repository.WebhookIntegrationRepository) *Sender {
+	return &Sender{
+		repo: repo,
+		client: &http.Client{
+			Timeout: time.Second * 5,
+		},
+		MaxAttempts:    defaultMaxAttempts,
+		InitialBackoff: defaultInitialBackoff,
+	}
+}
+
+// Sign computes the signature of a payload, as sent in the SignatureHeader
+func Sign(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// SendAsync delivers the payload in the background. Wait can be used to block until all
+// deliveries have finished.
+func (s *Sender) SendAsync(webhookInt *ints.WebhookIntegration, payload *types.WebhookPayload) {
+	s.wg.Add(1)
+
+	go func() {
+		defer s.wg.Done()
+
+		s.Send(webhookInt, payload)
+	}()
+}
+
+// Wait blocks until all deliveries started with SendAsync have finished
+func (s *Sender) Wait() {
+	s.wg.Wait()
+}
+
+// Send delivers the payload to the webhook, and returns the recorded delivery
+func (s *Sender) Send(webhookInt *ints.WebhookIntegration, payload *types.WebhookPayload) *ints.WebhookDelivery {
+	delivery := &ints.WebhookDelivery{
+		WebhookIntegrationID: webhookInt.ID,
+		DeliveryID:           uuid.New().String(),
+		Event:                payload.Event,
+	}
+
+	body, err := json.Marshal(payload)
+
+	if err != nil {
+		delivery.Status = types.WebhookDeliveryFailed
+		delivery.Error = fmt.Sprintf("error marshaling payload: %s", err.Error())
+
+		return s.record(delivery)
+	}
+
+	backoff := s.InitialBackoff
+
+	for attempt := 1; attempt <= s.MaxAttempts; attempt++ {
+		delivery.Attempts = uint(attempt)
+
+		statusCode, err := s.post(webhookInt, delivery, body)
+
+		delivery.ResponseCode = statusCode
+
+		if err == nil {
+			delivery.Status = types.WebhookDeliverySucceeded
+			delivery.Error = ""
+
+			return s.record(delivery)
+		}
+
+		delivery.Status = types.WebhookDeliveryFailed
+		delivery.Error = err.Error()
+
+		if !shouldRetry(statusCode) || attempt == s.MaxAttempts {
+			break
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+
+	return s.record(delivery)
+}
+
+func (s *Sender) post(webhookInt *ints.WebhookIntegration, delivery *ints.WebhookDelivery, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, string(webhookInt.URL), bytes.NewReader(body))
+
+	if err != nil {
+		return 0, err
+	}
+
+	// the timestamp is signed alongside the body so that receivers can reject replayed requests
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(EventHeader, string(delivery.Event))
+	req.Header.Set(DeliveryHeader, delivery.DeliveryID)
+	req.Header.Set(SignatureHeader, Sign(webhookInt.Secret, timestamp, body))
+
+	resp, err := s.client.Do(req)
+
+	if err != nil {
+		return 0, err
+	}
+
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status code %d", resp.StatusCode)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func (s *Sender) record(delivery *ints.WebhookDelivery) *ints.WebhookDelivery {
+	if delivery.Status == types.WebhookDeliveryFailed {
+		log.Printf("webhook delivery %s to integration %d failed after %d attempt(s): %s",
+			delivery.DeliveryID, delivery.WebhookIntegrationID, delivery.Attempts, delivery.Error)
+	}
+
+	if s.repo == nil {
+		return delivery
+	}
+
+	recorded, err := s.repo.CreateWebhookDelivery(delivery)
+
+	if err != nil {
+		log.Printf("error recording webhook delivery %s: %s", delivery.DeliveryID, err.Error())
+		return delivery
+	}
+
+	return recorded
+}
+
+// shouldRetry returns true if the request did not receive a response, or if the response
+// indicates that the receiver may accept the request later
+func shouldRetry(statusCode int) bool {
+	return statusCode == 0 || statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
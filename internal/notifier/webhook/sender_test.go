@@ -0,0 +1,102 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository/test"
+)
+
+func TestSendSignsPayload(t *testing.T) {
+	secret := []byte("secret")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+
+		if expected := Sign(secret, r.Header.Get(TimestampHeader), body); r.Header.Get(SignatureHeader) != expected {
+			t.Errorf("expected signature %s, got %s", expected, r.Header.Get(SignatureHeader))
+		}
+
+		if r.Header.Get(EventHeader) != string(types.WebhookEventTest) {
+			t.Errorf("expected event header %s, got %s", types.WebhookEventTest, r.Header.Get(EventHeader))
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	repo := test.NewWebhookIntegrationRepository(true)
+	sender := NewSender(repo)
+
+	delivery := sender.Send(&ints.WebhookIntegration{
+		URL:    []byte(server.URL),
+		Secret: secret,
+	}, &types.WebhookPayload{Event: types.WebhookEventTest})
+
+	if delivery.Status != types.WebhookDeliverySucceeded {
+		t.Fatalf("expected delivery to succeed, got error %s", delivery.Error)
+	}
+
+	if delivery.Attempts != 1 {
+		t.Errorf("expected 1 attempt, got %d", delivery.Attempts)
+	}
+}
+
+func TestSendRetries(t *testing.T) {
+	tests := []struct {
+		name             string
+		statusCode       int
+		expectedAttempts uint
+	}{
+		{"server error is retried", http.StatusInternalServerError, 3},
+		{"rate limit is retried", http.StatusTooManyRequests, 3},
+		{"client error is not retried", http.StatusBadRequest, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			repo := test.NewWebhookIntegrationRepository(true)
+
+			sender := NewSender(repo)
+			sender.MaxAttempts = 3
+			sender.InitialBackoff = time.Millisecond
+
+			delivery := sender.Send(&ints.WebhookIntegration{
+				URL:    []byte(server.URL),
+				Secret: []byte("secret"),
+			}, &types.WebhookPayload{Event: types.WebhookEventTest})
+
+			if delivery.Status != types.WebhookDeliveryFailed {
+				t.Fatalf("expected delivery to fail")
+			}
+
+			if delivery.Attempts != tt.expectedAttempts || uint(atomic.LoadInt32(&calls)) != tt.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.expectedAttempts, delivery.Attempts)
+			}
+
+			if delivery.ResponseCode != tt.statusCode {
+				t.Errorf("expected response code %d, got %d", tt.statusCode, delivery.ResponseCode)
+			}
+
+			deliveries, _ := repo.ListWebhookDeliveries(0, 0)
+
+			if len(deliveries) != 1 {
+				t.Errorf("expected 1 recorded delivery, got %d", len(deliveries))
+			}
+		})
+	}
+}
@@ -5,8 +5,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
+	"strings"
 
+	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/rego"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
 	"sigs.k8s.io/yaml"
 )
 
@@ -54,10 +58,7 @@ func LoadPolicies(configFilePathDir string) (*KubernetesPolicies, error) {
 				return nil, err
 			}
 
-			query, err := rego.New(
-				rego.Query(fmt.Sprintf("data.%s", cfPolicy.Name)),
-				rego.Module(cfPolicy.Name, string(fileBytes)),
-			).PrepareForEval(context.Background())
+			query, err := prepareQuery(cfPolicy.Name, string(fileBytes))
 
 			if err != nil {
 				// Handle error.
@@ -80,3 +81,103 @@ func LoadPolicies(configFilePathDir string) (*KubernetesPolicies, error) {
 		Policies: policies,
 	}, nil
 }
+
+// CompilePolicy prepares a Rego module for evaluation. The name of the policy must be the
+// package of the module, such as "web.version".
+func CompilePolicy(name, src string) (rego.PreparedEvalQuery, error) {
+	module, err := ast.ParseModule(name, src)
+
+	if err != nil {
+		return rego.PreparedEvalQuery{}, err
+	} else if module == nil {
+		return rego.PreparedEvalQuery{}, fmt.Errorf("policy %s is empty", name)
+	}
+
+	if pkg := strings.TrimPrefix(module.Package.Path.String(), "data."); pkg != name {
+		return rego.PreparedEvalQuery{}, fmt.Errorf("policy %s must declare package %s, but declares package %s", name, name, pkg)
+	}
+
+	return prepareQuery(name, src)
+}
+
+// CompilePolicyPack validates the match parameters of a policy pack uploaded to a project,
+// and compiles its policies into a query collection
+func CompilePolicyPack(pack *models.PolicyPack) (KubernetesOPAQueryCollection, error) {
+	match, err := pack.GetMatch()
+
+	if err != nil {
+		return KubernetesOPAQueryCollection{}, fmt.Errorf("invalid match parameters: %w", err)
+	}
+
+	switch pack.Kind {
+	case types.PolicyPackKindHelmRelease:
+		if match.Name == "" && match.ChartName == "" {
+			return KubernetesOPAQueryCollection{}, fmt.Errorf("helm_release policy packs must match a name or a chart_name")
+		}
+	case types.PolicyPackKindCRDList:
+		if match.Version == "" || match.Resource == "" {
+			return KubernetesOPAQueryCollection{}, fmt.Errorf("crd_list policy packs must match a version and a resource")
+		}
+	case types.PolicyPackKindPod, types.PolicyPackKindDaemonset:
+	default:
+		return KubernetesOPAQueryCollection{}, fmt.Errorf("%s is not a supported policy pack kind", pack.Kind)
+	}
+
+	queries := make([]rego.PreparedEvalQuery, 0)
+
+	for _, policy := range pack.Policies {
+		query, err := CompilePolicy(policy.Name, policy.Source)
+
+		if err != nil {
+			return KubernetesOPAQueryCollection{}, fmt.Errorf("error compiling policy %s: %w", policy.Name, err)
+		}
+
+		queries = append(queries, query)
+	}
+
+	return KubernetesOPAQueryCollection{
+		Kind: KubernetesBuiltInKind(pack.Kind),
+		Match: MatchParameters{
+			KubernetesService: match.KubernetesService,
+			Name:              match.Name,
+			Namespace:         match.Namespace,
+			ChartName:         match.ChartName,
+			Labels:            match.Labels,
+			Group:             match.Group,
+			Version:           match.Version,
+			Resource:          match.Resource,
+		},
+		MustExist:        pack.MustExist,
+		OverrideSeverity: pack.OverrideSeverity,
+		Queries:          queries,
+	}, nil
+}
+
+// unsafeBuiltins are the built-in functions which policies cannot call, since policies uploaded
+// by users are evaluated by the server and the workers. These builtins can reach the network,
+// read the environment of the process or write to its logs.
+var unsafeBuiltins = getUnsafeBuiltins()
+
+func getUnsafeBuiltins() map[string]struct{} {
+	res := map[string]struct{}{
+		ast.HTTPSend.Name:   {},
+		ast.OPARuntime.Name: {},
+		ast.Trace.Name:      {},
+	}
+
+	for _, builtin := range ast.Builtins {
+		if strings.HasPrefix(builtin.Name, "net.") {
+			res[builtin.Name] = struct{}{}
+		}
+	}
+
+	return res
+}
+
+func prepareQuery(name, src string) (rego.PreparedEvalQuery, error) {
+	return rego.New(
+		rego.Query(fmt.Sprintf("data.%s", name)),
+		rego.Module(name, src),
+		rego.UnsafeBuiltins(unsafeBuiltins),
+	).PrepareForEval(context.Background())
+}
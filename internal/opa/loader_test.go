@@ -0,0 +1,139 @@
+package opa
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+const memoryLimitsPolicy = `package web.memory_limits
+
+import future.keywords
+
+POLICY_ID := "web_memory_limits"
+
+POLICY_VERSION := "v0.0.1"
+
+POLICY_SEVERITY := "high"
+
+POLICY_TITLE := sprintf("The web application %s/%s should set memory limits", [input.namespace, input.name])
+
+POLICY_SUCCESS_MESSAGE := "Success: memory limits are set"
+
+allow if input.values.resources.limits.memory
+
+FAILURE_MESSAGE contains msg if {
+	not allow
+	msg := "Failed: memory limits are not set"
+}
+`
+
+func TestCompilePolicyPack(t *testing.T) {
+	pack := &models.PolicyPack{
+		Name:  "web-limits",
+		Kind:  types.PolicyPackKindHelmRelease,
+		Match: []byte(`{"chart_name":"web"}`),
+		Policies: []models.PolicyPackPolicy{
+			{Name: "web.memory_limits", Source: memoryLimitsPolicy},
+		},
+	}
+
+	collection, err := CompilePolicyPack(pack)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if collection.Kind != HelmRelease || collection.Match.ChartName != "web" || len(collection.Queries) != 1 {
+		t.Errorf("unexpected collection: %+v", collection)
+	}
+}
+
+func TestCompilePolicyPackErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		pack   *models.PolicyPack
+		errMsg string
+	}{
+		{
+			name: "mismatched package",
+			pack: &models.PolicyPack{
+				Kind:  types.PolicyPackKindHelmRelease,
+				Match: []byte(`{"chart_name":"web"}`),
+				Policies: []models.PolicyPackPolicy{
+					{Name: "web.limits", Source: memoryLimitsPolicy},
+				},
+			},
+			errMsg: "must declare package web.limits",
+		},
+		{
+			name: "invalid rego",
+			pack: &models.PolicyPack{
+				Kind:  types.PolicyPackKindPod,
+				Match: []byte(`{}`),
+				Policies: []models.PolicyPackPolicy{
+					{Name: "pods.broken", Source: "package pods.broken\n\nallow {"},
+				},
+			},
+			errMsg: "error compiling policy pods.broken",
+		},
+		{
+			name: "http.send",
+			pack: &models.PolicyPack{
+				Kind:  types.PolicyPackKindPod,
+				Match: []byte(`{}`),
+				Policies: []models.PolicyPackPolicy{
+					{Name: "pods.exfiltrate", Source: "package pods.exfiltrate\n\nallow {\n\thttp.send({\"method\": \"get\", \"url\": \"http://example.com\"})\n}"},
+				},
+			},
+			errMsg: "unsafe built-in function calls in expression: http.send",
+		},
+		{
+			name: "opa.runtime",
+			pack: &models.PolicyPack{
+				Kind:  types.PolicyPackKindPod,
+				Match: []byte(`{}`),
+				Policies: []models.PolicyPackPolicy{
+					{Name: "pods.env", Source: "package pods.env\n\nenv := opa.runtime().env"},
+				},
+			},
+			errMsg: "unsafe built-in function calls in expression: opa.runtime",
+		},
+		{
+			name: "net.lookup_ip_addr",
+			pack: &models.PolicyPack{
+				Kind:  types.PolicyPackKindPod,
+				Match: []byte(`{}`),
+				Policies: []models.PolicyPackPolicy{
+					{Name: "pods.lookup", Source: "package pods.lookup\n\naddrs := net.lookup_ip_addr(\"example.com\")"},
+				},
+			},
+			errMsg: "unsafe built-in function calls in expression: net.lookup_ip_addr",
+		},
+		{
+			name: "missing helm release match",
+			pack: &models.PolicyPack{
+				Kind:  types.PolicyPackKindHelmRelease,
+				Match: []byte(`{"namespace":"default"}`),
+			},
+			errMsg: "must match a name or a chart_name",
+		},
+		{
+			name: "unsupported kind",
+			pack: &models.PolicyPack{
+				Kind: types.PolicyPackKind("node"),
+			},
+			errMsg: "not a supported policy pack kind",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := CompilePolicyPack(test.pack)
+
+		if err == nil || !strings.Contains(err.Error(), test.errMsg) {
+			t.Errorf("%s: expected error containing %q, got %v", test.name, test.errMsg, err)
+		}
+	}
+}
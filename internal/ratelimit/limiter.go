@@ -0,0 +1,13 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Limiter counts requests per key in fixed windows
+type Limiter interface {
+	// Allow counts a request for the key in the current window. It returns whether the
+	// request is within the limit and, if it is not, how long until the window resets.
+	Allow(ctx context.Context, key string, limit uint, window time.Duration) (bool, time.Duration, error)
+}
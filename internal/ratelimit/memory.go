@@ -0,0 +1,75 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often expired windows are removed from a MemoryLimiter
+const sweepInterval = time.Minute
+
+type memoryWindow struct {
+	count   uint
+	resetAt time.Time
+}
+
+// MemoryLimiter is a Limiter which keeps counts in memory, and therefore only limits
+// requests served by a single instance of the server
+type MemoryLimiter struct {
+	mu        sync.Mutex
+	windows   map[string]*memoryWindow
+	lastSweep time.Time
+
+	// now returns the current time, and is overridden in tests
+	now func() time.Time
+}
+
+// NewMemoryLimiter returns a Limiter which keeps counts in memory
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{
+		windows: make(map[string]*memoryWindow),
+		now:     time.Now,
+	}
+}
+
+func (l *MemoryLimiter) Allow(ctx context.Context, key string, limit uint, window time.Duration) (bool, time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+
+	if now.Sub(l.lastSweep) >= sweepInterval {
+		l.sweep(now)
+	}
+
+	w, ok := l.windows[key]
+
+	if !ok || !now.Before(w.resetAt) {
+		w = &memoryWindow{
+			resetAt: now.Add(window),
+		}
+
+		l.windows[key] = w
+	}
+
+	w.count++
+
+	if w.count > limit {
+		return false, w.resetAt.Sub(now), nil
+	}
+
+	return true, 0, nil
+}
+
+// sweep removes the windows which have reset, so that keys of clients which stopped sending
+// requests do not accumulate
+func (l *MemoryLimiter) sweep(now time.Time) {
+	for key, w := range l.windows {
+		if !now.Before(w.resetAt) {
+			delete(l.windows, key)
+		}
+	}
+
+	l.lastSweep = now
+}
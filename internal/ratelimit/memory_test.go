@@ -0,0 +1,67 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemoryLimiter(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		allowed, _, err := limiter.Allow(context.Background(), "ip:10.0.0.1", 3, time.Minute)
+
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		if !allowed {
+			t.Fatalf("expected request %d to be allowed", i+1)
+		}
+	}
+
+	now = now.Add(20 * time.Second)
+
+	allowed, retryAfter, _ := limiter.Allow(context.Background(), "ip:10.0.0.1", 3, time.Minute)
+
+	if allowed {
+		t.Errorf("expected request over the limit to be denied")
+	}
+
+	if retryAfter != 40*time.Second {
+		t.Errorf("expected to retry after 40s, got %s", retryAfter)
+	}
+
+	// other keys have their own window
+	if allowed, _, _ := limiter.Allow(context.Background(), "ip:10.0.0.2", 3, time.Minute); !allowed {
+		t.Errorf("expected request for another key to be allowed")
+	}
+
+	// the count resets with the window
+	now = now.Add(40 * time.Second)
+
+	if allowed, _, _ := limiter.Allow(context.Background(), "ip:10.0.0.1", 3, time.Minute); !allowed {
+		t.Errorf("expected request in a new window to be allowed")
+	}
+}
+
+func TestMemoryLimiterSweep(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	limiter := NewMemoryLimiter()
+	limiter.now = func() time.Time { return now }
+
+	limiter.Allow(context.Background(), "ip:10.0.0.1", 1, time.Second)
+
+	now = now.Add(2 * time.Minute)
+
+	limiter.Allow(context.Background(), "ip:10.0.0.2", 1, time.Second)
+
+	if _, ok := limiter.windows["ip:10.0.0.1"]; ok || len(limiter.windows) != 1 {
+		t.Errorf("expected expired windows to be removed, got %d windows", len(limiter.windows))
+	}
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	redis "github.com/go-redis/redis/v8"
+)
+
+const redisKeyPrefix = "ratelimit:"
+
+// allowScript increments the count of the window and sets its expiry when the window
+// starts, returning the count and the time left in the window in milliseconds. The expiry
+// is also set if it is missing, so that a count never outlives its window.
+var allowScript = redis.NewScript(`
+local count = redis.call("INCR", KEYS[1])
+local ttl = redis.call("PTTL", KEYS[1])
+
+if count == 1 or ttl < 0 then
+	redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	ttl = tonumber(ARGV[1])
+end
+
+return {count, ttl}
+`)
+
+// RedisLimiter is a Limiter which keeps counts in Redis, and therefore limits requests
+// across all instances of the server
+type RedisLimiter struct {
+	client *redis.Client
+}
+
+// NewRedisLimiter returns a Limiter which keeps counts in Redis
+func NewRedisLimiter(client *redis.Client) *RedisLimiter {
+	return &RedisLimiter{client}
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string, limit uint, window time.Duration) (bool, time.Duration, error) {
+	val, err := allowScript.Run(ctx, l.client, []string{redisKeyPrefix + key}, window.Milliseconds()).Result()
+
+	if err != nil {
+		return false, 0, err
+	}
+
+	// the pinned client only returns script results as interface{}, so the array of two
+	// integers is converted by hand
+	res, ok := val.([]interface{})
+
+	if !ok || len(res) != 2 {
+		return false, 0, fmt.Errorf("unexpected rate limit script result: %v", res)
+	}
+
+	count, ok := res[0].(int64)
+
+	if !ok {
+		return false, 0, fmt.Errorf("unexpected rate limit count: %v", res[0])
+	}
+
+	ttl, ok := res[1].(int64)
+
+	if !ok {
+		return false, 0, fmt.Errorf("unexpected rate limit ttl: %v", res[1])
+	}
+
+	if uint(count) > limit {
+		return false, time.Duration(ttl) * time.Millisecond, nil
+	}
+
+	return true, 0, nil
+}
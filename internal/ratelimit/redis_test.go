@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	redis "github.com/go-redis/redis/v8"
+)
+
+func TestRedisLimiter(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	limiter := NewRedisLimiter(client)
+
+	for i := 0; i < 3; i++ {
+		allowed, _, err := limiter.Allow(context.Background(), "ip:10.0.0.1", 3, time.Minute)
+
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		if !allowed {
+			t.Fatalf("expected request %d to be allowed", i+1)
+		}
+	}
+
+	mr.FastForward(20 * time.Second)
+
+	allowed, retryAfter, err := limiter.Allow(context.Background(), "ip:10.0.0.1", 3, time.Minute)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if allowed {
+		t.Errorf("expected request over the limit to be denied")
+	}
+
+	if retryAfter != 40*time.Second {
+		t.Errorf("expected to retry after 40s, got %s", retryAfter)
+	}
+
+	// other keys have their own window
+	if allowed, _, _ := limiter.Allow(context.Background(), "ip:10.0.0.2", 3, time.Minute); !allowed {
+		t.Errorf("expected request for another key to be allowed")
+	}
+
+	// the count resets with the window
+	mr.FastForward(40 * time.Second)
+
+	if allowed, _, _ := limiter.Allow(context.Background(), "ip:10.0.0.1", 3, time.Minute); !allowed {
+		t.Errorf("expected request in a new window to be allowed")
+	}
+}
+
+func TestRedisLimiterSetsMissingExpiry(t *testing.T) {
+	mr := miniredis.RunT(t)
+
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	// a count without an expiry, for example left by an interrupted script, must not
+	// block the key forever
+	mr.Set(redisKeyPrefix+"ip:10.0.0.1", "5")
+
+	limiter := NewRedisLimiter(client)
+
+	allowed, retryAfter, err := limiter.Allow(context.Background(), "ip:10.0.0.1", 3, time.Minute)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if allowed {
+		t.Errorf("expected request over the limit to be denied")
+	}
+
+	if retryAfter != time.Minute {
+		t.Errorf("expected to retry after 1m, got %s", retryAfter)
+	}
+
+	if ttl := mr.TTL(redisKeyPrefix + "ip:10.0.0.1"); ttl != time.Minute {
+		t.Errorf("expected the expiry to be set to 1m, got %s", ttl)
+	}
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// AuditLogRepository represents the set of queries on the audit logs of a project
+type AuditLogRepository interface {
+	CreateAuditLog(log *models.AuditLog) (*models.AuditLog, error)
+	ListAuditLogsByProjectID(projectID uint, filter *types.AuditLogFilter, limit, skip int) ([]*models.AuditLog, int64, error)
+}
@@ -2,6 +2,7 @@ package gorm_test
 
 import (
 	"testing"
+	"time"
 )
 
 func TestListAPITokensByProjectID(t *testing.T) {
@@ -29,3 +30,44 @@ func TestListAPITokensByProjectID(t *testing.T) {
 		t.Errorf("expected found to be %d but got: %d", 1, found[0].ID)
 	}
 }
+
+func TestUpdateAPITokenLastUsed(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_tokens_last_used.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	initAPITokens(tester, t)
+	defer cleanup(tester, t)
+
+	token := tester.initAPITokens[0]
+	lastUsedAt := time.Now().UTC().Truncate(time.Second)
+
+	// revoke the token after it was read by the caller, which must not be overwritten
+	token.Revoked = true
+
+	if _, err := tester.repo.APIToken().UpdateAPIToken(token); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	err := tester.repo.APIToken().UpdateAPITokenLastUsed(token.ID, lastUsedAt, "10.0.0.1")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	found, err := tester.repo.APIToken().ReadAPIToken(token.ProjectID, token.UniqueID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if found.LastUsedAt == nil || !found.LastUsedAt.Equal(lastUsedAt) || found.LastUsedIP != "10.0.0.1" {
+		t.Errorf("expected token to be last used at %s from 10.0.0.1, got %v from %s", lastUsedAt, found.LastUsedAt, found.LastUsedIP)
+	}
+
+	if !found.Revoked {
+		t.Errorf("expected token to remain revoked")
+	}
+}
@@ -0,0 +1,96 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// AuditLogRepository uses gorm.DB for querying the database
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditLogRepository returns an AuditLogRepository which uses
+// gorm.DB for querying the database
+func NewAuditLogRepository(db *gorm.DB) repository.AuditLogRepository {
+	return &AuditLogRepository{db}
+}
+
+// CreateAuditLog creates a new audit log entry
+func (repo *AuditLogRepository) CreateAuditLog(log *models.AuditLog) (*models.AuditLog, error) {
+	if err := repo.db.Create(log).Error; err != nil {
+		return nil, err
+	}
+
+	return log, nil
+}
+
+// ListAuditLogsByProjectID lists the audit logs of a project matching the filter, most
+// recent first, along with the total count of matching logs. A limit of 0 returns all
+// matching logs.
+func (repo *AuditLogRepository) ListAuditLogsByProjectID(
+	projectID uint,
+	filter *types.AuditLogFilter,
+	limit, skip int,
+) ([]*models.AuditLog, int64, error) {
+	logs := []*models.AuditLog{}
+
+	query := repo.db.Where("project_id = ?", projectID)
+
+	if filter.ClusterID != 0 {
+		query = query.Where("cluster_id = ?", filter.ClusterID)
+	}
+
+	if filter.Namespace != "" {
+		query = query.Where("namespace = ?", filter.Namespace)
+	}
+
+	if filter.UserID != 0 {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+
+	if filter.APITokenID != "" {
+		query = query.Where("api_token_id = ?", filter.APITokenID)
+	}
+
+	if filter.ResourceType != "" {
+		query = query.Where("resource_type = ?", filter.ResourceType)
+	}
+
+	if filter.Verb != "" {
+		query = query.Where("verb = ?", filter.Verb)
+	}
+
+	if filter.Outcome != "" {
+		query = query.Where("outcome = ?", filter.Outcome)
+	}
+
+	if filter.StartRange != nil {
+		query = query.Where("created_at >= ?", filter.StartRange)
+	}
+
+	if filter.EndRange != nil {
+		query = query.Where("created_at <= ?", filter.EndRange)
+	}
+
+	// get the count before limit and offset
+	var count int64
+
+	if err := query.Model([]*models.AuditLog{}).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Order("created_at desc").Order("id desc").Offset(skip)
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, count, nil
+}
@@ -0,0 +1,68 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestListAuditLogsByProjectID(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_list_audit_logs.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	if err := tester.db.AutoMigrate(&models.AuditLog{}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	logs := []*models.AuditLog{
+		{ProjectID: 1, ClusterID: 1, UserID: 1, Verb: types.APIVerbCreate, Outcome: types.AuditLogOutcomeSuccess},
+		{ProjectID: 1, ClusterID: 1, APITokenID: "token", Verb: types.APIVerbUpdate, Outcome: types.AuditLogOutcomeFailure},
+		{ProjectID: 1, ClusterID: 2, UserID: 1, Verb: types.APIVerbDelete, Outcome: types.AuditLogOutcomeSuccess},
+		{ProjectID: 2, ClusterID: 1, UserID: 1, Verb: types.APIVerbCreate, Outcome: types.AuditLogOutcomeSuccess},
+	}
+
+	for _, log := range logs {
+		if _, err := tester.repo.AuditLog().CreateAuditLog(log); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	res, count, err := tester.repo.AuditLog().ListAuditLogsByProjectID(1, &types.AuditLogFilter{ClusterID: 1}, 1, 0)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 2 {
+		t.Errorf("expected count 2, got %d", count)
+	}
+
+	if len(res) != 1 || res[0].APITokenID != "token" {
+		t.Errorf("expected most recent audit log of cluster, got %v", res)
+	}
+
+	res, count, err = tester.repo.AuditLog().ListAuditLogsByProjectID(1, &types.AuditLogFilter{
+		UserID:  1,
+		Outcome: types.AuditLogOutcomeSuccess,
+	}, 0, 0)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 2 || len(res) != 2 {
+		t.Errorf("expected 2 audit logs, got %d (count %d)", len(res), count)
+	}
+
+	for _, log := range res {
+		if log.ID == 0 || log.ProjectID != 1 {
+			t.Errorf("unexpected audit log %v", log)
+		}
+	}
+}
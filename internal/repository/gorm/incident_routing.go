@@ -0,0 +1,156 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// IncidentRoutingRuleRepository uses gorm.DB for querying the database
+type IncidentRoutingRuleRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewIncidentRoutingRuleRepository returns an IncidentRoutingRuleRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// the routing keys of targets
+func NewIncidentRoutingRuleRepository(db *gorm.DB, key *[32]byte) repository.IncidentRoutingRuleRepository {
+	return &IncidentRoutingRuleRepository{db, key}
+}
+
+// CreateIncidentRoutingRule creates a new routing rule along with its targets
+func (repo *IncidentRoutingRuleRepository) CreateIncidentRoutingRule(
+	rule *models.IncidentRoutingRule,
+) (*models.IncidentRoutingRule, error) {
+	if err := repo.encryptTargets(rule); err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Create(rule).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptTargets(rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// ReadIncidentRoutingRule finds a routing rule by project ID and ID
+func (repo *IncidentRoutingRuleRepository) ReadIncidentRoutingRule(
+	projectID, ruleID uint,
+) (*models.IncidentRoutingRule, error) {
+	rule := &models.IncidentRoutingRule{}
+
+	if err := repo.db.Preload("Targets").Where("project_id = ? AND id = ?", projectID, ruleID).First(rule).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptTargets(rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// ListIncidentRoutingRulesByProjectID lists the routing rules of a project in the order
+// in which they are evaluated
+func (repo *IncidentRoutingRuleRepository) ListIncidentRoutingRulesByProjectID(
+	projectID uint,
+) ([]*models.IncidentRoutingRule, error) {
+	rules := []*models.IncidentRoutingRule{}
+
+	if err := repo.db.Preload("Targets").Where("project_id = ?", projectID).Order("priority asc, id asc").Find(&rules).Error; err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		if err := repo.decryptTargets(rule); err != nil {
+			return nil, err
+		}
+	}
+
+	return rules, nil
+}
+
+// UpdateIncidentRoutingRule modifies a routing rule, and replaces all of its targets
+func (repo *IncidentRoutingRuleRepository) UpdateIncidentRoutingRule(
+	rule *models.IncidentRoutingRule,
+) (*models.IncidentRoutingRule, error) {
+	if err := repo.encryptTargets(rule); err != nil {
+		return nil, err
+	}
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("incident_routing_rule_id = ?", rule.ID).Delete(&models.IncidentRoutingTarget{}).Error; err != nil {
+			return err
+		}
+
+		for i := range rule.Targets {
+			rule.Targets[i].ID = 0
+			rule.Targets[i].IncidentRoutingRuleID = rule.ID
+		}
+
+		return tx.Save(rule).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptTargets(rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// DeleteIncidentRoutingRule deletes a routing rule along with its targets
+func (repo *IncidentRoutingRuleRepository) DeleteIncidentRoutingRule(rule *models.IncidentRoutingRule) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("incident_routing_rule_id = ?", rule.ID).Delete(&models.IncidentRoutingTarget{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(rule).Error
+	})
+}
+
+func (repo *IncidentRoutingRuleRepository) encryptTargets(rule *models.IncidentRoutingRule) error {
+	for i, target := range rule.Targets {
+		if len(target.RoutingKey) == 0 {
+			continue
+		}
+
+		cipherData, err := encryption.Encrypt(target.RoutingKey, repo.key)
+
+		if err != nil {
+			return err
+		}
+
+		rule.Targets[i].RoutingKey = cipherData
+	}
+
+	return nil
+}
+
+func (repo *IncidentRoutingRuleRepository) decryptTargets(rule *models.IncidentRoutingRule) error {
+	for i, target := range rule.Targets {
+		if len(target.RoutingKey) == 0 {
+			continue
+		}
+
+		plaintext, err := encryption.Decrypt(target.RoutingKey, repo.key)
+
+		if err != nil {
+			return err
+		}
+
+		rule.Targets[i].RoutingKey = plaintext
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/encryption"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
@@ -130,6 +131,23 @@ func (repo *InfraRepository) ListInfrasByProjectID(
 	return infras, nil
 }
 
+// ListInfrasByStatus finds all infras with the given status, across all projects
+func (repo *InfraRepository) ListInfrasByStatus(
+	status types.InfraStatus,
+) ([]*models.Infra, error) {
+	infras := []*models.Infra{}
+
+	if err := repo.db.Where("status = ?", status).Order("id asc").Find(&infras).Error; err != nil {
+		return nil, err
+	}
+
+	for _, infra := range infras {
+		repo.DecryptInfraData(infra, repo.key)
+	}
+
+	return infras, nil
+}
+
 // UpdateInfra modifies an existing Infra in the database
 func (repo *InfraRepository) UpdateInfra(
 	ai *models.Infra,
@@ -228,6 +246,23 @@ func (repo *InfraRepository) GetLatestOperation(infra *models.Infra) (*models.Op
 	return operation, nil
 }
 
+// GetLatestAppliedOperation returns the latest operation which was run against the infra,
+// ignoring plan and drift detection operations which did not change the infra
+func (repo *InfraRepository) GetLatestAppliedOperation(infra *models.Infra) (*models.Operation, error) {
+	operation := &models.Operation{}
+
+	if err := repo.db.Order("id desc").Where("infra_id = ? AND type NOT IN ?", infra.ID, []string{"plan", "detect_drift"}).First(&operation).Error; err != nil {
+		return nil, err
+	}
+
+	// decrypt the operation data before returning it
+	if err := repo.DecryptOperationData(operation, repo.key); err != nil {
+		return nil, err
+	}
+
+	return operation, nil
+}
+
 // UpdateInfra modifies an existing Infra in the database
 func (repo *InfraRepository) UpdateOperation(
 	operation *models.Operation,
@@ -74,5 +74,15 @@ func AutoMigrate(db *gorm.DB, debug bool) error {
 		&ints.GithubAppInstallation{},
 		&ints.GithubAppOAuthIntegration{},
 		&ints.SlackIntegration{},
+		&ints.WebhookIntegration{},
+		&ints.WebhookDelivery{},
+		&models.IncidentRoutingRule{},
+		&models.IncidentRoutingTarget{},
+		&models.WorkerJob{},
+		&models.WorkerJobSchedule{},
+		&models.WorkerLease{},
+		&models.PolicyPack{},
+		&models.PolicyPackPolicy{},
+		&models.AuditLog{},
 	)
 }
@@ -0,0 +1,82 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// PolicyPackRepository uses gorm.DB for querying the database
+type PolicyPackRepository struct {
+	db *gorm.DB
+}
+
+// NewPolicyPackRepository returns a PolicyPackRepository which uses
+// gorm.DB for querying the database
+func NewPolicyPackRepository(db *gorm.DB) repository.PolicyPackRepository {
+	return &PolicyPackRepository{db}
+}
+
+// CreatePolicyPack creates a new policy pack along with its policies
+func (repo *PolicyPackRepository) CreatePolicyPack(pack *models.PolicyPack) (*models.PolicyPack, error) {
+	if err := repo.db.Create(pack).Error; err != nil {
+		return nil, err
+	}
+
+	return pack, nil
+}
+
+// ReadPolicyPack finds a policy pack by project ID and ID
+func (repo *PolicyPackRepository) ReadPolicyPack(projectID, packID uint) (*models.PolicyPack, error) {
+	pack := &models.PolicyPack{}
+
+	if err := repo.db.Preload("Policies").Where("project_id = ? AND id = ?", projectID, packID).First(pack).Error; err != nil {
+		return nil, err
+	}
+
+	return pack, nil
+}
+
+// ListPolicyPacksByProjectID lists the policy packs of a project
+func (repo *PolicyPackRepository) ListPolicyPacksByProjectID(projectID uint) ([]*models.PolicyPack, error) {
+	packs := []*models.PolicyPack{}
+
+	if err := repo.db.Preload("Policies").Where("project_id = ?", projectID).Order("id asc").Find(&packs).Error; err != nil {
+		return nil, err
+	}
+
+	return packs, nil
+}
+
+// UpdatePolicyPack modifies a policy pack, and replaces all of its policies
+func (repo *PolicyPackRepository) UpdatePolicyPack(pack *models.PolicyPack) (*models.PolicyPack, error) {
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("policy_pack_id = ?", pack.ID).Delete(&models.PolicyPackPolicy{}).Error; err != nil {
+			return err
+		}
+
+		for i := range pack.Policies {
+			pack.Policies[i].ID = 0
+			pack.Policies[i].PolicyPackID = pack.ID
+		}
+
+		return tx.Save(pack).Error
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return pack, nil
+}
+
+// DeletePolicyPack deletes a policy pack along with its policies
+func (repo *PolicyPackRepository) DeletePolicyPack(pack *models.PolicyPack) error {
+	return repo.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("policy_pack_id = ?", pack.ID).Delete(&models.PolicyPackPolicy{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(pack).Error
+	})
+}
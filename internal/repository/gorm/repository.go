@@ -33,6 +33,7 @@ type GormRepository struct {
 	githubAppInstallation     repository.GithubAppInstallationRepository
 	githubAppOAuthIntegration repository.GithubAppOAuthIntegrationRepository
 	slackIntegration          repository.SlackIntegrationRepository
+	webhookIntegration        repository.WebhookIntegrationRepository
 	gitlabIntegration         repository.GitlabIntegrationRepository
 	gitlabAppOAuthIntegration repository.GitlabAppOAuthIntegrationRepository
 	notificationConfig        repository.NotificationConfigRepository
@@ -49,6 +50,10 @@ type GormRepository struct {
 	tag                       repository.TagRepository
 	stack                     repository.StackRepository
 	monitor                   repository.MonitorTestResultRepository
+	incidentRoutingRule       repository.IncidentRoutingRuleRepository
+	workerJob                 repository.WorkerJobRepository
+	policyPack                repository.PolicyPackRepository
+	auditLog                  repository.AuditLogRepository
 }
 
 func (t *GormRepository) User() repository.UserRepository {
@@ -155,6 +160,10 @@ func (t *GormRepository) SlackIntegration() repository.SlackIntegrationRepositor
 	return t.slackIntegration
 }
 
+func (t *GormRepository) WebhookIntegration() repository.WebhookIntegrationRepository {
+	return t.webhookIntegration
+}
+
 func (t *GormRepository) GitlabIntegration() repository.GitlabIntegrationRepository {
 	return t.gitlabIntegration
 }
@@ -219,11 +228,27 @@ func (t *GormRepository) MonitorTestResult() repository.MonitorTestResultReposit
 	return t.monitor
 }
 
+func (t *GormRepository) IncidentRoutingRule() repository.IncidentRoutingRuleRepository {
+	return t.incidentRoutingRule
+}
+
+func (t *GormRepository) WorkerJob() repository.WorkerJobRepository {
+	return t.workerJob
+}
+
+func (t *GormRepository) PolicyPack() repository.PolicyPackRepository {
+	return t.policyPack
+}
+
+func (t *GormRepository) AuditLog() repository.AuditLogRepository {
+	return t.auditLog
+}
+
 // NewRepository returns a Repository which persists users in memory
 // and accepts a parameter that can trigger read/write errors
 func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.CredentialStorage) repository.Repository {
 	return &GormRepository{
-		user:                      NewUserRepository(db),
+		user:                      NewUserRepository(db, key),
 		session:                   NewSessionRepository(db),
 		project:                   NewProjectRepository(db),
 		cluster:                   NewClusterRepository(db, key),
@@ -249,6 +274,7 @@ func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.Creden
 		githubAppInstallation:     NewGithubAppInstallationRepository(db),
 		githubAppOAuthIntegration: NewGithubAppOAuthIntegrationRepository(db),
 		slackIntegration:          NewSlackIntegrationRepository(db, key),
+		webhookIntegration:        NewWebhookIntegrationRepository(db, key),
 		gitlabIntegration:         NewGitlabIntegrationRepository(db, key, storageBackend),
 		gitlabAppOAuthIntegration: NewGitlabAppOAuthIntegrationRepository(db, key, storageBackend),
 		notificationConfig:        NewNotificationConfigRepository(db),
@@ -265,5 +291,9 @@ func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.Creden
 		tag:                       NewTagRepository(db),
 		stack:                     NewStackRepository(db),
 		monitor:                   NewMonitorTestResultRepository(db),
+		incidentRoutingRule:       NewIncidentRoutingRuleRepository(db, key),
+		workerJob:                 NewWorkerJobRepository(db),
+		policyPack:                NewPolicyPackRepository(db),
+		auditLog:                  NewAuditLogRepository(db),
 	}
 }
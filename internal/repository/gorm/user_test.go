@@ -104,3 +104,55 @@ func TestReadUserByGoogleUserID(t *testing.T) {
 		t.Error(diff)
 	}
 }
+
+func TestUpdateUserTOTPSecretEncrypted(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_update_user_totp.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	user, err := tester.repo.User().CreateUser(&models.User{
+		Email:    "test@test.it",
+		Password: "fake",
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	user.TOTPSecret = []byte("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+
+	user, err = tester.repo.User().UpdateUser(user)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(user.TOTPSecret) != "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ" {
+		t.Errorf("expected updated user to have a decrypted secret, got %s", string(user.TOTPSecret))
+	}
+
+	// the secret should not be stored in plaintext
+	rawUser := &models.User{}
+
+	if err := tester.db.Where("id = ?", user.ID).First(rawUser).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(rawUser.TOTPSecret) == "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ" {
+		t.Errorf("expected stored secret to be encrypted")
+	}
+
+	readUser, err := tester.repo.User().ReadUser(user.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if diff := deep.Equal(user, readUser); diff != nil {
+		t.Errorf("users not equal:")
+		t.Error(diff)
+	}
+}
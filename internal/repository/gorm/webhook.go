@@ -0,0 +1,212 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// WebhookIntegrationRepository uses gorm.DB for querying the database
+type WebhookIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewWebhookIntegrationRepository returns a WebhookIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewWebhookIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.WebhookIntegrationRepository {
+	return &WebhookIntegrationRepository{db, key}
+}
+
+// CreateWebhookIntegration creates a new webhook integration
+func (repo *WebhookIntegrationRepository) CreateWebhookIntegration(
+	webhookInt *ints.WebhookIntegration,
+) (*ints.WebhookIntegration, error) {
+	err := repo.EncryptWebhookIntegrationData(webhookInt, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Create(webhookInt).Error; err != nil {
+		return nil, err
+	}
+
+	err = repo.DecryptWebhookIntegrationData(webhookInt, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookInt, nil
+}
+
+// ReadWebhookIntegration finds a webhook integration by project ID and ID
+func (repo *WebhookIntegrationRepository) ReadWebhookIntegration(
+	projectID, integrationID uint,
+) (*ints.WebhookIntegration, error) {
+	webhookInt := &ints.WebhookIntegration{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, integrationID).First(webhookInt).Error; err != nil {
+		return nil, err
+	}
+
+	err := repo.DecryptWebhookIntegrationData(webhookInt, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookInt, nil
+}
+
+// ListWebhookIntegrationsByProjectID finds all webhook integrations
+// for a given project id
+func (repo *WebhookIntegrationRepository) ListWebhookIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.WebhookIntegration, error) {
+	webhookInts := []*ints.WebhookIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Find(&webhookInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, webhookInt := range webhookInts {
+		err := repo.DecryptWebhookIntegrationData(webhookInt, repo.key)
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return webhookInts, nil
+}
+
+// UpdateWebhookIntegration modifies an existing webhook integration
+func (repo *WebhookIntegrationRepository) UpdateWebhookIntegration(
+	webhookInt *ints.WebhookIntegration,
+) (*ints.WebhookIntegration, error) {
+	err := repo.EncryptWebhookIntegrationData(webhookInt, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Save(webhookInt).Error; err != nil {
+		return nil, err
+	}
+
+	err = repo.DecryptWebhookIntegrationData(webhookInt, repo.key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return webhookInt, nil
+}
+
+// DeleteWebhookIntegration deletes a webhook integration by ID
+func (repo *WebhookIntegrationRepository) DeleteWebhookIntegration(
+	integrationID uint,
+) error {
+	if err := repo.db.Where("id = ?", integrationID).Delete(&ints.WebhookIntegration{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// CreateWebhookDelivery records the delivery of an event to a webhook
+func (repo *WebhookIntegrationRepository) CreateWebhookDelivery(
+	delivery *ints.WebhookDelivery,
+) (*ints.WebhookDelivery, error) {
+	if err := repo.db.Create(delivery).Error; err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// ListWebhookDeliveries lists the most recent deliveries to a webhook
+func (repo *WebhookIntegrationRepository) ListWebhookDeliveries(
+	integrationID uint,
+	limit int,
+) ([]*ints.WebhookDelivery, error) {
+	deliveries := []*ints.WebhookDelivery{}
+
+	query := repo.db.Where("webhook_integration_id = ?", integrationID).Order("id desc")
+
+	if limit > 0 {
+		query = query.Limit(limit)
+	}
+
+	if err := query.Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+// EncryptWebhookIntegrationData will encrypt the webhook integration data before
+// writing to the DB
+func (repo *WebhookIntegrationRepository) EncryptWebhookIntegrationData(
+	webhookInt *ints.WebhookIntegration,
+	key *[32]byte,
+) error {
+	if len(webhookInt.URL) > 0 {
+		cipherData, err := encryption.Encrypt(webhookInt.URL, key)
+
+		if err != nil {
+			return err
+		}
+
+		webhookInt.URL = cipherData
+	}
+
+	if len(webhookInt.Secret) > 0 {
+		cipherData, err := encryption.Encrypt(webhookInt.Secret, key)
+
+		if err != nil {
+			return err
+		}
+
+		webhookInt.Secret = cipherData
+	}
+
+	return nil
+}
+
+// DecryptWebhookIntegrationData will decrypt the webhook integration data before
+// returning it from the DB
+func (repo *WebhookIntegrationRepository) DecryptWebhookIntegrationData(
+	webhookInt *ints.WebhookIntegration,
+	key *[32]byte,
+) error {
+	if len(webhookInt.URL) > 0 {
+		plaintext, err := encryption.Decrypt(webhookInt.URL, key)
+
+		if err != nil {
+			return err
+		}
+
+		webhookInt.URL = plaintext
+	}
+
+	if len(webhookInt.Secret) > 0 {
+		plaintext, err := encryption.Decrypt(webhookInt.Secret, key)
+
+		if err != nil {
+			return err
+		}
+
+		webhookInt.Secret = plaintext
+	}
+
+	return nil
+}
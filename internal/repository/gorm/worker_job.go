@@ -0,0 +1,265 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WorkerJobRepository uses gorm.DB for querying the database
+type WorkerJobRepository struct {
+	db *gorm.DB
+}
+
+// NewWorkerJobRepository returns a WorkerJobRepository which uses
+// gorm.DB for querying the database
+func NewWorkerJobRepository(db *gorm.DB) repository.WorkerJobRepository {
+	return &WorkerJobRepository{db}
+}
+
+// CreateWorkerJob enqueues a new job
+func (repo *WorkerJobRepository) CreateWorkerJob(job *models.WorkerJob) (*models.WorkerJob, error) {
+	if err := repo.db.Create(job).Error; err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// ReadWorkerJob finds a job by ID
+func (repo *WorkerJobRepository) ReadWorkerJob(id uint) (*models.WorkerJob, error) {
+	job := &models.WorkerJob{}
+
+	if err := repo.db.Where("id = ?", id).First(job).Error; err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// ListWorkerJobs lists the most recent jobs matching the filter
+func (repo *WorkerJobRepository) ListWorkerJobs(filter *types.ListWorkerJobsRequest) ([]*models.WorkerJob, error) {
+	jobs := []*models.WorkerJob{}
+
+	query := repo.db.Order("id desc")
+
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+
+	if filter.JobID != "" {
+		query = query.Where("job_id = ?", filter.JobID)
+	}
+
+	if filter.Limit > 0 {
+		query = query.Limit(int(filter.Limit))
+	}
+
+	if err := query.Find(&jobs).Error; err != nil {
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// UpdateWorkerJob modifies an existing job
+func (repo *WorkerJobRepository) UpdateWorkerJob(job *models.WorkerJob) (*models.WorkerJob, error) {
+	if err := repo.db.Save(job).Error; err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// ClaimWorkerJob marks the next queued job which is due as running. Each candidate is claimed
+// with a conditional update, so that a job is only claimed by a single worker.
+func (repo *WorkerJobRepository) ClaimWorkerJob(workerID string) (*models.WorkerJob, error) {
+	now := time.Now().UTC()
+	candidates := []*models.WorkerJob{}
+
+	err := repo.db.Where("status = ? AND run_at <= ?", types.WorkerJobStatusQueued, now).
+		Order("run_at asc, id asc").
+		Limit(10).
+		Find(&candidates).Error
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		res := repo.db.Model(&models.WorkerJob{}).
+			Where("id = ? AND status = ?", candidate.ID, types.WorkerJobStatusQueued).
+			Updates(map[string]interface{}{
+				"status":       types.WorkerJobStatusRunning,
+				"attempts":     gorm.Expr("attempts + 1"),
+				"locked_by":    workerID,
+				"started_at":   now,
+				"heartbeat_at": now,
+			})
+
+		if res.Error != nil {
+			return nil, res.Error
+		}
+
+		if res.RowsAffected == 1 {
+			return repo.ReadWorkerJob(candidate.ID)
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// HeartbeatWorkerJob records that the worker is still running the job
+func (repo *WorkerJobRepository) HeartbeatWorkerJob(id uint, workerID string) error {
+	return repo.db.Model(&models.WorkerJob{}).
+		Where("id = ? AND locked_by = ? AND status = ?", id, workerID, types.WorkerJobStatusRunning).
+		Update("heartbeat_at", time.Now().UTC()).Error
+}
+
+// CompleteWorkerJob records the result of an attempt with a conditional update on the worker
+// and attempt which claimed the job, so that the result of a stale attempt is discarded
+func (repo *WorkerJobRepository) CompleteWorkerJob(job *models.WorkerJob, workerID string) (bool, error) {
+	res := repo.db.Model(&models.WorkerJob{}).
+		Where(
+			"id = ? AND status = ? AND locked_by = ? AND attempts = ?",
+			job.ID, types.WorkerJobStatusRunning, workerID, job.Attempts,
+		).
+		Updates(map[string]interface{}{
+			"status":      job.Status,
+			"locked_by":   job.LockedBy,
+			"last_error":  job.LastError,
+			"run_at":      job.RunAt,
+			"finished_at": job.FinishedAt,
+		})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected == 1, nil
+}
+
+// RequeueStaleWorkerJobs recovers the jobs of workers which stopped while running them
+func (repo *WorkerJobRepository) RequeueStaleWorkerJobs(heartbeatBefore time.Time) (int64, error) {
+	var count int64
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		stale := tx.Model(&models.WorkerJob{}).
+			Where("status = ? AND heartbeat_at < ?", types.WorkerJobStatusRunning, heartbeatBefore).
+			Session(&gorm.Session{})
+
+		res := stale.Where("attempts >= max_attempts").Updates(map[string]interface{}{
+			"status":      types.WorkerJobStatusDead,
+			"locked_by":   "",
+			"finished_at": time.Now().UTC(),
+			"last_error":  "worker stopped while running the job",
+		})
+
+		if res.Error != nil {
+			return res.Error
+		}
+
+		count += res.RowsAffected
+
+		res = stale.Where("attempts < max_attempts").Updates(map[string]interface{}{
+			"status":     types.WorkerJobStatusQueued,
+			"locked_by":  "",
+			"run_at":     time.Now().UTC(),
+			"last_error": "worker stopped while running the job",
+		})
+
+		if res.Error != nil {
+			return res.Error
+		}
+
+		count += res.RowsAffected
+
+		return nil
+	})
+
+	return count, err
+}
+
+// ReadWorkerJobSchedule finds the schedule of a job by the job ID
+func (repo *WorkerJobRepository) ReadWorkerJobSchedule(jobID string) (*models.WorkerJobSchedule, error) {
+	schedule := &models.WorkerJobSchedule{}
+
+	if err := repo.db.Where("job_id = ?", jobID).First(schedule).Error; err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// CreateWorkerJobSchedule creates the schedule of a job. Replicas of the worker pool create
+// the schedules on startup at the same time, so an existing schedule of the job is returned
+// instead of violating the unique index on the job ID.
+func (repo *WorkerJobRepository) CreateWorkerJobSchedule(schedule *models.WorkerJobSchedule) (*models.WorkerJobSchedule, error) {
+	res := repo.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "job_id"}},
+		DoNothing: true,
+	}).Create(schedule)
+
+	if res.Error != nil {
+		return nil, res.Error
+	}
+
+	if res.RowsAffected == 0 {
+		return repo.ReadWorkerJobSchedule(schedule.JobID)
+	}
+
+	return schedule, nil
+}
+
+// UpdateWorkerJobSchedule modifies the schedule of a job
+func (repo *WorkerJobRepository) UpdateWorkerJobSchedule(schedule *models.WorkerJobSchedule) (*models.WorkerJobSchedule, error) {
+	if err := repo.db.Save(schedule).Error; err != nil {
+		return nil, err
+	}
+
+	return schedule, nil
+}
+
+// AcquireWorkerLease renews the lease if it is held by the holder or has expired, and
+// otherwise attempts to create it
+func (repo *WorkerJobRepository) AcquireWorkerLease(name, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now().UTC()
+
+	res := repo.db.Model(&models.WorkerLease{}).
+		Where("name = ? AND (holder = ? OR expires_at < ?)", name, holder, now).
+		Updates(map[string]interface{}{
+			"holder":     holder,
+			"expires_at": now.Add(ttl),
+		})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	if res.RowsAffected == 1 {
+		return true, nil
+	}
+
+	res = repo.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.WorkerLease{
+		Name:      name,
+		Holder:    holder,
+		ExpiresAt: now.Add(ttl),
+	})
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	return res.RowsAffected == 1, nil
+}
+
+// ReleaseWorkerLease expires the lease if it is held by the holder
+func (repo *WorkerJobRepository) ReleaseWorkerLease(name, holder string) error {
+	return repo.db.Model(&models.WorkerLease{}).
+		Where("name = ? AND holder = ?", name, holder).
+		Update("expires_at", time.Now().UTC()).Error
+}
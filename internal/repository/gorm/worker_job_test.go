@@ -0,0 +1,135 @@
+package gorm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestCompleteWorkerJob(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_complete_worker_job.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	if err := tester.db.AutoMigrate(&models.WorkerJob{}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	job, err := tester.repo.WorkerJob().CreateWorkerJob(&models.WorkerJob{
+		JobID:       "test",
+		Status:      types.WorkerJobStatusQueued,
+		MaxAttempts: 3,
+		RunAt:       time.Now().UTC().Add(-time.Minute),
+	})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	staleAttempt, err := tester.repo.WorkerJob().ClaimWorkerJob("worker-1")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the first attempt misses its heartbeat, and the job is claimed again
+	if _, err := tester.repo.WorkerJob().RequeueStaleWorkerJobs(time.Now().UTC().Add(time.Second)); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	currAttempt, err := tester.repo.WorkerJob().ClaimWorkerJob("worker-1")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	staleAttempt.Status = types.WorkerJobStatusQueued
+	staleAttempt.LockedBy = ""
+	staleAttempt.LastError = "failed"
+
+	ok, err := tester.repo.WorkerJob().CompleteWorkerJob(staleAttempt, "worker-1")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if ok {
+		t.Errorf("expected the result of the stale attempt to be discarded")
+	}
+
+	now := time.Now().UTC()
+
+	currAttempt.Status = types.WorkerJobStatusSucceeded
+	currAttempt.LockedBy = ""
+	currAttempt.LastError = ""
+	currAttempt.FinishedAt = &now
+
+	if ok, err := tester.repo.WorkerJob().CompleteWorkerJob(currAttempt, "worker-2"); err != nil || ok {
+		t.Errorf("expected the result of another worker to be discarded, got %t, %v", ok, err)
+	}
+
+	if ok, err := tester.repo.WorkerJob().CompleteWorkerJob(currAttempt, "worker-1"); err != nil || !ok {
+		t.Fatalf("expected the result of the current attempt to be recorded, got %t, %v", ok, err)
+	}
+
+	job, err = tester.repo.WorkerJob().ReadWorkerJob(job.ID)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if job.Status != types.WorkerJobStatusSucceeded || job.Attempts != 2 || job.LockedBy != "" ||
+		job.LastError != "" || job.FinishedAt == nil {
+		t.Errorf("expected the job to have succeeded on the second attempt, got %+v", job)
+	}
+
+	// a job which was already completed cannot be completed again
+	if ok, err := tester.repo.WorkerJob().CompleteWorkerJob(currAttempt, "worker-1"); err != nil || ok {
+		t.Errorf("expected a completed job not to be updated, got %t, %v", ok, err)
+	}
+}
+
+func TestCreateWorkerJobScheduleTwice(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_create_worker_job_schedule.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	if err := tester.db.AutoMigrate(&models.WorkerJobSchedule{}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	first, err := tester.repo.WorkerJob().CreateWorkerJobSchedule(&models.WorkerJobSchedule{JobID: "recommender"})
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// a replica which starts at the same time creates the same schedule
+	second, err := tester.repo.WorkerJob().CreateWorkerJobSchedule(&models.WorkerJobSchedule{JobID: "recommender"})
+
+	if err != nil {
+		t.Fatalf("expected the existing schedule to be returned, got %v", err)
+	}
+
+	if second.ID != first.ID {
+		t.Errorf("expected schedule %d, got %d", first.ID, second.ID)
+	}
+
+	var count int64
+
+	if err := tester.db.Model(&models.WorkerJobSchedule{}).Count(&count).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 1 {
+		t.Errorf("expected 1 schedule, got %d", count)
+	}
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// IncidentRoutingRuleRepository represents the set of queries on the incident routing
+// rules of a project
+type IncidentRoutingRuleRepository interface {
+	CreateIncidentRoutingRule(rule *models.IncidentRoutingRule) (*models.IncidentRoutingRule, error)
+	ReadIncidentRoutingRule(projectID, ruleID uint) (*models.IncidentRoutingRule, error)
+	ListIncidentRoutingRulesByProjectID(projectID uint) ([]*models.IncidentRoutingRule, error)
+	UpdateIncidentRoutingRule(rule *models.IncidentRoutingRule) (*models.IncidentRoutingRule, error)
+	DeleteIncidentRoutingRule(rule *models.IncidentRoutingRule) error
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
 )
 
@@ -9,6 +10,7 @@ type InfraRepository interface {
 	CreateInfra(repo *models.Infra) (*models.Infra, error)
 	ReadInfra(projectID, infraID uint) (*models.Infra, error)
 	ListInfrasByProjectID(projectID uint, apiVersion string) ([]*models.Infra, error)
+	ListInfrasByStatus(status types.InfraStatus) ([]*models.Infra, error)
 	UpdateInfra(repo *models.Infra) (*models.Infra, error)
 
 	// Operations
@@ -16,5 +18,6 @@ type InfraRepository interface {
 	ReadOperation(infraID uint, operationUID string) (*models.Operation, error)
 	ListOperations(infraID uint) ([]*models.Operation, error)
 	GetLatestOperation(infra *models.Infra) (*models.Operation, error)
+	GetLatestAppliedOperation(infra *models.Infra) (*models.Operation, error)
 	UpdateOperation(repo *models.Operation) (*models.Operation, error)
 }
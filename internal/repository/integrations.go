@@ -53,6 +53,18 @@ type SlackIntegrationRepository interface {
 	DeleteSlackIntegration(integrationID uint) error
 }
 
+// WebhookIntegrationRepository represents the set of queries on an outgoing webhook
+// integration and its deliveries
+type WebhookIntegrationRepository interface {
+	CreateWebhookIntegration(webhookInt *ints.WebhookIntegration) (*ints.WebhookIntegration, error)
+	ReadWebhookIntegration(projectID, integrationID uint) (*ints.WebhookIntegration, error)
+	ListWebhookIntegrationsByProjectID(projectID uint) ([]*ints.WebhookIntegration, error)
+	UpdateWebhookIntegration(webhookInt *ints.WebhookIntegration) (*ints.WebhookIntegration, error)
+	DeleteWebhookIntegration(integrationID uint) error
+	CreateWebhookDelivery(delivery *ints.WebhookDelivery) (*ints.WebhookDelivery, error)
+	ListWebhookDeliveries(integrationID uint, limit int) ([]*ints.WebhookDelivery, error)
+}
+
 // AWSIntegrationRepository represents the set of queries on the AWS auth
 // mechanism
 type AWSIntegrationRepository interface {
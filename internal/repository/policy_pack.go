@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// PolicyPackRepository represents the set of queries on the policy packs of a project
+type PolicyPackRepository interface {
+	CreatePolicyPack(pack *models.PolicyPack) (*models.PolicyPack, error)
+	ReadPolicyPack(projectID, packID uint) (*models.PolicyPack, error)
+	ListPolicyPacksByProjectID(projectID uint) ([]*models.PolicyPack, error)
+	UpdatePolicyPack(pack *models.PolicyPack) (*models.PolicyPack, error)
+	DeletePolicyPack(pack *models.PolicyPack) error
+}
@@ -27,6 +27,7 @@ type Repository interface {
 	GithubAppInstallation() GithubAppInstallationRepository
 	GithubAppOAuthIntegration() GithubAppOAuthIntegrationRepository
 	SlackIntegration() SlackIntegrationRepository
+	WebhookIntegration() WebhookIntegrationRepository
 	GitlabIntegration() GitlabIntegrationRepository
 	GitlabAppOAuthIntegration() GitlabAppOAuthIntegrationRepository
 	NotificationConfig() NotificationConfigRepository
@@ -43,4 +44,8 @@ type Repository interface {
 	Tag() TagRepository
 	Stack() StackRepository
 	MonitorTestResult() MonitorTestResultRepository
+	IncidentRoutingRule() IncidentRoutingRuleRepository
+	WorkerJob() WorkerJobRepository
+	PolicyPack() PolicyPackRepository
+	AuditLog() AuditLogRepository
 }
@@ -0,0 +1,75 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type AuditLogRepository struct {
+	canQuery bool
+	logs     []*models.AuditLog
+}
+
+func NewAuditLogRepository(canQuery bool) repository.AuditLogRepository {
+	return &AuditLogRepository{canQuery, []*models.AuditLog{}}
+}
+
+func (repo *AuditLogRepository) CreateAuditLog(log *models.AuditLog) (*models.AuditLog, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.logs = append(repo.logs, log)
+	log.ID = uint(len(repo.logs))
+
+	return log, nil
+}
+
+func (repo *AuditLogRepository) ListAuditLogsByProjectID(
+	projectID uint,
+	filter *types.AuditLogFilter,
+	limit, skip int,
+) ([]*models.AuditLog, int64, error) {
+	if !repo.canQuery {
+		return nil, 0, errors.New("Cannot read from database")
+	}
+
+	matches := make([]*models.AuditLog, 0)
+
+	// iterate in reverse to list the most recent logs first
+	for i := len(repo.logs) - 1; i >= 0; i-- {
+		log := repo.logs[i]
+
+		if log.ProjectID != projectID ||
+			(filter.ClusterID != 0 && log.ClusterID != filter.ClusterID) ||
+			(filter.Namespace != "" && log.Namespace != filter.Namespace) ||
+			(filter.UserID != 0 && log.UserID != filter.UserID) ||
+			(filter.APITokenID != "" && log.APITokenID != filter.APITokenID) ||
+			(filter.ResourceType != "" && log.ResourceType != filter.ResourceType) ||
+			(filter.Verb != "" && log.Verb != filter.Verb) ||
+			(filter.Outcome != "" && log.Outcome != filter.Outcome) ||
+			(filter.StartRange != nil && log.CreatedAt.Before(*filter.StartRange)) ||
+			(filter.EndRange != nil && log.CreatedAt.After(*filter.EndRange)) {
+			continue
+		}
+
+		matches = append(matches, log)
+	}
+
+	count := int64(len(matches))
+
+	if skip >= len(matches) {
+		return []*models.AuditLog{}, count, nil
+	}
+
+	matches = matches[skip:]
+
+	if limit > 0 && limit < len(matches) {
+		matches = matches[:limit]
+	}
+
+	return matches, count, nil
+}
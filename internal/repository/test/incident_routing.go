@@ -0,0 +1,98 @@
+package test
+
+import (
+	"errors"
+	"sort"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+type IncidentRoutingRuleRepository struct {
+	canQuery bool
+	rules    []*models.IncidentRoutingRule
+}
+
+func NewIncidentRoutingRuleRepository(canQuery bool) repository.IncidentRoutingRuleRepository {
+	return &IncidentRoutingRuleRepository{canQuery, []*models.IncidentRoutingRule{}}
+}
+
+func (repo *IncidentRoutingRuleRepository) CreateIncidentRoutingRule(
+	rule *models.IncidentRoutingRule,
+) (*models.IncidentRoutingRule, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.rules = append(repo.rules, rule)
+	rule.ID = uint(len(repo.rules))
+
+	return rule, nil
+}
+
+func (repo *IncidentRoutingRuleRepository) ReadIncidentRoutingRule(
+	projectID, ruleID uint,
+) (*models.IncidentRoutingRule, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(ruleID-1) >= len(repo.rules) || repo.rules[ruleID-1] == nil || repo.rules[ruleID-1].ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.rules[ruleID-1], nil
+}
+
+func (repo *IncidentRoutingRuleRepository) ListIncidentRoutingRulesByProjectID(
+	projectID uint,
+) ([]*models.IncidentRoutingRule, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.IncidentRoutingRule, 0)
+
+	for _, rule := range repo.rules {
+		if rule != nil && rule.ProjectID == projectID {
+			res = append(res, rule)
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Priority < res[j].Priority
+	})
+
+	return res, nil
+}
+
+func (repo *IncidentRoutingRuleRepository) UpdateIncidentRoutingRule(
+	rule *models.IncidentRoutingRule,
+) (*models.IncidentRoutingRule, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(rule.ID-1) >= len(repo.rules) || repo.rules[rule.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.rules[rule.ID-1] = rule
+
+	return rule, nil
+}
+
+func (repo *IncidentRoutingRuleRepository) DeleteIncidentRoutingRule(rule *models.IncidentRoutingRule) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if int(rule.ID-1) >= len(repo.rules) || repo.rules[rule.ID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.rules[rule.ID-1] = nil
+
+	return nil
+}
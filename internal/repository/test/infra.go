@@ -3,6 +3,7 @@ package test
 import (
 	"errors"
 
+	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
@@ -10,8 +11,9 @@ import (
 
 // InfraRepository implements repository.InfraRepository
 type InfraRepository struct {
-	canQuery bool
-	infras   []*models.Infra
+	canQuery   bool
+	infras     []*models.Infra
+	operations []*models.Operation
 }
 
 // NewInfraRepository will return errors if canQuery is false
@@ -19,6 +21,7 @@ func NewInfraRepository(canQuery bool) repository.InfraRepository {
 	return &InfraRepository{
 		canQuery,
 		[]*models.Infra{},
+		[]*models.Operation{},
 	}
 }
 
@@ -74,6 +77,25 @@ func (repo *InfraRepository) ListInfrasByProjectID(
 	return res, nil
 }
 
+// ListInfrasByStatus finds all infras with the given status
+func (repo *InfraRepository) ListInfrasByStatus(
+	status types.InfraStatus,
+) ([]*models.Infra, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Infra, 0)
+
+	for _, infra := range repo.infras {
+		if infra != nil && infra.Status == status {
+			res = append(res, infra)
+		}
+	}
+
+	return res, nil
+}
+
 // UpdateInfra modifies an existing Infra in the database
 func (repo *InfraRepository) UpdateInfra(
 	ai *models.Infra,
@@ -92,24 +114,102 @@ func (repo *InfraRepository) UpdateInfra(
 	return ai, nil
 }
 
+// AddOperation adds a new operation to an infra
 func (repo *InfraRepository) AddOperation(infra *models.Infra, operation *models.Operation) (*models.Operation, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.operations = append(repo.operations, operation)
+	operation.ID = uint(len(repo.operations))
+	operation.InfraID = infra.ID
+
+	return operation, nil
 }
 
+// GetLatestOperation returns the latest operation of an infra
 func (repo *InfraRepository) GetLatestOperation(infra *models.Infra) (*models.Operation, error) {
-	panic("unimplemented")
+	return repo.getLatestOperation(infra.ID)
+}
+
+// GetLatestAppliedOperation returns the latest operation of an infra, ignoring plan and drift
+// detection operations
+func (repo *InfraRepository) GetLatestAppliedOperation(infra *models.Infra) (*models.Operation, error) {
+	return repo.getLatestOperation(infra.ID, "plan", "detect_drift")
+}
+
+func (repo *InfraRepository) getLatestOperation(infraID uint, ignoredTypes ...string) (*models.Operation, error) {
+	operations, err := repo.ListOperations(infraID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, operation := range operations {
+		ignored := false
+
+		for _, opType := range ignoredTypes {
+			if operation.Type == opType {
+				ignored = true
+				break
+			}
+		}
+
+		if !ignored {
+			return operation, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
 }
 
+// ListOperations lists the operations of an infra, starting with the latest one
 func (repo *InfraRepository) ListOperations(infraID uint) ([]*models.Operation, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Operation, 0)
+
+	for i := len(repo.operations) - 1; i >= 0; i-- {
+		if repo.operations[i].InfraID == infraID {
+			res = append(res, repo.operations[i])
+		}
+	}
+
+	return res, nil
 }
 
+// ReadOperation finds an operation of an infra by its UID
 func (repo *InfraRepository) ReadOperation(infraID uint, operationUID string) (*models.Operation, error) {
-	panic("unimplemented")
+	operations, err := repo.ListOperations(infraID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, operation := range operations {
+		if operation.UID == operationUID {
+			return operation, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
 }
 
+// UpdateOperation modifies an existing operation
 func (repo *InfraRepository) UpdateOperation(
 	operation *models.Operation,
 ) (*models.Operation, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(operation.ID-1) >= len(repo.operations) || repo.operations[operation.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.operations[operation.ID-1] = operation
+
+	return operation, nil
 }
@@ -0,0 +1,85 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+type PolicyPackRepository struct {
+	canQuery bool
+	packs    []*models.PolicyPack
+}
+
+func NewPolicyPackRepository(canQuery bool) repository.PolicyPackRepository {
+	return &PolicyPackRepository{canQuery, []*models.PolicyPack{}}
+}
+
+func (repo *PolicyPackRepository) CreatePolicyPack(pack *models.PolicyPack) (*models.PolicyPack, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.packs = append(repo.packs, pack)
+	pack.ID = uint(len(repo.packs))
+
+	return pack, nil
+}
+
+func (repo *PolicyPackRepository) ReadPolicyPack(projectID, packID uint) (*models.PolicyPack, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(packID-1) >= len(repo.packs) || repo.packs[packID-1] == nil || repo.packs[packID-1].ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.packs[packID-1], nil
+}
+
+func (repo *PolicyPackRepository) ListPolicyPacksByProjectID(projectID uint) ([]*models.PolicyPack, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.PolicyPack, 0)
+
+	for _, pack := range repo.packs {
+		if pack != nil && pack.ProjectID == projectID {
+			res = append(res, pack)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *PolicyPackRepository) UpdatePolicyPack(pack *models.PolicyPack) (*models.PolicyPack, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(pack.ID-1) >= len(repo.packs) || repo.packs[pack.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.packs[pack.ID-1] = pack
+
+	return pack, nil
+}
+
+func (repo *PolicyPackRepository) DeletePolicyPack(pack *models.PolicyPack) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if int(pack.ID-1) >= len(repo.packs) || repo.packs[pack.ID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.packs[pack.ID-1] = nil
+
+	return nil
+}
@@ -32,6 +32,7 @@ type TestRepository struct {
 	gitlabIntegration         repository.GitlabIntegrationRepository
 	gitlabAppOAuthIntegration repository.GitlabAppOAuthIntegrationRepository
 	slackIntegration          repository.SlackIntegrationRepository
+	webhookIntegration        repository.WebhookIntegrationRepository
 	notificationConfig        repository.NotificationConfigRepository
 	jobNotificationConfig     repository.JobNotificationConfigRepository
 	buildEvent                repository.BuildEventRepository
@@ -47,6 +48,10 @@ type TestRepository struct {
 	tag                       repository.TagRepository
 	stack                     repository.StackRepository
 	monitor                   repository.MonitorTestResultRepository
+	incidentRoutingRule       repository.IncidentRoutingRuleRepository
+	workerJob                 repository.WorkerJobRepository
+	policyPack                repository.PolicyPackRepository
+	auditLog                  repository.AuditLogRepository
 }
 
 func (t *TestRepository) User() repository.UserRepository {
@@ -157,6 +162,10 @@ func (t *TestRepository) SlackIntegration() repository.SlackIntegrationRepositor
 	return t.slackIntegration
 }
 
+func (t *TestRepository) WebhookIntegration() repository.WebhookIntegrationRepository {
+	return t.webhookIntegration
+}
+
 func (t *TestRepository) NotificationConfig() repository.NotificationConfigRepository {
 	return t.notificationConfig
 }
@@ -217,6 +226,22 @@ func (t *TestRepository) MonitorTestResult() repository.MonitorTestResultReposit
 	return t.monitor
 }
 
+func (t *TestRepository) IncidentRoutingRule() repository.IncidentRoutingRuleRepository {
+	return t.incidentRoutingRule
+}
+
+func (t *TestRepository) WorkerJob() repository.WorkerJobRepository {
+	return t.workerJob
+}
+
+func (t *TestRepository) PolicyPack() repository.PolicyPackRepository {
+	return t.policyPack
+}
+
+func (t *TestRepository) AuditLog() repository.AuditLogRepository {
+	return t.auditLog
+}
+
 // NewRepository returns a Repository which persists users in memory
 // and accepts a parameter that can trigger read/write errors
 func NewRepository(canQuery bool, failingMethods ...string) repository.Repository {
@@ -248,6 +273,7 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		gitlabIntegration:         NewGitlabIntegrationRepository(canQuery),
 		gitlabAppOAuthIntegration: NewGitlabAppOAuthIntegrationRepository(canQuery),
 		slackIntegration:          NewSlackIntegrationRepository(canQuery),
+		webhookIntegration:        NewWebhookIntegrationRepository(canQuery),
 		notificationConfig:        NewNotificationConfigRepository(canQuery),
 		jobNotificationConfig:     NewJobNotificationConfigRepository(canQuery),
 		buildEvent:                NewBuildEventRepository(canQuery),
@@ -263,5 +289,9 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		tag:                       NewTagRepository(),
 		stack:                     NewStackRepository(),
 		monitor:                   NewMonitorTestResultRepository(canQuery),
+		incidentRoutingRule:       NewIncidentRoutingRuleRepository(canQuery),
+		workerJob:                 NewWorkerJobRepository(canQuery),
+		policyPack:                NewPolicyPackRepository(canQuery),
+		auditLog:                  NewAuditLogRepository(canQuery),
 	}
 }
@@ -0,0 +1,164 @@
+package test
+
+import (
+	"errors"
+	"sync"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+type WebhookIntegrationRepository struct {
+	canQuery bool
+
+	mu                  sync.Mutex
+	webhookIntegrations []*ints.WebhookIntegration
+	deliveries          []*ints.WebhookDelivery
+}
+
+func NewWebhookIntegrationRepository(canQuery bool) repository.WebhookIntegrationRepository {
+	return &WebhookIntegrationRepository{
+		canQuery:            canQuery,
+		webhookIntegrations: []*ints.WebhookIntegration{},
+		deliveries:          []*ints.WebhookDelivery{},
+	}
+}
+
+func (repo *WebhookIntegrationRepository) CreateWebhookIntegration(
+	webhookInt *ints.WebhookIntegration,
+) (*ints.WebhookIntegration, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.webhookIntegrations = append(repo.webhookIntegrations, webhookInt)
+	webhookInt.ID = uint(len(repo.webhookIntegrations))
+
+	return webhookInt, nil
+}
+
+func (repo *WebhookIntegrationRepository) ReadWebhookIntegration(
+	projectID, integrationID uint,
+) (*ints.WebhookIntegration, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if int(integrationID-1) >= len(repo.webhookIntegrations) || repo.webhookIntegrations[integrationID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	webhookInt := repo.webhookIntegrations[integrationID-1]
+
+	if webhookInt.ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return webhookInt, nil
+}
+
+func (repo *WebhookIntegrationRepository) ListWebhookIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.WebhookIntegration, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	res := make([]*ints.WebhookIntegration, 0)
+
+	for _, webhookInt := range repo.webhookIntegrations {
+		if webhookInt != nil && webhookInt.ProjectID == projectID {
+			res = append(res, webhookInt)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *WebhookIntegrationRepository) UpdateWebhookIntegration(
+	webhookInt *ints.WebhookIntegration,
+) (*ints.WebhookIntegration, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if int(webhookInt.ID-1) >= len(repo.webhookIntegrations) || repo.webhookIntegrations[webhookInt.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.webhookIntegrations[webhookInt.ID-1] = webhookInt
+
+	return webhookInt, nil
+}
+
+func (repo *WebhookIntegrationRepository) DeleteWebhookIntegration(integrationID uint) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if int(integrationID-1) >= len(repo.webhookIntegrations) || repo.webhookIntegrations[integrationID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	repo.webhookIntegrations[integrationID-1] = nil
+
+	return nil
+}
+
+func (repo *WebhookIntegrationRepository) CreateWebhookDelivery(
+	delivery *ints.WebhookDelivery,
+) (*ints.WebhookDelivery, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.deliveries = append(repo.deliveries, delivery)
+	delivery.ID = uint(len(repo.deliveries))
+
+	return delivery, nil
+}
+
+func (repo *WebhookIntegrationRepository) ListWebhookDeliveries(
+	integrationID uint,
+	limit int,
+) ([]*ints.WebhookDelivery, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	res := make([]*ints.WebhookDelivery, 0)
+
+	for i := len(repo.deliveries) - 1; i >= 0; i-- {
+		if limit > 0 && len(res) >= limit {
+			break
+		}
+
+		if repo.deliveries[i].WebhookIntegrationID == integrationID {
+			res = append(res, repo.deliveries[i])
+		}
+	}
+
+	return res, nil
+}
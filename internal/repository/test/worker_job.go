@@ -0,0 +1,312 @@
+package test
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+type WorkerJobRepository struct {
+	canQuery bool
+
+	mu        sync.Mutex
+	jobs      []*models.WorkerJob
+	schedules []*models.WorkerJobSchedule
+	leases    map[string]*models.WorkerLease
+}
+
+func NewWorkerJobRepository(canQuery bool) repository.WorkerJobRepository {
+	return &WorkerJobRepository{
+		canQuery:  canQuery,
+		jobs:      []*models.WorkerJob{},
+		schedules: []*models.WorkerJobSchedule{},
+		leases:    make(map[string]*models.WorkerLease),
+	}
+}
+
+func (repo *WorkerJobRepository) CreateWorkerJob(job *models.WorkerJob) (*models.WorkerJob, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	repo.jobs = append(repo.jobs, job)
+	job.ID = uint(len(repo.jobs))
+
+	return job, nil
+}
+
+func (repo *WorkerJobRepository) ReadWorkerJob(id uint) (*models.WorkerJob, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if int(id-1) >= len(repo.jobs) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	jobCp := *repo.jobs[id-1]
+
+	return &jobCp, nil
+}
+
+func (repo *WorkerJobRepository) ListWorkerJobs(filter *types.ListWorkerJobsRequest) ([]*models.WorkerJob, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	res := make([]*models.WorkerJob, 0)
+
+	for i := len(repo.jobs) - 1; i >= 0; i-- {
+		job := repo.jobs[i]
+
+		if filter.Limit > 0 && uint(len(res)) >= filter.Limit {
+			break
+		}
+
+		if (filter.Status == "" || job.Status == filter.Status) && (filter.JobID == "" || job.JobID == filter.JobID) {
+			jobCp := *job
+			res = append(res, &jobCp)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *WorkerJobRepository) UpdateWorkerJob(job *models.WorkerJob) (*models.WorkerJob, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if int(job.ID-1) >= len(repo.jobs) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	jobCp := *job
+	repo.jobs[job.ID-1] = &jobCp
+
+	return job, nil
+}
+
+func (repo *WorkerJobRepository) ClaimWorkerJob(workerID string) (*models.WorkerJob, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	now := time.Now().UTC()
+
+	for _, job := range repo.jobs {
+		if job.Status == types.WorkerJobStatusQueued && !job.RunAt.After(now) {
+			job.Status = types.WorkerJobStatusRunning
+			job.Attempts++
+			job.LockedBy = workerID
+			job.StartedAt = &now
+			job.HeartbeatAt = &now
+
+			jobCp := *job
+
+			return &jobCp, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (repo *WorkerJobRepository) HeartbeatWorkerJob(id uint, workerID string) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if int(id-1) >= len(repo.jobs) {
+		return gorm.ErrRecordNotFound
+	}
+
+	if job := repo.jobs[id-1]; job.LockedBy == workerID && job.Status == types.WorkerJobStatusRunning {
+		now := time.Now().UTC()
+		job.HeartbeatAt = &now
+	}
+
+	return nil
+}
+
+func (repo *WorkerJobRepository) CompleteWorkerJob(job *models.WorkerJob, workerID string) (bool, error) {
+	if !repo.canQuery {
+		return false, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if int(job.ID-1) >= len(repo.jobs) {
+		return false, gorm.ErrRecordNotFound
+	}
+
+	curr := repo.jobs[job.ID-1]
+
+	if curr.Status != types.WorkerJobStatusRunning || curr.LockedBy != workerID || curr.Attempts != job.Attempts {
+		return false, nil
+	}
+
+	curr.Status = job.Status
+	curr.LockedBy = job.LockedBy
+	curr.LastError = job.LastError
+	curr.RunAt = job.RunAt
+	curr.FinishedAt = job.FinishedAt
+
+	return true, nil
+}
+
+func (repo *WorkerJobRepository) RequeueStaleWorkerJobs(heartbeatBefore time.Time) (int64, error) {
+	if !repo.canQuery {
+		return 0, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	var count int64
+	now := time.Now().UTC()
+
+	for _, job := range repo.jobs {
+		if job.Status != types.WorkerJobStatusRunning || job.HeartbeatAt == nil || !job.HeartbeatAt.Before(heartbeatBefore) {
+			continue
+		}
+
+		job.LockedBy = ""
+		job.LastError = "worker stopped while running the job"
+
+		if job.Attempts >= job.MaxAttempts {
+			job.Status = types.WorkerJobStatusDead
+			job.FinishedAt = &now
+		} else {
+			job.Status = types.WorkerJobStatusQueued
+			job.RunAt = now
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+func (repo *WorkerJobRepository) ReadWorkerJobSchedule(jobID string) (*models.WorkerJobSchedule, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, schedule := range repo.schedules {
+		if schedule.JobID == jobID {
+			scheduleCp := *schedule
+			return &scheduleCp, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (repo *WorkerJobRepository) CreateWorkerJobSchedule(schedule *models.WorkerJobSchedule) (*models.WorkerJobSchedule, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	for _, existing := range repo.schedules {
+		if existing.JobID == schedule.JobID {
+			scheduleCp := *existing
+
+			return &scheduleCp, nil
+		}
+	}
+
+	schedule.CreatedAt = time.Now().UTC()
+
+	repo.schedules = append(repo.schedules, schedule)
+	schedule.ID = uint(len(repo.schedules))
+
+	scheduleCp := *schedule
+
+	return &scheduleCp, nil
+}
+
+func (repo *WorkerJobRepository) UpdateWorkerJobSchedule(schedule *models.WorkerJobSchedule) (*models.WorkerJobSchedule, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if int(schedule.ID-1) >= len(repo.schedules) {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	scheduleCp := *schedule
+	repo.schedules[schedule.ID-1] = &scheduleCp
+
+	return schedule, nil
+}
+
+func (repo *WorkerJobRepository) AcquireWorkerLease(name, holder string, ttl time.Duration) (bool, error) {
+	if !repo.canQuery {
+		return false, errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	now := time.Now().UTC()
+
+	if lease, ok := repo.leases[name]; ok && lease.Holder != holder && lease.ExpiresAt.After(now) {
+		return false, nil
+	}
+
+	repo.leases[name] = &models.WorkerLease{
+		Name:      name,
+		Holder:    holder,
+		ExpiresAt: now.Add(ttl),
+	}
+
+	return true, nil
+}
+
+func (repo *WorkerJobRepository) ReleaseWorkerLease(name, holder string) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	repo.mu.Lock()
+	defer repo.mu.Unlock()
+
+	if lease, ok := repo.leases[name]; ok && lease.Holder == holder {
+		lease.ExpiresAt = time.Now().UTC()
+	}
+
+	return nil
+}
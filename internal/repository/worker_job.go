@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// WorkerJobRepository represents the set of queries on the persistent job queue of
+// the worker pool
+type WorkerJobRepository interface {
+	CreateWorkerJob(job *models.WorkerJob) (*models.WorkerJob, error)
+	ReadWorkerJob(id uint) (*models.WorkerJob, error)
+	ListWorkerJobs(filter *types.ListWorkerJobsRequest) ([]*models.WorkerJob, error)
+	UpdateWorkerJob(job *models.WorkerJob) (*models.WorkerJob, error)
+
+	// ClaimWorkerJob marks the next queued job which is due as running by the given worker,
+	// and returns gorm.ErrRecordNotFound if there is no such job
+	ClaimWorkerJob(workerID string) (*models.WorkerJob, error)
+
+	HeartbeatWorkerJob(id uint, workerID string) error
+
+	// CompleteWorkerJob records the result of the current attempt of a running job. It only
+	// updates the job if it is still held by the worker for that attempt, and returns false
+	// otherwise, for example when the job was requeued after a missed heartbeat.
+	CompleteWorkerJob(job *models.WorkerJob, workerID string) (bool, error)
+
+	// RequeueStaleWorkerJobs requeues running jobs whose last heartbeat is before the given
+	// time, or marks them as dead if they have no attempts left
+	RequeueStaleWorkerJobs(heartbeatBefore time.Time) (int64, error)
+
+	ReadWorkerJobSchedule(jobID string) (*models.WorkerJobSchedule, error)
+
+	// CreateWorkerJobSchedule creates the schedule of a job, or returns the existing schedule
+	// if one was already created for the job ID
+	CreateWorkerJobSchedule(schedule *models.WorkerJobSchedule) (*models.WorkerJobSchedule, error)
+
+	UpdateWorkerJobSchedule(schedule *models.WorkerJobSchedule) (*models.WorkerJobSchedule, error)
+
+	// AcquireWorkerLease acquires or renews the lease with the given name for the holder,
+	// and returns false if the lease is held by another holder which has not expired
+	AcquireWorkerLease(name, holder string, ttl time.Duration) (bool, error)
+	ReleaseWorkerLease(name, holder string) error
+}
@@ -0,0 +1,76 @@
+// Package secretstore reads secrets from external secret stores, so that env group
+// variables can reference values that are managed outside of Porter.
+package secretstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrSecretNotFound is returned when there is no secret at a path
+var ErrSecretNotFound = errors.New("secret not found")
+
+// Secret is a set of key-value pairs stored at a path
+type Secret struct {
+	Data map[string]string
+
+	// Version identifies the revision of the secret. It changes whenever the secret is
+	// written to.
+	Version string
+}
+
+// Store is an external secret store
+type Store interface {
+	// GetSecret returns the latest version of the secret at a path, or ErrSecretNotFound
+	GetSecret(ctx context.Context, path string) (*Secret, error)
+}
+
+// ErrInvalidSecretPath is returned for secret paths which are absolute, or which contain
+// empty, "." or ".." segments
+var ErrInvalidSecretPath = errors.New("invalid secret path")
+
+// ValidatePath checks that a secret path is a relative path without empty, "." or ".."
+// segments, so that it cannot reach outside of the prefix it is read under
+func ValidatePath(path string) error {
+	if path == "" {
+		return fmt.Errorf("%w: secret path cannot be empty", ErrInvalidSecretPath)
+	}
+
+	if strings.HasPrefix(path, "/") {
+		return fmt.Errorf("%w: secret path %s must be relative", ErrInvalidSecretPath, path)
+	}
+
+	for _, segment := range strings.Split(path, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return fmt.Errorf("%w: secret path %s cannot contain empty, \".\" or \"..\" segments", ErrInvalidSecretPath, path)
+		}
+	}
+
+	return nil
+}
+
+// projectStore reads the secrets of a single project, which are stored under the
+// projects/<project_id> prefix of a store shared by all projects
+type projectStore struct {
+	store  Store
+	prefix string
+}
+
+// NewProjectStore returns a Store which only reads the secrets of a project. Secret paths
+// are relative to the projects/<project_id> prefix of the underlying store.
+func NewProjectStore(store Store, projectID uint) Store {
+	return &projectStore{
+		store:  store,
+		prefix: fmt.Sprintf("projects/%d", projectID),
+	}
+}
+
+func (s *projectStore) GetSecret(ctx context.Context, path string) (*Secret, error) {
+	if err := ValidatePath(path); err != nil {
+		return nil, err
+	}
+
+	return s.store.GetSecret(ctx, s.prefix+"/"+path)
+}
@@ -0,0 +1,165 @@
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// VaultKVv2Store reads secrets from a HashiCorp Vault KV version 2 secrets engine
+type VaultKVv2Store struct {
+	address    string
+	token      string
+	mount      string
+	namespace  string
+	httpClient *http.Client
+}
+
+// VaultKVv2Opts are the options to connect to a Vault KV version 2 secrets engine
+type VaultKVv2Opts struct {
+	// Address of the Vault server, such as https://vault.example.com:8200
+	Address string
+
+	// Token is a Vault token with read access to the secrets
+	Token string
+
+	// Mount is the path the secrets engine is mounted at. Defaults to "secret".
+	Mount string
+
+	// Namespace is the Vault Enterprise namespace of the secrets engine, if any
+	Namespace string
+}
+
+// NewVaultKVv2Store returns a Store which reads secrets from a Vault KV version 2
+// secrets engine
+func NewVaultKVv2Store(opts *VaultKVv2Opts) *VaultKVv2Store {
+	mount := strings.Trim(opts.Mount, "/")
+
+	if mount == "" {
+		mount = "secret"
+	}
+
+	return &VaultKVv2Store{
+		address:   strings.TrimRight(opts.Address, "/"),
+		token:     opts.Token,
+		mount:     mount,
+		namespace: opts.Namespace,
+		httpClient: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+type vaultKVv2ReadResponse struct {
+	Data struct {
+		Data     map[string]interface{} `json:"data"`
+		Metadata struct {
+			Version      int    `json:"version"`
+			DeletionTime string `json:"deletion_time"`
+			Destroyed    bool   `json:"destroyed"`
+		} `json:"metadata"`
+	} `json:"data"`
+}
+
+type vaultErrorResponse struct {
+	Errors []string `json:"errors"`
+}
+
+func (s *VaultKVv2Store) GetSecret(ctx context.Context, path string) (*Secret, error) {
+	if err := ValidatePath(path); err != nil {
+		return nil, err
+	}
+
+	reqURL := fmt.Sprintf("%s/v1/%s/data/%s", s.address, url.PathEscape(s.mount), escapePath(path))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL, nil)
+
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("X-Vault-Token", s.token)
+
+	if s.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", s.namespace)
+	}
+
+	res, err := s.httpClient.Do(req)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading secret %s from vault: %w", path, err)
+	}
+
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+
+	if err != nil {
+		return nil, fmt.Errorf("error reading secret %s from vault: %w", path, err)
+	}
+
+	if res.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, path)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		errRes := &vaultErrorResponse{}
+		json.Unmarshal(body, errRes)
+
+		return nil, fmt.Errorf(
+			"error reading secret %s from vault: status code %d: %s",
+			path, res.StatusCode, strings.Join(errRes.Errors, ", "),
+		)
+	}
+
+	readRes := &vaultKVv2ReadResponse{}
+
+	if err := json.Unmarshal(body, readRes); err != nil {
+		return nil, fmt.Errorf("error decoding secret %s from vault: %w", path, err)
+	}
+
+	// the latest version of a deleted secret is returned without data
+	if readRes.Data.Data == nil || readRes.Data.Metadata.Destroyed || readRes.Data.Metadata.DeletionTime != "" {
+		return nil, fmt.Errorf("%w: %s", ErrSecretNotFound, path)
+	}
+
+	data := make(map[string]string)
+
+	for key, val := range readRes.Data.Data {
+		switch v := val.(type) {
+		case string:
+			data[key] = v
+		default:
+			// values which are not strings are kept as JSON, for example numbers or
+			// nested objects
+			encoded, err := json.Marshal(v)
+
+			if err != nil {
+				return nil, fmt.Errorf("error encoding key %s of secret %s: %w", key, path, err)
+			}
+
+			data[key] = string(encoded)
+		}
+	}
+
+	return &Secret{
+		Data:    data,
+		Version: fmt.Sprintf("%d", readRes.Data.Metadata.Version),
+	}, nil
+}
+
+// escapePath escapes each segment of a secret path
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.Join(segments, "/")
+}
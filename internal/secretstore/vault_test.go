@@ -0,0 +1,234 @@
+package secretstore_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/secretstore"
+)
+
+func newTestVaultServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/kv/data/apps/web", "/v1/kv/data/projects/1/apps/web":
+			w.Write([]byte(`{
+				"data": {
+					"data": {"DATABASE_URL": "postgres://db", "PORT": 8080},
+					"metadata": {"version": 3, "deletion_time": "", "destroyed": false}
+				}
+			}`))
+		case "/v1/kv/data/apps/deleted":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{
+				"data": {
+					"data": null,
+					"metadata": {"version": 2, "deletion_time": "2023-01-01T00:00:00Z", "destroyed": false}
+				}
+			}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+}
+
+func TestVaultKVv2StoreGetSecret(t *testing.T) {
+	server := newTestVaultServer(t)
+	defer server.Close()
+
+	store := secretstore.NewVaultKVv2Store(&secretstore.VaultKVv2Opts{
+		Address: server.URL,
+		Token:   "test-token",
+		Mount:   "kv",
+	})
+
+	secret, err := store.GetSecret(context.Background(), "apps/web")
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if secret.Version != "3" {
+		t.Errorf("expected version 3, got %s", secret.Version)
+	}
+
+	if secret.Data["DATABASE_URL"] != "postgres://db" {
+		t.Errorf("expected DATABASE_URL to be postgres://db, got %s", secret.Data["DATABASE_URL"])
+	}
+
+	if secret.Data["PORT"] != "8080" {
+		t.Errorf("expected PORT to be 8080, got %s", secret.Data["PORT"])
+	}
+}
+
+func TestVaultKVv2StoreSecretNotFound(t *testing.T) {
+	server := newTestVaultServer(t)
+	defer server.Close()
+
+	store := secretstore.NewVaultKVv2Store(&secretstore.VaultKVv2Opts{
+		Address: server.URL,
+		Token:   "test-token",
+		Mount:   "kv",
+	})
+
+	for _, path := range []string{"apps/missing", "apps/deleted"} {
+		_, err := store.GetSecret(context.Background(), path)
+
+		if !errors.Is(err, secretstore.ErrSecretNotFound) {
+			t.Errorf("expected secret not found error for %s, got %v", path, err)
+		}
+	}
+}
+
+func TestVaultKVv2StoreInvalidPath(t *testing.T) {
+	server := newTestVaultServer(t)
+	defer server.Close()
+
+	store := secretstore.NewVaultKVv2Store(&secretstore.VaultKVv2Opts{
+		Address: server.URL,
+		Token:   "test-token",
+		Mount:   "kv",
+	})
+
+	for _, path := range []string{"", "/apps/web", "apps/../web", "apps/./web", "apps//web", "apps/web/"} {
+		_, err := store.GetSecret(context.Background(), path)
+
+		if !errors.Is(err, secretstore.ErrInvalidSecretPath) {
+			t.Errorf("expected invalid path error for %q, got %v", path, err)
+		}
+	}
+}
+
+func TestProjectStore(t *testing.T) {
+	server := newTestVaultServer(t)
+	defer server.Close()
+
+	store := secretstore.NewVaultKVv2Store(&secretstore.VaultKVv2Opts{
+		Address: server.URL,
+		Token:   "test-token",
+		Mount:   "kv",
+	})
+
+	secret, err := secretstore.NewProjectStore(store, 1).GetSecret(context.Background(), "apps/web")
+
+	if err != nil || secret.Version != "3" {
+		t.Errorf("expected to read the secret of project 1, got %v", err)
+	}
+
+	// the secret is only stored under the prefix of project 1
+	_, err = secretstore.NewProjectStore(store, 2).GetSecret(context.Background(), "apps/web")
+
+	if !errors.Is(err, secretstore.ErrSecretNotFound) {
+		t.Errorf("expected secret not found error for project 2, got %v", err)
+	}
+
+	_, err = secretstore.NewProjectStore(store, 1).GetSecret(context.Background(), "../../apps/web")
+
+	if !errors.Is(err, secretstore.ErrInvalidSecretPath) {
+		t.Errorf("expected invalid path error, got %v", err)
+	}
+}
+
+func TestVaultKVv2StorePermissionDenied(t *testing.T) {
+	server := newTestVaultServer(t)
+	defer server.Close()
+
+	store := secretstore.NewVaultKVv2Store(&secretstore.VaultKVv2Opts{
+		Address: server.URL,
+		Token:   "bad-token",
+		Mount:   "kv",
+	})
+
+	_, err := store.GetSecret(context.Background(), "apps/web")
+
+	if err == nil || errors.Is(err, secretstore.ErrSecretNotFound) {
+		t.Fatalf("expected permission error, got %v", err)
+	}
+}
+
+// TestVaultKVv2StoreDevServer runs against a Vault dev server, which can be started with
+// `vault server -dev -dev-root-token-id=root`, if PORTER_TEST_VAULT_ADDR is set
+func TestVaultKVv2StoreDevServer(t *testing.T) {
+	addr := os.Getenv("PORTER_TEST_VAULT_ADDR")
+
+	if addr == "" {
+		t.Skip("PORTER_TEST_VAULT_ADDR is not set")
+	}
+
+	token := os.Getenv("PORTER_TEST_VAULT_TOKEN")
+
+	if token == "" {
+		token = "root"
+	}
+
+	path := fmt.Sprintf("porter-test/%d", time.Now().UnixNano())
+
+	writeSecret := func(value string) {
+		body, _ := json.Marshal(map[string]interface{}{
+			"data": map[string]string{"KEY": value},
+		})
+
+		req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/v1/secret/data/%s", addr, path), bytes.NewReader(body))
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		req.Header.Set("X-Vault-Token", token)
+
+		res, err := http.DefaultClient.Do(req)
+
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		res.Body.Close()
+
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("could not write secret to vault: status code %d", res.StatusCode)
+		}
+	}
+
+	store := secretstore.NewVaultKVv2Store(&secretstore.VaultKVv2Opts{
+		Address: addr,
+		Token:   token,
+	})
+
+	writeSecret("first")
+
+	first, err := store.GetSecret(context.Background(), path)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	writeSecret("second")
+
+	second, err := store.GetSecret(context.Background(), path)
+
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if first.Data["KEY"] != "first" || second.Data["KEY"] != "second" {
+		t.Errorf("unexpected secret values %s and %s", first.Data["KEY"], second.Data["KEY"])
+	}
+
+	if first.Version == second.Version {
+		t.Errorf("expected version to change after a write, got %s twice", first.Version)
+	}
+}
@@ -0,0 +1,50 @@
+package worker
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCronScheduleNext(t *testing.T) {
+	// a Wednesday
+	from := time.Date(2022, time.June, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"* * * * *", time.Date(2022, time.June, 15, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2022, time.June, 15, 10, 45, 0, 0, time.UTC)},
+		{"0 * * * *", time.Date(2022, time.June, 15, 11, 0, 0, 0, time.UTC)},
+		{"@daily", time.Date(2022, time.June, 16, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * 1-5", time.Date(2022, time.June, 16, 9, 0, 0, 0, time.UTC)},
+		{"0 0 * * 7", time.Date(2022, time.June, 19, 0, 0, 0, 0, time.UTC)},
+		{"30 2 1 * *", time.Date(2022, time.July, 1, 2, 30, 0, 0, time.UTC)},
+		{"0 0 29 2 *", time.Date(2024, time.February, 29, 0, 0, 0, 0, time.UTC)},
+		// when both day fields are restricted, either of them matches
+		{"0 0 1 * 5", time.Date(2022, time.June, 17, 0, 0, 0, 0, time.UTC)},
+		// fields starting with "*" are not restricted, so both day fields have to match
+		{"0 0 */2 * 1", time.Date(2022, time.June, 27, 0, 0, 0, 0, time.UTC)},
+		{"0 0 1 * */2", time.Date(2022, time.September, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		s, err := ParseCronSchedule(tt.expr)
+
+		if err != nil {
+			t.Fatalf("unexpected error parsing %q: %v", tt.expr, err)
+		}
+
+		if next := s.Next(from); !next.Equal(tt.expected) {
+			t.Errorf("expected next run of %q to be %s, got %s", tt.expr, tt.expected, next)
+		}
+	}
+}
+
+func TestParseCronScheduleErrors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "*/0 * * * *", "5-1 * * * *", "a * * * *", "0 0 31 2 *", "0 0 30 2 *"} {
+		if _, err := ParseCronSchedule(expr); err == nil {
+			t.Errorf("expected an error parsing %q", expr)
+		}
+	}
+}
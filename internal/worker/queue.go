@@ -0,0 +1,329 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// ErrJobNotRetryable is returned when retrying a job which has not been moved to the
+// dead-letter state
+var ErrJobNotRetryable = errors.New("only dead jobs can be retried")
+
+// JobFactory constructs the job to run for a job in the persistent queue
+type JobFactory func(record *models.WorkerJob) (Job, error)
+
+// PersistentQueueOpts are the options of a PersistentQueue. Zero values are replaced
+// by the defaults.
+type PersistentQueueOpts struct {
+	Repo   repository.WorkerJobRepository
+	NewJob JobFactory
+
+	// MaxConcurrent is the maximum number of jobs which are claimed at the same time,
+	// which should match the number of workers
+	MaxConcurrent int
+
+	// MaxAttempts is the number of attempts of a job before it is marked as dead
+	MaxAttempts uint
+
+	// The delay before the first retry of a failed job, doubled with every attempt
+	// up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+
+	PollInterval      time.Duration
+	HeartbeatInterval time.Duration
+
+	// StaleTimeout is the time after which a running job without a heartbeat is considered
+	// to be abandoned by its worker, and is requeued
+	StaleTimeout time.Duration
+}
+
+// PersistentQueue stores jobs in the database, so that they survive restarts of the worker
+// pool, and feeds them to a Dispatcher. Failed jobs are retried with an exponential backoff,
+// and are marked as dead once they run out of attempts.
+type PersistentQueue struct {
+	opts     *PersistentQueueOpts
+	workerID string
+
+	slots    chan struct{}
+	exitChan chan bool
+}
+
+func NewPersistentQueue(opts *PersistentQueueOpts) (*PersistentQueue, error) {
+	if opts.Repo == nil || opts.NewJob == nil {
+		return nil, fmt.Errorf("a repository and a job factory are required")
+	}
+
+	if opts.MaxConcurrent <= 0 {
+		opts.MaxConcurrent = 1
+	}
+
+	if opts.MaxAttempts == 0 {
+		opts.MaxAttempts = 5
+	}
+
+	if opts.InitialBackoff == 0 {
+		opts.InitialBackoff = 30 * time.Second
+	}
+
+	if opts.MaxBackoff == 0 {
+		opts.MaxBackoff = time.Hour
+	}
+
+	if opts.PollInterval == 0 {
+		opts.PollInterval = 5 * time.Second
+	}
+
+	if opts.HeartbeatInterval == 0 {
+		opts.HeartbeatInterval = 30 * time.Second
+	}
+
+	if opts.StaleTimeout == 0 {
+		opts.StaleTimeout = 4 * opts.HeartbeatInterval
+	}
+
+	workerID, err := uuid.NewUUID()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &PersistentQueue{
+		opts:     opts,
+		workerID: workerID.String(),
+		slots:    make(chan struct{}, opts.MaxConcurrent),
+		exitChan: make(chan bool),
+	}, nil
+}
+
+// Enqueue stores a new job with the given JSON input
+func (q *PersistentQueue) Enqueue(jobID string, input []byte) (*models.WorkerJob, error) {
+	return q.opts.Repo.CreateWorkerJob(&models.WorkerJob{
+		JobID:       jobID,
+		Input:       input,
+		Status:      types.WorkerJobStatusQueued,
+		MaxAttempts: q.opts.MaxAttempts,
+		RunAt:       time.Now().UTC(),
+	})
+}
+
+// Retry requeues a dead job with a fresh set of attempts
+func (q *PersistentQueue) Retry(id uint) (*models.WorkerJob, error) {
+	record, err := q.opts.Repo.ReadWorkerJob(id)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if record.Status != types.WorkerJobStatusDead {
+		return nil, ErrJobNotRetryable
+	}
+
+	record.Status = types.WorkerJobStatusQueued
+	record.Attempts = 0
+	record.MaxAttempts = q.opts.MaxAttempts
+	record.RunAt = time.Now().UTC()
+	record.FinishedAt = nil
+
+	return q.opts.Repo.UpdateWorkerJob(record)
+}
+
+// Run starts claiming jobs which are due and sending them to the job queue of a Dispatcher
+func (q *PersistentQueue) Run(jobQueue chan Job) {
+	go func() {
+		ticker := time.NewTicker(q.opts.PollInterval)
+		defer ticker.Stop()
+
+		for {
+			// wait for a free slot, so that jobs are only claimed when a worker can run them
+			select {
+			case q.slots <- struct{}{}:
+			case <-q.exitChan:
+				return
+			}
+
+			job, err := q.claim()
+
+			if err != nil {
+				<-q.slots
+
+				if !errors.Is(err, gorm.ErrRecordNotFound) {
+					log.Printf("error claiming job: %v", err)
+				}
+
+				select {
+				case <-ticker.C:
+					continue
+				case <-q.exitChan:
+					return
+				}
+			}
+
+			select {
+			case jobQueue <- job:
+			case <-q.exitChan:
+				// the job is requeued by another worker once its heartbeat is stale
+				job.stop()
+				<-q.slots
+
+				return
+			}
+		}
+	}()
+}
+
+// Exit stops claiming jobs. Jobs which were already claimed still run.
+func (q *PersistentQueue) Exit() {
+	q.exitChan <- true
+}
+
+func (q *PersistentQueue) claim() (*queuedJob, error) {
+	_, err := q.opts.Repo.RequeueStaleWorkerJobs(time.Now().UTC().Add(-q.opts.StaleTimeout))
+
+	if err != nil {
+		log.Printf("error requeuing stale jobs: %v", err)
+	}
+
+	record, err := q.opts.Repo.ClaimWorkerJob(q.workerID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("claimed job %d with ID '%s', attempt %d of %d", record.ID, record.JobID, record.Attempts, record.MaxAttempts)
+
+	job := &queuedJob{
+		queue:  q,
+		record: record,
+		done:   make(chan struct{}),
+	}
+
+	job.inner, err = q.opts.NewJob(record)
+
+	if err != nil {
+		// jobs which cannot be constructed are still sent to a worker, so that the failure
+		// is recorded and retried like any other failure
+		job.initErr = fmt.Errorf("error creating job: %w", err)
+	}
+
+	go job.heartbeat()
+
+	return job, nil
+}
+
+// complete records the result of an attempt
+func (q *PersistentQueue) complete(record *models.WorkerJob, runErr error) {
+	now := time.Now().UTC()
+
+	record.LockedBy = ""
+
+	if runErr == nil {
+		record.Status = types.WorkerJobStatusSucceeded
+		record.LastError = ""
+		record.FinishedAt = &now
+	} else if record.Attempts >= record.MaxAttempts {
+		log.Printf("job %d with ID '%s' failed all %d attempts: %v", record.ID, record.JobID, record.Attempts, runErr)
+
+		record.Status = types.WorkerJobStatusDead
+		record.LastError = runErr.Error()
+		record.FinishedAt = &now
+	} else {
+		record.Status = types.WorkerJobStatusQueued
+		record.LastError = runErr.Error()
+		record.RunAt = now.Add(q.getBackoff(record.Attempts))
+	}
+
+	ok, err := q.opts.Repo.CompleteWorkerJob(record, q.workerID)
+
+	if err != nil {
+		log.Printf("error recording result of job %d: %v", record.ID, err)
+	} else if !ok {
+		// the job was requeued while this attempt was running, so the result of the attempt
+		// which currently holds the job takes precedence
+		log.Printf("discarding result of attempt %d of job %d, which is no longer held by this worker",
+			record.Attempts, record.ID)
+	}
+}
+
+func (q *PersistentQueue) getBackoff(attempts uint) time.Duration {
+	backoff := q.opts.InitialBackoff
+
+	for i := uint(1); i < attempts && backoff < q.opts.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > q.opts.MaxBackoff {
+		return q.opts.MaxBackoff
+	}
+
+	return backoff
+}
+
+// queuedJob wraps a job claimed from the persistent queue, and records its result
+type queuedJob struct {
+	queue   *PersistentQueue
+	record  *models.WorkerJob
+	inner   Job
+	initErr error
+
+	done chan struct{}
+}
+
+func (j *queuedJob) ID() string {
+	return fmt.Sprintf("%s-%d", j.record.JobID, j.record.ID)
+}
+
+func (j *queuedJob) EnqueueTime() time.Time {
+	return j.record.CreatedAt
+}
+
+func (j *queuedJob) Run() error {
+	defer func() {
+		j.stop()
+		<-j.queue.slots
+	}()
+
+	err := j.initErr
+
+	if err == nil {
+		err = j.inner.Run()
+	}
+
+	j.queue.complete(j.record, err)
+
+	return err
+}
+
+func (j *queuedJob) SetData(data []byte) {
+	if j.inner != nil {
+		j.inner.SetData(data)
+	}
+}
+
+func (j *queuedJob) heartbeat() {
+	ticker := time.NewTicker(j.queue.opts.HeartbeatInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := j.queue.opts.Repo.HeartbeatWorkerJob(j.record.ID, j.queue.workerID); err != nil {
+				log.Printf("error sending heartbeat for job %d: %v", j.record.ID, err)
+			}
+		case <-j.done:
+			return
+		}
+	}
+}
+
+func (j *queuedJob) stop() {
+	close(j.done)
+}
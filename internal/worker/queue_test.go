@@ -0,0 +1,203 @@
+package worker
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/repository/test"
+)
+
+type testJob struct {
+	runs     *int32
+	failures int32
+}
+
+func (j *testJob) ID() string             { return "test" }
+func (j *testJob) EnqueueTime() time.Time { return time.Now() }
+func (j *testJob) SetData([]byte)         {}
+
+func (j *testJob) Run() error {
+	if atomic.AddInt32(j.runs, 1) <= j.failures {
+		return errors.New("failed")
+	}
+
+	return nil
+}
+
+func waitForStatus(t *testing.T, repo repository.WorkerJobRepository, id uint, status types.WorkerJobStatus) *models.WorkerJob {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for time.Now().Before(deadline) {
+		record, err := repo.ReadWorkerJob(id)
+
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		if record.Status == status {
+			return record
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Fatalf("job %d did not reach status %s", id, status)
+
+	return nil
+}
+
+func TestPersistentQueue(t *testing.T) {
+	tests := []struct {
+		name             string
+		failures         int32
+		expectedStatus   types.WorkerJobStatus
+		expectedAttempts uint
+	}{
+		{"job succeeds after retries", 2, types.WorkerJobStatusSucceeded, 3},
+		{"job is dead after all attempts", 5, types.WorkerJobStatusDead, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var runs int32
+
+			repo := test.NewWorkerJobRepository(true)
+
+			q, err := NewPersistentQueue(&PersistentQueueOpts{
+				Repo: repo,
+				NewJob: func(record *models.WorkerJob) (Job, error) {
+					return &testJob{runs: &runs, failures: tt.failures}, nil
+				},
+				MaxConcurrent:  1,
+				MaxAttempts:    3,
+				InitialBackoff: time.Millisecond,
+				PollInterval:   5 * time.Millisecond,
+			})
+
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			jobQueue := make(chan Job)
+
+			d := NewDispatcher(1)
+
+			if err := d.Run(jobQueue); err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			q.Run(jobQueue)
+
+			record, err := q.Enqueue("test", nil)
+
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			record = waitForStatus(t, repo, record.ID, tt.expectedStatus)
+
+			q.Exit()
+			d.Exit()
+
+			if record.Attempts != tt.expectedAttempts {
+				t.Errorf("expected %d attempts, got %d", tt.expectedAttempts, record.Attempts)
+			}
+
+			if tt.expectedStatus == types.WorkerJobStatusDead {
+				if _, err := q.Retry(record.ID); err != nil {
+					t.Errorf("expected dead job to be retryable, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestPersistentQueueRequeuesStaleJobs(t *testing.T) {
+	repo := test.NewWorkerJobRepository(true)
+
+	record, _ := repo.CreateWorkerJob(&models.WorkerJob{
+		JobID:       "test",
+		Status:      types.WorkerJobStatusQueued,
+		MaxAttempts: 3,
+	})
+
+	// simulate a worker which stopped while running the job
+	if _, err := repo.ClaimWorkerJob("stopped-worker"); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	count, err := repo.RequeueStaleWorkerJobs(time.Now().Add(time.Second))
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if count != 1 {
+		t.Fatalf("expected 1 stale job, got %d", count)
+	}
+
+	waitForStatus(t, repo, record.ID, types.WorkerJobStatusQueued)
+}
+
+func TestPersistentQueueDiscardsResultOfStaleAttempt(t *testing.T) {
+	repo := test.NewWorkerJobRepository(true)
+
+	q, err := NewPersistentQueue(&PersistentQueueOpts{
+		Repo: repo,
+		NewJob: func(record *models.WorkerJob) (Job, error) {
+			return &testJob{runs: new(int32)}, nil
+		},
+		MaxAttempts: 3,
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	record, _ := q.Enqueue("test", nil)
+
+	job, err := q.claim()
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	job.stop()
+
+	// the attempt misses its heartbeat, and the job is claimed by another worker
+	if _, err := repo.RequeueStaleWorkerJobs(time.Now().Add(time.Second)); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if _, err := repo.ClaimWorkerJob("other-worker"); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	q.complete(job.record, errors.New("failed"))
+
+	record, err = repo.ReadWorkerJob(record.ID)
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if record.Status != types.WorkerJobStatusRunning || record.LockedBy != "other-worker" || record.LastError == "failed" {
+		t.Errorf("expected the job to still be held by the other worker, got status %s held by %s with error %q",
+			record.Status, record.LockedBy, record.LastError)
+	}
+
+	// the attempt of the other worker is recorded
+	record.Status = types.WorkerJobStatusSucceeded
+	record.LockedBy = ""
+
+	if ok, err := repo.CompleteWorkerJob(record, "other-worker"); err != nil || !ok {
+		t.Errorf("expected the current attempt to be recorded, got %t, %v", ok, err)
+	}
+}
@@ -0,0 +1,280 @@
+package worker
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+const schedulerLeaseName = "scheduler"
+
+// ScheduledJob is a job which is enqueued on a cron schedule, evaluated in UTC
+type ScheduledJob struct {
+	JobID    string
+	Schedule string
+	Input    []byte
+
+	cron *CronSchedule
+}
+
+// SchedulerOpts are the options of a Scheduler. Zero values are replaced by the defaults.
+type SchedulerOpts struct {
+	Repo  repository.WorkerJobRepository
+	Queue *PersistentQueue
+	Jobs  []*ScheduledJob
+
+	// LeaseTTL is the time after which another replica becomes the leader if the
+	// current leader stops renewing its lease
+	LeaseTTL time.Duration
+
+	TickInterval time.Duration
+}
+
+// Scheduler enqueues jobs in the persistent queue on their cron schedules. Only the replica of
+// the worker pool which holds the scheduler lease enqueues jobs, and a run is skipped if the
+// previous job with the same ID is still queued or running. Runs which were missed while no
+// replica was the leader are caught up with a single run.
+type Scheduler struct {
+	opts *SchedulerOpts
+	id   string
+
+	mu     sync.Mutex
+	leader bool
+
+	exitChan chan bool
+}
+
+func NewScheduler(opts *SchedulerOpts) (*Scheduler, error) {
+	if opts.Repo == nil || opts.Queue == nil {
+		return nil, fmt.Errorf("a repository and a queue are required")
+	}
+
+	if opts.LeaseTTL == 0 {
+		opts.LeaseTTL = time.Minute
+	}
+
+	if opts.TickInterval == 0 {
+		opts.TickInterval = 15 * time.Second
+	}
+
+	for _, job := range opts.Jobs {
+		cron, err := ParseCronSchedule(job.Schedule)
+
+		if err != nil {
+			return nil, fmt.Errorf("invalid schedule for job %s: %w", job.JobID, err)
+		}
+
+		job.cron = cron
+
+		// the schedule record is created on the first start, so that the first run happens
+		// on the schedule instead of at startup. Another replica may create it at the same
+		// time, in which case its record is used.
+		_, err = opts.Repo.ReadWorkerJobSchedule(job.JobID)
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			_, err = opts.Repo.CreateWorkerJobSchedule(&models.WorkerJobSchedule{JobID: job.JobID})
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading schedule of job %s: %w", job.JobID, err)
+		}
+	}
+
+	id, err := uuid.NewUUID()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &Scheduler{
+		opts:     opts,
+		id:       id.String(),
+		exitChan: make(chan bool),
+	}, nil
+}
+
+// Run starts evaluating the schedules in the background
+func (s *Scheduler) Run() {
+	go func() {
+		ticker := time.NewTicker(s.opts.TickInterval)
+		defer ticker.Stop()
+
+		for {
+			s.tick()
+
+			select {
+			case <-ticker.C:
+			case <-s.exitChan:
+				// let another replica take over without waiting for the lease to expire
+				if err := s.opts.Repo.ReleaseWorkerLease(schedulerLeaseName, s.id); err != nil {
+					log.Printf("error releasing scheduler lease: %v", err)
+				}
+
+				return
+			}
+		}
+	}()
+}
+
+// Exit stops the scheduler
+func (s *Scheduler) Exit() {
+	s.exitChan <- true
+}
+
+// IsLeader returns true if this replica enqueues the scheduled jobs
+func (s *Scheduler) IsLeader() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.leader
+}
+
+func (s *Scheduler) setLeader(leader bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if leader != s.leader {
+		log.Printf("scheduler %s leadership changed, leader: %t", s.id, leader)
+	}
+
+	s.leader = leader
+}
+
+func (s *Scheduler) tick() {
+	leader, err := s.opts.Repo.AcquireWorkerLease(schedulerLeaseName, s.id, s.opts.LeaseTTL)
+
+	if err != nil {
+		log.Printf("error acquiring scheduler lease: %v", err)
+		leader = false
+	}
+
+	s.setLeader(leader)
+
+	if !leader {
+		return
+	}
+
+	now := time.Now().UTC()
+
+	for _, job := range s.opts.Jobs {
+		record, err := s.opts.Repo.ReadWorkerJobSchedule(job.JobID)
+
+		if err != nil {
+			log.Printf("error reading schedule of job %s: %v", job.JobID, err)
+			continue
+		}
+
+		next := job.cron.Next(lastScheduledAt(record))
+
+		// a zero time means that the schedule never matches again
+		if next.IsZero() || now.Before(next) {
+			continue
+		}
+
+		s.run(job, record, now)
+	}
+}
+
+func (s *Scheduler) run(job *ScheduledJob, record *models.WorkerJobSchedule, now time.Time) {
+	record.LastRunAt = &now
+	record.LastError = ""
+
+	active, err := s.hasActiveJob(job.JobID)
+
+	if err != nil {
+		record.LastResult = types.WorkerJobScheduleFailed
+		record.LastError = err.Error()
+	} else if active {
+		log.Printf("skipping scheduled run of job %s, since the previous run is still queued or running", job.JobID)
+
+		record.LastResult = types.WorkerJobScheduleSkipped
+	} else {
+		queued, err := s.opts.Queue.Enqueue(job.JobID, job.Input)
+
+		if err != nil {
+			record.LastResult = types.WorkerJobScheduleFailed
+			record.LastError = err.Error()
+		} else {
+			log.Printf("enqueued scheduled run of job %s as job %d", job.JobID, queued.ID)
+
+			record.LastResult = types.WorkerJobScheduleEnqueued
+			record.LastWorkerJobID = queued.ID
+		}
+	}
+
+	if _, err := s.opts.Repo.UpdateWorkerJobSchedule(record); err != nil {
+		log.Printf("error updating schedule of job %s: %v", job.JobID, err)
+	}
+}
+
+func (s *Scheduler) hasActiveJob(jobID string) (bool, error) {
+	for _, status := range []types.WorkerJobStatus{types.WorkerJobStatusQueued, types.WorkerJobStatusRunning} {
+		jobs, err := s.opts.Repo.ListWorkerJobs(&types.ListWorkerJobsRequest{
+			Status: status,
+			JobID:  jobID,
+			Limit:  1,
+		})
+
+		if err != nil {
+			return false, err
+		}
+
+		if len(jobs) > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Status returns the last and next runs of all scheduled jobs
+func (s *Scheduler) Status() (*types.ListWorkerJobSchedulesResponse, error) {
+	res := &types.ListWorkerJobSchedulesResponse{
+		Leader:    s.IsLeader(),
+		Schedules: make([]*types.WorkerJobSchedule, 0),
+	}
+
+	for _, job := range s.opts.Jobs {
+		record, err := s.opts.Repo.ReadWorkerJobSchedule(job.JobID)
+
+		if err != nil {
+			return nil, err
+		}
+
+		schedule := &types.WorkerJobSchedule{
+			JobID:      job.JobID,
+			Schedule:   job.Schedule,
+			LastRunAt:  record.LastRunAt,
+			LastResult: record.LastResult,
+			LastError:  record.LastError,
+			NextRunAt:  job.cron.Next(lastScheduledAt(record)),
+		}
+
+		if record.LastWorkerJobID != 0 {
+			if queued, err := s.opts.Repo.ReadWorkerJob(record.LastWorkerJobID); err == nil {
+				schedule.LastWorkerJob = queued.ToWorkerJobType()
+			}
+		}
+
+		res.Schedules = append(res.Schedules, schedule)
+	}
+
+	return res, nil
+}
+
+// lastScheduledAt returns the time from which the next run of a schedule is computed
+func lastScheduledAt(record *models.WorkerJobSchedule) time.Time {
+	if record.LastRunAt != nil {
+		return record.LastRunAt.UTC()
+	}
+
+	return record.CreatedAt.UTC()
+}
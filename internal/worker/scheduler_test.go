@@ -0,0 +1,159 @@
+package worker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/repository/test"
+)
+
+func newTestScheduler(t *testing.T, repo repository.WorkerJobRepository) *Scheduler {
+	t.Helper()
+
+	q, err := NewPersistentQueue(&PersistentQueueOpts{
+		Repo: repo,
+		NewJob: func(record *models.WorkerJob) (Job, error) {
+			return nil, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	s, err := NewScheduler(&SchedulerOpts{
+		Repo:  repo,
+		Queue: q,
+		Jobs: []*ScheduledJob{
+			{JobID: "test", Schedule: "* * * * *"},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	return s
+}
+
+// setLastRun moves the last run of the test job into the past, so that the job is due
+func setLastRun(t *testing.T, repo repository.WorkerJobRepository, lastRun time.Time) {
+	t.Helper()
+
+	record, err := repo.ReadWorkerJobSchedule("test")
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	record.LastRunAt = &lastRun
+
+	if _, err := repo.UpdateWorkerJobSchedule(record); err != nil {
+		t.Fatalf("%v", err)
+	}
+}
+
+func TestScheduler(t *testing.T) {
+	repo := test.NewWorkerJobRepository(true)
+
+	leader := newTestScheduler(t, repo)
+	follower := newTestScheduler(t, repo)
+
+	// the job is not enqueued before it is due
+	setLastRun(t, repo, time.Now().UTC().Add(time.Minute))
+
+	leader.tick()
+	follower.tick()
+
+	if !leader.IsLeader() || follower.IsLeader() {
+		t.Fatalf("expected exactly one scheduler to be the leader")
+	}
+
+	if jobs, _ := repo.ListWorkerJobs(&types.ListWorkerJobsRequest{}); len(jobs) != 0 {
+		t.Fatalf("expected no jobs to be enqueued before the schedule is due, got %d", len(jobs))
+	}
+
+	setLastRun(t, repo, time.Now().UTC().Add(-2*time.Minute))
+	leader.tick()
+
+	status, err := leader.Status()
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if status.Schedules[0].LastResult != types.WorkerJobScheduleEnqueued || status.Schedules[0].LastWorkerJob == nil {
+		t.Fatalf("expected the job to be enqueued, got %s", status.Schedules[0].LastResult)
+	}
+
+	// the previous job is still queued, so the next run is skipped
+	setLastRun(t, repo, time.Now().UTC().Add(-2*time.Minute))
+	leader.tick()
+
+	status, _ = leader.Status()
+
+	if status.Schedules[0].LastResult != types.WorkerJobScheduleSkipped {
+		t.Errorf("expected the run to be skipped, got %s", status.Schedules[0].LastResult)
+	}
+
+	if jobs, _ := repo.ListWorkerJobs(&types.ListWorkerJobsRequest{}); len(jobs) != 1 {
+		t.Errorf("expected 1 enqueued job, got %d", len(jobs))
+	}
+
+	// the follower takes over once the leader releases its lease
+	if err := repo.ReleaseWorkerLease(schedulerLeaseName, leader.id); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	follower.tick()
+
+	if !follower.IsLeader() {
+		t.Errorf("expected the follower to become the leader")
+	}
+}
+
+func TestSchedulerSkipsSchedulesWhichNeverMatch(t *testing.T) {
+	repo := test.NewWorkerJobRepository(true)
+	s := newTestScheduler(t, repo)
+
+	// schedules which never match are rejected when parsed, but are skipped if they stop
+	// matching within the range searched by Next
+	s.opts.Jobs[0].cron = &CronSchedule{}
+
+	setLastRun(t, repo, time.Now().UTC().Add(-2*time.Minute))
+	s.tick()
+
+	if jobs, _ := repo.ListWorkerJobs(&types.ListWorkerJobsRequest{}); len(jobs) != 0 {
+		t.Errorf("expected no jobs to be enqueued for a schedule which never matches, got %d", len(jobs))
+	}
+}
+
+func TestNewSchedulerRejectsSchedulesWhichNeverMatch(t *testing.T) {
+	repo := test.NewWorkerJobRepository(true)
+
+	q, err := NewPersistentQueue(&PersistentQueueOpts{
+		Repo: repo,
+		NewJob: func(record *models.WorkerJob) (Job, error) {
+			return nil, nil
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, err = NewScheduler(&SchedulerOpts{
+		Repo:  repo,
+		Queue: q,
+		Jobs: []*ScheduledJob{
+			{JobID: "test", Schedule: "0 0 31 2 *"},
+		},
+	})
+
+	if err == nil {
+		t.Errorf("expected an error for a schedule which never matches")
+	}
+}
@@ -0,0 +1,28 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	ptypes "github.com/porter-dev/porter/provisioner/types"
+)
+
+// GetPlan returns the structured plan of a plan operation, once terraform has planned
+// all changes
+func (c *Client) GetPlan(
+	ctx context.Context,
+	workspaceID string,
+) (*ptypes.TFPlan, error) {
+	resp := &ptypes.TFPlan{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/%s/plan/changes",
+			workspaceID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
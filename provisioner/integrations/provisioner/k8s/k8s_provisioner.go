@@ -162,5 +162,19 @@ func (k *KubernetesProvisioner) getTFEnv(opts *provisioner.ProvisionOpts) ([]v1.
 		Value: opts.Kind,
 	})
 
+	if opts.PlanWorkspaceID != "" {
+		env = append(env, v1.EnvVar{
+			Name:  "TF_PLAN_WORKSPACE_ID",
+			Value: opts.PlanWorkspaceID,
+		})
+	}
+
+	if opts.RefreshOnly {
+		env = append(env, v1.EnvVar{
+			Name:  "TF_PLAN_REFRESH_ONLY",
+			Value: "true",
+		})
+	}
+
 	return env, nil
 }
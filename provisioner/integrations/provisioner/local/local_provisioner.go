@@ -69,5 +69,13 @@ func (l *LocalProvisioner) getEnv(opts *provisioner.ProvisionOpts) ([]string, er
 	env = append(env, fmt.Sprintf("TF_VALUES=%s", base64.StdEncoding.EncodeToString(valBytes)))
 	env = append(env, fmt.Sprintf("TF_KIND=%s", opts.Kind))
 
+	if opts.PlanWorkspaceID != "" {
+		env = append(env, fmt.Sprintf("TF_PLAN_WORKSPACE_ID=%s", opts.PlanWorkspaceID))
+	}
+
+	if opts.RefreshOnly {
+		env = append(env, "TF_PLAN_REFRESH_ONLY=true")
+	}
+
 	return env, nil
 }
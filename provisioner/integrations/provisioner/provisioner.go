@@ -4,11 +4,35 @@ import (
 	"github.com/porter-dev/porter/internal/models"
 )
 
+// ProvisionerOperation is the operation which the tf-runner is started with. The runner reads
+// its inputs from the environment set by the provisioner (TF_ORG_ID, TF_VALUES, TF_KIND, ...)
+// and reports back to the provisioner service under /{workspace_id}.
+//
+// Plan operations rely on the following contract with the tf-runner:
+//
+//   - "plan" runs terraform plan -out against the current state, without applying it. The
+//     planned changes are streamed through the StoreLog RPC as "planned_change" logs, followed
+//     by a "change_summary" log with the "plan" operation, which stores the changes for review.
+//   - The binary plan file is then uploaded with POST /{workspace_id}/plan. Storing the plan
+//     marks the operation "completed" and pushes the "planned" status to the global stream,
+//     which cleans up the operation without merging its state into the state of the infra.
+//   - When TF_PLAN_REFRESH_ONLY is set, the plan only contains the changes made to the infra
+//     outside of terraform, as with terraform plan -refresh-only.
+//   - "apply" with TF_PLAN_WORKSPACE_ID set downloads the plan file with
+//     GET /{TF_PLAN_WORKSPACE_ID}/plan and applies it instead of planning again. The plan is
+//     selected with the PlanOperationID of the apply request, and TF_VALUES holds the values
+//     the plan was created with.
+//   - A failing plan is reported with POST /{workspace_id}/error like any other operation, but
+//     leaves the status of the infra untouched.
 type ProvisionerOperation string
 
 const (
 	Apply   ProvisionerOperation = "apply"
 	Destroy ProvisionerOperation = "destroy"
+
+	// Plan only plans the changes to the infra, and uploads the resulting plan file so that it
+	// can be applied once it has been reviewed
+	Plan ProvisionerOperation = "plan"
 )
 
 type ProvisionCredentialExchange struct {
@@ -26,6 +50,14 @@ type ProvisionOpts struct {
 	OperationKind      ProvisionerOperation
 	Kind               string
 	Values             map[string]interface{}
+
+	// PlanWorkspaceID is the workspace of a completed plan operation. If set, an apply
+	// operation applies the plan stored by that operation instead of planning again.
+	PlanWorkspaceID string
+
+	// RefreshOnly makes a plan operation only plan the changes made to the infra outside of
+	// terraform, which are reported as planned changes
+	RefreshOnly bool
 }
 
 type Provisioner interface {
@@ -171,7 +171,7 @@ func GlobalStreamListener(
 			config.Logger.Debug().Msg(fmt.Sprintf("pushing state and log file for %s with status %v", workspaceID, statusVal))
 
 			switch fmt.Sprintf("%v", statusVal) {
-			case "created", "error", "destroyed":
+			case "created", "error", "destroyed", "planned":
 				err := cleanupOperation(config, client, infra, operation, workspaceID)
 
 				if err != nil {
@@ -186,17 +186,21 @@ func GlobalStreamListener(
 
 func cleanupOperation(config *config.Config, client *redis.Client, infra *models.Infra, operation *models.Operation, workspaceID string) error {
 	l := config.Logger
-	l.Debug().Msg(fmt.Sprintf("pushing state for %s", workspaceID))
 
-	err := pushNewStateToStorage(config, client, infra, operation, workspaceID)
+	// plan operations only report planned changes, which must not be merged into the current state
+	if !operation.IsPlan() {
+		l.Debug().Msg(fmt.Sprintf("pushing state for %s", workspaceID))
 
-	if err != nil {
-		return err
+		err := pushNewStateToStorage(config, client, infra, operation, workspaceID)
+
+		if err != nil {
+			return err
+		}
 	}
 
 	l.Debug().Msg(fmt.Sprintf("cleaning state stream for %s", workspaceID))
 
-	err = cleanupStateStream(config, client, workspaceID)
+	err := cleanupStateStream(config, client, workspaceID)
 
 	if err != nil {
 		return nil
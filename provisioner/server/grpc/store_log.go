@@ -1,10 +1,12 @@
 package grpc
 
 import (
+	"encoding/json"
 	"fmt"
 	"io"
 	"strings"
 
+	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/provisioner/integrations/redis_stream"
 	"github.com/porter-dev/porter/provisioner/pb"
 	"github.com/porter-dev/porter/provisioner/types"
@@ -29,6 +31,10 @@ func (s *ProvisionerServer) StoreLog(stream pb.Provisioner_StoreLogServer) error
 		return err
 	}
 
+	// plan and drift detection operations collect the planned changes, which are stored once terraform
+	// has logged the summary of the plan
+	plan := &types.TFPlan{}
+
 	for {
 		tfLog, err := stream.Recv()
 
@@ -46,6 +52,34 @@ func (s *ProvisionerServer) StoreLog(stream pb.Provisioner_StoreLogServer) error
 			return err
 		}
 
+		if operation.IsPlan() {
+			switch logType.Type {
+			case types.PlannedChange:
+				plan.Changes = append(plan.Changes, tfLog.Change)
+			case types.ChangeSummary:
+				if logType.Changes.Operation == "plan" {
+					plan.Summary = tfLog.Changes
+
+					planBytes, err := json.Marshal(plan)
+
+					if err != nil {
+						return err
+					}
+
+					err = s.config.StorageManager.WriteFile(
+						infra,
+						types.GetPlanChangesFileName(models.GetWorkspaceID(infra, operation)),
+						planBytes,
+						false,
+					)
+
+					if err != nil {
+						return err
+					}
+				}
+			}
+		}
+
 		stateUpdate := &types.TFResourceState{}
 
 		switch logType.Type {
@@ -2,6 +2,7 @@ package provision
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -16,6 +17,7 @@ import (
 	"github.com/porter-dev/porter/provisioner/integrations/redis_stream"
 	"github.com/porter-dev/porter/provisioner/server/config"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 
 	ptypes "github.com/porter-dev/porter/provisioner/types"
 )
@@ -47,6 +49,61 @@ func (c *ProvisionApplyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	operationKind := provisioner.Apply
+
+	if req.OperationKind == "plan" || req.OperationKind == "detect_drift" {
+		operationKind = provisioner.Plan
+	}
+
+	// if a plan operation is being applied, make sure that the plan has completed, and apply
+	// it with the values it was created with
+	var planWorkspaceID string
+
+	if req.PlanOperationID != "" {
+		if operationKind == provisioner.Plan {
+			apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("a plan operation cannot apply an existing plan"),
+				http.StatusBadRequest,
+			), true)
+
+			return
+		}
+
+		planOperation, err := c.Config.Repo.Infra().ReadOperation(infra.ID, req.PlanOperationID)
+
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("plan operation %s not found", req.PlanOperationID),
+					http.StatusNotFound,
+				), true)
+
+				return
+			}
+
+			apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrInternal(err), true)
+			return
+		}
+
+		if planOperation.Type != "plan" || planOperation.Status != "completed" {
+			apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("operation %s is not a completed plan operation", req.PlanOperationID),
+				http.StatusBadRequest,
+			), true)
+
+			return
+		}
+
+		planWorkspaceID = models.GetWorkspaceID(infra, planOperation)
+
+		req.Values = make(map[string]interface{})
+
+		if err := json.Unmarshal(planOperation.LastApplied, &req.Values); err != nil {
+			apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrInternal(err), true)
+			return
+		}
+	}
+
 	// create a new operation and write it to the database
 	operationUID, err := models.GetOperationID()
 
@@ -100,7 +157,7 @@ func (c *ProvisionApplyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	err = c.Config.Provisioner.Provision(&provisioner.ProvisionOpts{
 		Infra:         infra,
 		Operation:     operation,
-		OperationKind: provisioner.Apply,
+		OperationKind: operationKind,
 		Kind:          req.Kind,
 		Values:        req.Values,
 		CredentialExchange: &provisioner.ProvisionCredentialExchange{
@@ -112,6 +169,8 @@ func (c *ProvisionApplyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 			CredExchangeToken: rawToken,
 			CredExchangeID:    ceToken.ID,
 		},
+		PlanWorkspaceID: planWorkspaceID,
+		RefreshOnly:     req.OperationKind == "detect_drift",
 	})
 
 	if err != nil {
@@ -126,6 +185,12 @@ func (c *ProvisionApplyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		infra.Status = types.InfraStatus("updating")
 	}
 
+	// applying the infra overwrites changes which were made outside of Porter, so any drift is
+	// cleared until it is detected again
+	if operationKind == provisioner.Apply {
+		infra.DriftStatus = ""
+	}
+
 	infra, err = c.Config.Repo.Infra().UpdateInfra(infra)
 
 	if err != nil {
@@ -143,6 +208,11 @@ func (c *ProvisionApplyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 	// return the operation response type to the server
 	c.resultWriter.WriteResult(w, r, op)
 
+	// plans do not provision anything, so they are not tracked
+	if operationKind == provisioner.Plan {
+		return
+	}
+
 	// if this is a cluster or registry infra type, send to analytics client
 	switch infra.Kind {
 	case types.InfraDOKS, types.InfraEKS, types.InfraGKE, types.InfraAKS:
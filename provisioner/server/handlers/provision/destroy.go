@@ -42,8 +42,8 @@ func (c *ProvisionDestroyHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	// get the values from the previous operation to re-use
-	lastOp, err := c.Config.Repo.Infra().GetLatestOperation(infra)
+	// get the values from the previous operation to re-use, ignoring plans which were never applied
+	lastOp, err := c.Config.Repo.Infra().GetLatestAppliedOperation(infra)
 
 	if err != nil {
 		apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrInternal(err), true)
@@ -0,0 +1,67 @@
+package state
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/provisioner/integrations/storage"
+	"github.com/porter-dev/porter/provisioner/server/config"
+
+	ptypes "github.com/porter-dev/porter/provisioner/types"
+)
+
+type PlanGetHandler struct {
+	Config       *config.Config
+	resultWriter shared.ResultWriter
+}
+
+func NewPlanGetHandler(
+	config *config.Config,
+) *PlanGetHandler {
+	return &PlanGetHandler{
+		Config:       config,
+		resultWriter: shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	}
+}
+
+func (c *PlanGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// read the infra from the attached scope
+	infra, _ := r.Context().Value(types.InfraScope).(*models.Infra)
+	operation, _ := r.Context().Value(types.OperationScope).(*models.Operation)
+
+	fileBytes, err := c.Config.StorageManager.ReadFile(
+		infra,
+		ptypes.GetPlanChangesFileName(models.GetWorkspaceID(infra, operation)),
+		false,
+	)
+
+	if err != nil {
+		// if the plan has not been summarized yet, return a 404 status code
+		if errors.Is(err, storage.FileDoesNotExist) {
+			apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("plan does not exist yet"),
+				http.StatusNotFound,
+			), true)
+
+			return
+		}
+
+		apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrInternal(err), true)
+		return
+	}
+
+	resp := &ptypes.TFPlan{}
+
+	if err := json.Unmarshal(fileBytes, resp); err != nil {
+		apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrInternal(err), true)
+		return
+	}
+
+	c.resultWriter.WriteResult(w, r, resp)
+}
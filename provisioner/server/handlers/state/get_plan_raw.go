@@ -0,0 +1,59 @@
+package state
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/provisioner/integrations/storage"
+	"github.com/porter-dev/porter/provisioner/server/config"
+
+	ptypes "github.com/porter-dev/porter/provisioner/types"
+)
+
+type RawPlanGetHandler struct {
+	Config *config.Config
+}
+
+func NewRawPlanGetHandler(
+	config *config.Config,
+) *RawPlanGetHandler {
+	return &RawPlanGetHandler{
+		Config: config,
+	}
+}
+
+func (c *RawPlanGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// read the infra from the attached scope
+	infra, _ := r.Context().Value(types.InfraScope).(*models.Infra)
+	operation, _ := r.Context().Value(types.OperationScope).(*models.Operation)
+
+	fileBytes, err := c.Config.StorageManager.ReadFile(
+		infra,
+		ptypes.GetPlanFileName(models.GetWorkspaceID(infra, operation)),
+		true,
+	)
+
+	if err != nil {
+		if errors.Is(err, storage.FileDoesNotExist) {
+			apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("plan file does not exist for operation %s", operation.UID),
+				http.StatusNotFound,
+			), true)
+
+			return
+		}
+
+		apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrInternal(err), true)
+		return
+	}
+
+	if _, err = w.Write(fileBytes); err != nil {
+		apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrInternal(err), true)
+
+		return
+	}
+}
@@ -39,14 +39,19 @@ func (c *ReportErrorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// update the infra to indicate error
-	infra.Status = "errored"
+	var err error
 
-	infra, err := c.Config.Repo.Infra().UpdateInfra(infra)
+	// update the infra to indicate error, unless only a plan or drift detection has failed,
+	// which leaves the infra untouched
+	if !operation.IsPlan() {
+		infra.Status = "errored"
 
-	if err != nil {
-		apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrInternal(err), true)
-		return
+		infra, err = c.Config.Repo.Infra().UpdateInfra(infra)
+
+		if err != nil {
+			apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrInternal(err), true)
+			return
+		}
 	}
 
 	// update the operation with the error
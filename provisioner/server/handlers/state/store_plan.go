@@ -0,0 +1,89 @@
+package state
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/provisioner/integrations/redis_stream"
+	"github.com/porter-dev/porter/provisioner/server/config"
+
+	ptypes "github.com/porter-dev/porter/provisioner/types"
+)
+
+type PlanStoreHandler struct {
+	Config *config.Config
+}
+
+func NewPlanStoreHandler(
+	config *config.Config,
+) *PlanStoreHandler {
+	return &PlanStoreHandler{
+		Config: config,
+	}
+}
+
+func (c *PlanStoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// read the infra from the attached scope
+	infra, _ := r.Context().Value(types.InfraScope).(*models.Infra)
+	operation, _ := r.Context().Value(types.OperationScope).(*models.Operation)
+
+	if !operation.IsPlan() {
+		apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("operation %s is not a plan operation", operation.UID),
+			http.StatusBadRequest,
+		), true)
+
+		return
+	}
+
+	// read the binary plan file written by terraform plan -out
+	fileBytes, err := io.ReadAll(r.Body)
+
+	if err != nil {
+		apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrInternal(err), true)
+		return
+	}
+
+	// plan files contain the values of sensitive variables, so they are encrypted
+	err = c.Config.StorageManager.WriteFile(
+		infra,
+		ptypes.GetPlanFileName(models.GetWorkspaceID(infra, operation)),
+		fileBytes,
+		true,
+	)
+
+	if err != nil {
+		apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrInternal(err), true)
+		return
+	}
+
+	// update the operation to indicate completion
+	operation.Status = "completed"
+
+	operation, err = c.Config.Repo.Infra().UpdateOperation(operation)
+
+	if err != nil {
+		apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrInternal(err), true)
+		return
+	}
+
+	// push to the operation stream
+	err = redis_stream.SendOperationCompleted(c.Config.RedisClient, infra, operation)
+
+	if err != nil {
+		apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrInternal(err), true)
+		return
+	}
+
+	// push to the global stream
+	err = redis_stream.PushToGlobalStream(c.Config.RedisClient, infra, operation, "planned")
+
+	if err != nil {
+		apierrors.HandleAPIError(c.Config.Logger, c.Config.Alerter, w, r, apierrors.NewErrInternal(err), true)
+		return
+	}
+}
@@ -38,6 +38,8 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 				r.Method("DELETE", "/{workspace_id}/resource", state.NewDeleteResourceHandler(config))
 				r.Method("POST", "/{workspace_id}/error", state.NewReportErrorHandler(config))
 				r.Method("GET", "/{workspace_id}/credentials", credentials.NewCredentialsGetHandler(config))
+				r.Method("POST", "/{workspace_id}/plan", state.NewPlanStoreHandler(config))
+				r.Method("GET", "/{workspace_id}/plan", state.NewRawPlanGetHandler(config))
 			})
 
 			// This group is meant to be called from Terraform via basic auth
@@ -59,6 +61,7 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 				// HTTP backend.
 				r.Method("GET", "/{workspace_id}/tfstate/raw", state.NewRawStateGetHandler(config))
 				r.Method("GET", "/{workspace_id}/logs", state.NewLogsGetHandler(config))
+				r.Method("GET", "/{workspace_id}/plan/changes", state.NewPlanGetHandler(config))
 			})
 		})
 
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/provisioner/pb"
+)
+
+// TFPlan is the structured result of a plan operation, built from the planned changes and
+// the change summary which are logged by terraform while planning
+type TFPlan struct {
+	Changes []*pb.TerraformChange `json:"changes"`
+	Summary *pb.TerraformChanges  `json:"summary"`
+}
+
+// ToInfraPlan converts the plan of an operation to the plan which is shared over REST
+func (p *TFPlan) ToInfraPlan(operationID string) *types.InfraPlan {
+	res := &types.InfraPlan{
+		OperationID: operationID,
+		Changes:     make([]*types.InfraPlanChange, 0),
+	}
+
+	if p.Summary != nil {
+		res.Add = p.Summary.Add
+		res.Change = p.Summary.Change
+		res.Destroy = p.Summary.Remove
+	}
+
+	for _, change := range p.Changes {
+		if change == nil || change.Resource == nil {
+			continue
+		}
+
+		res.Changes = append(res.Changes, &types.InfraPlanChange{
+			Address:      change.Resource.Addr,
+			ResourceType: change.Resource.ResourceType,
+			ResourceName: change.Resource.ResourceName,
+			Provider:     change.Resource.Provider,
+			Action:       change.Action,
+		})
+	}
+
+	return res
+}
+
+// GetPlanFileName returns the name of the file storing the binary plan of a plan operation,
+// which is applied when the plan is approved
+func GetPlanFileName(workspaceID string) string {
+	return fmt.Sprintf("%s-plan.tfplan", workspaceID)
+}
+
+// GetPlanChangesFileName returns the name of the file storing the structured plan of a plan
+// operation
+func GetPlanChangesFileName(workspaceID string) string {
+	return fmt.Sprintf("%s-plan.json", workspaceID)
+}
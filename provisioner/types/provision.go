@@ -3,7 +3,11 @@ package types
 type ApplyBaseRequest struct {
 	Kind          string                 `json:"kind"`
 	Values        map[string]interface{} `json:"values"`
-	OperationKind string                 `json:"operation_kind" form:"oneof=create retry_create update"`
+	OperationKind string                 `json:"operation_kind" form:"oneof=create retry_create update plan detect_drift"`
+
+	// PlanOperationID is the ID of a completed plan operation to apply. If set, the stored plan
+	// is applied as-is and the values of the plan operation are used.
+	PlanOperationID string `json:"plan_operation_id"`
 }
 
 type DeleteBaseRequest struct {
@@ -114,7 +114,13 @@ func main() {
 
 	expiry := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 
-	secretKey := "volume-miss-king-master"
+	// the secret key is hashed like the keys of api tokens created through the API, since
+	// the server checks the secret of the token on every request
+	hashedSecretKey, err := bcrypt.GenerateFromPassword([]byte("volume-miss-king-master"), 8)
+
+	if err != nil {
+		log.Fatalf("Failed to hash API token secret: %v", err)
+	}
 
 	_, err = repo.APIToken().CreateAPIToken(&models.APIToken{
 		UniqueID:        "test-user-admin-token",
@@ -124,7 +130,7 @@ func main() {
 		PolicyUID:       policy.UniqueID,
 		PolicyName:      policy.Name,
 		Name:            "Admin Token",
-		SecretKey:       []byte(secretKey),
+		SecretKey:       hashedSecretKey,
 	})
 
 	if err != nil {
@@ -22,6 +22,16 @@ ARCHITECTURE
   - The worker pool has an exposed HTTP POST endpoint to enqueue jobs with their IDs. Depending on the kind of job,
     a job can expect to receive a body of JSON data in the HTTP request.
   - By exposing an HTTP endpoint, the worker pool can be called to enqueue jobs using crontab and other sources.
+  - Enqueued jobs are stored in the `worker_jobs` table, so that they survive restarts of the worker pool. A failed
+    job is retried with an exponential backoff until it runs out of attempts (`JOB_MAX_ATTEMPTS`), after which it is
+    marked as dead and can be retried manually.
+  - Running jobs send periodic heartbeats. Jobs of a worker pool which stopped while running them are requeued once
+    their heartbeat is stale.
+  - Jobs and their results can be listed with `GET /jobs`, filtered by the `status`, `job_id` and `limit` query
+    parameters, and read with `GET /jobs/{id}`. Dead jobs are retried with `POST /jobs/{id}/retry`.
+  - Jobs can be enqueued on cron schedules configured with `JOB_SCHEDULES`. Only the replica holding the scheduler
+    lease enqueues scheduled jobs, and a run is skipped while the previous job with the same ID is queued or running.
+    The last and next runs of each schedule are returned by `GET /schedules`.
 
 */
 
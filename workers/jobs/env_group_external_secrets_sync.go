@@ -0,0 +1,247 @@
+//go:build ee
+
+/*
+
+                            === Env Group External Secrets Sync Job ===
+
+This job keeps the secret variables of env groups in sync with the external secret store they
+are read from.
+
+  - The job goes through every cluster and lists the latest version of all env groups.
+  - Env groups which reference external secrets have their secrets read from the secret store again.
+  - If the version of any referenced secret changed, a new version of the env group is created.
+  - The releases synced to the env group are then upgraded to the new version.
+
+*/
+
+package jobs
+
+import (
+	"context"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/pkg/logger"
+
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/kubernetes/envgroup"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/repository"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"github.com/porter-dev/porter/internal/secretstore"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+type envGroupExternalSecretsSync struct {
+	enqueueTime                 time.Time
+	db                          *gorm.DB
+	repo                        repository.Repository
+	doConf                      *oauth2.Config
+	store                       secretstore.Store
+	disablePullSecretsInjection bool
+}
+
+// EnvGroupExternalSecretsSyncOpts holds the options required to run this job
+type EnvGroupExternalSecretsSyncOpts struct {
+	DBConf                      *env.DBConf
+	DOClientID                  string
+	DOClientSecret              string
+	DOScopes                    []string
+	ServerURL                   string
+	VaultAddress                string
+	VaultToken                  string
+	VaultMount                  string
+	VaultNamespace              string
+	DisablePullSecretsInjection bool
+}
+
+func NewEnvGroupExternalSecretsSync(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *EnvGroupExternalSecretsSyncOpts,
+) (*envGroupExternalSecretsSync, error) {
+	if opts.VaultAddress == "" {
+		return nil, envgroup.ErrNoSecretStore
+	}
+
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	doConf := oauth.NewDigitalOceanClient(&oauth.Config{
+		ClientID:     opts.DOClientID,
+		ClientSecret: opts.DOClientSecret,
+		Scopes:       opts.DOScopes,
+		BaseURL:      opts.ServerURL,
+	})
+
+	store := secretstore.NewVaultKVv2Store(&secretstore.VaultKVv2Opts{
+		Address:   opts.VaultAddress,
+		Token:     opts.VaultToken,
+		Mount:     opts.VaultMount,
+		Namespace: opts.VaultNamespace,
+	})
+
+	return &envGroupExternalSecretsSync{
+		enqueueTime, db, repo, doConf, store, opts.DisablePullSecretsInjection,
+	}, nil
+}
+
+func (t *envGroupExternalSecretsSync) ID() string {
+	return "env-group-external-secrets-sync"
+}
+
+func (t *envGroupExternalSecretsSync) EnqueueTime() time.Time {
+	return t.enqueueTime
+}
+
+func (t *envGroupExternalSecretsSync) Run() error {
+	var count int64
+
+	if err := t.db.Model(&models.Cluster{}).Count(&count).Error; err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < (int(count)/stepSize)+1; i++ {
+		var clusters []*models.Cluster
+
+		if err := t.db.Order("id asc").Offset(i * stepSize).Limit(stepSize).Find(&clusters).Error; err != nil {
+			return err
+		}
+
+		for _, cluster := range clusters {
+			wg.Add(1)
+
+			go func(projID, clusterID uint) {
+				defer wg.Done()
+
+				cluster, err := t.repo.Cluster().ReadCluster(projID, clusterID)
+
+				if err != nil {
+					log.Printf("error reading cluster ID %d: %v. skipping cluster ...", clusterID, err)
+					return
+				}
+
+				t.syncCluster(cluster)
+			}(cluster.ProjectID, cluster.ID)
+		}
+
+		wg.Wait()
+	}
+
+	return nil
+}
+
+func (t *envGroupExternalSecretsSync) syncCluster(cluster *models.Cluster) {
+	k8sAgent, err := kubernetes.GetAgentOutOfClusterConfig(&kubernetes.OutOfClusterConfig{
+		Cluster:                   cluster,
+		Repo:                      t.repo,
+		DigitalOceanOAuth:         t.doConf,
+		AllowInClusterConnections: false,
+		Timeout:                   5 * time.Second,
+	})
+
+	if err != nil {
+		log.Printf("error getting k8s agent for cluster ID %d: %v. skipping cluster ...", cluster.ID, err)
+		return
+	}
+
+	// list the latest version of the env groups in all namespaces
+	configMaps, err := k8sAgent.ListAllVersionedConfigMaps("")
+
+	if err != nil {
+		log.Printf("error listing env groups for cluster ID %d: %v. skipping cluster ...", cluster.ID, err)
+		return
+	}
+
+	for i := range configMaps {
+		cm := &configMaps[i]
+
+		if _, exists := cm.Annotations[envgroup.ExternalSecretsAnnotationName]; !exists {
+			continue
+		}
+
+		newCM, err := envgroup.RefreshExternalSecrets(context.Background(), k8sAgent, t.store, cluster.ProjectID, cm)
+
+		if err != nil {
+			log.Printf("error refreshing external secrets of env group %s in namespace %s of cluster ID %d: %v. "+
+				"skipping env group ...", cm.Labels["envgroup"], cm.Namespace, cluster.ID, err)
+			continue
+		} else if newCM == nil {
+			continue
+		}
+
+		envGroup, err := envgroup.ToEnvGroup(newCM)
+
+		if err != nil {
+			log.Printf("error reading new version of env group %s in namespace %s of cluster ID %d: %v",
+				cm.Labels["envgroup"], cm.Namespace, cluster.ID, err)
+			continue
+		}
+
+		log.Printf("created version %d of env group %s in namespace %s of cluster ID %d with updated external secrets",
+			envGroup.Version, envGroup.Name, envGroup.Namespace, cluster.ID)
+
+		helmAgent, err := helm.GetAgentOutOfClusterConfig(&helm.Form{
+			Cluster:                   cluster,
+			Namespace:                 envGroup.Namespace,
+			Repo:                      t.repo,
+			DigitalOceanOAuth:         t.doConf,
+			AllowInClusterConnections: false,
+			Timeout:                   5 * time.Second,
+		}, logger.New(true, os.Stdout))
+
+		if err != nil {
+			log.Printf("error fetching helm client for namespace %s in cluster ID %d: %v. "+
+				"skipping rollout of env group %s ...", envGroup.Namespace, cluster.ID, err, envGroup.Name)
+			continue
+		}
+
+		releases, err := envgroup.GetSyncedReleases(helmAgent, newCM)
+
+		if err != nil {
+			log.Printf("error listing releases synced to env group %s in namespace %s of cluster ID %d: %v",
+				envGroup.Name, envGroup.Namespace, cluster.ID, err)
+			continue
+		}
+
+		errs := envgroup.RolloutApplications(&envgroup.RolloutOpts{
+			Repo:                        t.repo,
+			Cluster:                     cluster,
+			HelmAgent:                   helmAgent,
+			DOConf:                      t.doConf,
+			DisablePullSecretsInjection: t.disablePullSecretsInjection,
+		}, envGroup, newCM, releases)
+
+		for _, err := range errs {
+			log.Printf("error rolling out env group %s in namespace %s of cluster ID %d: %v",
+				envGroup.Name, envGroup.Namespace, cluster.ID, err)
+		}
+	}
+}
+
+func (t *envGroupExternalSecretsSync) SetData([]byte) {}
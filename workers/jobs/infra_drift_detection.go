@@ -0,0 +1,329 @@
+//go:build ee
+
+/*
+
+                            === Infra Drift Detection Job ===
+
+This job detects changes which were made to provisioned infrastructure outside of Porter, for example
+through the cloud console.
+
+  - The job looks for infras with the "created" status which were provisioned through operations.
+  - Infras with a running operation are skipped, so that drift detection never interferes with an
+    operation started by a user. Plan and drift detection operations do not count as running operations.
+  - Infras with a running drift detection are skipped, unless the drift detection was started more than
+    an hour ago. The runner is then assumed to have died, and the drift detection is marked as errored.
+  - If the latest drift detection of an infra has completed since the latest applied operation, the
+    resources which drifted are read from the provisioner service and recorded against the latest
+    applied operation of the infra. The infra is marked as either "drifted" or "in_sync".
+  - A notification is sent through the slack and webhook integrations of the project when an infra
+    starts drifting.
+  - A new drift detection operation is then started, which runs a refresh-only plan with the values of
+    the latest applied operation. Its result is recorded on the next run of the job.
+
+*/
+
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+	"github.com/porter-dev/porter/provisioner/client"
+
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	ptypes "github.com/porter-dev/porter/provisioner/types"
+	"gorm.io/gorm"
+)
+
+// driftDetectionTimeout is the time after which a drift detection operation which is still
+// starting is marked as errored
+const driftDetectionTimeout = time.Hour
+
+type infraDriftDetection struct {
+	enqueueTime       time.Time
+	db                *gorm.DB
+	repo              repository.Repository
+	provisionerClient *client.Client
+	serverURL         string
+}
+
+// InfraDriftDetectionOpts holds the options required to run this job
+type InfraDriftDetectionOpts struct {
+	DBConf               *env.DBConf
+	ServerURL            string
+	ProvisionerServerURL string
+	ProvisionerToken     string
+}
+
+func NewInfraDriftDetection(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *InfraDriftDetectionOpts,
+) (*infraDriftDetection, error) {
+	if opts.ProvisionerServerURL == "" || opts.ProvisionerToken == "" {
+		return nil, fmt.Errorf("PROVISIONER_SERVER_URL and PROVISIONER_TOKEN must be set")
+	}
+
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	provisionerClient, err := client.NewClient(fmt.Sprintf("%s/api/v1", opts.ProvisionerServerURL), opts.ProvisionerToken, 0)
+
+	if err != nil {
+		return nil, fmt.Errorf("error creating provisioner client: %w", err)
+	}
+
+	return &infraDriftDetection{
+		enqueueTime:       enqueueTime,
+		db:                db,
+		repo:              repo,
+		provisionerClient: provisionerClient,
+		serverURL:         opts.ServerURL,
+	}, nil
+}
+
+func (t *infraDriftDetection) ID() string {
+	return "infra-drift-detection"
+}
+
+func (t *infraDriftDetection) EnqueueTime() time.Time {
+	return t.enqueueTime
+}
+
+func (t *infraDriftDetection) Run() error {
+	defer t.provisionerClient.CloseConnection()
+
+	infras, err := t.repo.Infra().ListInfrasByStatus(types.StatusCreated)
+
+	if err != nil {
+		return err
+	}
+
+	for _, infra := range infras {
+		if err := t.processInfra(infra); err != nil {
+			log.Printf("error detecting drift of infra ID %d: %v", infra.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (t *infraDriftDetection) processInfra(infra *models.Infra) error {
+	appliedOperation, err := t.repo.Infra().GetLatestAppliedOperation(infra)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// legacy infras were not provisioned through operations, so drift cannot be detected
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error reading latest applied operation: %w", err)
+	}
+
+	if appliedOperation.Status == "starting" {
+		return nil
+	}
+
+	driftOperation, err := t.getLatestDriftOperation(infra)
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("error reading latest drift detection operation: %w", err)
+	}
+
+	if driftOperation != nil && driftOperation.Status == "starting" {
+		if time.Since(driftOperation.CreatedAt) < driftDetectionTimeout {
+			return nil
+		}
+
+		// the runner of the drift detection did not report back, so the operation is marked as
+		// errored and a new drift detection is started
+		driftOperation.Status = "errored"
+		driftOperation.Errored = true
+		driftOperation.Error = "drift detection timed out"
+
+		if _, err := t.repo.Infra().UpdateOperation(driftOperation); err != nil {
+			return fmt.Errorf("error updating drift detection operation %s: %w", driftOperation.UID, err)
+		}
+	}
+
+	// drift detections which started before the latest applied operation compared the infra
+	// against outdated values, so their results are not recorded
+	if driftOperation != nil && driftOperation.Status == "completed" && driftOperation.ID > appliedOperation.ID {
+		if err := t.recordDrift(infra, driftOperation); err != nil {
+			return fmt.Errorf("error recording drift of operation %s: %w", driftOperation.UID, err)
+		}
+	}
+
+	return t.detectDrift(infra)
+}
+
+// getLatestDriftOperation returns the latest drift detection operation of the infra
+func (t *infraDriftDetection) getLatestDriftOperation(infra *models.Infra) (*models.Operation, error) {
+	operations, err := t.repo.Infra().ListOperations(infra.ID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for _, operation := range operations {
+		if operation.Type == "detect_drift" {
+			// listed operations are not decrypted, so the operation is read again
+			return t.repo.Infra().ReadOperation(infra.ID, operation.UID)
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// recordDrift records the resources which drifted, as found by a completed drift detection
+// operation, against the latest applied operation of the infra
+func (t *infraDriftDetection) recordDrift(infra *models.Infra, operation *models.Operation) error {
+	plan, err := t.provisionerClient.GetPlan(context.Background(), models.GetWorkspaceID(infra, operation))
+
+	if err != nil {
+		return fmt.Errorf("error getting drift detection plan: %w", err)
+	}
+
+	resources := make([]*types.InfraPlanChange, 0)
+
+	for _, change := range plan.ToInfraPlan(operation.UID).Changes {
+		if change.Action != "noop" && change.Action != "read" {
+			resources = append(resources, change)
+		}
+	}
+
+	appliedOperation, err := t.repo.Infra().GetLatestAppliedOperation(infra)
+
+	if err != nil {
+		return fmt.Errorf("error reading latest applied operation: %w", err)
+	}
+
+	now := time.Now()
+	prevStatus := infra.DriftStatus
+
+	infra.DriftCheckedAt = &now
+	appliedOperation.DriftedResources = nil
+	appliedOperation.DriftDetectedAt = nil
+
+	if len(resources) == 0 {
+		infra.DriftStatus = types.InfraDriftInSync
+	} else {
+		infra.DriftStatus = types.InfraDriftDrifted
+
+		resourcesBytes, err := json.Marshal(resources)
+
+		if err != nil {
+			return err
+		}
+
+		appliedOperation.DriftedResources = resourcesBytes
+		appliedOperation.DriftDetectedAt = &now
+	}
+
+	if _, err := t.repo.Infra().UpdateOperation(appliedOperation); err != nil {
+		return fmt.Errorf("error updating latest applied operation: %w", err)
+	}
+
+	if _, err := t.repo.Infra().UpdateInfra(infra); err != nil {
+		return fmt.Errorf("error updating infra: %w", err)
+	}
+
+	// only notify when the infra starts drifting, rather than on every run
+	if infra.DriftStatus != types.InfraDriftDrifted || prevStatus == types.InfraDriftDrifted {
+		return nil
+	}
+
+	// the name of the infra is read from the values it was applied with
+	namedInfra := *infra
+	namedInfra.LastApplied = appliedOperation.LastApplied
+
+	err = t.getNotifier(infra.ProjectID).NotifyDrift(
+		namedInfra.ToInfraType(),
+		&types.InfraDrift{
+			OperationID: appliedOperation.UID,
+			DetectedAt:  now,
+			Resources:   resources,
+		},
+		fmt.Sprintf("%s/infrastructure/%d?project_id=%d", t.serverURL, infra.ID, infra.ProjectID),
+	)
+
+	if err != nil {
+		return fmt.Errorf("error sending drift notification: %w", err)
+	}
+
+	return nil
+}
+
+// detectDrift starts a new drift detection operation with the values of the latest applied
+// operation of the infra
+func (t *infraDriftDetection) detectDrift(infra *models.Infra) error {
+	appliedOperation, err := t.repo.Infra().GetLatestAppliedOperation(infra)
+
+	if err != nil {
+		return fmt.Errorf("error reading latest applied operation: %w", err)
+	}
+
+	values := make(map[string]interface{})
+
+	if err := json.Unmarshal(appliedOperation.LastApplied, &values); err != nil {
+		return fmt.Errorf("error decoding values of operation %s: %w", appliedOperation.UID, err)
+	}
+
+	_, err = t.provisionerClient.Apply(context.Background(), infra.ProjectID, infra.ID, &ptypes.ApplyBaseRequest{
+		Kind:          string(infra.Kind),
+		Values:        values,
+		OperationKind: "detect_drift",
+	})
+
+	if err != nil {
+		return fmt.Errorf("error starting drift detection operation: %w", err)
+	}
+
+	return nil
+}
+
+func (t *infraDriftDetection) getNotifier(projectID uint) notifier.InfraDriftNotifier {
+	notifiers := make([]notifier.InfraDriftNotifier, 0)
+
+	if slackInts, err := t.repo.SlackIntegration().ListSlackIntegrationsByProjectID(projectID); err == nil && len(slackInts) > 0 {
+		notifiers = append(notifiers, slack.NewInfraDriftNotifier(slackInts...))
+	}
+
+	if webhookInts, err := t.repo.WebhookIntegration().ListWebhookIntegrationsByProjectID(projectID); err == nil && len(webhookInts) > 0 {
+		notifiers = append(notifiers, webhook.NewInfraDriftNotifier(
+			projectID,
+			webhook.NewSender(t.repo.WebhookIntegration()),
+			webhookInts...,
+		))
+	}
+
+	return notifier.NewMultiInfraDriftNotifier(notifiers...)
+}
+
+func (t *infraDriftDetection) SetData([]byte) {}
@@ -0,0 +1,324 @@
+//go:build ee
+
+package jobs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/repository/test"
+	"github.com/porter-dev/porter/provisioner/client"
+	"github.com/porter-dev/porter/provisioner/pb"
+	ptypes "github.com/porter-dev/porter/provisioner/types"
+	"gorm.io/gorm"
+)
+
+// fakeDriftProvisioner returns the same plan for every drift detection, and records the drift
+// detections which were started
+type fakeDriftProvisioner struct {
+	plan      *ptypes.TFPlan
+	applyReqs []*ptypes.ApplyBaseRequest
+}
+
+func (p *fakeDriftProvisioner) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.Method == http.MethodGet && strings.HasSuffix(r.URL.Path, "/plan/changes"):
+		json.NewEncoder(w).Encode(p.plan)
+	case r.Method == http.MethodPost && r.URL.Path == "/projects/1/infras/1/apply":
+		req := &ptypes.ApplyBaseRequest{}
+
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		p.applyReqs = append(p.applyReqs, req)
+
+		json.NewEncoder(w).Encode(&types.Operation{
+			OperationMeta: &types.OperationMeta{
+				InfraID: 1,
+				Type:    req.OperationKind,
+				Status:  "starting",
+			},
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+type driftTestEnv struct {
+	job           *infraDriftDetection
+	repo          repository.Repository
+	infra         *models.Infra
+	provisioner   *fakeDriftProvisioner
+	notifications chan *types.WebhookPayload
+}
+
+// newDriftTestEnv creates an infra with the given operations, and a webhook integration which
+// receives the drift notifications of its project
+func newDriftTestEnv(t *testing.T, operations ...*models.Operation) *driftTestEnv {
+	repo := test.NewRepository(true)
+
+	infra, err := repo.Infra().CreateInfra(&models.Infra{
+		ProjectID: 1,
+		Kind:      types.InfraEKS,
+		Status:    types.StatusCreated,
+		Suffix:    "abcdef",
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, op := range operations {
+		if _, err := repo.Infra().AddOperation(infra, op); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	provisioner := &fakeDriftProvisioner{plan: &ptypes.TFPlan{}}
+	provisionerServer := httptest.NewServer(provisioner)
+
+	t.Cleanup(provisionerServer.Close)
+
+	notifications := make(chan *types.WebhookPayload, 10)
+
+	webhookServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload := &types.WebhookPayload{}
+
+		if err := json.NewDecoder(r.Body).Decode(payload); err == nil {
+			notifications <- payload
+		}
+
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	t.Cleanup(webhookServer.Close)
+
+	_, err = repo.WebhookIntegration().CreateWebhookIntegration(&ints.WebhookIntegration{
+		ProjectID:      1,
+		IncidentEvents: true,
+		URL:            []byte(webhookServer.URL),
+		Secret:         []byte("secret"),
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &driftTestEnv{
+		job: &infraDriftDetection{
+			repo: repo,
+			provisionerClient: &client.Client{
+				BaseURL:    provisionerServer.URL,
+				HTTPClient: provisionerServer.Client(),
+			},
+			serverURL: "https://dashboard.getporter.dev",
+		},
+		repo:          repo,
+		infra:         infra,
+		provisioner:   provisioner,
+		notifications: notifications,
+	}
+}
+
+func (e *driftTestEnv) readOperation(t *testing.T, uid string) *models.Operation {
+	operation, err := e.repo.Infra().ReadOperation(e.infra.ID, uid)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return operation
+}
+
+func newAppliedOperation(uid, status string) *models.Operation {
+	return &models.Operation{
+		UID:         uid,
+		Type:        "update",
+		Status:      status,
+		LastApplied: []byte(`{"cluster_name":"test-cluster"}`),
+	}
+}
+
+func newDriftOperation(uid, status string, createdAt time.Time) *models.Operation {
+	return &models.Operation{
+		Model:  gorm.Model{CreatedAt: createdAt},
+		UID:    uid,
+		Type:   "detect_drift",
+		Status: status,
+	}
+}
+
+var driftedPlan = &ptypes.TFPlan{
+	Changes: []*pb.TerraformChange{
+		{
+			Resource: &pb.TerraformResource{Addr: "aws_eks_cluster.cluster", ResourceType: "aws_eks_cluster"},
+			Action:   "update",
+		},
+		{
+			Resource: &pb.TerraformResource{Addr: "aws_vpc.vpc", ResourceType: "aws_vpc"},
+			Action:   "noop",
+		},
+	},
+}
+
+func TestRecordDrift(t *testing.T) {
+	env := newDriftTestEnv(t,
+		newAppliedOperation("applied", "completed"),
+		newDriftOperation("drift", "completed", time.Now()),
+	)
+
+	env.provisioner.plan = driftedPlan
+
+	if err := env.job.recordDrift(env.infra, env.readOperation(t, "drift")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if env.infra.DriftStatus != types.InfraDriftDrifted || env.infra.DriftCheckedAt == nil {
+		t.Errorf("expected infra to be marked as drifted, got %q", env.infra.DriftStatus)
+	}
+
+	applied := env.readOperation(t, "applied")
+	resources := make([]*types.InfraPlanChange, 0)
+
+	if err := json.Unmarshal(applied.DriftedResources, &resources); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(resources) != 1 || resources[0].Address != "aws_eks_cluster.cluster" || applied.DriftDetectedAt == nil {
+		t.Errorf("expected only the changed resource to be recorded against the applied operation, got %+v", resources)
+	}
+
+	select {
+	case payload := <-env.notifications:
+		if payload.Event != types.WebhookEventInfraDrift || payload.InfraDrift.InfraDrift.OperationID != "applied" {
+			t.Errorf("expected a drift notification for the applied operation, got %+v", payload)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("expected a drift notification to be sent")
+	}
+
+	// the infra is still drifting, so no new notification is sent
+	if err := env.job.recordDrift(env.infra, env.readOperation(t, "drift")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case <-env.notifications:
+		t.Errorf("expected no notification while the infra keeps drifting")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	env.provisioner.plan = &ptypes.TFPlan{}
+
+	if err := env.job.recordDrift(env.infra, env.readOperation(t, "drift")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	applied = env.readOperation(t, "applied")
+
+	if env.infra.DriftStatus != types.InfraDriftInSync || applied.DriftedResources != nil || applied.DriftDetectedAt != nil {
+		t.Errorf("expected infra to be marked as in sync and the drift to be cleared, got %q", env.infra.DriftStatus)
+	}
+}
+
+func TestProcessInfra(t *testing.T) {
+	tests := []struct {
+		name            string
+		operations      []*models.Operation
+		expDetections   int
+		expDriftStatus  types.InfraDriftStatus
+		expDriftErrored string
+	}{
+		{
+			name:       "legacy infra without operations",
+			operations: []*models.Operation{},
+		},
+		{
+			name: "applied operation in progress",
+			operations: []*models.Operation{
+				newAppliedOperation("applied", "starting"),
+			},
+		},
+		{
+			name: "first drift detection",
+			operations: []*models.Operation{
+				newAppliedOperation("applied", "completed"),
+			},
+			expDetections: 1,
+		},
+		{
+			name: "drift detection in progress",
+			operations: []*models.Operation{
+				newAppliedOperation("applied", "completed"),
+				newDriftOperation("drift", "starting", time.Now()),
+			},
+		},
+		{
+			name: "drift detection timed out",
+			operations: []*models.Operation{
+				newAppliedOperation("applied", "completed"),
+				newDriftOperation("drift", "starting", time.Now().Add(-2*driftDetectionTimeout)),
+			},
+			expDetections:   1,
+			expDriftErrored: "drift",
+		},
+		{
+			name: "drift detection completed",
+			operations: []*models.Operation{
+				newAppliedOperation("applied", "completed"),
+				newDriftOperation("drift", "completed", time.Now()),
+				{UID: "plan", Type: "plan", Status: "starting"},
+			},
+			expDetections:  1,
+			expDriftStatus: types.InfraDriftDrifted,
+		},
+		{
+			name: "drift detection completed before the latest applied operation",
+			operations: []*models.Operation{
+				newDriftOperation("drift", "completed", time.Now()),
+				newAppliedOperation("applied", "completed"),
+			},
+			expDetections: 1,
+		},
+	}
+
+	for _, test := range tests {
+		env := newDriftTestEnv(t, test.operations...)
+		env.provisioner.plan = driftedPlan
+
+		if err := env.job.processInfra(env.infra); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		if len(env.provisioner.applyReqs) != test.expDetections {
+			t.Errorf("%s: expected %d drift detections to be started, got %d", test.name, test.expDetections, len(env.provisioner.applyReqs))
+		}
+
+		for _, req := range env.provisioner.applyReqs {
+			if req.OperationKind != "detect_drift" || req.Values["cluster_name"] != "test-cluster" {
+				t.Errorf("%s: expected a drift detection with the applied values, got %+v", test.name, req)
+			}
+		}
+
+		if env.infra.DriftStatus != test.expDriftStatus {
+			t.Errorf("%s: expected drift status %q, got %q", test.name, test.expDriftStatus, env.infra.DriftStatus)
+		}
+
+		if test.expDriftErrored != "" {
+			if op := env.readOperation(t, test.expDriftErrored); op.Status != "errored" || !op.Errored {
+				t.Errorf("%s: expected stale drift detection to be marked as errored, got status %s", test.name, op.Status)
+			}
+		}
+	}
+}
@@ -0,0 +1,413 @@
+//go:build ee
+
+/*
+
+                            === Preview Environment Lifecycle Job ===
+
+This job puts idle preview deployments to sleep and deletes the namespaces of preview deployments
+which reached their maximum age.
+
+  - The job looks for preview environments with an idle timeout or a maximum deployment age.
+  - Deployments older than the maximum age of their environment have their namespace deleted and are
+    marked as expired. A new push to the pull request deploys them again.
+  - Branch deploys are never put to sleep or expired.
+  - Deployments without a push or wake up for longer than the idle timeout are checked for HTTP traffic
+    through the NGINX ingress metrics in Prometheus. Deployments in clusters without Prometheus or
+    without NGINX ingress metrics are never put to sleep, as their traffic is unknown.
+  - Idle deployments without traffic have all their Kubernetes deployments scaled to zero and are marked
+    as sleeping. A new push or the wake API endpoint scales them back up.
+  - The pull request comment of the deployment is updated in both cases.
+
+*/
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bradleyfalzon/ghinstallation/v2"
+	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/api/types"
+	gitlabCI "github.com/porter-dev/porter/internal/integrations/ci/gitlab"
+	"github.com/porter-dev/porter/internal/kubernetes/prometheus"
+	"github.com/xanzy/go-gitlab"
+
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/repository"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+type previewEnvironmentLifecycle struct {
+	enqueueTime     time.Time
+	db              *gorm.DB
+	repo            repository.Repository
+	doConf          *oauth2.Config
+	serverConf      *config.Config
+	githubAppID     int64
+	githubAppSecret []byte
+}
+
+// PreviewEnvironmentLifecycleOpts holds the options required to run this job
+type PreviewEnvironmentLifecycleOpts struct {
+	DBConf              *env.DBConf
+	DOClientID          string
+	DOClientSecret      string
+	DOScopes            []string
+	ServerURL           string
+	GithubAppID         string
+	GithubAppSecretPath string
+}
+
+func NewPreviewEnvironmentLifecycle(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *PreviewEnvironmentLifecycleOpts,
+) (*previewEnvironmentLifecycle, error) {
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	doConf := oauth.NewDigitalOceanClient(&oauth.Config{
+		ClientID:     opts.DOClientID,
+		ClientSecret: opts.DOClientSecret,
+		Scopes:       opts.DOScopes,
+		BaseURL:      opts.ServerURL,
+	})
+
+	res := &previewEnvironmentLifecycle{
+		enqueueTime: enqueueTime,
+		db:          db,
+		repo:        repo,
+		doConf:      doConf,
+		// the GitLab OAuth configuration only requires the server URL
+		serverConf: &config.Config{
+			ServerConf: &env.ServerConf{
+				ServerURL: opts.ServerURL,
+			},
+		},
+	}
+
+	// pull request comments on GitHub are only updated when the GitHub app is configured
+	if opts.GithubAppID != "" && opts.GithubAppSecretPath != "" {
+		appID, err := strconv.ParseInt(opts.GithubAppID, 10, 64)
+
+		if err != nil {
+			return nil, fmt.Errorf("malformed GITHUB_APP_ID: %w", err)
+		}
+
+		secret, err := os.ReadFile(opts.GithubAppSecretPath)
+
+		if err != nil {
+			return nil, fmt.Errorf("error reading github app secret: %w", err)
+		}
+
+		res.githubAppID = appID
+		res.githubAppSecret = secret
+	}
+
+	return res, nil
+}
+
+func (t *previewEnvironmentLifecycle) ID() string {
+	return "preview-environment-lifecycle"
+}
+
+func (t *previewEnvironmentLifecycle) EnqueueTime() time.Time {
+	return t.enqueueTime
+}
+
+func (t *previewEnvironmentLifecycle) Run() error {
+	var envs []*models.Environment
+
+	if err := t.db.Where("idle_timeout_hours > 0 OR max_deployment_age_hours > 0").Find(&envs).Error; err != nil {
+		return err
+	}
+
+	for _, env := range envs {
+		depls, err := t.repo.Environment().ListDeployments(
+			env.ID,
+			string(types.DeploymentStatusCreated),
+			string(types.DeploymentStatusFailed),
+			string(types.DeploymentStatusSleeping),
+		)
+
+		if err != nil {
+			log.Printf("error listing deployments of environment ID %d: %v. skipping environment ...", env.ID, err)
+			continue
+		} else if len(depls) == 0 {
+			continue
+		}
+
+		cluster, err := t.repo.Cluster().ReadCluster(env.ProjectID, env.ClusterID)
+
+		if err != nil {
+			log.Printf("error reading cluster ID %d: %v. skipping environment ID %d ...", env.ClusterID, err, env.ID)
+			continue
+		}
+
+		k8sAgent, err := kubernetes.GetAgentOutOfClusterConfig(&kubernetes.OutOfClusterConfig{
+			Cluster:                   cluster,
+			Repo:                      t.repo,
+			DigitalOceanOAuth:         t.doConf,
+			AllowInClusterConnections: false,
+			Timeout:                   5 * time.Second,
+		})
+
+		if err != nil {
+			log.Printf("error getting k8s agent for cluster ID %d: %v. skipping environment ID %d ...",
+				cluster.ID, err, env.ID)
+			continue
+		}
+
+		for _, depl := range depls {
+			if err := t.processDeployment(k8sAgent, env, depl); err != nil {
+				log.Printf("error processing deployment ID %d of environment ID %d: %v", depl.ID, env.ID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (t *previewEnvironmentLifecycle) processDeployment(
+	k8sAgent *kubernetes.Agent,
+	env *models.Environment,
+	depl *models.Deployment,
+) error {
+	// branch deploys are long-lived, so they are never put to sleep or expired
+	if depl.IsBranchDeploy() {
+		return nil
+	}
+
+	maxAge := time.Duration(env.MaxDeploymentAgeHours) * time.Hour
+
+	if env.MaxDeploymentAgeHours > 0 && time.Since(depl.GetStartedAt()) > maxAge {
+		return t.expireDeployment(k8sAgent, env, depl)
+	}
+
+	idleTimeout := time.Duration(env.IdleTimeoutHours) * time.Hour
+
+	if env.IdleTimeoutHours == 0 || depl.Status != types.DeploymentStatusCreated ||
+		time.Since(depl.GetLastActivityAt()) <= idleTimeout {
+		return nil
+	}
+
+	promSvc, found, err := prometheus.GetPrometheusService(k8sAgent.Clientset)
+
+	if err != nil {
+		return fmt.Errorf("error getting prometheus service: %w", err)
+	}
+
+	if !found {
+		// without Prometheus there is no way to tell whether the deployment still serves traffic
+		return nil
+	}
+
+	count, found, err := prometheus.QueryNamespaceRequestCount(k8sAgent.Clientset, promSvc, depl.Namespace, idleTimeout)
+
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		// the ingress metrics are missing, so the traffic of the deployment is unknown
+		return nil
+	}
+
+	if count > 0 {
+		// the deployment served traffic during the idle timeout, so it counts as active from now on
+		depl.RecordActivity()
+
+		_, err := t.repo.Environment().UpdateDeployment(depl)
+
+		return err
+	}
+
+	return t.sleepDeployment(k8sAgent, env, depl)
+}
+
+func (t *previewEnvironmentLifecycle) sleepDeployment(
+	k8sAgent *kubernetes.Agent,
+	env *models.Environment,
+	depl *models.Deployment,
+) error {
+	if err := k8sAgent.SleepNamespace(depl.Namespace); err != nil {
+		return fmt.Errorf("error scaling down namespace %s: %w", depl.Namespace, err)
+	}
+
+	depl.Status = types.DeploymentStatusSleeping
+
+	if _, err := t.repo.Environment().UpdateDeployment(depl); err != nil {
+		return err
+	}
+
+	log.Printf("put deployment ID %d in namespace %s to sleep", depl.ID, depl.Namespace)
+
+	return t.updateComment(env, depl, fmt.Sprintf(
+		"## Porter Preview Environments\n"+
+			"💤 This preview environment was put to sleep after %d hour(s) without any pushes or traffic. "+
+			"Push a new commit or run `porter preview wake %d` to wake it up.",
+		env.IdleTimeoutHours, depl.ID,
+	))
+}
+
+func (t *previewEnvironmentLifecycle) expireDeployment(
+	k8sAgent *kubernetes.Agent,
+	env *models.Environment,
+	depl *models.Deployment,
+) error {
+	// make sure we do not delete any kubernetes "system" namespaces
+	if depl.Namespace != "" && !isSystemNamespace(depl.Namespace) {
+		if err := k8sAgent.DeleteNamespace(depl.Namespace); err != nil {
+			return fmt.Errorf("error deleting namespace %s: %w", depl.Namespace, err)
+		}
+	}
+
+	depl.Status = types.DeploymentStatusExpired
+
+	if _, err := t.repo.Environment().UpdateDeployment(depl); err != nil {
+		return err
+	}
+
+	log.Printf("deleted namespace %s of expired deployment ID %d", depl.Namespace, depl.ID)
+
+	return t.updateComment(env, depl, fmt.Sprintf(
+		"## Porter Preview Environments\n"+
+			"⌛ This preview environment was deleted after reaching its maximum age of %d hour(s). "+
+			"Push a new commit to deploy it again.",
+		env.MaxDeploymentAgeHours,
+	))
+}
+
+// updateComment updates the comment of a deployment on its pull request, or creates a new one
+// when new comments are enabled for the environment
+func (t *previewEnvironmentLifecycle) updateComment(env *models.Environment, depl *models.Deployment, body string) error {
+	if depl.IsBranchDeploy() {
+		return nil
+	}
+
+	if env.IsGitlabEnvironment() {
+		return t.updateGitlabNote(env, depl, body)
+	}
+
+	if t.githubAppID == 0 {
+		return nil
+	}
+
+	itr, err := ghinstallation.New(http.DefaultTransport, t.githubAppID, int64(env.GitInstallationID), t.githubAppSecret)
+
+	if err != nil {
+		return fmt.Errorf("error creating github client: %w", err)
+	}
+
+	client := github.NewClient(&http.Client{Transport: itr})
+
+	if env.NewCommentsDisabled && depl.GHPRCommentID != 0 {
+		_, _, err := client.Issues.EditComment(
+			context.Background(), depl.RepoOwner, depl.RepoName, depl.GHPRCommentID,
+			&github.IssueComment{Body: github.String(body)},
+		)
+
+		if err == nil {
+			return nil
+		} else if !strings.Contains(err.Error(), "404") {
+			return fmt.Errorf("error updating github comment: %w", err)
+		}
+
+		// perhaps a deleted comment? create a new comment
+	}
+
+	comment, _, err := client.Issues.CreateComment(
+		context.Background(), depl.RepoOwner, depl.RepoName, int(depl.PullRequestID),
+		&github.IssueComment{Body: github.String(body)},
+	)
+
+	if err != nil {
+		return fmt.Errorf("error creating github comment: %w", err)
+	}
+
+	depl.GHPRCommentID = comment.GetID()
+
+	_, err = t.repo.Environment().UpdateDeployment(depl)
+
+	return err
+}
+
+func (t *previewEnvironmentLifecycle) updateGitlabNote(env *models.Environment, depl *models.Deployment, body string) error {
+	client, err := gitlabCI.NewClient(t.repo, t.serverConf, env.GitlabUserID, env.ProjectID, env.GitlabIntegrationID)
+
+	if err != nil {
+		return fmt.Errorf("error creating gitlab client: %w", err)
+	}
+
+	pID := fmt.Sprintf("%s/%s", depl.RepoOwner, depl.RepoName)
+
+	if env.NewCommentsDisabled && depl.GitlabNoteID != 0 {
+		_, resp, err := client.Notes.UpdateMergeRequestNote(
+			pID, int(depl.PullRequestID), depl.GitlabNoteID,
+			&gitlab.UpdateMergeRequestNoteOptions{Body: gitlab.String(body)},
+		)
+
+		if err == nil {
+			return nil
+		} else if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("error updating gitlab note: %w", err)
+		}
+
+		// perhaps a deleted note? create a new note
+	}
+
+	note, _, err := client.Notes.CreateMergeRequestNote(
+		pID, int(depl.PullRequestID),
+		&gitlab.CreateMergeRequestNoteOptions{Body: gitlab.String(body)},
+	)
+
+	if err != nil {
+		return fmt.Errorf("error creating gitlab note: %w", err)
+	}
+
+	depl.GitlabNoteID = note.ID
+
+	_, err = t.repo.Environment().UpdateDeployment(depl)
+
+	return err
+}
+
+func (t *previewEnvironmentLifecycle) SetData([]byte) {}
+
+func isSystemNamespace(namespace string) bool {
+	return namespace == "cert-manager" || namespace == "ingress-nginx" ||
+		namespace == "kube-node-lease" || namespace == "kube-public" ||
+		namespace == "kube-system" || namespace == "monitoring" ||
+		namespace == "porter-agent-system" || namespace == "default" ||
+		namespace == "ingress-nginx-private"
+}
@@ -0,0 +1,139 @@
+//go:build ee
+
+package jobs
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+// fakePromResponse is the response of a prometheus query proxied through the fake clientset
+type fakePromResponse struct {
+	body string
+}
+
+func (r *fakePromResponse) DoRaw(context.Context) ([]byte, error) {
+	return []byte(r.body), nil
+}
+
+func (r *fakePromResponse) Stream(context.Context) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader(r.body)), nil
+}
+
+// newIdleDeployment returns a deployment past the idle timeout and maximum age of the test
+// environments. The jobs in these tests have no repository, so sleeping or expiring it panics.
+func newIdleDeployment(prID uint, branchFrom, branchInto string) *models.Deployment {
+	startedAt := time.Now().Add(-48 * time.Hour)
+
+	return &models.Deployment{
+		Model:         gorm.Model{ID: 1},
+		Namespace:     "pr-1-app",
+		Status:        types.DeploymentStatusCreated,
+		PullRequestID: prID,
+		PRBranchFrom:  branchFrom,
+		PRBranchInto:  branchInto,
+		StartedAt:     &startedAt,
+	}
+}
+
+func TestProcessDeploymentSkipsBranchDeploys(t *testing.T) {
+	job := &previewEnvironmentLifecycle{}
+	agent := &kubernetes.Agent{Clientset: fake.NewSimpleClientset()}
+	env := &models.Environment{IdleTimeoutHours: 1, MaxDeploymentAgeHours: 24}
+	depl := newIdleDeployment(0, "main", "main")
+
+	if err := job.processDeployment(agent, env, depl); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if depl.Status != types.DeploymentStatusCreated {
+		t.Errorf("expected branch deploy to be left untouched, got status %s", depl.Status)
+	}
+}
+
+func TestProcessDeploymentWithoutPrometheus(t *testing.T) {
+	job := &previewEnvironmentLifecycle{}
+	agent := &kubernetes.Agent{Clientset: fake.NewSimpleClientset()}
+	env := &models.Environment{IdleTimeoutHours: 1}
+	depl := newIdleDeployment(1, "feature", "main")
+
+	if err := job.processDeployment(agent, env, depl); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if depl.Status != types.DeploymentStatusCreated {
+		t.Errorf("expected deployment not to be put to sleep without prometheus, got status %s", depl.Status)
+	}
+}
+
+func TestProcessDeploymentWithoutIngressMetrics(t *testing.T) {
+	clientset := fake.NewSimpleClientset(&v1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "prometheus-server",
+			Namespace: "monitoring",
+			Labels:    map[string]string{"app": "prometheus", "component": "server", "heritage": "Helm"},
+		},
+		Spec: v1.ServiceSpec{Ports: []v1.ServicePort{{Port: 80}}},
+	})
+
+	// prometheus has no NGINX ingress metrics, so the request count query has no result
+	clientset.PrependProxyReactor("services", func(action k8stesting.Action) (bool, restclient.ResponseWrapper, error) {
+		return true, &fakePromResponse{body: `{"data":{"result":[]}}`}, nil
+	})
+
+	job := &previewEnvironmentLifecycle{}
+	agent := &kubernetes.Agent{Clientset: clientset}
+	env := &models.Environment{IdleTimeoutHours: 1}
+	depl := newIdleDeployment(1, "feature", "main")
+
+	if err := job.processDeployment(agent, env, depl); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if depl.Status != types.DeploymentStatusCreated {
+		t.Errorf("expected deployment not to be put to sleep without ingress metrics, got status %s", depl.Status)
+	}
+}
+
+func TestRecordActivity(t *testing.T) {
+	depl := newIdleDeployment(1, "feature", "main")
+	startedAt := *depl.StartedAt
+
+	depl.RecordActivity()
+
+	if !depl.GetStartedAt().Equal(startedAt) {
+		t.Errorf("expected activity not to restart the age of the deployment")
+	}
+
+	if time.Since(depl.GetLastActivityAt()) > time.Minute {
+		t.Errorf("expected activity to be recorded, got %s", depl.GetLastActivityAt())
+	}
+
+	depl.Status = types.DeploymentStatusExpired
+	depl.LastActivityAt = nil
+
+	depl.RecordActivity()
+
+	if depl.LastActivityAt != nil || !depl.GetStartedAt().Equal(startedAt) {
+		t.Errorf("expected expired deployment to be left untouched")
+	}
+
+	depl.Restart()
+
+	if time.Since(depl.GetStartedAt()) > time.Minute {
+		t.Errorf("expected restart to reset the age of the deployment, got %s", depl.GetStartedAt())
+	}
+}
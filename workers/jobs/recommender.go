@@ -4,7 +4,8 @@
 
                             === Recommender Job ===
 
-This job checks to see if a cluster matches policies set by the OPA config file.
+This job checks to see if a cluster matches policies set by the OPA config file, along
+with the policy packs uploaded to the project of the cluster.
 
 */
 
@@ -14,6 +15,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/mitchellh/mapstructure"
@@ -224,9 +226,17 @@ func (n *recommender) Run() error {
 			continue
 		}
 
-		runner := opa.NewRunner(n.policies, cluster, k8sAgent, dynamicClient)
+		policies := n.getProjectPolicies(ids.projectID)
+		categories := getProjectCategories(n.categories, policies)
 
-		queryResults, err := runner.GetRecommendations(n.categories)
+		if len(n.categories) > 0 && len(categories) == 0 {
+			log.Printf("no matching categories for cluster ID %d. skipping cluster ...", ids.clusterID)
+			continue
+		}
+
+		runner := opa.NewRunner(policies, cluster, k8sAgent, dynamicClient)
+
+		queryResults, err := runner.GetRecommendations(categories)
 
 		if err != nil {
 			log.Printf("error querying opa policies for cluster ID %d: %v. skipping cluster ...", ids.clusterID, err)
@@ -271,6 +281,62 @@ func (n *recommender) Run() error {
 	return nil
 }
 
+// getProjectPolicies returns the built-in policies along with the policy packs uploaded to
+// the project. Policy packs which fail to compile are skipped.
+func (n *recommender) getProjectPolicies(projectID uint) *opa.KubernetesPolicies {
+	packs, err := n.repo.PolicyPack().ListPolicyPacksByProjectID(projectID)
+
+	if err != nil {
+		log.Printf("error listing policy packs for project ID %d: %v. skipping policy packs ...", projectID, err)
+		return n.policies
+	}
+
+	if len(packs) == 0 {
+		return n.policies
+	}
+
+	policies := make(map[string]opa.KubernetesOPAQueryCollection)
+
+	for name, collection := range n.policies.Policies {
+		policies[name] = collection
+	}
+
+	for _, pack := range packs {
+		collection, err := opa.CompilePolicyPack(pack)
+
+		if err != nil {
+			log.Printf("error compiling policy pack ID %d: %v. skipping policy pack ...", pack.ID, err)
+			continue
+		}
+
+		policies[pack.Category()] = collection
+	}
+
+	return &opa.KubernetesPolicies{
+		Policies: policies,
+	}
+}
+
+// getProjectCategories filters out the requested policy pack categories which do not exist
+// in the project, so that they do not fail the run for every other project
+func getProjectCategories(categories []string, policies *opa.KubernetesPolicies) []string {
+	if len(categories) == 0 {
+		return nil
+	}
+
+	res := make([]string, 0)
+
+	for _, category := range categories {
+		if _, exists := policies.Policies[category]; !exists && strings.HasPrefix(category, models.PolicyPackCategoryPrefix) {
+			continue
+		}
+
+		res = append(res, category)
+	}
+
+	return res
+}
+
 func (n *recommender) getMonitorTestResultFromQueryResult(cluster *models.Cluster, queryRes *opa.OPARecommenderQueryResult, recommenderID string) *models.MonitorTestResult {
 	runResult := types.MonitorTestStatusSuccess
 
@@ -5,11 +5,13 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
 	"time"
 
@@ -17,7 +19,9 @@ import (
 	"github.com/go-chi/chi/middleware"
 	"github.com/joeshaw/envdecode"
 	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/adapter"
+	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/opa"
 	"github.com/porter-dev/porter/internal/repository"
 	"github.com/porter-dev/porter/internal/worker"
@@ -31,6 +35,8 @@ import (
 
 var (
 	jobQueue    chan worker.Job
+	queue       *worker.PersistentQueue
+	scheduler   *worker.Scheduler
 	envDecoder  = EnvConf{}
 	dbConn      *gorm.DB
 	repo        repository.Repository
@@ -58,6 +64,38 @@ type EnvConf struct {
 	Port uint `env:"PORT,default=3000"`
 
 	RevisionsCount int `env:"REVISIONS_COUNT,default=20"`
+
+	DisablePullSecretsInjection bool `env:"DISABLE_PULL_SECRETS_INJECTION,default=false"`
+
+	ExternalSecretsVaultAddress   string `env:"EXTERNAL_SECRETS_VAULT_ADDR"`
+	ExternalSecretsVaultToken     string `env:"EXTERNAL_SECRETS_VAULT_TOKEN"`
+	ExternalSecretsVaultMount     string `env:"EXTERNAL_SECRETS_VAULT_MOUNT,default=secret"`
+	ExternalSecretsVaultNamespace string `env:"EXTERNAL_SECRETS_VAULT_NAMESPACE"`
+
+	// GithubAppID and GithubAppSecretPath are used to update the pull request comments of
+	// preview deployments which are put to sleep or expired
+	GithubAppID         string `env:"GITHUB_APP_ID"`
+	GithubAppSecretPath string `env:"GITHUB_APP_SECRET_PATH"`
+
+	// ProvisionerServerURL and ProvisionerToken are used to run drift detection operations
+	// against provisioned infra
+	ProvisionerServerURL string `env:"PROVISIONER_SERVER_URL"`
+	ProvisionerToken     string `env:"PROVISIONER_TOKEN"`
+
+	JobMaxAttempts  uint          `env:"JOB_MAX_ATTEMPTS,default=5"`
+	JobRetryBackoff time.Duration `env:"JOB_RETRY_BACKOFF,default=30s"`
+	JobPollInterval time.Duration `env:"JOB_POLL_INTERVAL,default=5s"`
+
+	// JobSchedules is a JSON array of jobs to enqueue on a cron schedule, evaluated in UTC, such as
+	// [{"job_id": "recommender", "schedule": "0 */6 * * *", "input": {"priority": "high"}}]
+	JobSchedules string `env:"JOB_SCHEDULES"`
+}
+
+// JobScheduleConf is the configuration of a job which is enqueued on a cron schedule
+type JobScheduleConf struct {
+	JobID    string                 `json:"job_id"`
+	Schedule string                 `json:"schedule"`
+	Input    map[string]interface{} `json:"input"`
 }
 
 func main() {
@@ -113,6 +151,43 @@ func main() {
 		log.Fatalln(err)
 	}
 
+	queue, err = worker.NewPersistentQueue(&worker.PersistentQueueOpts{
+		Repo:           repo.WorkerJob(),
+		NewJob:         getQueuedJob,
+		MaxConcurrent:  int(envDecoder.MaxWorkers),
+		MaxAttempts:    envDecoder.JobMaxAttempts,
+		InitialBackoff: envDecoder.JobRetryBackoff,
+		PollInterval:   envDecoder.JobPollInterval,
+	})
+
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	log.Println("starting persistent job queue")
+
+	queue.Run(jobQueue)
+
+	scheduledJobs, err := getScheduledJobs(envDecoder.JobSchedules)
+
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	scheduler, err = worker.NewScheduler(&worker.SchedulerOpts{
+		Repo:  repo.WorkerJob(),
+		Queue: queue,
+		Jobs:  scheduledJobs,
+	})
+
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	log.Printf("starting scheduler with %d scheduled job(s)", len(scheduledJobs))
+
+	scheduler.Run()
+
 	server := &http.Server{Addr: fmt.Sprintf(":%d", envDecoder.Port), Handler: httpService()}
 
 	serverCtx, serverStopCtx := context.WithCancel(context.Background())
@@ -155,6 +230,8 @@ func main() {
 	// Wait for server context to be stopped
 	<-serverCtx.Done()
 
+	scheduler.Exit()
+	queue.Exit()
 	d.Exit()
 }
 
@@ -172,30 +249,205 @@ func httpService() http.Handler {
 	log.Println("setting up HTTP POST endpoint to enqueue jobs")
 
 	r.Post("/enqueue/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := chi.URLParam(r, "id")
+
+		if !isKnownJob(id) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
 		req := make(map[string]interface{})
 
 		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 			log.Printf("error converting body to json: %v", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		input, err := json.Marshal(req)
+
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		record, err := queue.Enqueue(id, input)
+
+		if err != nil {
+			log.Printf("error enqueuing job with ID %s: %v", id, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, record.ToWorkerJobType())
+	})
+
+	log.Println("setting up HTTP endpoints to list jobs and their results")
+
+	r.Get("/jobs", func(w http.ResponseWriter, r *http.Request) {
+		filter := &types.ListWorkerJobsRequest{
+			Status: types.WorkerJobStatus(r.URL.Query().Get("status")),
+			JobID:  r.URL.Query().Get("job_id"),
+			Limit:  100,
+		}
+
+		if limit, err := strconv.ParseUint(r.URL.Query().Get("limit"), 10, 32); err == nil && limit > 0 {
+			filter.Limit = uint(limit)
+		}
+
+		records, err := repo.WorkerJob().ListWorkerJobs(filter)
+
+		if err != nil {
+			log.Printf("error listing jobs: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		res := make(types.ListWorkerJobsResponse, 0)
+
+		for _, record := range records {
+			res = append(res, record.ToWorkerJobType())
+		}
+
+		writeJSON(w, http.StatusOK, res)
+	})
+
+	r.Get("/jobs/{job_id}", func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseUint(chi.URLParam(r, "job_id"), 10, 32)
+
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
 
-		job := getJob(chi.URLParam(r, "id"), req)
+		record, err := repo.WorkerJob().ReadWorkerJob(uint(id))
 
-		if job == nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
 			w.WriteHeader(http.StatusNotFound)
 			return
+		} else if err != nil {
+			log.Printf("error reading job %d: %v", id, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
 		}
 
-		jobQueue <- job
-		w.WriteHeader(http.StatusCreated)
+		writeJSON(w, http.StatusOK, record.ToWorkerJobType())
+	})
+
+	r.Post("/jobs/{job_id}/retry", func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseUint(chi.URLParam(r, "job_id"), 10, 32)
+
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		record, err := queue.Retry(uint(id))
+
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		} else if errors.Is(err, worker.ErrJobNotRetryable) {
+			w.WriteHeader(http.StatusConflict)
+			return
+		} else if err != nil {
+			log.Printf("error retrying job %d: %v", id, err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, record.ToWorkerJobType())
+	})
+
+	r.Get("/schedules", func(w http.ResponseWriter, r *http.Request) {
+		res, err := scheduler.Status()
+
+		if err != nil {
+			log.Printf("error reading schedules: %v", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, res)
 	})
 
 	return r
 }
 
-func getJob(id string, input map[string]interface{}) worker.Job {
+func getScheduledJobs(schedulesJSON string) ([]*worker.ScheduledJob, error) {
+	res := make([]*worker.ScheduledJob, 0)
+
+	if schedulesJSON == "" {
+		return res, nil
+	}
+
+	confs := make([]*JobScheduleConf, 0)
+
+	if err := json.Unmarshal([]byte(schedulesJSON), &confs); err != nil {
+		return nil, fmt.Errorf("error parsing JOB_SCHEDULES: %w", err)
+	}
+
+	for _, conf := range confs {
+		if !isKnownJob(conf.JobID) {
+			return nil, fmt.Errorf("unknown job ID %s in JOB_SCHEDULES", conf.JobID)
+		}
+
+		if conf.Input == nil {
+			conf.Input = make(map[string]interface{})
+		}
+
+		input, err := json.Marshal(conf.Input)
+
+		if err != nil {
+			return nil, fmt.Errorf("error encoding input of job %s: %w", conf.JobID, err)
+		}
+
+		res = append(res, &worker.ScheduledJob{
+			JobID:    conf.JobID,
+			Schedule: conf.Schedule,
+			Input:    input,
+		})
+	}
+
+	return res, nil
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("error writing response: %v", err)
+	}
+}
+
+func isKnownJob(id string) bool {
+	return id == "helm-revisions-count-tracker" || id == "recommender" || id == "env-group-external-secrets-sync" ||
+		id == "preview-environment-lifecycle" || id == "infra-drift-detection"
+}
+
+// getQueuedJob constructs the job to run for a job in the persistent queue
+func getQueuedJob(record *models.WorkerJob) (worker.Job, error) {
+	input := make(map[string]interface{})
+
+	if len(record.Input) > 0 {
+		if err := json.Unmarshal(record.Input, &input); err != nil {
+			return nil, fmt.Errorf("error decoding input of job: %w", err)
+		}
+	}
+
+	job := getJob(record.JobID, record.CreatedAt, input)
+
+	if job == nil {
+		return nil, fmt.Errorf("could not create job with ID %s", record.JobID)
+	}
+
+	return job, nil
+}
+
+func getJob(id string, enqueueTime time.Time, input map[string]interface{}) worker.Job {
 	if id == "helm-revisions-count-tracker" {
-		newJob, err := jobs.NewHelmRevisionsCountTracker(dbConn, time.Now().UTC(), &jobs.HelmRevisionsCountTrackerOpts{
+		newJob, err := jobs.NewHelmRevisionsCountTracker(dbConn, enqueueTime, &jobs.HelmRevisionsCountTrackerOpts{
 			DBConf:             &envDecoder.DBConf,
 			DOClientID:         envDecoder.DOClientID,
 			DOClientSecret:     envDecoder.DOClientSecret,
@@ -216,7 +468,7 @@ func getJob(id string, input map[string]interface{}) worker.Job {
 
 		return newJob
 	} else if id == "recommender" {
-		newJob, err := jobs.NewRecommender(dbConn, time.Now().UTC(), &jobs.RecommenderOpts{
+		newJob, err := jobs.NewRecommender(dbConn, enqueueTime, &jobs.RecommenderOpts{
 			DBConf:           &envDecoder.DBConf,
 			DOClientID:       envDecoder.DOClientID,
 			DOClientSecret:   envDecoder.DOClientSecret,
@@ -231,6 +483,57 @@ func getJob(id string, input map[string]interface{}) worker.Job {
 			return nil
 		}
 
+		return newJob
+	} else if id == "env-group-external-secrets-sync" {
+		newJob, err := jobs.NewEnvGroupExternalSecretsSync(dbConn, enqueueTime, &jobs.EnvGroupExternalSecretsSyncOpts{
+			DBConf:                      &envDecoder.DBConf,
+			DOClientID:                  envDecoder.DOClientID,
+			DOClientSecret:              envDecoder.DOClientSecret,
+			DOScopes:                    []string{"read", "write"},
+			ServerURL:                   envDecoder.ServerURL,
+			VaultAddress:                envDecoder.ExternalSecretsVaultAddress,
+			VaultToken:                  envDecoder.ExternalSecretsVaultToken,
+			VaultMount:                  envDecoder.ExternalSecretsVaultMount,
+			VaultNamespace:              envDecoder.ExternalSecretsVaultNamespace,
+			DisablePullSecretsInjection: envDecoder.DisablePullSecretsInjection,
+		})
+
+		if err != nil {
+			log.Printf("error creating job with ID: env-group-external-secrets-sync. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "preview-environment-lifecycle" {
+		newJob, err := jobs.NewPreviewEnvironmentLifecycle(dbConn, enqueueTime, &jobs.PreviewEnvironmentLifecycleOpts{
+			DBConf:              &envDecoder.DBConf,
+			DOClientID:          envDecoder.DOClientID,
+			DOClientSecret:      envDecoder.DOClientSecret,
+			DOScopes:            []string{"read", "write"},
+			ServerURL:           envDecoder.ServerURL,
+			GithubAppID:         envDecoder.GithubAppID,
+			GithubAppSecretPath: envDecoder.GithubAppSecretPath,
+		})
+
+		if err != nil {
+			log.Printf("error creating job with ID: preview-environment-lifecycle. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "infra-drift-detection" {
+		newJob, err := jobs.NewInfraDriftDetection(dbConn, enqueueTime, &jobs.InfraDriftDetectionOpts{
+			DBConf:               &envDecoder.DBConf,
+			ServerURL:            envDecoder.ServerURL,
+			ProvisionerServerURL: envDecoder.ProvisionerServerURL,
+			ProvisionerToken:     envDecoder.ProvisionerToken,
+		})
+
+		if err != nil {
+			log.Printf("error creating job with ID: infra-drift-detection. Error: %v", err)
+			return nil
+		}
+
 		return newJob
 	}
 